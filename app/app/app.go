@@ -5,11 +5,15 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"api/app/domain/entities"
 	"api/app/domain/services"
 	"api/app/domain/usecases"
 	"api/app/internal/config"
@@ -17,7 +21,10 @@ import (
 	"api/app/internal/presentation/middleware"
 
 	"api/app/internal/infrastructure/external/card_craft_ai"
+	"api/app/internal/infrastructure/external/httpclient"
+	"api/app/internal/infrastructure/external/openai"
 	"api/app/internal/infrastructure/external/ozon"
+	"api/app/internal/infrastructure/external/resilience"
 	"api/app/internal/infrastructure/external/token_counter"
 	"api/app/internal/infrastructure/external/wb"
 	"api/app/internal/infrastructure/file_storage"
@@ -30,12 +37,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// shutdownTimeout is how long running requests are waited for on shutdown
+const shutdownTimeout = 30 * time.Second
+
 type App struct {
 	cardCraftAiAPIURL string
 	httpClient        *http.Client
 	cfg               *config.Config
 	mux               *http.ServeMux
 	balanceStorage    *pgstorage.BalanceStorage
+	wbService         *services.WbService
+	stockSyncService  *services.StockSyncService
+	wbCatalogSync     *services.WbCatalogSyncService
 }
 
 // NewApp creates a new ProductServer instance
@@ -55,12 +68,68 @@ func NewApp() *App {
 		log.Fatalf("failed to init postgres client: %v", err)
 	}
 	balanceStorage := pgstorage.NewBalanceStorage(pgClient)
+	cardContentCacheStorage := pgstorage.NewCardContentCacheStorage(pgClient)
+	contentSettingsStorage := pgstorage.NewContentSettingsStorage(pgClient)
+	contentVariantStorage := pgstorage.NewContentVariantStorage(pgClient)
+	stockSyncStorage, err := pgstorage.NewStockSyncStorage(pgClient, cfg.StockSync.EncryptionKey)
+	if err != nil {
+		log.Fatalf("failed to init stock sync storage: %v", err)
+	}
+	if encrypted, err := stockSyncStorage.EncryptStockSyncCredentials(context.Background()); err != nil {
+		log.Printf("Failed to encrypt stored stock sync credentials: %v", err)
+	} else if encrypted > 0 {
+		log.Printf("Encrypted the stored stock sync credentials of %d accounts", encrypted)
+	}
+	categoryMappingStorage := pgstorage.NewCategoryMappingStorage(pgClient)
+	wbCatalogStorage := pgstorage.NewWBCatalogStorage(pgClient)
 
 	// clients
-	cardCraftAiClient := card_craft_ai.NewCardCraftAiClient("http://" + cfg.CardCraftAi.URL + ":" + strconv.Itoa(cfg.CardCraftAi.Port))
-	wbClient := wb.NewWBClient()
-	ozonClient := ozon.NewClient()
-	tokenCounterClient := token_counter.NewClient("http://" + cfg.TokenCounter.APIURL + ":" + strconv.Itoa(cfg.TokenCounter.Port))
+	cardCraftAiBreaker := resilience.NewCircuitBreaker("card_craft_ai", cfg.CardCraftAi.BreakerFailureThreshold, time.Duration(cfg.CardCraftAi.BreakerOpenSeconds)*time.Second)
+	cardCraftAiClient := card_craft_ai.NewCardCraftAiClient(
+		"http://"+cfg.CardCraftAi.URL+":"+strconv.Itoa(cfg.CardCraftAi.Port),
+		card_craft_ai.Options{
+			SessionTimeout: time.Duration(cfg.CardCraftAi.SessionTimeoutSeconds) * time.Second,
+			RunTimeout:     time.Duration(cfg.CardCraftAi.RunTimeoutSeconds) * time.Second,
+			MaxRetries:     cfg.CardCraftAi.MaxRetries,
+			RetryBaseDelay: time.Duration(cfg.CardCraftAi.RetryBaseDelayMs) * time.Millisecond,
+			RetryMaxDelay:  time.Duration(cfg.CardCraftAi.RetryMaxDelayMs) * time.Millisecond,
+		},
+		cardCraftAiBreaker,
+		newHTTPClient("card_craft_ai", 0, cfg.CardCraftAi.ProxyURL), // Timeouts are set per call
+	)
+	wbClient := wb.NewWBClient(cfg.WB.APIURL, wb.Options{
+		RequestsPerMinute: cfg.WB.RateLimitPerMinute,
+		Burst:             cfg.WB.RateLimitBurst,
+		MaxRetries:        cfg.WB.MaxRetries,
+		RetryBaseDelay:    time.Duration(cfg.WB.RetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:     time.Duration(cfg.WB.RetryMaxDelayMs) * time.Millisecond,
+	}, newHTTPClient("wb", cfg.WB.TimeoutSeconds, cfg.WB.ProxyURL))
+	wbPricesClient := wb.NewPricesClient(cfg.WB.PricesAPIURL, wb.Options{
+		RequestsPerMinute: cfg.WB.PricesRateLimitPerMinute,
+		Burst:             cfg.WB.PricesRateLimitBurst,
+		MaxRetries:        cfg.WB.MaxRetries,
+		RetryBaseDelay:    time.Duration(cfg.WB.RetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:     time.Duration(cfg.WB.RetryMaxDelayMs) * time.Millisecond,
+	}, newHTTPClient("wb_prices", cfg.WB.TimeoutSeconds, cfg.WB.ProxyURL))
+	wbMarketplaceClient := wb.NewMarketplaceClient(cfg.WB.MarketplaceAPIURL, wb.Options{
+		RequestsPerMinute: cfg.WB.MarketplaceRateLimitPerMinute,
+		Burst:             cfg.WB.MarketplaceRateLimitBurst,
+		MaxRetries:        cfg.WB.MaxRetries,
+		RetryBaseDelay:    time.Duration(cfg.WB.RetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:     time.Duration(cfg.WB.RetryMaxDelayMs) * time.Millisecond,
+	}, newHTTPClient("wb_marketplace", cfg.WB.TimeoutSeconds, cfg.WB.ProxyURL))
+	ozonClient := ozon.NewClient(cfg.Ozon.APIURL, ozon.Options{
+		RequestsPerMinute: cfg.Ozon.RateLimitPerMinute,
+		Burst:             cfg.Ozon.RateLimitBurst,
+		MaxRetries:        cfg.Ozon.MaxRetries,
+		RetryBaseDelay:    time.Duration(cfg.Ozon.RetryBaseDelayMs) * time.Millisecond,
+		RetryMaxDelay:     time.Duration(cfg.Ozon.RetryMaxDelayMs) * time.Millisecond,
+	}, newHTTPClient("ozon", cfg.Ozon.TimeoutSeconds, cfg.Ozon.ProxyURL))
+	openAiClient := openai.NewClient(cfg.OpenAi.BaseURL, cfg.OpenAi.APIKey, cfg.OpenAi.Model, newHTTPClient("openai", cfg.OpenAi.TimeoutSeconds, cfg.OpenAi.ProxyURL))
+	tokenCounterClient := token_counter.NewClient(
+		"http://"+cfg.TokenCounter.APIURL+":"+strconv.Itoa(cfg.TokenCounter.Port),
+		newHTTPClient("token_counter", cfg.TokenCounter.TimeoutSeconds, cfg.TokenCounter.ProxyURL),
+	)
 
 	// file storage client - configure upload directory and base URL
 	uploadDir := cfg.FileStorage.UploadDir // Directory to store uploaded files
@@ -95,24 +164,83 @@ func NewApp() *App {
 
 	// services
 	cardCraftAiService := services.NewCardCraftAiService(cardCraftAiClient)
-	tokenBillingService := services.NewTokenBillingService(tokenCounterClient, balanceStorage)
-	wbService := services.NewWbService(cfg.WB.GetCardListMaxAttempts, wbClient)
-	fileUploadService := services.NewFileUploadService(fileStorageClient)
-	ozonService := services.NewOzonService(ozonClient, fileUploadService)
+	cachedCardCraftAiService := services.NewCachedCardCraftAiService(cardCraftAiService, cardContentCacheStorage, tokenCounterClient, time.Duration(cfg.CardCraftAi.CacheTTLHours)*time.Hour)
+	go cachedCardCraftAiService.StartCleanupRoutine(time.Hour)
+	openAiContentService := services.NewOpenAiContentService(openAiClient, contentSettingsStorage, wbCatalogStorage, cfg.OpenAi.PromptTemplate)
+	cachedOpenAiContentService := services.NewCachedCardCraftAiService(openAiContentService, cardContentCacheStorage, tokenCounterClient, time.Duration(cfg.CardCraftAi.CacheTTLHours)*time.Hour)
+	contentGenerationService := services.NewContentGenerationService(contentSettingsStorage, entities.ContentProvider(cfg.Content.DefaultProvider))
+	contentGenerationService.AddProvider(entities.ContentProviderCardCraftAi, cachedCardCraftAiService)
+	contentGenerationService.AddProvider(entities.ContentProviderOpenAi, cachedOpenAiContentService)
+	categoryMappingService := services.NewCategoryMappingService(services.CategoryMappingOptions{
+		MinResolutions: cfg.CategoryMapping.MinResolutions,
+		MinConfidence:  cfg.CategoryMapping.MinConfidence,
+	}, contentGenerationService, categoryMappingStorage)
+	tokenBillingService := services.NewTokenBillingService(tokenCounterClient, balanceStorage, cfg.CardCraftAi.CacheHitBillingPercent)
+	// Pending media links and inline files point to uploaded files, so cards are not waited for longer than the files are kept
+	wbService := services.NewWbService(services.NmIDResolverOptions{
+		BaseDelay:      time.Duration(cfg.WB.NmIDResolveBaseDelaySeconds) * time.Second,
+		MaxDelay:       time.Duration(cfg.WB.NmIDResolveMaxDelaySeconds) * time.Second,
+		Timeout:        min(time.Duration(cfg.WB.NmIDResolveTimeoutMinutes)*time.Minute, fileTTL),
+		MaxPendingJobs: cfg.WB.NmIDResolveMaxPendingJobs,
+	}, services.WbPricesOptions{
+		PollInterval: time.Duration(cfg.WB.PricesTaskPollSeconds) * time.Second,
+		Timeout:      time.Duration(cfg.WB.PricesTaskTimeoutSeconds) * time.Second,
+	}, wbClient, wbPricesClient, wbMarketplaceClient, fileStorageClient)
+	fileUploadService := services.NewFileUploadService(fileStorageClient, int64(cfg.FileStorage.MaxUploadMB)<<20)
+	ozonService := services.NewOzonService(services.OzonBarcodeOptions{
+		PollInterval: time.Duration(cfg.Ozon.BarcodePollSeconds) * time.Second,
+		Timeout:      time.Duration(cfg.Ozon.BarcodeTimeoutSeconds) * time.Second,
+	}, ozonClient, fileUploadService)
+	stockSyncService := services.NewStockSyncService(services.StockSyncOptions{
+		Interval:        time.Duration(cfg.StockSync.IntervalMinutes) * time.Minute,
+		AccountTimeout:  time.Duration(cfg.StockSync.AccountTimeoutMinutes) * time.Minute,
+		ReportRetention: time.Duration(cfg.StockSync.ReportRetentionDays) * 24 * time.Hour,
+	}, stockSyncStorage, wbService, ozonService)
+	go stockSyncService.StartSyncRoutine()
+	wbCatalogSyncService := services.NewWbCatalogSyncService(services.WbCatalogSyncOptions{
+		ApiKey:              cfg.WbCatalog.ApiKey,
+		Locale:              cfg.WbCatalog.Locale,
+		TnvedSubjectsPerRun: cfg.WbCatalog.TnvedSubjectsPerRun,
+	}, wbClient, wbCatalogStorage)
+	go wbCatalogSyncService.StartSyncRoutine(time.Duration(cfg.WbCatalog.SyncIntervalHours) * time.Hour)
+	wbContentConstraints := entities.DefaultWbContentConstraints()
+	if len(cfg.Content.WbStopWords) > 0 {
+		wbContentConstraints.StopWords = cfg.Content.WbStopWords
+	}
+	ozonContentConstraints := entities.DefaultOzonContentConstraints()
+	if len(cfg.Content.OzonStopWords) > 0 {
+		ozonContentConstraints.StopWords = cfg.Content.OzonStopWords
+	}
+	contentValidationService := services.NewContentValidationService(wbContentConstraints, ozonContentConstraints)
+	wbAttributeValidationService := services.NewWbAttributeValidationService(wbCatalogStorage)
 
 	// usecases
-	createCardUsecase := usecases.NewCreateCardUsecase(cardCraftAiService, wbService, ozonService, tokenBillingService)
+	createCardUsecase := usecases.NewCreateCardUsecase(categoryMappingService, wbService, ozonService, tokenBillingService, fileUploadService, contentVariantStorage, contentValidationService, wbAttributeValidationService, cfg.IsDev)
+	publishVariantUsecase := usecases.NewPublishVariantUsecase(contentVariantStorage, wbService, ozonService, contentValidationService, wbAttributeValidationService, fileUploadService, cfg.IsDev)
 	getBalanceUsecase := usecases.NewGetBalanceUsecase(balanceStorage)
 	updateBalanceUsecase := usecases.NewUpdateBalanceUsecase(balanceStorage)
+	uploadMediaUsecase := usecases.NewUploadMediaUsecase(fileUploadService)
+	listWBCardsUsecase := usecases.NewListWBCardsUsecase(wbService)
+	updatePricesUsecase := usecases.NewUpdatePricesUsecase(wbService, ozonService)
+	crossListUsecase := usecases.NewCrossListUsecase(contentGenerationService, categoryMappingService, wbService, ozonService, contentValidationService, wbAttributeValidationService, cfg.IsDev)
+	stockUsecase := usecases.NewStockUsecase(wbService, ozonService)
+	stockSyncUsecase := usecases.NewStockSyncUsecase(stockSyncStorage, stockSyncService)
+	categoryMappingUsecase := usecases.NewCategoryMappingUsecase(categoryMappingStorage, cfg.CategoryMapping.EditorApiKeys)
+	catalogUsecase := usecases.NewCatalogUsecase(wbCatalogStorage)
 
 	// handlers
-	createProductCardHandler := presentation.NewCreateProductCardHandler(createCardUsecase)
+	createProductCardHandler := presentation.NewCreateProductCardHandler(createCardUsecase, publishVariantUsecase, listWBCardsUsecase, updatePricesUsecase, crossListUsecase, categoryMappingUsecase)
 	balanceHandler := presentation.NewBalanceHandler(getBalanceUsecase)
+	mediaUploadHandler := presentation.NewMediaUploadHandler(uploadMediaUsecase)
+	stockHandler := presentation.NewStockHandler(stockUsecase, stockSyncUsecase)
+	catalogHandler := presentation.NewCatalogHandler(catalogUsecase)
 	tinkoffHandler := presentation.NewTinkoffNotificationHandler(
 		updateBalanceUsecase,
 		cfg.Tinkoff.SecretKey,
 		cfg.Tinkoff.TerminalKey,
 		cfg.Tinkoff.TelegramBotToken,
+		cfg.Tinkoff.APIURL,
+		newHTTPClient("tinkoff", cfg.Tinkoff.TimeoutSeconds, cfg.Tinkoff.ProxyURL),
 	)
 
 	// middleware
@@ -123,6 +251,9 @@ func NewApp() *App {
 	path, baseHandler := apiv1connect.NewProductServiceHandler(createProductCardHandler)
 	balancePath, balanceServiceHandler := apiv1connect.NewBalanceServiceHandler(balanceHandler)
 	paymentPath, paymentServiceHandler := apiv1connect.NewPaymentServiceHandler(tinkoffHandler)
+	mediaPath, mediaServiceHandler := apiv1connect.NewMediaServiceHandler(mediaUploadHandler)
+	stockPath, stockServiceHandler := apiv1connect.NewStockServiceHandler(stockHandler)
+	catalogPath, catalogServiceHandler := apiv1connect.NewCatalogServiceHandler(catalogHandler)
 
 	// Wrap the base handler with balance check and Prometheus metrics instrumentation
 	balanceCheckedHandler := balanceCheckMiddleware.CheckBalance(baseHandler)
@@ -142,9 +273,36 @@ func NewApp() *App {
 		),
 	)
 
+	mediaMetricsWrappedHandler := promhttp.InstrumentHandlerCounter(
+		metrics.HTTPRequestsTotal.MustCurryWith(prometheus.Labels{"handler": mediaPath}),
+		promhttp.InstrumentHandlerDuration(
+			metrics.HTTPRequestDuration.MustCurryWith(prometheus.Labels{"handler": mediaPath}),
+			balanceCheckMiddleware.CheckBalance(mediaServiceHandler), // Only accounts of the service may upload files
+		),
+	)
+
+	stockMetricsWrappedHandler := promhttp.InstrumentHandlerCounter(
+		metrics.HTTPRequestsTotal.MustCurryWith(prometheus.Labels{"handler": stockPath}),
+		promhttp.InstrumentHandlerDuration(
+			metrics.HTTPRequestDuration.MustCurryWith(prometheus.Labels{"handler": stockPath}),
+			stockServiceHandler,
+		),
+	)
+
+	catalogMetricsWrappedHandler := promhttp.InstrumentHandlerCounter(
+		metrics.HTTPRequestsTotal.MustCurryWith(prometheus.Labels{"handler": catalogPath}),
+		promhttp.InstrumentHandlerDuration(
+			metrics.HTTPRequestDuration.MustCurryWith(prometheus.Labels{"handler": catalogPath}),
+			catalogServiceHandler,
+		),
+	)
+
 	mux.Handle(path, metricsWrappedHandler)
 	mux.Handle(balancePath, balanceMetricsWrappedHandler)
 	mux.Handle(paymentPath, paymentServiceHandler)
+	mux.Handle(mediaPath, mediaMetricsWrappedHandler)
+	mux.Handle(stockPath, stockMetricsWrappedHandler)
+	mux.Handle(catalogPath, catalogMetricsWrappedHandler)
 	mux.Handle("/metrics", promhttp.Handler()) // Expose Prometheus metrics
 	mux.HandleFunc("/balance", balanceHandler.GetBalanceHTTP)
 	mux.HandleFunc("/balance-by-token", balanceHandler.GetBalanceByToken)
@@ -162,7 +320,22 @@ func NewApp() *App {
 		cfg:               cfg,
 		mux:               mux,
 		balanceStorage:    balanceStorage,
+		wbService:         wbService,
+		stockSyncService:  stockSyncService,
+		wbCatalogSync:     wbCatalogSyncService,
+	}
+}
+
+// newHTTPClient creates the HTTP client of an external API and stops the app on invalid settings.
+func newHTTPClient(apiName string, timeoutSeconds int, proxyURL string) *http.Client {
+	client, err := httpclient.New(httpclient.Options{
+		Timeout:  time.Duration(timeoutSeconds) * time.Second,
+		ProxyURL: proxyURL,
+	})
+	if err != nil {
+		log.Fatalf("failed to init %s HTTP client: %v", apiName, err)
 	}
+	return client
 }
 
 func (a *App) Run() error {
@@ -170,5 +343,27 @@ func (a *App) Run() error {
 	log.Printf("Starting ConnectRPC server on %s", addr)
 	log.Printf("CardCraftAI API URL: %s", a.cardCraftAiAPIURL)
 
-	return http.ListenAndServe(addr, a.mux)
+	server := &http.Server{Addr: addr, Handler: a.mux}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down ConnectRPC server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(shutdownCtx)
+	a.stockSyncService.Stop()
+	a.wbCatalogSync.Stop()
+	a.wbService.Stop()
+	return err
 }
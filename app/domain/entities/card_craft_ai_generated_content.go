@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 // CardCraftAiGeneratedContent represents the response structure from the Python API
 type CardCraftAiGeneratedContent struct {
 	Title       string            `json:"title"`
@@ -16,4 +18,23 @@ type CardCraftAiGeneratedContent struct {
 	SubID       *int32            `json:"sub_id"`
 	SubName     *string           `json:"sub_name"`
 	SessionID   string            `json:"session_id"`
+
+	FromCache  bool            `json:"-"` // Served from the content cache, no tokens were spent
+	TokenUsage *SessionData    `json:"-"` // Tokens spent on the original generation, if known
+	Provider   ContentProvider `json:"-"` // Provider that generated the content
+}
+
+// CardCraftAiRequest is the product context sent to CardCraftAI together with
+// the marketplaces whose categories still have to be classified.
+type CardCraftAiRequest struct {
+	ProductCard         ProductCard
+	ResolveWbCategory   bool // Classify WB subject; false when the caller supplied subject_id
+	ResolveOzonCategory bool // Classify Ozon description category and type; false when the caller supplied sub_id and type_id
+}
+
+// CachedCardContent is a previously generated content stored in the content cache.
+type CachedCardContent struct {
+	Content    CardCraftAiGeneratedContent
+	TokenUsage SessionData
+	CreatedAt  time.Time
 }
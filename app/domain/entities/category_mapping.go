@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCategoryMappingNotFound = errors.New("category mapping not found")
+
+// CategoryMapping is a WB subject and an Ozon description category and type that describe the same products.
+// Pairs are recorded each time CardCraftAI resolves them, content managers can override the pair of a WB subject.
+type CategoryMapping struct {
+	WbSubjectID                 int32
+	WbSubjectName               string
+	OzonDescriptionCategoryID   int32
+	OzonDescriptionCategoryName string
+	OzonTypeID                  int32
+	OzonTypeName                string
+	Resolutions                 int     // Times CardCraftAI resolved this pair
+	Confidence                  float64 // Share of the resolutions of the WB subject, or of the Ozon type when looked up by it, that gave this pair
+	Overridden                  bool    // Set by a content manager, always wins over resolved pairs
+	UpdatedAt                   time.Time
+}
+
+// CategoryMappingFromContent returns the pair of categories of the generated content, or nil if it lacks
+// the WB subject or the Ozon category and type.
+func CategoryMappingFromContent(content *CardCraftAiGeneratedContent) *CategoryMapping {
+	if content.SubjectID == nil || content.SubID == nil || content.TypeID == nil || *content.SubjectID == 0 || *content.SubID == 0 || *content.TypeID == 0 {
+		return nil
+	}
+	name := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	return &CategoryMapping{
+		WbSubjectID:                 *content.SubjectID,
+		WbSubjectName:               name(content.SubjectName),
+		OzonDescriptionCategoryID:   *content.SubID,
+		OzonDescriptionCategoryName: name(content.SubName),
+		OzonTypeID:                  *content.TypeID,
+		OzonTypeName:                name(content.TypeName),
+	}
+}
+
+// SetNamesOn fills the category names missing on content for the categories of the mapping.
+func (m *CategoryMapping) SetNamesOn(content *CardCraftAiGeneratedContent) {
+	if content.SubjectID != nil && *content.SubjectID == m.WbSubjectID && content.SubjectName == nil && m.WbSubjectName != "" {
+		content.SubjectName = &m.WbSubjectName
+	}
+	if content.SubID != nil && *content.SubID == m.OzonDescriptionCategoryID && content.SubName == nil && m.OzonDescriptionCategoryName != "" {
+		content.SubName = &m.OzonDescriptionCategoryName
+	}
+	if content.TypeID != nil && *content.TypeID == m.OzonTypeID && content.TypeName == nil && m.OzonTypeName != "" {
+		content.TypeName = &m.OzonTypeName
+	}
+}
+
+// CategoryMappingFilter selects the category mappings to list; zero fields match everything.
+type CategoryMappingFilter struct {
+	WbSubjectID               int32
+	OzonDescriptionCategoryID int32
+	OzonTypeID                int32
+	OverriddenOnly            bool
+	Limit                     int
+	Offset                    int
+}
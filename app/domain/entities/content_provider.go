@@ -0,0 +1,17 @@
+package entities
+
+// ContentProvider identifies a card content generator.
+type ContentProvider string
+
+const (
+	ContentProviderCardCraftAi ContentProvider = "card_craft_ai"
+	ContentProviderOpenAi      ContentProvider = "openai"
+)
+
+// PromptTemplate is a server-side prompt used by LLM based content providers.
+// UserPrompt is a text/template rendered with the product card.
+type PromptTemplate struct {
+	Name         string
+	SystemPrompt string
+	UserPrompt   string
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContentValidationMode defines what happens when generated content breaks marketplace constraints.
+type ContentValidationMode string
+
+const (
+	ContentValidationTruncate   ContentValidationMode = "truncate"   // Fix the content in place (default)
+	ContentValidationRegenerate ContentValidationMode = "regenerate" // Ask the generator once more, then fix what is left
+	ContentValidationReject     ContentValidationMode = "reject"     // Fail before any marketplace call
+)
+
+// Content validation rules.
+const (
+	ContentRuleMaxLength      = "max_length"
+	ContentRuleEmpty          = "empty"
+	ContentRuleHTML           = "html"
+	ContentRuleForbiddenChars = "forbidden_characters"
+	ContentRuleStopWord       = "stop_word"
+	ContentRuleBrandInTitle   = "brand_in_title"
+	ContentRuleUnknownValue   = "unknown_value" // An attribute value missing from the mirrored WB directory
+)
+
+// ContentConstraints are the marketplace limits for titles and descriptions.
+type ContentConstraints struct {
+	Marketplace          Marketplace
+	TitleMaxLength       int    // In characters
+	DescriptionMaxLength int    // In characters
+	AllowHTML            bool   // HTML markup is accepted in the description
+	ForbiddenChars       string // Characters that must not appear in the title or the description; emoji are always forbidden
+	StopWords            []string
+	ForbidBrandInTitle   bool // The brand is shown separately and must not be repeated in the title
+}
+
+// DefaultWbContentConstraints returns the Wildberries content limits.
+func DefaultWbContentConstraints() ContentConstraints {
+	return ContentConstraints{
+		Marketplace:          MarketplaceWB,
+		TitleMaxLength:       60,
+		DescriptionMaxLength: 5000,
+		ForbiddenChars:       "<>{}[]|\\^~@#$*",
+		StopWords:            []string{"скидка", "акция", "распродажа", "хит продаж", "лучший", "бесплатно", "дешево", "sale"},
+		ForbidBrandInTitle:   true,
+	}
+}
+
+// DefaultOzonContentConstraints returns the Ozon content limits.
+func DefaultOzonContentConstraints() ContentConstraints {
+	return ContentConstraints{
+		Marketplace:          MarketplaceOzon,
+		TitleMaxLength:       500,
+		DescriptionMaxLength: 6000,
+		ForbiddenChars:       "<>{}|\\^~",
+		StopWords:            []string{"скидка", "акция", "распродажа", "лучший", "№1", "оригинал"},
+	}
+}
+
+// VariantContent adapts the content shared by the variants of a multi-variant product to the color of one of them:
+// the color is appended to the title and the description unless they already mention it or the result would break
+// the length limits. Content without a color is returned as is.
+func (c ContentConstraints) VariantContent(content *CardCraftAiGeneratedContent, color string) *CardCraftAiGeneratedContent {
+	if color == "" {
+		return content
+	}
+	adapted := *content
+	lowerColor := strings.ToLower(color)
+	if title := content.Title + ", " + color; !strings.Contains(strings.ToLower(content.Title), lowerColor) && utf8.RuneCountInString(title) <= c.TitleMaxLength {
+		adapted.Title = title
+	}
+	if description := content.Description + "\n\nЦвет: " + color + "."; content.Description != "" &&
+		!strings.Contains(strings.ToLower(content.Description), lowerColor) && utf8.RuneCountInString(description) <= c.DescriptionMaxLength {
+		adapted.Description = description
+	}
+	return &adapted
+}
+
+// ContentViolation describes a constraint broken by the generated content.
+type ContentViolation struct {
+	Marketplace Marketplace
+	Field       string // "title", "description" or "attributes.<name>"
+	Rule        string
+	Message     string
+	Fixed       bool // The content was corrected automatically
+}
+
+// ContentValidationError is returned when the content breaks marketplace constraints and cannot be published.
+type ContentValidationError struct {
+	Violations []ContentViolation
+}
+
+func (e *ContentValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("%s %s: %s", v.Marketplace, v.Field, v.Message)
+	}
+	return "content violates marketplace constraints: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrContentVariantNotFound = errors.New("content variant not found")
+	// ErrContentVariantMediaMissing is returned when the media of a stored variant is no longer available.
+	ErrContentVariantMediaMissing = errors.New("media of the content variant is no longer available")
+)
+
+// ContentVariant is one of the content alternatives generated for a product card.
+// Variants are stored so that any of them can be published later and compared by conversion.
+type ContentVariant struct {
+	ID           string
+	GenerationID string // Shared by all variants generated by one Create call
+	Index        int
+	ApiKey       string
+	Content      CardCraftAiGeneratedContent
+	ProductCard  ProductCard // Generation input without marketplace credentials, inline media and resolved media links
+	Cost         int         // Amount charged from the balance
+	CreatedAt    time.Time
+	PublishedAt  *time.Time
+	PublishedTo  []Marketplace
+	// Inline media files of the card, which are not stored, so the variant cannot be published later
+	InlineMediaFiles int
+}
+
+// ContentVariantPublication records that a variant was sent to a marketplace as the card with the vendor code,
+// so the conversion of the card can be attributed to the variant.
+type ContentVariantPublication struct {
+	Marketplace Marketplace
+	VendorCode  string
+}
+
+// PublishVariantRequest selects the marketplaces a stored variant is pushed to.
+type PublishVariantRequest struct {
+	VariantID         string
+	Wb                bool
+	Ozon              bool
+	WbApiKey          string
+	OzonApiClientId   string
+	OzonApiKey        string
+	ContentValidation ContentValidationMode
+	DryRun            *bool // Prepare marketplace requests without sending them, nil for the environment default
+}
@@ -2,22 +2,41 @@ package entities
 
 type CreateProductCardResult struct {
 	CardCraftAiGeneratedContent *CardCraftAiGeneratedContent
+	ContentVariants             []*ContentVariant
+	ContentViolations           []ContentViolation
 	OzonApiResponseJson         *string
 	OzonRequestAttempted        *bool
+	OzonPreparedRequestJson     *string
+	OzonError                   *OzonError // Parsed Ozon error if the Ozon call failed
 	WbApiResponseJson           *string
 	WbPreparedRequestJson       *string
 	WbRequestAttempted          *bool
 	WbMediaUploadResponses      []*WbMediaUploadIndividualResponse
-	WbMediaSaveResponse         *WbMediaSaveByLinksResponse
+	WbMediaSaveResponse         *WbMediaSaveByLinksResponse   // Response for the first card
+	WbMediaSaveResponses        []*WbMediaSaveByLinksResponse // Responses for every card of a multi-variant product
+	WbMediaPending              bool                          // WB media is uploaded in the background once WB has created the card
+	DryRun                      bool                          // Marketplace requests were prepared but not sent
+	GeneratedBarcodes           []*GeneratedBarcodes          // Barcodes the marketplaces generated for sizes without SKUs
+	OzonInitialStockError       string                        // Why the initial Ozon stock was not fully set, empty if it was or none was requested
+}
+
+// GeneratedBarcodes are the barcodes a marketplace generated for a size the seller gave no SKUs for.
+type GeneratedBarcodes struct {
+	Marketplace Marketplace
+	VendorCode  string
+	TechSize    string
+	Barcodes    []string
 }
 
 type WbMediaUploadIndividualResponse struct {
+	VendorCode   string
 	PhotoNumber  int32
 	ResponseJson *string
 	ErrorMessage *string
 }
 
 type WbMediaSaveByLinksResponse struct {
+	VendorCode   string
 	ResponseJson *string
 	ErrorMessage *string
 }
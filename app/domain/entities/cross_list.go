@@ -0,0 +1,45 @@
+package entities
+
+import "errors"
+
+var (
+	// ErrWBCardNotFound is returned when the seller has no WB card with the requested nmID.
+	ErrWBCardNotFound = errors.New("wb card not found")
+	// ErrOzonProductNotFound is returned when the seller has no Ozon product with the requested offer ID.
+	ErrOzonProductNotFound = errors.New("ozon product not found")
+)
+
+// CrossListRequest copies an existing WB card to Ozon, or an existing Ozon product to WB if OfferID is set.
+type CrossListRequest struct {
+	WbApiKey              string
+	OzonApiClientId       string
+	OzonApiKey            string
+	NmID                  int    // WB card copied to Ozon
+	OfferID               string // Ozon product copied to WB
+	DescriptionCategoryID int32  // Ozon category, resolved from the WB subject if zero
+	TypeID                int32  // Ozon type, resolved together with the category if zero
+	SubjectID             int32  // WB subject, resolved from the Ozon category if zero
+	Price                 int    // Price of every size on the target, the source price if zero
+	DryRun                *bool  // Prepare the target request without sending it, nil for the environment default
+}
+
+// ProductCharacteristic is a characteristic of a marketplace card by name, converted to the characteristic
+// of the other marketplace with the same name.
+type ProductCharacteristic struct {
+	Name   string
+	Values []string
+}
+
+// OzonProduct is an existing Ozon product with the names of its attributes resolved.
+type OzonProduct struct {
+	Info            OzonProductInfo
+	Details         OzonProductDetails
+	Characteristics []ProductCharacteristic // Attributes except those mapped to card fields, e.g. brand and description
+}
+
+// CrossListResult is the outcome of publishing the copied card and how the source card was converted.
+type CrossListResult struct {
+	CreateProductCardResult
+	VendorCode              string
+	UnmappedCharacteristics []string // Source characteristics without a matching attribute of the target category
+}
@@ -0,0 +1,9 @@
+package entities
+
+// Marketplace identifies a supported marketplace.
+type Marketplace string
+
+const (
+	MarketplaceWB   Marketplace = "wb"
+	MarketplaceOzon Marketplace = "ozon"
+)
@@ -0,0 +1,55 @@
+package entities
+
+import "errors"
+
+var (
+	// ErrMediaNotFound is returned when a referenced media ID does not exist in the file storage.
+	ErrMediaNotFound = errors.New("media not found")
+	// ErrMediaTooLarge is returned when an uploaded media stream exceeds the configured size limit.
+	ErrMediaTooLarge = errors.New("media exceeds maximum upload size")
+)
+
+// MediaFile describes a media file persisted in the file storage and addressable by ID.
+type MediaFile struct {
+	ID          string // Storage-unique identifier returned to the client
+	Filename    string // Original filename
+	ContentType string
+	URL         string // Public URL of the stored file
+	Size        int64  // Size in bytes
+}
+
+// MediaKind describes the role of a media file in a product card.
+type MediaKind int32
+
+const (
+	MediaKindPhoto       MediaKind = iota // Default kind
+	MediaKindVideo                        // WB accepts one video per card, Ozon takes videos as complex attributes
+	MediaKindImage360                     // Frame of a 360-degree image set, Ozon only
+	MediaKindColorSwatch                  // Color sample image, Ozon only
+)
+
+// MediaReference points at a media file previously uploaded through the media upload endpoint.
+type MediaReference struct {
+	MediaID     string
+	PhotoNumber int32 // Position of the media in the card (1-based)
+	Kind        MediaKind
+	Primary     bool // Main photo, or video cover when Kind is MediaKindVideo
+}
+
+// MediaLink is a publicly reachable media file ready to be passed to a marketplace.
+type MediaLink struct {
+	URL     string
+	Name    string
+	Kind    MediaKind
+	Primary bool
+}
+
+// IsOzonVideoCover reports whether the reference is the cover of an Ozon video, which WB has no place for.
+func (r *MediaReference) IsOzonVideoCover() bool {
+	return r.Kind == MediaKindVideo && r.Primary
+}
+
+// IsOzonVideoCover reports whether the link is the cover of an Ozon video, which WB has no place for.
+func (l *MediaLink) IsOzonVideoCover() bool {
+	return l.Kind == MediaKindVideo && l.Primary
+}
@@ -0,0 +1,42 @@
+package entities
+
+// ChatMessage is a single message of an OpenAI-compatible chat completion.
+type ChatMessage struct {
+	Role    string `json:"role"` // "system", "user" or "assistant"
+	Content string `json:"content"`
+}
+
+// ChatResponseFormat asks the model for a specific output format.
+type ChatResponseFormat struct {
+	Type string `json:"type"` // e.g., "json_object"
+}
+
+// ChatCompletionRequest is the request body for POST /v1/chat/completions.
+type ChatCompletionRequest struct {
+	Model          string              `json:"model"`
+	Messages       []ChatMessage       `json:"messages"`
+	Temperature    *float64            `json:"temperature,omitempty"`
+	ResponseFormat *ChatResponseFormat `json:"response_format,omitempty"`
+}
+
+// ChatCompletionChoice is a single completion returned by the model.
+type ChatCompletionChoice struct {
+	Index        int         `json:"index"`
+	Message      ChatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+// ChatCompletionUsage reports tokens spent on the completion.
+type ChatCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// ChatCompletionResponse is the response from POST /v1/chat/completions.
+type ChatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Model   string                 `json:"model"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   ChatCompletionUsage    `json:"usage"`
+}
@@ -1,5 +1,10 @@
 package entities
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // OzonProductAttributeValue represents a value for a product attribute.
 type OzonProductAttributeValue struct {
 	DictionaryValueID int64  `json:"dictionary_value_id,omitempty"`
@@ -13,6 +18,25 @@ type OzonProductAttribute struct {
 	Values    []OzonProductAttributeValue `json:"values"`
 }
 
+// Ozon attributes filled from the generated content and the request.
+const (
+	OzonBrandAttributeID      = 85    // Brand
+	OzonAnnotationAttributeID = 4191  // Annotation (product description)
+	OzonModelNameAttributeID  = 9048  // Model name, used to merge products into one card
+	OzonColorNameAttributeID  = 10097 // Color name
+	OzonSizeAttributeID       = 4295  // Russian size, a dictionary attribute
+	OzonColorAttributeID      = 10096 // Color, a dictionary attribute
+)
+
+// Complex attributes used to attach videos to an Ozon product.
+const (
+	OzonVideoComplexID           = 100001 // Video group
+	OzonVideoURLAttributeID      = 21841  // Video link
+	OzonVideoNameAttributeID     = 21837  // Video name
+	OzonVideoCoverComplexID      = 100002 // Video cover group
+	OzonVideoCoverURLAttributeID = 21845  // Video cover link
+)
+
 // OzonComplexAttribute represents a complex attribute for Ozon (e.g., for video).
 type OzonComplexAttribute struct {
 	Attributes []OzonProductAttribute `json:"attributes"`
@@ -48,6 +72,15 @@ type OzonProductImportItem struct {
 	Width                    int32                  `json:"width"`
 }
 
+// OzonProductMedia groups the media-related fields of an Ozon product.
+type OzonProductMedia struct {
+	Images            []string
+	Images360         []string
+	PrimaryImage      string
+	ColorImage        string
+	ComplexAttributes []OzonComplexAttribute
+}
+
 // OzonProductImportRequest is the request body for POST /v3/product/import.
 type OzonProductImportRequest struct {
 	Items []OzonProductImportItem `json:"items"`
@@ -63,6 +96,283 @@ type OzonProductImportResponse struct {
 	Result OzonProductImportResponseResult `json:"result"`
 }
 
+// OzonAttributeValuesSearchRequest is the request body for POST /v1/description-category/attribute/values/search.
+type OzonAttributeValuesSearchRequest struct {
+	AttributeID           int64  `json:"attribute_id"`
+	DescriptionCategoryID int64  `json:"description_category_id"`
+	TypeID                int64  `json:"type_id"`
+	Value                 string `json:"value"`
+	Limit                 int    `json:"limit"`
+}
+
+// OzonAttributeValue is a dictionary value of an Ozon attribute.
+type OzonAttributeValue struct {
+	ID      int64  `json:"id"`
+	Value   string `json:"value"`
+	Info    string `json:"info,omitempty"`
+	Picture string `json:"picture,omitempty"`
+}
+
+// OzonAttributeValuesSearchResponse is the response from POST /v1/description-category/attribute/values/search.
+type OzonAttributeValuesSearchResponse struct {
+	Result []OzonAttributeValue `json:"result"`
+}
+
+// OzonCategoryAttributesRequest is the request body for POST /v1/description-category/attribute.
+type OzonCategoryAttributesRequest struct {
+	DescriptionCategoryID int64  `json:"description_category_id"`
+	TypeID                int64  `json:"type_id"`
+	Language              string `json:"language,omitempty"` // DEFAULT is Russian
+}
+
+// OzonCategoryAttribute is an attribute of an Ozon description category and type.
+type OzonCategoryAttribute struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Type         string `json:"type"`
+	IsCollection bool   `json:"is_collection"` // Accepts several values
+	IsRequired   bool   `json:"is_required"`
+	DictionaryID int64  `json:"dictionary_id"` // Values must be taken from the dictionary, 0 for free values
+	GroupName    string `json:"group_name"`
+}
+
+// OzonCategoryAttributesResponse is the response from POST /v1/description-category/attribute.
+type OzonCategoryAttributesResponse struct {
+	Result []OzonCategoryAttribute `json:"result"`
+}
+
+// OzonProductInfoListRequest is the request body for POST /v3/product/info/list.
+type OzonProductInfoListRequest struct {
+	OfferID   []string `json:"offer_id,omitempty"`
+	ProductID []string `json:"product_id,omitempty"`
+}
+
+// OzonProductInfo is a product in the /v3/product/info/list response, reduced to the fields used here.
+type OzonProductInfo struct {
+	ID                    int64         `json:"id"`
+	OfferID               string        `json:"offer_id"`
+	Barcodes              []string      `json:"barcodes"`
+	Name                  string        `json:"name"`
+	DescriptionCategoryID int64         `json:"description_category_id"`
+	TypeID                int64         `json:"type_id"`
+	Price                 string        `json:"price"`     // Price with discount
+	OldPrice              string        `json:"old_price"` // Price before discount, empty or "0" without discount
+	ModelInfo             OzonModelInfo `json:"model_info"`
+}
+
+// OzonModelInfo is the model a product is merged into one card with, Count includes the product itself.
+type OzonModelInfo struct {
+	ModelID int64 `json:"model_id"`
+	Count   int64 `json:"count"`
+}
+
+// OzonProductInfoListResponse is the response from POST /v3/product/info/list.
+type OzonProductInfoListResponse struct {
+	Items []OzonProductInfo `json:"items"`
+}
+
+// OzonProductAttributesFilter selects the products of POST /v4/product/info/attributes.
+type OzonProductAttributesFilter struct {
+	OfferID    []string `json:"offer_id,omitempty"`
+	Visibility string   `json:"visibility,omitempty"` // ALL includes archived products
+}
+
+// OzonProductAttributesRequest is the request body for POST /v4/product/info/attributes.
+type OzonProductAttributesRequest struct {
+	Filter OzonProductAttributesFilter `json:"filter"`
+	Limit  int                         `json:"limit"`
+}
+
+// OzonProductDetails is a product with its attributes, dimensions and images.
+type OzonProductDetails struct {
+	ID                    int64                  `json:"id"`
+	OfferID               string                 `json:"offer_id"`
+	Name                  string                 `json:"name"`
+	Barcodes              []string               `json:"barcodes"`
+	DescriptionCategoryID int64                  `json:"description_category_id"`
+	TypeID                int64                  `json:"type_id"`
+	Depth                 int32                  `json:"depth"`
+	Width                 int32                  `json:"width"`
+	Height                int32                  `json:"height"`
+	DimensionUnit         string                 `json:"dimension_unit"`
+	Weight                int32                  `json:"weight"`
+	WeightUnit            string                 `json:"weight_unit"`
+	PrimaryImage          string                 `json:"primary_image"`
+	Images                []string               `json:"images"`
+	Attributes            []OzonProductAttribute `json:"attributes"`
+	ComplexAttributes     []OzonComplexAttribute `json:"complex_attributes"`
+}
+
+// AttributeValues returns the values of the attribute with id as text.
+func (d *OzonProductDetails) AttributeValues(id int64) []string {
+	var values []string
+	for _, a := range d.Attributes {
+		if a.ID != id {
+			continue
+		}
+		for _, v := range a.Values {
+			if v.Value != "" {
+				values = append(values, v.Value)
+			}
+		}
+	}
+	return values
+}
+
+// OzonProductAttributesResponse is the response from POST /v4/product/info/attributes.
+type OzonProductAttributesResponse struct {
+	Result []OzonProductDetails `json:"result"`
+	Total  int                  `json:"total"`
+	LastID string               `json:"last_id"`
+}
+
+// OzonBarcodeGenerateRequest is the request body for POST /v1/barcode/generate.
+type OzonBarcodeGenerateRequest struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+// OzonBarcodeError is a product Ozon could not generate a barcode for.
+type OzonBarcodeError struct {
+	Code      string `json:"code"`
+	Error     string `json:"error"`
+	Barcode   string `json:"barcode"`
+	ProductID int64  `json:"product_id"`
+}
+
+// OzonBarcodeGenerateResponse is the response from POST /v1/barcode/generate.
+type OzonBarcodeGenerateResponse struct {
+	Errors []OzonBarcodeError `json:"errors"`
+}
+
+// OzonPrice is the price of one Ozon offer for POST /v1/product/import/prices.
+type OzonPrice struct {
+	OfferID      string `json:"offer_id"`
+	Price        string `json:"price"`
+	OldPrice     string `json:"old_price,omitempty"` // Price before discount, "0" resets it
+	MinPrice     string `json:"min_price,omitempty"`
+	CurrencyCode string `json:"currency_code,omitempty"`
+}
+
+// OzonImportPricesRequest is the request body for POST /v1/product/import/prices.
+type OzonImportPricesRequest struct {
+	Prices []OzonPrice `json:"prices"`
+}
+
+// OzonPriceUpdateError is an error of one offer in the price update response.
+type OzonPriceUpdateError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OzonPriceUpdateResult is the result of one offer in the price update response.
+type OzonPriceUpdateResult struct {
+	ProductID int64                  `json:"product_id"`
+	OfferID   string                 `json:"offer_id"`
+	Updated   bool                   `json:"updated"`
+	Errors    []OzonPriceUpdateError `json:"errors"`
+}
+
+// OzonImportPricesResponse is the response from POST /v1/product/import/prices.
+type OzonImportPricesResponse struct {
+	Result []OzonPriceUpdateResult `json:"result"`
+}
+
+// OzonWarehouse is a seller warehouse returned by POST /v1/warehouse/list.
+type OzonWarehouse struct {
+	WarehouseID int64  `json:"warehouse_id"`
+	Name        string `json:"name"`
+	IsRFBS      bool   `json:"is_rfbs"`
+	Status      string `json:"status"`
+}
+
+// OzonWarehouseListResponse is the response from POST /v1/warehouse/list.
+type OzonWarehouseListResponse struct {
+	Result []OzonWarehouse `json:"result"`
+}
+
+// OzonStock is the stock of one offer in a seller warehouse for POST /v2/products/stocks.
+type OzonStock struct {
+	OfferID     string `json:"offer_id"`
+	Stock       int    `json:"stock"`
+	WarehouseID int64  `json:"warehouse_id"`
+}
+
+// OzonStocksRequest is the request body for POST /v2/products/stocks.
+type OzonStocksRequest struct {
+	Stocks []OzonStock `json:"stocks"`
+}
+
+// OzonStockUpdateResult is the result of one offer in the stock update response.
+type OzonStockUpdateResult struct {
+	WarehouseID int64                  `json:"warehouse_id"`
+	ProductID   int64                  `json:"product_id"`
+	OfferID     string                 `json:"offer_id"`
+	Updated     bool                   `json:"updated"`
+	Errors      []OzonPriceUpdateError `json:"errors"`
+}
+
+// OzonStocksResponse is the response from POST /v2/products/stocks.
+type OzonStocksResponse struct {
+	Result []OzonStockUpdateResult `json:"result"`
+}
+
+// OzonProductStocksFilter selects the products of POST /v4/product/info/stocks.
+type OzonProductStocksFilter struct {
+	OfferID    []string `json:"offer_id,omitempty"`
+	Visibility string   `json:"visibility"`
+}
+
+// OzonProductStocksRequest is the request body for POST /v4/product/info/stocks.
+type OzonProductStocksRequest struct {
+	Filter OzonProductStocksFilter `json:"filter"`
+	Cursor string                  `json:"cursor,omitempty"`
+	Limit  int                     `json:"limit"`
+}
+
+// OzonProductStock is the stock of a product of one fulfillment type.
+type OzonProductStock struct {
+	Type     string `json:"type"` // "fbo" or "fbs"
+	SKU      int64  `json:"sku"`  // Ozon SKU of the product for this fulfillment type
+	Present  int    `json:"present"`
+	Reserved int    `json:"reserved"`
+}
+
+// OzonProductStocks are the stocks of one product.
+type OzonProductStocks struct {
+	OfferID   string             `json:"offer_id"`
+	ProductID int64              `json:"product_id"`
+	Stocks    []OzonProductStock `json:"stocks"`
+}
+
+// OzonProductStocksResponse is the response from POST /v4/product/info/stocks.
+type OzonProductStocksResponse struct {
+	Cursor string              `json:"cursor"`
+	Items  []OzonProductStocks `json:"items"`
+	Total  int                 `json:"total"`
+}
+
+// OzonWarehouseStocksRequest is the request body for POST /v1/product/info/stocks-by-warehouse/fbs.
+type OzonWarehouseStocksRequest struct {
+	SKU []string `json:"sku"`
+}
+
+// OzonWarehouseStock is the FBS stock of a product in one seller warehouse.
+type OzonWarehouseStock struct {
+	SKU           int64  `json:"sku"`
+	FBSSKU        int64  `json:"fbs_sku"`
+	ProductID     int64  `json:"product_id"`
+	Present       int    `json:"present"`
+	Reserved      int    `json:"reserved"`
+	WarehouseID   int64  `json:"warehouse_id"`
+	WarehouseName string `json:"warehouse_name"`
+}
+
+// OzonWarehouseStocksResponse is the response from POST /v1/product/info/stocks-by-warehouse/fbs.
+type OzonWarehouseStocksResponse struct {
+	Result []OzonWarehouseStock `json:"result"`
+}
+
 // OzonErrorDetail represents a detail in Ozon's error response.
 type OzonErrorDetail struct {
 	TypeURL string `json:"typeUrl"` // Note: Ozon's actual error structure might differ.
@@ -71,7 +381,24 @@ type OzonErrorDetail struct {
 
 // OzonError represents an error response from Ozon API.
 type OzonError struct {
-	Code    interface{}       `json:"code"` // Can be string or int
-	Message string            `json:"message"`
-	Details []OzonErrorDetail `json:"details,omitempty"`
+	Code       interface{}       `json:"code"` // Can be string or int
+	Message    string            `json:"message"`
+	Details    []OzonErrorDetail `json:"details,omitempty"`
+	HTTPStatus int               `json:"-"` // Status code of the response the error was parsed from
+}
+
+func (e *OzonError) Error() string {
+	return fmt.Sprintf("ozon API returned status %d (code %s): %s", e.HTTPStatus, e.CodeString(), e.Message)
+}
+
+// CodeString returns the Ozon error code as text; numeric codes are formatted without fraction.
+func (e *OzonError) CodeString() string {
+	switch code := e.Code.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(code, 'f', -1, 64)
+	default:
+		return fmt.Sprint(code)
+	}
 }
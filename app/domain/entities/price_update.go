@@ -0,0 +1,20 @@
+package entities
+
+// PriceUpdateRequest sets prices on one or both marketplaces. Lists that are empty are skipped.
+type PriceUpdateRequest struct {
+	WbApiKey        string
+	OzonApiClientId string
+	OzonApiKey      string
+	WbPrices        []WBPriceGood
+	OzonPrices      []OzonPrice
+}
+
+// PriceUpdateResult is the outcome of a PriceUpdateRequest. A failure on one marketplace
+// does not prevent the update on the other, its error is reported instead.
+type PriceUpdateResult struct {
+	WbUploadID  int64
+	WbTask      *WBPriceTask // nil while WB is still processing the upload
+	WbError     string
+	OzonResults []OzonPriceUpdateResult
+	OzonError   string
+}
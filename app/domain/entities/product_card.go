@@ -21,6 +21,94 @@ type ProductCard struct {
 	WbMediaToSaveLinks   []string
 	OzonApiClientId      string
 	OzonApiKey           string
+	Media                []*MediaReference
+	MediaLinks           []*MediaLink           // Media resolved to public links, filled by the create card use case
+	ResolveCategories    []Marketplace          // Marketplaces whose categories CardCraftAI should resolve, empty for defaults
+	ForceRegenerate      bool                   // Bypass the generated content cache
+	ContentProvider      ContentProvider        // Requested content generator, empty for the account or server default
+	ContentVariants      int                    // Number of content alternatives to generate
+	ContentVariant       int                    // 0-based index of the alternative being generated, set by the content generation service
+	ContentValidation    ContentValidationMode  // Handling of content that breaks marketplace constraints, empty for truncate
+	ContentFeedback      string                 // Problems of the previous generation the generator should avoid
+	DryRun               *bool                  // Prepare marketplace requests without sending them, nil for the environment default
+	ImtID                int64                  // Existing WB card group the product is added to, 0 to create a new card
+	ModelName            string                 // Ozon model name shared by the offers of one product, empty for ProductTitle
+	Variants             []*ProductVariant      // Colors of the product, each a WB variant of one card and an Ozon offer
+	Color                string                 // Color of the variant, set on the cards returned by ExpandVariants
+	WbDiscount           int                    // Discount in percent set on WB together with the price
+	InitialStock         *InitialStock          // Stock set once the marketplaces have created the card, nil to leave it at zero
+	OzonAttributes       []OzonProductAttribute // Attributes of the Ozon category sent as is, e.g. converted from a WB card
+	WbCharacteristics    []WBCharacteristic     // Characteristics of the WB subject sent as is, e.g. converted from an Ozon product
+}
+
+// ProductVariant is one color of a multi-variant product. It overrides the vendor code, sizes and media of the card.
+type ProductVariant struct {
+	VendorCode           string
+	Color                string
+	Sizes                []*WBSize
+	WbMediaToUploadFiles []*WBClientMediaFile
+	WbMediaToSaveLinks   []string
+	Media                []*MediaReference
+	MediaLinks           []*MediaLink // Media resolved to public links, filled by the create card use case
+}
+
+// ExpandVariants returns a card per variant, sharing everything else with pc.
+// Variants without own media use the media of pc. A card without variants is returned as is.
+func (pc *ProductCard) ExpandVariants() []*ProductCard {
+	if len(pc.Variants) == 0 {
+		return []*ProductCard{pc}
+	}
+	cards := make([]*ProductCard, len(pc.Variants))
+	for i, v := range pc.Variants {
+		card := *pc
+		card.ModelName = pc.GetModelName()
+		card.Variants = nil
+		card.VendorCode = v.VendorCode
+		card.Color = v.Color
+		card.Sizes = v.Sizes
+		if len(v.WbMediaToUploadFiles) > 0 || len(v.WbMediaToSaveLinks) > 0 || len(v.Media) > 0 {
+			card.WbMediaToUploadFiles = v.WbMediaToUploadFiles
+			card.WbMediaToSaveLinks = v.WbMediaToSaveLinks
+			card.Media = v.Media
+			card.MediaLinks = v.MediaLinks
+		}
+		cards[i] = &card
+	}
+	return cards
+}
+
+// WithBarcodes returns a copy of pc whose sizes without SKUs get the generated barcodes of their vendor code and
+// tech size, in the order they were generated. pc and its sizes are left as is.
+func (pc *ProductCard) WithBarcodes(generated []*GeneratedBarcodes) *ProductCard {
+	type sizeKey struct{ vendorCode, techSize string }
+	barcodes := make(map[sizeKey][][]string)
+	for _, g := range generated {
+		key := sizeKey{g.VendorCode, g.TechSize}
+		barcodes[key] = append(barcodes[key], g.Barcodes)
+	}
+	withBarcodes := func(vendorCode string, sizes []*WBSize) []*WBSize {
+		copied := make([]*WBSize, len(sizes))
+		for i, s := range sizes {
+			size := *s
+			if key := (sizeKey{vendorCode, s.TechSize}); len(size.Skus) == 0 && len(barcodes[key]) > 0 {
+				size.Skus, barcodes[key] = barcodes[key][0], barcodes[key][1:]
+			}
+			copied[i] = &size
+		}
+		return copied
+	}
+
+	card := *pc
+	card.Sizes = withBarcodes(pc.VendorCode, pc.Sizes)
+	if pc.Variants != nil {
+		card.Variants = make([]*ProductVariant, len(pc.Variants))
+		for i, v := range pc.Variants {
+			variant := *v
+			variant.Sizes = withBarcodes(v.VendorCode, v.Sizes)
+			card.Variants[i] = &variant
+		}
+	}
+	return &card
 }
 
 func (pc *ProductCard) GetOzonApiClientId() string {
@@ -54,3 +142,48 @@ func (pc *ProductCard) GetWbMediaToUploadFiles() []*WBClientMediaFile {
 func (pc *ProductCard) GetWbMediaToSaveLinks() []string {
 	return pc.WbMediaToSaveLinks
 }
+
+// IsDryRun reports whether marketplace requests must only be prepared, not sent.
+func (pc *ProductCard) IsDryRun() bool {
+	return pc.DryRun != nil && *pc.DryRun
+}
+
+// GetModelName returns the Ozon model name. Offers with equal model names are merged into one product card,
+// so it falls back to the vendor code, which is unique per product, rather than a title that unrelated products
+// may share. The variants of a multi-variant product share the vendor code of the first variant.
+func (pc *ProductCard) GetModelName() string {
+	if pc.ModelName != "" {
+		return pc.ModelName
+	}
+	if len(pc.Variants) > 0 {
+		return pc.Variants[0].VendorCode
+	}
+	return pc.VendorCode
+}
+
+func (pc *ProductCard) GetMedia() []*MediaReference {
+	return pc.Media
+}
+
+func (pc *ProductCard) GetMediaLinks() []*MediaLink {
+	return pc.MediaLinks
+}
+
+// SetCategoriesFrom copies the categories resolved for the generated content, so they are not resolved again.
+func (pc *ProductCard) SetCategoriesFrom(content *CardCraftAiGeneratedContent) {
+	if content.ParentID != nil {
+		pc.ParentId = *content.ParentID
+	}
+	if content.SubjectID != nil {
+		pc.SubjectId = *content.SubjectID
+	}
+	if content.RootID != nil {
+		pc.RootId = *content.RootID
+	}
+	if content.SubID != nil {
+		pc.SubId = *content.SubID
+	}
+	if content.TypeID != nil {
+		pc.TypeId = *content.TypeID
+	}
+}
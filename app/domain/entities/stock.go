@@ -0,0 +1,47 @@
+package entities
+
+// Warehouse is a seller warehouse on a marketplace that stock can be set in.
+type Warehouse struct {
+	Marketplace Marketplace
+	ID          int64
+	Name        string
+}
+
+// InitialStock is the stock set for every size of a new card once the marketplaces have created it.
+// A zero warehouse ID skips the marketplace.
+type InitialStock struct {
+	WbWarehouseID   int64
+	OzonWarehouseID int64
+	Amount          int
+}
+
+// WBWarehouseStock is the stock of one WB size in a seller warehouse.
+type WBWarehouseStock struct {
+	WarehouseID int64
+	WBStock
+}
+
+// StockRequest lists warehouses or sets stocks on one or both marketplaces.
+// Marketplaces without credentials are skipped when listing warehouses.
+type StockRequest struct {
+	WbApiKey        string
+	OzonApiClientId string
+	OzonApiKey      string
+	WbStocks        []WBWarehouseStock
+	OzonStocks      []OzonStock
+}
+
+// WarehouseListResult is the outcome of listing warehouses. A failure on one marketplace
+// does not prevent listing the other, its error is reported instead.
+type WarehouseListResult struct {
+	Warehouses []Warehouse
+	WbError    string
+	OzonError  string
+}
+
+// StockUpdateResult is the outcome of setting stocks, reported per marketplace like WarehouseListResult.
+type StockUpdateResult struct {
+	WbError     string
+	OzonResults []OzonStockUpdateResult
+	OzonError   string
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrStockSyncNotConfigured = errors.New("stock sync is not configured")
+
+// StockSyncSettings configures the stock sync of one account. Stocks are read from the source marketplace
+// and written to the other one, in the warehouses given for each marketplace.
+type StockSyncSettings struct {
+	ApiKey          string
+	Source          Marketplace
+	WbApiKey        string
+	WbWarehouseID   int64
+	OzonApiClientId string
+	OzonApiKey      string
+	OzonWarehouseID int64
+	Enabled         bool // Disabled accounts are skipped by the periodic sync but can be synced on demand
+	DryRun          bool // Report the changes without writing them
+}
+
+// Target returns the marketplace stocks are written to.
+func (s *StockSyncSettings) Target() Marketplace {
+	if s.Source == MarketplaceWB {
+		return MarketplaceOzon
+	}
+	return MarketplaceWB
+}
+
+// StockSyncMapping links a WB size, identified by its barcode, to the Ozon offer of the same item.
+type StockSyncMapping struct {
+	WbSku       string
+	VendorCode  string // WB vendor code, informational
+	OzonOfferID string
+	LastAmount  *int // Amount written by the last sync, nil if the item has not been synced yet
+}
+
+// StockSyncChange is a stock written, or to be written in dry-run mode, to the target marketplace.
+type StockSyncChange struct {
+	WbSku       string `json:"wb_sku"`
+	OzonOfferID string `json:"ozon_offer_id"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+}
+
+// StockSyncConflictKind tells why an item could not be synced cleanly.
+type StockSyncConflictKind string
+
+const (
+	StockSyncMissingInSource StockSyncConflictKind = "missing_in_source" // Not found on the source marketplace, left as is
+	StockSyncMissingInTarget StockSyncConflictKind = "missing_in_target" // Not found on the target marketplace, left as is
+	StockSyncTargetChanged   StockSyncConflictKind = "target_changed"    // Changed on the target since the last sync, overwritten
+	StockSyncUpdateFailed    StockSyncConflictKind = "update_failed"     // Rejected by the target marketplace
+)
+
+// StockSyncConflict is an item that could not be synced cleanly.
+type StockSyncConflict struct {
+	WbSku        string                `json:"wb_sku"`
+	OzonOfferID  string                `json:"ozon_offer_id"`
+	Kind         StockSyncConflictKind `json:"kind"`
+	SourceAmount int                   `json:"source_amount"`
+	TargetAmount int                   `json:"target_amount"`
+	Message      string                `json:"message,omitempty"`
+}
+
+// StockSyncReport is the outcome of one sync of an account.
+type StockSyncReport struct {
+	ApiKey     string
+	Source     Marketplace
+	DryRun     bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Changes    []StockSyncChange
+	Conflicts  []StockSyncConflict
+	Error      string // Set if the sync failed as a whole, e.g. stocks could not be read
+}
@@ -1,5 +1,11 @@
 package entities
 
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
 // WBDimensions represents product dimensions for the Wildberries API.
 type WBDimensions struct {
 	Length       *int32   `json:"length"`
@@ -18,14 +24,17 @@ type WBDimensions struct {
 type WBSize struct {
 	TechSize string   `json:"techSize"`
 	WbSize   string   `json:"wbSize,omitempty"`
-	Price    int      `json:"price,omitempty"` // Primary price in kopecks (used as fallback if marketplace-specific prices not provided)
+	Price    int      `json:"price,omitempty"` // Primary price in rubles (used as fallback if marketplace-specific prices not provided)
 	Skus     []string `json:"skus"`
 
-	// Marketplace-specific prices (in kopecks for consistency)
-	WbPrice   *int32 `json:"wbPrice,omitempty"`   // Price for WildBerries in kopecks
-	OzonPrice *int32 `json:"ozonPrice,omitempty"` // Price for Ozon in kopecks
+	// Marketplace-specific prices, in rubles like the WB and Ozon price APIs
+	WbPrice   *int32 `json:"wbPrice,omitempty"`   // Price for WildBerries in rubles
+	OzonPrice *int32 `json:"ozonPrice,omitempty"` // Price for Ozon in rubles
 }
 
+// WBColorCharacteristicID is the "Цвет" characteristic of WB cards.
+const WBColorCharacteristicID = 14177449
+
 // WBCharacteristic represents a product characteristic for the Wildberries API.
 type WBCharacteristic struct {
 	ID    int         `json:"id"`
@@ -52,6 +61,13 @@ type WBCardRequestItem struct {
 // WBCardUploadPayload is the type for the entire request payload for Wildberries card upload.
 type WBCardUploadPayload []WBCardRequestItem
 
+// WBCardAddPayload is the request payload for /content/v2/cards/upload/add, which creates cards
+// joined to an existing card group.
+type WBCardAddPayload struct {
+	ImtID      int64       `json:"imtID"`
+	CardsToAdd []WBVariant `json:"cardsToAdd"`
+}
+
 // WBCardUploadResponse represents the response from the Wildberries card upload API.
 type WBCardUploadResponse struct {
 	Data             interface{} `json:"data"` // Can be null or an object
@@ -75,11 +91,99 @@ type WBMediaGenericResponse struct {
 	AdditionalErrors interface{} `json:"additionalErrors"`
 }
 
+// WBBarcodesRequest is the request body for POST /content/v2/barcodes.
+type WBBarcodesRequest struct {
+	Count int `json:"count"`
+}
+
+// WBBarcodesResponse is the response from POST /content/v2/barcodes.
+type WBBarcodesResponse struct {
+	Data             []string    `json:"data"`
+	Error            bool        `json:"error"`
+	ErrorText        string      `json:"errorText"`
+	AdditionalErrors interface{} `json:"additionalErrors"`
+}
+
+// WBPriceGood is the price and discount of one WB card, prices are set per nmID.
+type WBPriceGood struct {
+	NmID     int `json:"nmID"`
+	Price    int `json:"price,omitempty"`
+	Discount int `json:"discount,omitempty"` // Percent
+}
+
+// WBPriceTaskRequest is the request body for POST /api/v2/upload/task.
+type WBPriceTaskRequest struct {
+	Data []WBPriceGood `json:"data"`
+}
+
+// WBPriceTaskResponse is the response from POST /api/v2/upload/task.
+type WBPriceTaskResponse struct {
+	Data struct {
+		ID            int64 `json:"id"`
+		AlreadyExists bool  `json:"alreadyExists"`
+	} `json:"data"`
+	Error     bool   `json:"error"`
+	ErrorText string `json:"errorText"`
+}
+
+// Statuses of a processed WB price upload.
+const (
+	WBPriceTaskProcessed       = 3 // All prices are set
+	WBPriceTaskCanceled        = 4
+	WBPriceTaskPartiallyFailed = 5 // Prices without errors are set
+	WBPriceTaskFailed          = 6
+)
+
+// WBPriceTask is the state of a processed WB price upload.
+type WBPriceTask struct {
+	UploadID           int64  `json:"uploadID"`
+	Status             int    `json:"status"`
+	UploadDate         string `json:"uploadDate"`
+	ActivationDate     string `json:"activationDate"`
+	OverAllGoodsNumber int    `json:"overAllGoodsNumber"`
+	SuccessGoodsNumber int    `json:"successGoodsNumber"`
+	ErrorText          string `json:"-"` // Errors of the goods, e.g. prices moved to quarantine
+}
+
+// WBPriceTaskHistoryResponse is the response from GET /api/v2/history/tasks.
+type WBPriceTaskHistoryResponse struct {
+	Data      *WBPriceTask `json:"data"`
+	Error     bool         `json:"error"`
+	ErrorText string       `json:"errorText"`
+}
+
+// WBGoodSizePrice is the price of one size of a WB card.
+type WBGoodSizePrice struct {
+	SizeID          int64   `json:"sizeID"`
+	Price           int     `json:"price"`           // Price before discount
+	DiscountedPrice float64 `json:"discountedPrice"` // Price with the seller discount
+	TechSizeName    string  `json:"techSizeName"`
+}
+
+// WBGoodPrices are the prices of the sizes of one WB card.
+type WBGoodPrices struct {
+	NmID       int               `json:"nmID"`
+	VendorCode string            `json:"vendorCode"`
+	Sizes      []WBGoodSizePrice `json:"sizes"`
+	Currency   string            `json:"currencyIsoCode4217"`
+	Discount   int               `json:"discount"` // Percent
+}
+
+// WBGoodsPriceListResponse is the response from GET /api/v2/list/goods/filter.
+type WBGoodsPriceListResponse struct {
+	Data struct {
+		ListGoods []WBGoodPrices `json:"listGoods"`
+	} `json:"data"`
+	Error     bool   `json:"error"`
+	ErrorText string `json:"errorText"`
+}
+
 // WBClientMediaFile represents a file to be uploaded by the WBClient.
 type WBClientMediaFile struct {
 	Filename    string
 	Content     []byte
 	PhotoNumber int32 // Corresponds to X-Photo-Number
+	Kind        MediaKind
 }
 
 // WBMediaUploadResult holds the outcome of a single file upload attempt.
@@ -126,9 +230,144 @@ type WBGetCardListRequest struct {
 
 // WBCardDefinition represents a card as returned by /content/v2/get/cards/list.
 type WBCardDefinition struct {
-	NmID       int    `json:"nmID"`
-	VendorCode string `json:"vendorCode"`
-	// Add other fields if necessary, e.g., Title, SubjectName for debugging
+	NmID            int                    `json:"nmID"`
+	ImtID           int                    `json:"imtID"`
+	VendorCode      string                 `json:"vendorCode"`
+	SubjectID       int                    `json:"subjectID"`
+	SubjectName     string                 `json:"subjectName"`
+	Brand           string                 `json:"brand"`
+	Title           string                 `json:"title"`
+	Description     string                 `json:"description"`
+	Photos          []WBCardPhoto          `json:"photos,omitempty"`
+	Video           string                 `json:"video,omitempty"`
+	Dimensions      *WBDimensions          `json:"dimensions,omitempty"`
+	Characteristics []WBCardCharacteristic `json:"characteristics,omitempty"`
+	Sizes           []WBCardSize           `json:"sizes,omitempty"`
+	Tags            []WBCardTag            `json:"tags,omitempty"`
+	CreatedAt       string                 `json:"createdAt,omitempty"`
+	UpdatedAt       string                 `json:"updatedAt,omitempty"`
+}
+
+// WBCardPhoto holds the URLs of one card photo in different resolutions.
+type WBCardPhoto struct {
+	Big      string `json:"big"` // 900x1200
+	C246x328 string `json:"c246x328"`
+	C516x688 string `json:"c516x688"`
+	Square   string `json:"square"` // 600x600
+	Tm       string `json:"tm"`     // 75x100
+}
+
+// WBCardCharacteristic is a characteristic of an existing card. The value type depends on the characteristic.
+type WBCardCharacteristic struct {
+	ID    int         `json:"id"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// Values returns the values of the characteristic as text. WB returns lists of strings for most
+// characteristics and a number or a string for the others.
+func (c WBCardCharacteristic) Values() []string {
+	return characteristicValues(c.Value)
+}
+
+func characteristicValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return []string{strings.TrimSpace(v)}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, characteristicValues(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// WB characteristic value types of /content/v2/object/charcs.
+const (
+	WBCharcTypeStrings = 1 // List of strings
+	WBCharcTypeNumber  = 4 // Number
+)
+
+// WBSubjectCharacteristic is a characteristic of a WB subject.
+type WBSubjectCharacteristic struct {
+	CharcID   int    `json:"charcID"`
+	SubjectID int    `json:"subjectID"`
+	Name      string `json:"name"`
+	Required  bool   `json:"required"`
+	UnitName  string `json:"unitName"`
+	MaxCount  int    `json:"maxCount"` // Maximum number of values, 0 for no limit
+	Popular   bool   `json:"popular"`
+	CharcType int    `json:"charcType"` // WBCharcTypeStrings or WBCharcTypeNumber, 0 for unused
+}
+
+// WBSubjectCharacteristicsResponse is the response from GET /content/v2/object/charcs/{subjectId}.
+type WBSubjectCharacteristicsResponse struct {
+	Data      []WBSubjectCharacteristic `json:"data"`
+	Error     bool                      `json:"error"`
+	ErrorText string                    `json:"errorText"`
+}
+
+// WBParentCategory is an entry of GET /content/v2/object/parent/all.
+type WBParentCategory struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsVisible bool   `json:"isVisible"`
+}
+
+// WBSubject is an entry of GET /content/v2/object/all.
+type WBSubject struct {
+	SubjectID   int    `json:"subjectID"`
+	SubjectName string `json:"subjectName"`
+	ParentID    int    `json:"parentID"`
+	ParentName  string `json:"parentName"`
+}
+
+// WBColor is an entry of GET /content/v2/directory/colors.
+type WBColor struct {
+	Name       string `json:"name"`
+	ParentName string `json:"parentName"` // Basic color the color belongs to
+}
+
+// WBCountry is an entry of GET /content/v2/directory/countries.
+type WBCountry struct {
+	Name     string `json:"name"` // Value of the characteristic
+	FullName string `json:"fullName"`
+}
+
+// WBTnved is an entry of GET /content/v2/directory/tnved.
+type WBTnved struct {
+	Tnved string `json:"tnved"`
+	IsKiz bool   `json:"isKiz"` // The goods need a marking code
+}
+
+// WBDirectoryResponse is the response of the category and directory methods of the content API,
+// Data is decoded by the caller.
+type WBDirectoryResponse struct {
+	Data      json.RawMessage `json:"data"`
+	Error     bool            `json:"error"`
+	ErrorText string          `json:"errorText"`
+}
+
+// WBCardSize is a size of an existing card with its barcodes.
+type WBCardSize struct {
+	ChrtID   int      `json:"chrtID"`
+	TechSize string   `json:"techSize"`
+	WbSize   string   `json:"wbSize"`
+	Skus     []string `json:"skus"`
+}
+
+// WBCardTag is a seller tag attached to a card.
+type WBCardTag struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
 }
 
 // WBGetCardListResponseCursorData is the cursor part of the response from /content/v2/get/cards/list.
@@ -145,3 +384,33 @@ type WBGetCardListResponse struct {
 	// Standard error fields are not part of the 200 response schema for this endpoint.
 	// Errors are typically handled via HTTP status codes or a different error response structure for 4xx/5xx.
 }
+
+// WBWarehouse is a seller warehouse returned by GET /api/v3/warehouses.
+type WBWarehouse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	OfficeID     int64  `json:"officeId"`
+	CargoType    int    `json:"cargoType"`
+	DeliveryType int    `json:"deliveryType"`
+}
+
+// WBStock is the stock of one size, identified by its barcode, in a seller warehouse.
+type WBStock struct {
+	Sku    string `json:"sku"`
+	Amount int    `json:"amount"`
+}
+
+// WBStocksRequest is the request body for PUT /api/v3/stocks/{warehouseId}.
+type WBStocksRequest struct {
+	Stocks []WBStock `json:"stocks"`
+}
+
+// WBStocksQuery is the request body for POST /api/v3/stocks/{warehouseId}.
+type WBStocksQuery struct {
+	Skus []string `json:"skus"`
+}
+
+// WBStocksResponse is the response from POST /api/v3/stocks/{warehouseId}. Sizes without stock are not listed.
+type WBStocksResponse struct {
+	Stocks []WBStock `json:"stocks"`
+}
@@ -0,0 +1,48 @@
+package entities
+
+// WBDirectory is a WB directory of characteristic values mirrored by the catalog sync.
+type WBDirectory string
+
+const (
+	WBDirectoryColors    WBDirectory = "colors"
+	WBDirectoryKinds     WBDirectory = "kinds"
+	WBDirectoryCountries WBDirectory = "countries"
+	WBDirectorySeasons   WBDirectory = "seasons"
+	WBDirectoryVat       WBDirectory = "vat"
+	WBDirectoryTnved     WBDirectory = "tnved" // Per subject
+)
+
+// WBDirectoryAttributes maps the lowercased names of generated attributes to the WB directory of their values.
+var WBDirectoryAttributes = map[string]WBDirectory{
+	"цвет":                WBDirectoryColors,
+	"страна производства": WBDirectoryCountries,
+	"сезон":               WBDirectorySeasons,
+	"тнвэд":               WBDirectoryTnved,
+	"код тнвэд":           WBDirectoryTnved,
+}
+
+// WBDirectoryValue is a value of a WB directory.
+type WBDirectoryValue struct {
+	Directory   WBDirectory
+	Value       string
+	Description string // Basic color of a color, full name of a country
+	SubjectID   int    // Subject of a TNVED code
+	IsKiz       bool   // The goods of a TNVED code need a marking code
+}
+
+// WBSubjectFilter selects WB subjects of the catalog mirror.
+type WBSubjectFilter struct {
+	Query    string // Part of the subject name, case-insensitive
+	ParentID int
+	Limit    int
+	Offset   int
+}
+
+// WBDirectoryFilter selects values of a WB directory of the catalog mirror.
+type WBDirectoryFilter struct {
+	Directory WBDirectory
+	Query     string // Part of the value, case-insensitive
+	SubjectID int    // Required for TNVED codes
+	Limit     int
+	Offset    int
+}
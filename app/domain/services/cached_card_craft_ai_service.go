@@ -0,0 +1,138 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// cardContentGenerator generates card content for the account with apiKey.
+type cardContentGenerator interface {
+	GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error)
+}
+
+type cardContentCacheStorage interface {
+	GetCardContent(ctx context.Context, cacheKey string, notBefore time.Time) (*entities.CachedCardContent, error)
+	SetCardContent(ctx context.Context, cacheKey string, content entities.CardCraftAiGeneratedContent, usage entities.SessionData) error
+	DeleteExpiredCardContent(ctx context.Context, notBefore time.Time) error
+}
+
+// CachedCardCraftAiService serves repeated requests of an account for identical input from the content cache
+// instead of paying tokens for the same generation again.
+type CachedCardCraftAiService struct {
+	generator     cardContentGenerator
+	cacheStorage  cardContentCacheStorage
+	counterClient tokenCounterClient
+	ttl           time.Duration
+}
+
+func NewCachedCardCraftAiService(generator cardContentGenerator, cacheStorage cardContentCacheStorage, counterClient tokenCounterClient, ttl time.Duration) *CachedCardCraftAiService {
+	return &CachedCardCraftAiService{
+		generator:     generator,
+		cacheStorage:  cacheStorage,
+		counterClient: counterClient,
+		ttl:           ttl,
+	}
+}
+
+func (c *CachedCardCraftAiService) GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+	if c.ttl <= 0 {
+		return c.generator.GetCardContent(ctx, apiKey, req)
+	}
+
+	cacheKey := cardContentCacheKey(apiKey, req)
+	if !req.ForceRegenerate {
+		cached, err := c.cacheStorage.GetCardContent(ctx, cacheKey, time.Now().Add(-c.ttl))
+		if err != nil {
+			log.Printf("[CONTENT CACHE] Failed to read cache for key %s: %v", cacheKey, err)
+		} else if cached != nil {
+			log.Printf("[CONTENT CACHE] Hit for key %s, cached at %s", cacheKey, cached.CreatedAt.Format(time.RFC3339))
+			content := cached.Content
+			content.SessionID = ""
+			content.FromCache = true
+			content.TokenUsage = &cached.TokenUsage
+			return &content, nil
+		}
+	} else {
+		log.Printf("[CONTENT CACHE] Bypassing cache for key %s: force_regenerate is set", cacheKey)
+	}
+
+	content, err := c.generator.GetCardContent(ctx, apiKey, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remember the token usage with the content, so cache hits can be billed relative to the original cost
+	var usage entities.SessionData
+	if content.TokenUsage != nil {
+		usage = *content.TokenUsage
+	} else if content.SessionID != "" {
+		sessionData, err := c.counterClient.GetSessionData(ctx, content.SessionID)
+		if err != nil {
+			log.Printf("[CONTENT CACHE] Failed to get token usage for session %s: %v", content.SessionID, err)
+		} else {
+			usage = *sessionData
+			content.TokenUsage = sessionData
+		}
+	}
+
+	if err := c.cacheStorage.SetCardContent(ctx, cacheKey, *content, usage); err != nil {
+		log.Printf("[CONTENT CACHE] Failed to store content for key %s: %v", cacheKey, err)
+	}
+	return content, nil
+}
+
+// StartCleanupRoutine periodically removes expired cache entries. It blocks, so run it in a goroutine.
+func (c *CachedCardCraftAiService) StartCleanupRoutine(interval time.Duration) {
+	if c.ttl <= 0 || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := c.cacheStorage.DeleteExpiredCardContent(context.Background(), time.Now().Add(-c.ttl)); err != nil {
+			log.Printf("[CONTENT CACHE] Failed to delete expired entries: %v", err)
+		}
+	}
+}
+
+// cardContentCacheKey hashes the account and the normalized input that affects the generated content. The key
+// is scoped to the account, since prompts are rendered from the whole card and the account's settings.
+func cardContentCacheKey(apiKey string, req entities.ProductCard) string {
+	cardCraftAiRequest := newCardCraftAiRequest(req)
+	// Sent to the generator as they are, so they are compared exactly
+	product, _ := json.Marshal(struct {
+		VendorCode string
+		Color      string
+		Sizes      []*entities.WBSize
+		Dimensions *entities.WBDimensions
+	}{req.VendorCode, req.Color, req.Sizes, req.Dimensions})
+	normalized := strings.Join([]string{
+		fmt.Sprintf("account=%s", apiKey),
+		normalizeCacheText(req.ProductTitle),
+		normalizeCacheText(req.ProductDescription),
+		normalizeCacheText(req.Brand),
+		fmt.Sprintf("provider=%s", req.ContentProvider),
+		fmt.Sprintf("variant=%d", req.ContentVariant),
+		fmt.Sprintf("generate_content=%t", req.GenerateContent),
+		fmt.Sprintf("translate=%t", req.Translate),
+		fmt.Sprintf("resolve_wb=%t", cardCraftAiRequest.ResolveWbCategory),
+		fmt.Sprintf("resolve_ozon=%t", cardCraftAiRequest.ResolveOzonCategory),
+		fmt.Sprintf("categories=%d/%d/%d/%d/%d", req.ParentId, req.SubjectId, req.RootId, req.SubId, req.TypeId),
+		fmt.Sprintf("product=%s", product),
+	}, "\x00")
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// normalizeCacheText lowercases the text and collapses whitespace.
+func normalizeCacheText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
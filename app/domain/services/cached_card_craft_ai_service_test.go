@@ -0,0 +1,137 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeContentGenerator struct {
+	calls int
+}
+
+func (f *fakeContentGenerator) GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+	f.calls++
+	return &entities.CardCraftAiGeneratedContent{Title: "Футболка", SessionID: "session"}, nil
+}
+
+type fakeContentCacheStorage struct {
+	entries map[string]entities.CachedCardContent
+}
+
+func (f *fakeContentCacheStorage) GetCardContent(ctx context.Context, cacheKey string, notBefore time.Time) (*entities.CachedCardContent, error) {
+	cached, ok := f.entries[cacheKey]
+	if !ok || cached.CreatedAt.Before(notBefore) {
+		return nil, nil
+	}
+	return &cached, nil
+}
+
+func (f *fakeContentCacheStorage) SetCardContent(ctx context.Context, cacheKey string, content entities.CardCraftAiGeneratedContent, usage entities.SessionData) error {
+	f.entries[cacheKey] = entities.CachedCardContent{Content: content, TokenUsage: usage, CreatedAt: time.Now()}
+	return nil
+}
+
+func (f *fakeContentCacheStorage) DeleteExpiredCardContent(ctx context.Context, notBefore time.Time) error {
+	return nil
+}
+
+type fakeTokenCounter struct {
+	calls int
+}
+
+func (f *fakeTokenCounter) GetSessionData(ctx context.Context, sessionID string) (*entities.SessionData, error) {
+	f.calls++
+	return &entities.SessionData{SessionID: sessionID, TotalPromptTokens: 100, TotalCompletionTokens: 50, TotalTokens: 150}, nil
+}
+
+func TestCachedCardCraftAiService_ServesRepeatedInputFromCache(t *testing.T) {
+	generator := &fakeContentGenerator{}
+	storage := &fakeContentCacheStorage{entries: map[string]entities.CachedCardContent{}}
+	s := NewCachedCardCraftAiService(generator, storage, &fakeTokenCounter{}, time.Hour)
+
+	first, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Футболка  хлопковая", GenerateContent: true})
+	if err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if first.FromCache || first.TokenUsage == nil || first.TokenUsage.TotalTokens != 150 {
+		t.Errorf("first content = %+v, want generated content with its token usage", first)
+	}
+
+	// Case and whitespace do not change the cache key
+	second, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "футболка хлопковая ", GenerateContent: true})
+	if err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if generator.calls != 1 {
+		t.Errorf("generator called %d times, want 1", generator.calls)
+	}
+	if !second.FromCache || second.SessionID != "" || second.TokenUsage == nil || second.TokenUsage.TotalPromptTokens != 100 {
+		t.Errorf("second content = %+v, want a cache hit with the original token usage and no session", second)
+	}
+
+	if _, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Футболка хлопковая", GenerateContent: true, ForceRegenerate: true}); err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if generator.calls != 2 {
+		t.Errorf("generator called %d times, want force_regenerate to bypass the cache", generator.calls)
+	}
+}
+
+func TestCachedCardCraftAiService_KeyDependsOnCategories(t *testing.T) {
+	generator := &fakeContentGenerator{}
+	s := NewCachedCardCraftAiService(generator, &fakeContentCacheStorage{entries: map[string]entities.CachedCardContent{}}, &fakeTokenCounter{}, time.Hour)
+
+	for _, req := range []entities.ProductCard{
+		{ProductTitle: "Футболка"},
+		{ProductTitle: "Футболка", SubjectId: 192},
+		{ProductTitle: "Футболка", SubjectId: 192, SubId: 17028922, TypeId: 91565},
+	} {
+		if _, err := s.GetCardContent(context.Background(), "key", req); err != nil {
+			t.Fatalf("GetCardContent: %v", err)
+		}
+	}
+	if generator.calls != 3 {
+		t.Errorf("generator called %d times, want a separate entry per set of supplied categories", generator.calls)
+	}
+}
+
+func TestCachedCardCraftAiService_KeyDependsOnAccountAndProduct(t *testing.T) {
+	generator := &fakeContentGenerator{}
+	s := NewCachedCardCraftAiService(generator, &fakeContentCacheStorage{entries: map[string]entities.CachedCardContent{}}, &fakeTokenCounter{}, time.Hour)
+
+	length := int32(30)
+	for _, request := range []struct {
+		apiKey string
+		card   entities.ProductCard
+	}{
+		{"key", entities.ProductCard{ProductTitle: "Футболка"}},
+		{"other", entities.ProductCard{ProductTitle: "Футболка"}},
+		{"key", entities.ProductCard{ProductTitle: "Футболка", VendorCode: "tshirt-1"}},
+		{"key", entities.ProductCard{ProductTitle: "Футболка", Sizes: []*entities.WBSize{{TechSize: "42"}}}},
+		{"key", entities.ProductCard{ProductTitle: "Футболка", Dimensions: &entities.WBDimensions{Length: &length}}},
+	} {
+		if _, err := s.GetCardContent(context.Background(), request.apiKey, request.card); err != nil {
+			t.Fatalf("GetCardContent: %v", err)
+		}
+	}
+	if generator.calls != 5 {
+		t.Errorf("Expected a cache miss for every account, vendor code, sizes and dimensions, got %d generations", generator.calls)
+	}
+}
+
+func TestCachedCardCraftAiService_DisabledWithoutTTL(t *testing.T) {
+	generator := &fakeContentGenerator{}
+	storage := &fakeContentCacheStorage{entries: map[string]entities.CachedCardContent{}}
+	s := NewCachedCardCraftAiService(generator, storage, &fakeTokenCounter{}, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Футболка"}); err != nil {
+			t.Fatalf("GetCardContent: %v", err)
+		}
+	}
+	if generator.calls != 2 || len(storage.entries) != 0 {
+		t.Errorf("generator called %d times with %d cache entries, want the cache bypassed", generator.calls, len(storage.entries))
+	}
+}
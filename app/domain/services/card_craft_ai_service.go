@@ -4,10 +4,11 @@ import (
 	"api/app/domain/entities"
 	"api/metrics"
 	"context"
+	"log"
 )
 
 type cardCraftAiClient interface {
-	GetCardContent(ctx context.Context, sessionID string, productCard entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error)
+	GetCardContent(ctx context.Context, sessionID string, cardCraftAiRequest entities.CardCraftAiRequest) (*entities.CardCraftAiGeneratedContent, error)
 	GetSessionID(ctx context.Context) (string, error)
 }
 
@@ -21,18 +22,71 @@ func NewCardCraftAiService(cardCraftAiClient cardCraftAiClient) *CardCraftAiServ
 	}
 }
 
-func (c *CardCraftAiService) GetCardContent(ctx context.Context, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+func (c *CardCraftAiService) GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+	cardCraftAiRequest := newCardCraftAiRequest(req)
+	log.Printf("CardCraftAI category resolution - WB: %t, Ozon: %t", cardCraftAiRequest.ResolveWbCategory, cardCraftAiRequest.ResolveOzonCategory)
+
 	sessionID, err := c.cardCraftAiClient.GetSessionID(ctx)
 	if err != nil {
 		metrics.AppExternalAPIErrorsTotal.WithLabelValues("card_craft_ai_session").Inc()
 		return nil, err
 	}
 
-	cardCraftAiAPIResponse, err := c.cardCraftAiClient.GetCardContent(ctx, sessionID, req)
+	cardCraftAiAPIResponse, err := c.cardCraftAiClient.GetCardContent(ctx, sessionID, cardCraftAiRequest)
 	if err != nil {
 		metrics.AppExternalAPIErrorsTotal.WithLabelValues("card_craft_ai_content").Inc()
 		return nil, err
 	}
 
+	applyCallerCategories(cardCraftAiAPIResponse, cardCraftAiRequest)
+
 	return cardCraftAiAPIResponse, nil
 }
+
+// newCardCraftAiRequest decides which marketplace categories CardCraftAI has to classify.
+// Without an explicit choice WB is always resolved and Ozon only when ozon=true.
+// Categories supplied by the caller are never resolved again to save tokens.
+func newCardCraftAiRequest(req entities.ProductCard) entities.CardCraftAiRequest {
+	wantWb, wantOzon := true, req.Ozon
+	if len(req.ResolveCategories) > 0 {
+		wantWb, wantOzon = false, false
+		for _, marketplace := range req.ResolveCategories {
+			switch marketplace {
+			case entities.MarketplaceWB:
+				wantWb = true
+			case entities.MarketplaceOzon:
+				wantOzon = true
+			}
+		}
+	}
+
+	return entities.CardCraftAiRequest{
+		ProductCard:         req,
+		ResolveWbCategory:   wantWb && req.SubjectId == 0,
+		ResolveOzonCategory: wantOzon && (req.SubId == 0 || req.TypeId == 0),
+	}
+}
+
+// applyCallerCategories fills the category IDs that were supplied by the caller instead of being resolved.
+func applyCallerCategories(content *entities.CardCraftAiGeneratedContent, cardCraftAiRequest entities.CardCraftAiRequest) {
+	req := cardCraftAiRequest.ProductCard
+	if !cardCraftAiRequest.ResolveWbCategory {
+		if req.SubjectId != 0 {
+			content.SubjectID = &req.SubjectId
+		}
+		if req.ParentId != 0 {
+			content.ParentID = &req.ParentId
+		}
+	}
+	if !cardCraftAiRequest.ResolveOzonCategory {
+		if req.SubId != 0 {
+			content.SubID = &req.SubId
+		}
+		if req.TypeId != 0 {
+			content.TypeID = &req.TypeId
+		}
+		if req.RootId != 0 {
+			content.RootID = &req.RootId
+		}
+	}
+}
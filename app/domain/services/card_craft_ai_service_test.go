@@ -0,0 +1,50 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"testing"
+)
+
+func TestNewCardCraftAiRequest_ResolveFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     entities.ProductCard
+		wantWb   bool
+		wantOzon bool
+	}{
+		{"WB only by default", entities.ProductCard{}, true, false},
+		{"ozon adds Ozon", entities.ProductCard{Ozon: true}, true, true},
+		{"supplied subject", entities.ProductCard{Ozon: true, SubjectId: 105}, false, true},
+		{"supplied Ozon category", entities.ProductCard{Ozon: true, SubId: 17028922, TypeId: 91565}, true, false},
+		{"Ozon category without type", entities.ProductCard{Ozon: true, SubId: 17028922}, true, true},
+		{"all categories supplied", entities.ProductCard{Ozon: true, SubjectId: 105, SubId: 17028922, TypeId: 91565}, false, false},
+		{"explicit Ozon only", entities.ProductCard{ResolveCategories: []entities.Marketplace{entities.MarketplaceOzon}}, false, true},
+		{"explicit WB ignores ozon", entities.ProductCard{Ozon: true, ResolveCategories: []entities.Marketplace{entities.MarketplaceWB}}, true, false},
+		{"explicit both", entities.ProductCard{ResolveCategories: []entities.Marketplace{entities.MarketplaceWB, entities.MarketplaceOzon}}, true, true},
+		{"explicit but supplied", entities.ProductCard{SubjectId: 105, ResolveCategories: []entities.Marketplace{entities.MarketplaceWB}}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newCardCraftAiRequest(tt.card)
+			if got.ResolveWbCategory != tt.wantWb || got.ResolveOzonCategory != tt.wantOzon {
+				t.Errorf("resolve WB %t, Ozon %t, want WB %t, Ozon %t", got.ResolveWbCategory, got.ResolveOzonCategory, tt.wantWb, tt.wantOzon)
+			}
+		})
+	}
+}
+
+func TestApplyCallerCategories(t *testing.T) {
+	resolvedSubject, resolvedType := int32(1), int32(2)
+	card := entities.ProductCard{Ozon: true, SubjectId: 105, ParentId: 4, SubId: 17028922}
+	content := &entities.CardCraftAiGeneratedContent{SubjectID: &resolvedSubject, TypeID: &resolvedType}
+
+	applyCallerCategories(content, newCardCraftAiRequest(card))
+
+	if *content.SubjectID != 105 || *content.ParentID != 4 {
+		t.Errorf("subject %d, parent %v, want the supplied WB categories", *content.SubjectID, content.ParentID)
+	}
+	// The Ozon category was resolved since the type is missing, so the resolved one is kept
+	if *content.TypeID != 2 || content.SubID != nil {
+		t.Errorf("type %d, sub %v, want the resolved Ozon category", *content.TypeID, content.SubID)
+	}
+}
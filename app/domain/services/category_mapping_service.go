@@ -0,0 +1,145 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"api/metrics"
+	"context"
+	"log"
+)
+
+type categoryMappingStorage interface {
+	GetCategoryMappingByWbSubject(ctx context.Context, subjectID int32) (*entities.CategoryMapping, error)
+	GetCategoryMappingByOzonType(ctx context.Context, categoryID, typeID int32) (*entities.CategoryMapping, error)
+	RecordCategoryMapping(ctx context.Context, mapping *entities.CategoryMapping) error
+}
+
+type cardContentVariantsGenerator interface {
+	GetCardContentVariants(ctx context.Context, apiKey string, req entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error)
+}
+
+// CategoryMappingOptions decide when a resolved pair of categories is trusted enough to skip CardCraftAI.
+type CategoryMappingOptions struct {
+	MinResolutions int     // Times the pair must have been resolved
+	MinConfidence  float64 // Share of the resolutions of the known category that must have given the pair
+}
+
+// CategoryMappingService remembers which WB subject and Ozon category CardCraftAI resolved together. When the
+// category of one marketplace is known, the category of the other one is taken from the mappings instead of
+// being classified again; overrides of content managers are always used.
+type CategoryMappingService struct {
+	generator cardContentVariantsGenerator
+	storage   categoryMappingStorage
+	options   CategoryMappingOptions
+}
+
+func NewCategoryMappingService(options CategoryMappingOptions, generator cardContentVariantsGenerator, storage categoryMappingStorage) *CategoryMappingService {
+	return &CategoryMappingService{
+		generator: generator,
+		storage:   storage,
+		options:   options,
+	}
+}
+
+// GetCardContentVariants generates content with the wrapped generator, taking the categories it can from the
+// mappings beforehand and recording the categories resolved by CardCraftAI afterwards.
+func (s *CategoryMappingService) GetCardContentVariants(ctx context.Context, apiKey string, req entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error) {
+	mapped := req
+	mapping := s.MapCategories(ctx, &mapped)
+	contents, err := s.generator.GetCardContentVariants(ctx, apiKey, mapped, count)
+	if err != nil {
+		return nil, err
+	}
+	if mapping != nil {
+		for _, content := range contents {
+			mapping.SetNamesOn(content)
+		}
+		return contents, nil
+	}
+	s.RecordCategories(ctx, req, contents)
+	return contents, nil
+}
+
+// MapCategories fills the category of the card that CardCraftAI would have to resolve from the mapping of the
+// category known on the other marketplace. It returns the mapping used, or nil if there is no trusted one.
+func (s *CategoryMappingService) MapCategories(ctx context.Context, card *entities.ProductCard) *entities.CategoryMapping {
+	cardCraftAiRequest := newCardCraftAiRequest(*card)
+	switch {
+	case cardCraftAiRequest.ResolveOzonCategory && card.SubjectId != 0:
+		mapping, err := s.storage.GetCategoryMappingByWbSubject(ctx, card.SubjectId)
+		if err != nil {
+			log.Printf("[CATEGORY MAPPING] Failed to get mapping of WB subject %d: %v", card.SubjectId, err)
+			return nil
+		}
+		if !s.trusted(mapping) {
+			return nil
+		}
+		card.SubId, card.TypeId = mapping.OzonDescriptionCategoryID, mapping.OzonTypeID
+		metrics.AppCategoryMappingHitsTotal.WithLabelValues(string(entities.MarketplaceOzon)).Inc()
+		log.Printf("[CATEGORY MAPPING] WB subject %d mapped to Ozon category %d, type %d (overridden: %t)", card.SubjectId, card.SubId, card.TypeId, mapping.Overridden)
+		return mapping
+
+	case cardCraftAiRequest.ResolveWbCategory && card.SubId != 0 && card.TypeId != 0:
+		mapping, err := s.storage.GetCategoryMappingByOzonType(ctx, card.SubId, card.TypeId)
+		if err != nil {
+			log.Printf("[CATEGORY MAPPING] Failed to get mapping of Ozon category %d, type %d: %v", card.SubId, card.TypeId, err)
+			return nil
+		}
+		if !s.trusted(mapping) {
+			return nil
+		}
+		card.SubjectId = mapping.WbSubjectID
+		metrics.AppCategoryMappingHitsTotal.WithLabelValues(string(entities.MarketplaceWB)).Inc()
+		log.Printf("[CATEGORY MAPPING] Ozon category %d, type %d mapped to WB subject %d (overridden: %t)", card.SubId, card.TypeId, card.SubjectId, mapping.Overridden)
+		return mapping
+	}
+	return nil
+}
+
+// RecordCategories records the pair of categories of contents generated for req if CardCraftAI resolved at
+// least one of them; content served from the cache is not recorded again. When it resolved both, the WB subject
+// decides: its override replaces the resolved Ozon category on all contents and the pair is not recorded.
+func (s *CategoryMappingService) RecordCategories(ctx context.Context, req entities.ProductCard, contents []*entities.CardCraftAiGeneratedContent) {
+	cardCraftAiRequest := newCardCraftAiRequest(req)
+	if len(contents) == 0 || !cardCraftAiRequest.ResolveWbCategory && !cardCraftAiRequest.ResolveOzonCategory {
+		return
+	}
+	resolved := entities.CategoryMappingFromContent(contents[0])
+	if resolved == nil {
+		return
+	}
+
+	if cardCraftAiRequest.ResolveWbCategory && cardCraftAiRequest.ResolveOzonCategory {
+		mapping, err := s.storage.GetCategoryMappingByWbSubject(ctx, resolved.WbSubjectID)
+		if err != nil {
+			log.Printf("[CATEGORY MAPPING] Failed to get mapping of WB subject %d: %v", resolved.WbSubjectID, err)
+		} else if mapping != nil && mapping.Overridden {
+			if mapping.OzonDescriptionCategoryID != resolved.OzonDescriptionCategoryID || mapping.OzonTypeID != resolved.OzonTypeID {
+				log.Printf("[CATEGORY MAPPING] Replacing resolved Ozon category %d, type %d with the override %d, type %d of WB subject %d",
+					resolved.OzonDescriptionCategoryID, resolved.OzonTypeID, mapping.OzonDescriptionCategoryID, mapping.OzonTypeID, resolved.WbSubjectID)
+				for _, content := range contents {
+					content.SubID, content.TypeID = &mapping.OzonDescriptionCategoryID, &mapping.OzonTypeID
+					content.SubName, content.TypeName = nil, nil
+					content.RootID, content.RootName = nil, nil // The root of the resolved category does not apply to the override
+					mapping.SetNamesOn(content)
+				}
+				metrics.AppCategoryMappingHitsTotal.WithLabelValues(string(entities.MarketplaceOzon)).Inc()
+			}
+			return
+		}
+	}
+
+	if contents[0].FromCache {
+		return // Not a new classification
+	}
+	if err := s.storage.RecordCategoryMapping(ctx, resolved); err != nil {
+		log.Printf("[CATEGORY MAPPING] Failed to record WB subject %d with Ozon category %d, type %d: %v",
+			resolved.WbSubjectID, resolved.OzonDescriptionCategoryID, resolved.OzonTypeID, err)
+	}
+}
+
+func (s *CategoryMappingService) trusted(mapping *entities.CategoryMapping) bool {
+	if mapping == nil {
+		return false
+	}
+	return mapping.Overridden || mapping.Resolutions >= s.options.MinResolutions && mapping.Confidence >= s.options.MinConfidence
+}
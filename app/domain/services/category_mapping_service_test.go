@@ -0,0 +1,126 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"testing"
+)
+
+type fakeCategoryMappingStorage struct {
+	bySubject map[int32]*entities.CategoryMapping
+	byType    map[[2]int32]*entities.CategoryMapping
+	recorded  []*entities.CategoryMapping
+}
+
+func (f *fakeCategoryMappingStorage) GetCategoryMappingByWbSubject(ctx context.Context, subjectID int32) (*entities.CategoryMapping, error) {
+	return f.bySubject[subjectID], nil
+}
+
+func (f *fakeCategoryMappingStorage) GetCategoryMappingByOzonType(ctx context.Context, categoryID, typeID int32) (*entities.CategoryMapping, error) {
+	return f.byType[[2]int32{categoryID, typeID}], nil
+}
+
+func (f *fakeCategoryMappingStorage) RecordCategoryMapping(ctx context.Context, mapping *entities.CategoryMapping) error {
+	f.recorded = append(f.recorded, mapping)
+	return nil
+}
+
+// fakeCategoryGenerator resolves the categories the request leaves to CardCraftAI to fixed values.
+type fakeCategoryGenerator struct {
+	requests []entities.ProductCard
+}
+
+func (f *fakeCategoryGenerator) GetCardContentVariants(ctx context.Context, apiKey string, req entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error) {
+	f.requests = append(f.requests, req)
+	cardCraftAiRequest := newCardCraftAiRequest(req)
+	content := &entities.CardCraftAiGeneratedContent{Title: "title"}
+	if cardCraftAiRequest.ResolveWbCategory {
+		subjectID, subjectName := int32(105), "Футболки"
+		content.SubjectID, content.SubjectName = &subjectID, &subjectName
+	}
+	if cardCraftAiRequest.ResolveOzonCategory {
+		subID, typeID, typeName := int32(200), int32(300), "Футболка"
+		content.SubID, content.TypeID, content.TypeName = &subID, &typeID, &typeName
+	}
+	applyCallerCategories(content, cardCraftAiRequest)
+	return []*entities.CardCraftAiGeneratedContent{content}, nil
+}
+
+func TestCategoryMappingService_MapsKnownCategory(t *testing.T) {
+	storage := &fakeCategoryMappingStorage{bySubject: map[int32]*entities.CategoryMapping{
+		105: {WbSubjectID: 105, OzonDescriptionCategoryID: 201, OzonTypeID: 301, OzonTypeName: "Майка", Resolutions: 5, Confidence: 0.9},
+	}}
+	generator := &fakeCategoryGenerator{}
+	s := NewCategoryMappingService(CategoryMappingOptions{MinResolutions: 3, MinConfidence: 0.8}, generator, storage)
+
+	contents, err := s.GetCardContentVariants(context.Background(), "key", entities.ProductCard{SubjectId: 105, Ozon: true}, 1)
+	if err != nil {
+		t.Fatalf("GetCardContentVariants: %v", err)
+	}
+	if got := generator.requests[0]; got.SubId != 201 || got.TypeId != 301 {
+		t.Errorf("generator got Ozon category %d, type %d, want 201, 301", got.SubId, got.TypeId)
+	}
+	if c := contents[0]; *c.SubID != 201 || *c.TypeID != 301 || c.TypeName == nil || *c.TypeName != "Майка" {
+		t.Errorf("content has Ozon category %d, type %d, name %v, want the mapped one", *c.SubID, *c.TypeID, c.TypeName)
+	}
+	if len(storage.recorded) != 0 {
+		t.Errorf("recorded %d mappings, want none for mapped categories", len(storage.recorded))
+	}
+}
+
+func TestCategoryMappingService_RecordsUntrustedMapping(t *testing.T) {
+	storage := &fakeCategoryMappingStorage{bySubject: map[int32]*entities.CategoryMapping{
+		105: {WbSubjectID: 105, OzonDescriptionCategoryID: 201, OzonTypeID: 301, Resolutions: 2, Confidence: 1},
+	}}
+	generator := &fakeCategoryGenerator{}
+	s := NewCategoryMappingService(CategoryMappingOptions{MinResolutions: 3, MinConfidence: 0.8}, generator, storage)
+
+	if _, err := s.GetCardContentVariants(context.Background(), "key", entities.ProductCard{SubjectId: 105, Ozon: true}, 1); err != nil {
+		t.Fatalf("GetCardContentVariants: %v", err)
+	}
+	if got := generator.requests[0]; got.SubId != 0 || got.TypeId != 0 {
+		t.Errorf("generator got Ozon category %d, type %d, want it left to CardCraftAI", got.SubId, got.TypeId)
+	}
+	if len(storage.recorded) != 1 {
+		t.Fatalf("recorded %d mappings, want 1", len(storage.recorded))
+	}
+	if m := storage.recorded[0]; m.WbSubjectID != 105 || m.OzonDescriptionCategoryID != 200 || m.OzonTypeID != 300 || m.OzonTypeName != "Футболка" {
+		t.Errorf("recorded %+v, want WB subject 105 with the resolved Ozon category", m)
+	}
+}
+
+func TestCategoryMappingService_MapsOzonTypeToWbSubject(t *testing.T) {
+	storage := &fakeCategoryMappingStorage{byType: map[[2]int32]*entities.CategoryMapping{
+		{200, 300}: {WbSubjectID: 106, WbSubjectName: "Майки", OzonDescriptionCategoryID: 200, OzonTypeID: 300, Overridden: true},
+	}}
+	generator := &fakeCategoryGenerator{}
+	s := NewCategoryMappingService(CategoryMappingOptions{MinResolutions: 3, MinConfidence: 0.8}, generator, storage)
+
+	card := entities.ProductCard{SubId: 200, TypeId: 300, ResolveCategories: []entities.Marketplace{entities.MarketplaceWB}}
+	contents, err := s.GetCardContentVariants(context.Background(), "key", card, 1)
+	if err != nil {
+		t.Fatalf("GetCardContentVariants: %v", err)
+	}
+	if c := contents[0]; *c.SubjectID != 106 || c.SubjectName == nil || *c.SubjectName != "Майки" {
+		t.Errorf("content has WB subject %d, name %v, want the overridden one", *c.SubjectID, c.SubjectName)
+	}
+}
+
+func TestCategoryMappingService_OverrideReplacesResolvedOzonCategory(t *testing.T) {
+	storage := &fakeCategoryMappingStorage{bySubject: map[int32]*entities.CategoryMapping{
+		105: {WbSubjectID: 105, OzonDescriptionCategoryID: 201, OzonTypeID: 301, OzonTypeName: "Майка", Overridden: true},
+	}}
+	s := NewCategoryMappingService(CategoryMappingOptions{MinResolutions: 3, MinConfidence: 0.8}, &fakeCategoryGenerator{}, storage)
+
+	// Both categories are resolved, the override of the resolved WB subject wins
+	contents, err := s.GetCardContentVariants(context.Background(), "key", entities.ProductCard{Ozon: true}, 1)
+	if err != nil {
+		t.Fatalf("GetCardContentVariants: %v", err)
+	}
+	if c := contents[0]; *c.SubjectID != 105 || *c.SubID != 201 || *c.TypeID != 301 || *c.TypeName != "Майка" {
+		t.Errorf("content has WB subject %d, Ozon category %d, type %d, want the override", *c.SubjectID, *c.SubID, *c.TypeID)
+	}
+	if len(storage.recorded) != 0 {
+		t.Errorf("recorded %d mappings, want none when the override applies", len(storage.recorded))
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type contentSettingsStorage interface {
+	GetContentProvider(ctx context.Context, apiKey string) (entities.ContentProvider, error)
+}
+
+// ContentGenerationService routes content generation to the provider chosen in the request,
+// then to the one configured for the account, then to the server default.
+type ContentGenerationService struct {
+	providers       map[entities.ContentProvider]cardContentGenerator
+	settings        contentSettingsStorage
+	defaultProvider entities.ContentProvider
+}
+
+func NewContentGenerationService(settings contentSettingsStorage, defaultProvider entities.ContentProvider) *ContentGenerationService {
+	return &ContentGenerationService{
+		providers:       make(map[entities.ContentProvider]cardContentGenerator),
+		settings:        settings,
+		defaultProvider: defaultProvider,
+	}
+}
+
+// AddProvider registers a content generator under the provider name.
+func (s *ContentGenerationService) AddProvider(provider entities.ContentProvider, generator cardContentGenerator) {
+	s.providers[provider] = generator
+}
+
+func (s *ContentGenerationService) GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+	provider := s.selectProvider(ctx, apiKey, req.ContentProvider)
+	generator, ok := s.providers[provider]
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("content provider %q is not available", provider))
+	}
+	log.Printf("[CONTENT] Generating content with provider %s", provider)
+
+	req.ContentProvider = provider
+	content, err := generator.GetCardContent(ctx, apiKey, req)
+	if err != nil {
+		return nil, err
+	}
+	content.Provider = provider
+	return content, nil
+}
+
+// GetCardContentVariants generates count content alternatives with the same provider. Categories resolved
+// for the first variant are reused by the others, so only the first one pays for classification.
+// A failure after the first variant is logged and the variants generated so far are returned.
+func (s *ContentGenerationService) GetCardContentVariants(ctx context.Context, apiKey string, req entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error) {
+	first, err := s.GetCardContent(ctx, apiKey, req)
+	if err != nil {
+		return nil, err
+	}
+	contents := []*entities.CardCraftAiGeneratedContent{first}
+
+	req.ContentProvider = first.Provider
+	req.SetCategoriesFrom(first)
+	for i := 1; i < count; i++ {
+		req.ContentVariant = i
+		content, err := s.GetCardContent(ctx, apiKey, req)
+		if err != nil {
+			log.Printf("[CONTENT] Failed to generate content variant %d of %d: %v", i+1, count, err)
+			break
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+func (s *ContentGenerationService) selectProvider(ctx context.Context, apiKey string, requested entities.ContentProvider) entities.ContentProvider {
+	if requested != "" {
+		return requested
+	}
+	if apiKey != "" {
+		provider, err := s.settings.GetContentProvider(ctx, apiKey)
+		if err != nil {
+			log.Printf("[CONTENT] Failed to get content provider for account: %v", err)
+		} else if provider != "" {
+			return provider
+		}
+	}
+	return s.defaultProvider
+}
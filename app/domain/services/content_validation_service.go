@@ -0,0 +1,188 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	htmlTagRegexp    = regexp.MustCompile(`<[^>]*>`)
+	spacesRegexp     = regexp.MustCompile(`[ \t]+`)
+	blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// ContentValidationService checks generated content against marketplace constraints
+// and corrects it where possible.
+type ContentValidationService struct {
+	constraints map[entities.Marketplace]entities.ContentConstraints
+}
+
+func NewContentValidationService(constraints ...entities.ContentConstraints) *ContentValidationService {
+	s := &ContentValidationService{constraints: make(map[entities.Marketplace]entities.ContentConstraints)}
+	for _, c := range constraints {
+		s.constraints[c.Marketplace] = c
+	}
+	return s
+}
+
+// Validate returns the violations of the marketplace constraints without changing the content.
+func (s *ContentValidationService) Validate(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) []entities.ContentViolation {
+	_, violations := s.Fix(marketplace, brand, content)
+	for i := range violations {
+		violations[i].Fixed = false
+	}
+	return violations
+}
+
+// Fix returns a copy of the content corrected for the marketplace along with the violations found.
+// Violations that could not be corrected have Fixed set to false.
+func (s *ContentValidationService) Fix(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation) {
+	fixed := *content
+	constraints, ok := s.constraints[marketplace]
+	if !ok {
+		return &fixed, nil
+	}
+
+	var violations []entities.ContentViolation
+	report := func(field, rule, message string, isFixed bool) {
+		violations = append(violations, entities.ContentViolation{
+			Marketplace: marketplace,
+			Field:       field,
+			Rule:        rule,
+			Message:     message,
+			Fixed:       isFixed,
+		})
+	}
+
+	for _, field := range []struct {
+		name      string
+		value     *string
+		maxLength int
+	}{
+		{"title", &fixed.Title, constraints.TitleMaxLength},
+		{"description", &fixed.Description, constraints.DescriptionMaxLength},
+	} {
+		value := *field.value
+		isTitle := field.name == "title"
+
+		if (isTitle || !constraints.AllowHTML) && htmlTagRegexp.MatchString(value) {
+			value = html.UnescapeString(htmlTagRegexp.ReplaceAllString(value, " "))
+			report(field.name, entities.ContentRuleHTML, "HTML markup is not allowed", true)
+		}
+
+		if cleaned, removed := removeForbiddenChars(value, constraints.ForbiddenChars); removed != "" {
+			value = cleaned
+			report(field.name, entities.ContentRuleForbiddenChars, fmt.Sprintf("forbidden characters removed: %q", removed), true)
+		}
+
+		for _, word := range constraints.StopWords {
+			if cleaned, found := removeWord(value, word); found {
+				value = cleaned
+				report(field.name, entities.ContentRuleStopWord, fmt.Sprintf("stop-word %q is not allowed", word), true)
+			}
+		}
+
+		if isTitle && constraints.ForbidBrandInTitle && strings.TrimSpace(brand) != "" {
+			if cleaned, found := removeWord(value, strings.TrimSpace(brand)); found {
+				value = cleaned
+				report(field.name, entities.ContentRuleBrandInTitle, fmt.Sprintf("brand %q must not be repeated in the title", brand), true)
+			}
+		}
+
+		value = normalizeContentText(value, isTitle)
+
+		if field.maxLength > 0 && utf8.RuneCountInString(value) > field.maxLength {
+			length := utf8.RuneCountInString(value)
+			if isTitle {
+				value = truncateAtWord(value, field.maxLength)
+			} else {
+				value = truncateAtSentence(value, field.maxLength)
+			}
+			report(field.name, entities.ContentRuleMaxLength, fmt.Sprintf("%d characters exceed the limit of %d", length, field.maxLength), true)
+		}
+
+		if isTitle && value == "" {
+			report(field.name, entities.ContentRuleEmpty, "title is empty", false)
+		}
+		*field.value = value
+	}
+
+	return &fixed, violations
+}
+
+// removeForbiddenChars drops the forbidden characters and emoji, returning the removed ones.
+func removeForbiddenChars(s, forbidden string) (string, string) {
+	var kept, removed strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(forbidden, r) || unicode.Is(unicode.So, r) || unicode.Is(unicode.Cs, r) {
+			if !strings.ContainsRune(removed.String(), r) {
+				removed.WriteRune(r)
+			}
+			continue
+		}
+		kept.WriteRune(r)
+	}
+	return kept.String(), removed.String()
+}
+
+// removeWord removes whole-word, case-insensitive occurrences of word.
+func removeWord(s, word string) (string, bool) {
+	re := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(word) + `([^\p{L}\p{N}]|$)`)
+	if !re.MatchString(s) {
+		return s, false
+	}
+	// Repeat, since adjacent matches share the separator
+	for re.MatchString(s) {
+		s = re.ReplaceAllString(s, "$1$2")
+	}
+	return s, true
+}
+
+// normalizeContentText collapses whitespace; titles become a single line.
+func normalizeContentText(s string, singleLine bool) string {
+	if singleLine {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacesRegexp.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// truncateAtWord cuts s to at most max characters at a word boundary.
+func truncateAtWord(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	cut := string(runes[:max])
+	if !unicode.IsSpace(runes[max]) {
+		if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) && r != ')' && r != '"'
+	})
+}
+
+// truncateAtSentence cuts s to at most max characters at the end of a sentence,
+// falling back to a word boundary when no sentence ends in the second half of the limit.
+func truncateAtSentence(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	cut := string(runes[:max])
+	end := strings.LastIndexAny(cut, ".!?\n")
+	if end >= 0 && utf8.RuneCountInString(cut[:end]) >= max/2 {
+		return strings.TrimSpace(cut[:end+1])
+	}
+	return truncateAtWord(s, max)
+}
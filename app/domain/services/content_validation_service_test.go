@@ -0,0 +1,80 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestContentValidationServiceFixWb(t *testing.T) {
+	s := NewContentValidationService(entities.DefaultWbContentConstraints())
+	content := &entities.CardCraftAiGeneratedContent{
+		Title:       "Nike Кроссовки мужские <b>беговые</b> лёгкие дышащие для зала и улицы, скидка 🔥",
+		Description: strings.Repeat("Удобные кроссовки для бега. ", 300),
+	}
+
+	fixed, violations := s.Fix(entities.MarketplaceWB, "Nike", content)
+
+	if utf8.RuneCountInString(fixed.Title) > 60 {
+		t.Errorf("title is longer than 60 characters: %q", fixed.Title)
+	}
+	if utf8.RuneCountInString(fixed.Description) > 5000 {
+		t.Errorf("description is longer than 5000 characters: %d", utf8.RuneCountInString(fixed.Description))
+	}
+	if !strings.HasSuffix(fixed.Description, ".") {
+		t.Errorf("description is not cut at the end of a sentence: %q", fixed.Description[len(fixed.Description)-20:])
+	}
+	for _, unwanted := range []string{"Nike", "<b>", "скидка", "🔥"} {
+		if strings.Contains(fixed.Title, unwanted) {
+			t.Errorf("title %q still contains %q", fixed.Title, unwanted)
+		}
+	}
+	if content.Title == fixed.Title {
+		t.Error("original content was modified or not fixed")
+	}
+
+	rules := make(map[string]bool)
+	for _, v := range violations {
+		if !v.Fixed {
+			t.Errorf("violation %s was not fixed", v.Rule)
+		}
+		rules[v.Rule] = true
+	}
+	for _, rule := range []string{entities.ContentRuleHTML, entities.ContentRuleForbiddenChars, entities.ContentRuleStopWord, entities.ContentRuleBrandInTitle, entities.ContentRuleMaxLength} {
+		if !rules[rule] {
+			t.Errorf("expected violation %s, got %+v", rule, violations)
+		}
+	}
+}
+
+func TestContentValidationServiceValidateValidContent(t *testing.T) {
+	s := NewContentValidationService(entities.DefaultWbContentConstraints(), entities.DefaultOzonContentConstraints())
+	content := &entities.CardCraftAiGeneratedContent{Title: "Кроссовки мужские беговые", Description: "Лёгкие кроссовки для бега."}
+
+	for _, marketplace := range []entities.Marketplace{entities.MarketplaceWB, entities.MarketplaceOzon} {
+		if violations := s.Validate(marketplace, "Nike", content); len(violations) != 0 {
+			t.Errorf("%s: unexpected violations %+v", marketplace, violations)
+		}
+	}
+}
+
+func TestContentValidationServiceEmptyTitle(t *testing.T) {
+	s := NewContentValidationService(entities.DefaultWbContentConstraints())
+	content := &entities.CardCraftAiGeneratedContent{Title: "Nike"}
+
+	_, violations := s.Fix(entities.MarketplaceWB, "Nike", content)
+
+	var unfixed int
+	for _, v := range violations {
+		if !v.Fixed {
+			unfixed++
+			if v.Rule != entities.ContentRuleEmpty {
+				t.Errorf("unexpected unfixed violation %s", v.Rule)
+			}
+		}
+	}
+	if unfixed != 1 {
+		t.Errorf("expected the empty title to be reported, got %+v", violations)
+	}
+}
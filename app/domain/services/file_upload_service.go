@@ -4,25 +4,104 @@ import (
 	"api/app/domain/entities"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"mime"
 	"path/filepath"
+	"sort"
 )
 
 type fileStorageClient interface {
 	UploadFiles(ctx context.Context, files []entities.FileUploadRequest) ([]entities.FileUploadResult, error)
+	SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error)
+	GetMedia(ctx context.Context, mediaID string) (*entities.MediaFile, error)
 }
 
 type FileUploadService struct {
 	fileStorageClient fileStorageClient
+	maxUploadBytes    int64
 }
 
-func NewFileUploadService(fileStorageClient fileStorageClient) *FileUploadService {
+func NewFileUploadService(fileStorageClient fileStorageClient, maxUploadBytes int64) *FileUploadService {
 	return &FileUploadService{
 		fileStorageClient: fileStorageClient,
+		maxUploadBytes:    maxUploadBytes,
 	}
 }
 
+// UploadMediaStream streams media content into the file storage, rejecting content larger than the configured limit.
+func (fus *FileUploadService) UploadMediaStream(ctx context.Context, filename, contentType string, content io.Reader) (*entities.MediaFile, error) {
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+		if contentType == "" {
+			contentType = "application/octet-stream" // Default fallback
+		}
+	}
+
+	log.Printf("[FILE UPLOAD] Starting streaming upload of %s (content-type: %s, limit: %d bytes)", filename, contentType, fus.maxUploadBytes)
+
+	mediaFile, err := fus.fileStorageClient.SaveStream(ctx, filename, contentType, &sizeLimitedReader{r: content, remaining: fus.maxUploadBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store media %s: %w", filename, err)
+	}
+
+	log.Printf("[FILE UPLOAD] Successfully streamed file %s -> %s (media ID: %s, size: %d bytes)", filename, mediaFile.URL, mediaFile.ID, mediaFile.Size)
+	return mediaFile, nil
+}
+
+// ResolveMedia returns public links of the referenced media ordered by photo number.
+// References without a photo number keep their request order after the numbered ones.
+func (fus *FileUploadService) ResolveMedia(ctx context.Context, refs []*entities.MediaReference) ([]*entities.MediaLink, error) {
+	ordered := make([]*entities.MediaReference, len(refs))
+	copy(ordered, refs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i].PhotoNumber, ordered[j].PhotoNumber
+		if a <= 0 || b <= 0 {
+			return a > 0 && b <= 0
+		}
+		return a < b
+	})
+
+	links := make([]*entities.MediaLink, 0, len(ordered))
+	for _, ref := range ordered {
+		mediaFile, err := fus.fileStorageClient.GetMedia(ctx, ref.MediaID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve media %s: %w", ref.MediaID, err)
+		}
+		links = append(links, &entities.MediaLink{
+			URL:     mediaFile.URL,
+			Name:    mediaFile.ID,
+			Kind:    ref.Kind,
+			Primary: ref.Primary,
+		})
+	}
+	return links, nil
+}
+
+// sizeLimitedReader fails with entities.ErrMediaTooLarge instead of silently truncating like io.LimitReader.
+type sizeLimitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Probe for one more byte to distinguish an exact fit from an overflow.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, entities.ErrMediaTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
 // UploadWBMediaFiles converts WB media files to upload requests and uploads them
 func (fus *FileUploadService) UploadWBMediaFiles(ctx context.Context, wbFiles []*entities.WBClientMediaFile) ([]string, error) {
 	if len(wbFiles) == 0 {
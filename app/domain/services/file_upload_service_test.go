@@ -0,0 +1,84 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fakeFileStorageClient struct {
+	saved       []byte
+	contentType string
+}
+
+func (f *fakeFileStorageClient) UploadFiles(ctx context.Context, files []entities.FileUploadRequest) ([]entities.FileUploadResult, error) {
+	return nil, nil
+}
+
+func (f *fakeFileStorageClient) SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.saved, f.contentType = content, contentType
+	return &entities.MediaFile{ID: "id-" + filename, Filename: filename, ContentType: contentType, Size: int64(len(content))}, nil
+}
+
+func (f *fakeFileStorageClient) GetMedia(ctx context.Context, mediaID string) (*entities.MediaFile, error) {
+	if mediaID == "missing" {
+		return nil, entities.ErrMediaNotFound
+	}
+	return &entities.MediaFile{ID: mediaID, URL: "https://files.example.com/" + mediaID}, nil
+}
+
+func TestFileUploadService_UploadMediaStream(t *testing.T) {
+	storage := &fakeFileStorageClient{}
+	s := NewFileUploadService(storage, 5)
+
+	mediaFile, err := s.UploadMediaStream(context.Background(), "photo.png", "", strings.NewReader("12345"))
+	if err != nil {
+		t.Fatalf("UploadMediaStream: %v", err)
+	}
+	if mediaFile.Size != 5 || string(storage.saved) != "12345" {
+		t.Errorf("saved %q (%d bytes), want the whole content at the exact limit", storage.saved, mediaFile.Size)
+	}
+	if storage.contentType != "image/png" {
+		t.Errorf("content type = %q, want it detected from the extension", storage.contentType)
+	}
+
+	_, err = s.UploadMediaStream(context.Background(), "video.mp4", "video/mp4", strings.NewReader("123456"))
+	if !errors.Is(err, entities.ErrMediaTooLarge) {
+		t.Errorf("err = %v, want ErrMediaTooLarge for content over the limit", err)
+	}
+}
+
+func TestFileUploadService_ResolveMedia(t *testing.T) {
+	s := NewFileUploadService(&fakeFileStorageClient{}, 1024)
+
+	links, err := s.ResolveMedia(context.Background(), []*entities.MediaReference{
+		{MediaID: "unnumbered"},
+		{MediaID: "second", PhotoNumber: 2},
+		{MediaID: "video", Kind: entities.MediaKindVideo},
+		{MediaID: "first", PhotoNumber: 1, Primary: true},
+	})
+	if err != nil {
+		t.Fatalf("ResolveMedia: %v", err)
+	}
+	var names []string
+	for _, link := range links {
+		names = append(names, link.Name)
+	}
+	if got := strings.Join(names, ","); got != "first,second,unnumbered,video" {
+		t.Errorf("order = %s, want numbered media first, then the request order", got)
+	}
+	if !links[0].Primary || links[3].Kind != entities.MediaKindVideo || links[0].URL != "https://files.example.com/first" {
+		t.Errorf("links = %+v, want kind, primary flag and URL taken over", links)
+	}
+
+	if _, err := s.ResolveMedia(context.Background(), []*entities.MediaReference{{MediaID: "missing"}}); !errors.Is(err, entities.ErrMediaNotFound) {
+		t.Errorf("err = %v, want ErrMediaNotFound", err)
+	}
+}
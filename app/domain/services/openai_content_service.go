@@ -0,0 +1,202 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"api/metrics"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+
+	"connectrpc.com/connect"
+)
+
+// defaultPromptTemplate is used when no template with the configured name is stored in the database.
+var defaultPromptTemplate = entities.PromptTemplate{
+	Name: "default",
+	SystemPrompt: "You are an e-commerce copywriter preparing product cards for Russian marketplaces (Wildberries, Ozon). " +
+		"Answer with a single JSON object with the keys \"title\" (string), \"description\" (string) and \"attributes\" (object of string values).",
+	UserPrompt: `Product title: {{.ProductTitle}}
+Product description: {{.ProductDescription}}
+{{- if .Brand}}
+Brand: {{.Brand}}
+{{- end}}
+{{- if .VendorCode}}
+Vendor code: {{.VendorCode}}
+{{- end}}
+{{- if .Translate}}
+Translate the content to Russian.
+{{- end}}
+{{- if .GenerateContent}}
+Write an SEO-friendly title (up to 60 characters), a description and product attributes.
+{{- else}}
+Keep the title and the description as close to the original as possible and only extract product attributes.
+{{- end}}
+{{- if .ContentFeedback}}
+The previous answer was rejected by the marketplace rules, fix these problems: {{.ContentFeedback}}
+{{- end}}
+{{- if .ContentVariant}}
+This is alternative #{{.ContentVariant}}: use a noticeably different wording and emphasis than a typical first draft.
+{{- end}}`,
+}
+
+type chatCompletionClient interface {
+	CreateChatCompletion(ctx context.Context, request entities.ChatCompletionRequest) (*entities.ChatCompletionResponse, error)
+}
+
+// wbSubjectPrompt asks the model for the WB subject, which is then looked up in the mirrored WB catalog.
+const wbSubjectPrompt = "\nAlso add the key \"subject_name\" with the name of the Wildberries subject of the product " +
+	"(its most specific category, in Russian, e.g. \"Футболки\")."
+
+type promptTemplateStorage interface {
+	GetPromptTemplate(ctx context.Context, name string) (*entities.PromptTemplate, error)
+}
+
+type wbSubjectCatalog interface {
+	SearchWBSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error)
+}
+
+// OpenAiContentService generates card content through an OpenAI-compatible chat completions API.
+// The WB subject is resolved from the subject name suggested by the model if the mirrored WB catalog has a
+// subject of that name. Ozon categories are not classified; categories that cannot be resolved are left unset.
+type OpenAiContentService struct {
+	client       chatCompletionClient
+	templates    promptTemplateStorage
+	subjects     wbSubjectCatalog
+	templateName string
+}
+
+func NewOpenAiContentService(client chatCompletionClient, templates promptTemplateStorage, subjects wbSubjectCatalog, templateName string) *OpenAiContentService {
+	return &OpenAiContentService{
+		client:       client,
+		templates:    templates,
+		subjects:     subjects,
+		templateName: templateName,
+	}
+}
+
+func (s *OpenAiContentService) GetCardContent(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+	contentRequest := newCardCraftAiRequest(req)
+	if contentRequest.ResolveOzonCategory {
+		log.Printf("[OPENAI] Ozon categories are not classified by the openai provider, supply sub_id and type_id")
+	}
+
+	tmpl, err := s.getPromptTemplate(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load prompt template: %w", err))
+	}
+
+	userPrompt, err := renderPrompt(tmpl.UserPrompt, req)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to render prompt template %s: %w", tmpl.Name, err))
+	}
+	if contentRequest.ResolveWbCategory {
+		userPrompt += wbSubjectPrompt
+	}
+
+	completion, err := s.client.CreateChatCompletion(ctx, entities.ChatCompletionRequest{
+		Messages: []entities.ChatMessage{
+			{Role: "system", Content: tmpl.SystemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		ResponseFormat: &entities.ChatResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("openai_chat_completion").Inc()
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to generate content: %w", err))
+	}
+	if len(completion.Choices) == 0 {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("chat completion returned no choices"))
+	}
+
+	var content entities.CardCraftAiGeneratedContent
+	if err := json.Unmarshal([]byte(stripCodeFence(completion.Choices[0].Message.Content)), &content); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse generated content: %w", err))
+	}
+	log.Printf("[OPENAI] Generated content with template %s, tokens: %d prompt / %d completion", tmpl.Name, completion.Usage.PromptTokens, completion.Usage.CompletionTokens)
+
+	// Category IDs made up by the model are never used
+	suggestedSubject := content.SubjectName
+	content.ParentID, content.ParentName, content.SubjectID, content.SubjectName = nil, nil, nil, nil
+	content.RootID, content.RootName, content.SubID, content.SubName, content.TypeID, content.TypeName = nil, nil, nil, nil, nil, nil
+	if contentRequest.ResolveWbCategory {
+		s.resolveWbSubject(ctx, &content, suggestedSubject)
+	}
+	applyCallerCategories(&content, contentRequest)
+	// Token usage comes with the completion, so billing does not need the token counter service
+	content.TokenUsage = &entities.SessionData{
+		TotalPromptTokens:     completion.Usage.PromptTokens,
+		TotalCompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:           completion.Usage.TotalTokens,
+		RequestCount:          1,
+	}
+	return &content, nil
+}
+
+// resolveWbSubject sets the mirrored WB subject named like the suggestion of the model on content.
+// The subject stays unset if there is no subject of that name.
+func (s *OpenAiContentService) resolveWbSubject(ctx context.Context, content *entities.CardCraftAiGeneratedContent, suggested *string) {
+	if suggested == nil || strings.TrimSpace(*suggested) == "" {
+		log.Printf("[OPENAI] The model suggested no WB subject, leaving it unresolved")
+		return
+	}
+	name := strings.TrimSpace(*suggested)
+	subjects, err := s.subjects.SearchWBSubjects(ctx, entities.WBSubjectFilter{Query: name, Limit: 20})
+	if err != nil {
+		log.Printf("[OPENAI] Failed to look up WB subject %q: %v", name, err)
+		return
+	}
+	for _, subject := range subjects {
+		if strings.EqualFold(subject.SubjectName, name) {
+			subjectID, parentID := int32(subject.SubjectID), int32(subject.ParentID)
+			subjectName, parentName := subject.SubjectName, subject.ParentName
+			content.SubjectID, content.SubjectName = &subjectID, &subjectName
+			content.ParentID, content.ParentName = &parentID, &parentName
+			log.Printf("[OPENAI] Resolved WB subject %q to %d", name, subjectID)
+			return
+		}
+	}
+	log.Printf("[OPENAI] WB subject %q suggested by the model is not in the WB catalog, leaving it unresolved", name)
+}
+
+// getPromptTemplate loads the configured template, falling back to the built-in default.
+func (s *OpenAiContentService) getPromptTemplate(ctx context.Context) (*entities.PromptTemplate, error) {
+	if s.templateName != "" {
+		tmpl, err := s.templates.GetPromptTemplate(ctx, s.templateName)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl != nil {
+			return tmpl, nil
+		}
+		log.Printf("[OPENAI] Prompt template %s not found, using the default one", s.templateName)
+	}
+	return &defaultPromptTemplate, nil
+}
+
+func renderPrompt(text string, req entities.ProductCard) (string, error) {
+	tmpl, err := template.New("prompt").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, req); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// stripCodeFence removes a markdown code fence some models wrap JSON output into.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	return strings.TrimSpace(strings.TrimSuffix(s, "```"))
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"strings"
+	"testing"
+)
+
+type fakeChatCompletionClient struct {
+	answer  string
+	request entities.ChatCompletionRequest
+}
+
+func (f *fakeChatCompletionClient) CreateChatCompletion(ctx context.Context, request entities.ChatCompletionRequest) (*entities.ChatCompletionResponse, error) {
+	f.request = request
+	return &entities.ChatCompletionResponse{
+		Choices: []entities.ChatCompletionChoice{{Message: entities.ChatMessage{Role: "assistant", Content: f.answer}}},
+		Usage:   entities.ChatCompletionUsage{PromptTokens: 120, CompletionTokens: 80, TotalTokens: 200},
+	}, nil
+}
+
+type fakePromptTemplateStorage struct{}
+
+func (f *fakePromptTemplateStorage) GetPromptTemplate(ctx context.Context, name string) (*entities.PromptTemplate, error) {
+	return nil, nil
+}
+
+type fakeWBSubjectCatalog struct{}
+
+func (f *fakeWBSubjectCatalog) SearchWBSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error) {
+	var result []entities.WBSubject
+	for _, s := range []entities.WBSubject{
+		{SubjectID: 192, SubjectName: "Футболки", ParentID: 1, ParentName: "Одежда"},
+		{SubjectID: 193, SubjectName: "Футболки-поло", ParentID: 1, ParentName: "Одежда"},
+	} {
+		if strings.Contains(strings.ToLower(s.SubjectName), strings.ToLower(filter.Query)) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func TestOpenAiContentService_ResolvesWbSubject(t *testing.T) {
+	client := &fakeChatCompletionClient{answer: "```json\n" + `{"title":"Футболка","description":"Хлопок","subject_name":"футболки","subject_id":7,"type_id":9}` + "\n```"}
+	s := NewOpenAiContentService(client, &fakePromptTemplateStorage{}, &fakeWBSubjectCatalog{}, "card_content")
+
+	content, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Футболка", Ozon: true})
+	if err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if !strings.Contains(client.request.Messages[1].Content, "subject_name") {
+		t.Error("Expected the prompt to ask for the WB subject")
+	}
+	if content.SubjectID == nil || *content.SubjectID != 192 || *content.SubjectName != "Футболки" || *content.ParentID != 1 {
+		t.Errorf("Expected the mirrored subject 192, got %+v", content)
+	}
+	if content.TypeID != nil {
+		t.Errorf("Expected the Ozon type made up by the model to be dropped, got %d", *content.TypeID)
+	}
+	if content.TokenUsage == nil || content.TokenUsage.TotalTokens != 200 {
+		t.Errorf("Expected token usage from the completion, got %+v", content.TokenUsage)
+	}
+}
+
+func TestOpenAiContentService_LeavesUnknownSubjectUnresolved(t *testing.T) {
+	client := &fakeChatCompletionClient{answer: `{"title":"Кружка","description":"Керамика","subject_name":"Кружки"}`}
+	s := NewOpenAiContentService(client, &fakePromptTemplateStorage{}, &fakeWBSubjectCatalog{}, "")
+
+	content, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Кружка"})
+	if err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if content.Title != "Кружка" || content.SubjectID != nil || content.SubjectName != nil {
+		t.Errorf("Expected content without a subject, got %+v", content)
+	}
+}
+
+func TestOpenAiContentService_KeepsSuppliedCategories(t *testing.T) {
+	client := &fakeChatCompletionClient{answer: `{"title":"Футболка","description":"Хлопок"}`}
+	s := NewOpenAiContentService(client, &fakePromptTemplateStorage{}, &fakeWBSubjectCatalog{}, "")
+
+	content, err := s.GetCardContent(context.Background(), "key", entities.ProductCard{ProductTitle: "Футболка", Ozon: true, SubjectId: 105, SubId: 17028922, TypeId: 91565})
+	if err != nil {
+		t.Fatalf("GetCardContent: %v", err)
+	}
+	if strings.Contains(client.request.Messages[1].Content, "subject_name") {
+		t.Error("Expected no subject question when subject_id is supplied")
+	}
+	if *content.SubjectID != 105 || *content.SubID != 17028922 || *content.TypeID != 91565 {
+		t.Errorf("Expected the supplied categories, got %+v", content)
+	}
+}
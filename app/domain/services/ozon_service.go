@@ -5,12 +5,40 @@ import (
 	"api/metrics"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 )
 
 type ozonClient interface {
 	ImportProductsV3(ctx context.Context, clientID, apiKey string, request entities.OzonProductImportRequest) (*entities.OzonProductImportResponse, error)
+	SearchAttributeValues(ctx context.Context, clientID, apiKey string, request entities.OzonAttributeValuesSearchRequest) (*entities.OzonAttributeValuesSearchResponse, error)
+	GetCategoryAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonCategoryAttributesRequest) (*entities.OzonCategoryAttributesResponse, error)
+	GetProductInfoList(ctx context.Context, clientID, apiKey string, request entities.OzonProductInfoListRequest) (*entities.OzonProductInfoListResponse, error)
+	GetProductAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonProductAttributesRequest) (*entities.OzonProductAttributesResponse, error)
+	GenerateBarcodes(ctx context.Context, clientID, apiKey string, request entities.OzonBarcodeGenerateRequest) (*entities.OzonBarcodeGenerateResponse, error)
+	ImportPrices(ctx context.Context, clientID, apiKey string, request entities.OzonImportPricesRequest) (*entities.OzonImportPricesResponse, error)
+	ListWarehouses(ctx context.Context, clientID, apiKey string) (*entities.OzonWarehouseListResponse, error)
+	SetStocks(ctx context.Context, clientID, apiKey string, request entities.OzonStocksRequest) (*entities.OzonStocksResponse, error)
+	GetProductStocks(ctx context.Context, clientID, apiKey string, request entities.OzonProductStocksRequest) (*entities.OzonProductStocksResponse, error)
+	GetWarehouseStocks(ctx context.Context, clientID, apiKey string, request entities.OzonWarehouseStocksRequest) (*entities.OzonWarehouseStocksResponse, error)
+}
+
+// ozonStocksPageSize is the maximum number of products Ozon returns in one stocks page
+const ozonStocksPageSize = 1000
+
+// ozonWarehouseStocksBatchSize is the maximum number of SKUs of one stocks-by-warehouse request
+const ozonWarehouseStocksBatchSize = 500
+
+// OzonBarcodeOptions configures waiting for imported products before Ozon barcodes are generated for them.
+type OzonBarcodeOptions struct {
+	PollInterval time.Duration // Delay between checks whether Ozon has created the products
+	Timeout      time.Duration // Barcodes are not generated for products Ozon has not created in time
 }
 
 type fileUploadService interface {
@@ -18,31 +46,39 @@ type fileUploadService interface {
 }
 
 type ozonService struct {
+	barcodeOpts       OzonBarcodeOptions
 	ozonClient        ozonClient
 	fileUploadService fileUploadService
 }
 
-func NewOzonService(ozonClient ozonClient, fileUploadService fileUploadService) *ozonService {
+func NewOzonService(barcodeOpts OzonBarcodeOptions, ozonClient ozonClient, fileUploadService fileUploadService) *ozonService {
+	if barcodeOpts.PollInterval <= 0 {
+		barcodeOpts.PollInterval = time.Second
+	}
 	return &ozonService{
+		barcodeOpts:       barcodeOpts,
 		ozonClient:        ozonClient,
 		fileUploadService: fileUploadService,
 	}
 }
 
-func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *bool, error) {
+func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error) {
 	var ozonApiResponseJSON *string
+	var ozonPreparedRequestJSON *string
 	var ozonRequestAttempted *bool
 
 	log.Printf("[OZON DEBUG] Starting CreateCard - ProductTitle: %s", req.ProductTitle)
-	log.Printf("[OZON DEBUG] Request flags - Ozon: %t, ApiKey present: %t, ClientID present: %t",
-		req.GetOzon(), req.GetOzonApiKey() != "", req.GetOzonApiClientId() != "")
+	log.Printf("[OZON DEBUG] Request flags - Ozon: %t, DryRun: %t, ApiKey present: %t, ClientID present: %t",
+		req.GetOzon(), req.IsDryRun(), req.GetOzonApiKey() != "", req.GetOzonApiClientId() != "")
 
-	attemptAPICall := req.GetOzon() && req.GetOzonApiKey() != "" && req.GetOzonApiClientId() != ""
+	attemptAPICall := req.GetOzon() && !req.IsDryRun() && req.GetOzonApiKey() != "" && req.GetOzonApiClientId() != ""
 	ozonRequestAttempted = &attemptAPICall
 
 	log.Printf("[OZON DEBUG] Will attempt API call: %t", attemptAPICall)
 
-	if !attemptAPICall {
+	// In dry-run mode the payload is prepared without credentials, but never sent
+	preparePayload := attemptAPICall || req.GetOzon() && req.IsDryRun()
+	if !preparePayload {
 		if req.GetOzon() {
 			log.Printf("[OZON DEBUG] Ozon integration requested but API key or Client ID is missing. Skipping Ozon API call.")
 			log.Printf("[OZON DEBUG] Missing - ClientID: %t, ApiKey: %t",
@@ -53,25 +89,17 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		// No API call will be made, so response JSON is empty.
 		emptyStr := ""
 		ozonApiResponseJSON = &emptyStr
-		return ozonApiResponseJSON, ozonRequestAttempted, nil
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, nil
 	}
 
 	log.Printf("[OZON DEBUG] Starting validation checks")
 
 	// Validate required fields for Ozon
-	if req.GetVendorCode() == "" {
-		log.Printf("[OZON DEBUG] Validation failed: vendor_code is missing")
-		errMsg := `{"error":true,"errorText":"vendor_code (for offer_id) is required for Ozon integration"}`
-		ozonApiResponseJSON = &errMsg
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("vendor_code (for offer_id) is required for Ozon integration")
-	}
-	log.Printf("[OZON DEBUG] VendorCode validation passed: %s", req.GetVendorCode())
-
 	if ccaApiResponse.Title == "" {
 		log.Printf("[OZON DEBUG] Validation failed: CardCraftAI title is missing")
 		errMsg := `{"error":true,"errorText":"CardCraftAI title (for name) is required for Ozon integration"}`
 		ozonApiResponseJSON = &errMsg
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI title (for name) is required for Ozon integration")
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI title (for name) is required for Ozon integration")
 	}
 	log.Printf("[OZON DEBUG] Title validation passed: %s", ccaApiResponse.Title)
 
@@ -79,7 +107,7 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		log.Printf("[OZON DEBUG] Validation failed: CardCraftAI SubID is missing")
 		errMsg := `{"error":true,"errorText":"CardCraftAI SubID (for Ozon description_category_id) is required for Ozon integration"}`
 		ozonApiResponseJSON = &errMsg
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI SubID (for Ozon description_category_id) is required for Ozon integration")
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI SubID (for Ozon description_category_id) is required for Ozon integration")
 	}
 	log.Printf("[OZON DEBUG] SubID validation passed: %d", *ccaApiResponse.SubID)
 
@@ -87,7 +115,7 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		log.Printf("[OZON DEBUG] Validation failed: CardCraftAI TypeID is missing")
 		errMsg := `{"error":true,"errorText":"CardCraftAI TypeID (for Ozon type_id) is required for Ozon integration"}`
 		ozonApiResponseJSON = &errMsg
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI TypeID (for Ozon type_id) is required for Ozon integration")
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, fmt.Errorf("CardCraftAI TypeID (for Ozon type_id) is required for Ozon integration")
 	}
 	log.Printf("[OZON DEBUG] TypeID validation passed: %d", *ccaApiResponse.TypeID)
 
@@ -100,12 +128,93 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		}
 		errMsg := `{"error":true,"errorText":"Dimensions (depth, width, height, weight) are required and must be non-zero for Ozon integration"}`
 		ozonApiResponseJSON = &errMsg
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("dimensions (depth, width, height, weight) are required and must be non-zero for Ozon integration")
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, fmt.Errorf("dimensions (depth, width, height, weight) are required and must be non-zero for Ozon integration")
 	}
 	log.Printf("[OZON DEBUG] Dimensions validation passed: %dx%dx%d, weight: %d",
 		*req.Dimensions.Depth, *req.Dimensions.Width, *req.Dimensions.Height, *req.Dimensions.Weight)
 
-	log.Printf("[OZON DEBUG] All validations passed, processing images")
+	log.Printf("[OZON DEBUG] All validations passed, preparing offers")
+
+	// One offer per color and size of the product, merged into one Ozon product card by the model name
+	var ozonItems []entities.OzonProductImportItem
+	sizeValueIDs := make(map[string]int64) // Resolved dictionary values of the size attribute, by size
+	for _, card := range req.ExpandVariants() {
+		content := ccaApiResponse
+		if len(req.Variants) > 0 {
+			content = entities.DefaultOzonContentConstraints().VariantContent(ccaApiResponse, card.Color)
+		}
+		ozonItem, err := ozs.buildImportItem(ctx, card, content)
+		if err != nil {
+			errBytes, _ := json.Marshal(map[string]interface{}{"error": true, "errorText": err.Error()})
+			errMsg := string(errBytes)
+			ozonApiResponseJSON = &errMsg
+			return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, err
+		}
+		ozonItems = append(ozonItems, ozs.sizeOffers(ctx, card, ozonItem, sizeValueIDs)...)
+	}
+
+	ozonPayload := entities.OzonProductImportRequest{Items: ozonItems}
+
+	// Debug: Log the final payload structure
+	log.Printf("[OZON DEBUG] Final payload items count: %d", len(ozonPayload.Items))
+	if len(ozonPayload.Items) > 0 {
+		log.Printf("[OZON DEBUG] Final payload item[0] images count: %d", len(ozonPayload.Items[0].Images))
+		log.Printf("[OZON DEBUG] Final payload item[0] images: %v", ozonPayload.Items[0].Images)
+	}
+
+	preparedBytes, err := json.Marshal(ozonPayload)
+	if err != nil {
+		log.Printf("Error marshalling Ozon prepared request: %v", err)
+		errMsg := fmt.Sprintf("{\"error\":true,\"errorText\":\"Failed to marshal prepared Ozon request: %s\"}", err.Error())
+		ozonPreparedRequestJSON = &errMsg
+	} else {
+		preparedStr := string(preparedBytes)
+		ozonPreparedRequestJSON = &preparedStr
+	}
+
+	if !attemptAPICall {
+		log.Printf("[OZON DEBUG] Dry-run: Ozon import request prepared but not sent")
+		emptyStr := ""
+		ozonApiResponseJSON = &emptyStr
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, nil
+	}
+
+	log.Printf("Attempting to import product to Ozon with ClientID: %s", req.GetOzonApiClientId())
+	ozonResp, ozonErr := ozs.ozonClient.ImportProductsV3(ctx, req.GetOzonApiClientId(), req.GetOzonApiKey(), ozonPayload)
+
+	var responseStringToStore string
+	if ozonErr != nil {
+		log.Printf("Error importing product to Ozon: %v", ozonErr)
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_import").Inc()
+		// Keep Ozon's own error object when the client could parse it, a generic structure otherwise
+		var errorResponse interface{} = map[string]interface{}{"error": true, "errorText": ozonErr.Error()}
+		var parsedErr *entities.OzonError
+		if errors.As(ozonErr, &parsedErr) {
+			errorResponse = parsedErr
+		}
+		errBytes, _ := json.Marshal(errorResponse) // Ignore marshalling error for error response
+		responseStringToStore = string(errBytes)
+		ozonApiResponseJSON = &responseStringToStore
+		return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, fmt.Errorf("ozon product import failed: %w", ozonErr)
+	} else {
+		log.Printf("Successfully called Ozon API. Response received.")
+		// Ozon's v3/product/import response doesn't have a top-level error field like WB.
+		// Errors are typically indicated by non-200 HTTP status, handled by the ozonClient.
+		// If specific task-level errors need to be parsed from the response, that logic would go here.
+		respBytes, _ := json.Marshal(ozonResp) // Ignore marshalling error for success response
+		responseStringToStore = string(respBytes)
+	}
+	ozonApiResponseJSON = &responseStringToStore
+	return ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, nil
+}
+
+// buildImportItem prepares the Ozon offer of card with the generated content shared by all offers of the product.
+func (ozs *ozonService) buildImportItem(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (entities.OzonProductImportItem, error) {
+	if req.GetVendorCode() == "" {
+		log.Printf("[OZON DEBUG] Validation failed: vendor_code is missing")
+		return entities.OzonProductImportItem{}, fmt.Errorf("vendor_code (for offer_id) is required for Ozon integration")
+	}
+	log.Printf("[OZON DEBUG] Processing images for offer %s", req.GetVendorCode())
 
 	// Process images for Ozon - combine uploaded files and existing links
 	var ozonImageURLs []string
@@ -128,58 +237,51 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		ozonImageURLs = append(ozonImageURLs, req.GetWbMediaToSaveLinks()...)
 	}
 
-	// Second, upload WbMediaToUploadFiles and get URLs
+	// Second, upload WbMediaToUploadFiles and get URLs, keeping the media kind of every file
+	var mediaLinks []*entities.MediaLink
 	if len(req.GetWbMediaToUploadFiles()) > 0 {
 		log.Printf("[OZON DEBUG] Uploading %d files from WbMediaToUploadFiles to get URLs for Ozon", len(req.GetWbMediaToUploadFiles()))
 
-		uploadedURLs, err := ozs.fileUploadService.UploadWBMediaFiles(ctx, req.GetWbMediaToUploadFiles())
+		uploadedLinks, err := ozs.uploadMediaFiles(ctx, req.GetWbMediaToUploadFiles())
 		if err != nil {
 			log.Printf("[OZON DEBUG] ERROR: File upload service failed: %v", err)
 			// Don't continue on error - this is critical for Ozon
-			errMsg := `{"error":true,"errorText":"Failed to upload image files for Ozon: ` + err.Error() + `"}`
-			ozonApiResponseJSON = &errMsg
-			return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("failed to upload image files for Ozon: %w", err)
+			return entities.OzonProductImportItem{}, fmt.Errorf("failed to upload image files for Ozon: %w", err)
 		}
 
-		log.Printf("[OZON DEBUG] File upload service returned %d URLs", len(uploadedURLs))
-		for i, url := range uploadedURLs {
-			log.Printf("[OZON DEBUG] Uploaded URL[%d]: %s", i, url)
+		log.Printf("[OZON DEBUG] File upload service returned %d URLs", len(uploadedLinks))
+		for i, link := range uploadedLinks {
+			log.Printf("[OZON DEBUG] Uploaded URL[%d]: %s (kind: %d)", i, link.URL, link.Kind)
 		}
 
-		if len(uploadedURLs) > 0 {
-			log.Printf("[OZON DEBUG] Successfully uploaded %d files, adding URLs to Ozon images", len(uploadedURLs))
-			ozonImageURLs = append(ozonImageURLs, uploadedURLs...)
-		} else {
+		if len(uploadedLinks) == 0 {
 			log.Printf("[OZON DEBUG] WARNING: File upload service returned 0 URLs despite %d input files", len(req.GetWbMediaToUploadFiles()))
 			// This is suspicious - let's not proceed with empty images for Ozon
-			errMsg := `{"error":true,"errorText":"No images were successfully uploaded for Ozon despite having input files"}`
-			ozonApiResponseJSON = &errMsg
-			return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("no images were successfully uploaded for Ozon despite having %d input files", len(req.GetWbMediaToUploadFiles()))
+			return entities.OzonProductImportItem{}, fmt.Errorf("no images were successfully uploaded for Ozon despite having %d input files", len(req.GetWbMediaToUploadFiles()))
 		}
+		mediaLinks = append(mediaLinks, uploadedLinks...)
 	}
 
-	log.Printf("[OZON DEBUG] Total images for Ozon: %d", len(ozonImageURLs))
+	// Third, add media uploaded beforehand and referenced by ID
+	mediaLinks = append(mediaLinks, req.GetMediaLinks()...)
+
+	ozonMedia := buildOzonMedia(ozonImageURLs, mediaLinks)
+	ozonImageURLs = ozonMedia.Images
+
+	log.Printf("[OZON DEBUG] Total images for Ozon: %d, primary: %q, color: %q, 360: %d, videos: %d",
+		len(ozonImageURLs), ozonMedia.PrimaryImage, ozonMedia.ColorImage, len(ozonMedia.Images360), len(ozonMedia.ComplexAttributes))
 	for i, url := range ozonImageURLs {
 		log.Printf("[OZON DEBUG] Final Ozon image[%d]: %s", i, url)
 	}
 
-	if len(ozonImageURLs) == 0 {
+	if len(ozonImageURLs) == 0 && ozonMedia.PrimaryImage == "" {
 		log.Printf("[OZON DEBUG] Warning: No images available for Ozon product")
 	}
 
 	log.Printf("[OZON DEBUG] Creating Ozon payload")
 
-	// Determine price from sizes if available, otherwise use a default minimum price
-	var price string = "100" // Minimum price 100 kopecks = 1 ruble
-	if len(req.Sizes) > 0 {
-		// Prefer Ozon-specific price, fallback to general price
-		if req.Sizes[0].OzonPrice != nil && *req.Sizes[0].OzonPrice > 0 {
-			price = fmt.Sprintf("%d", *req.Sizes[0].OzonPrice)
-		} else if req.Sizes[0].Price > 0 {
-			price = fmt.Sprintf("%d", req.Sizes[0].Price)
-		}
-		log.Printf("[OZON DEBUG] Using price: %s kopecks for Ozon", price)
-	}
+	// Price and barcode are set per size by sizeOffers; the minimum price is kept for products without sizes
+	price := "100" // Default price in rubles
 
 	ozonItem := entities.OzonProductImportItem{
 		Name:                  ccaApiResponse.Title,
@@ -196,63 +298,619 @@ func (ozs *ozonService) CreateCard(ctx context.Context, req *entities.ProductCar
 		Weight:                int32(*req.Dimensions.Weight),
 		WeightUnit:            req.Dimensions.WeightUnit,
 		Images:                ozonImageURLs, // Use processed image URLs
+		PrimaryImage:          ozonMedia.PrimaryImage,
+		ColorImage:            ozonMedia.ColorImage,
+		Images360:             ozonMedia.Images360,
 		Attributes:            []entities.OzonProductAttribute{},
-		ComplexAttributes:     []entities.OzonComplexAttribute{},
+		ComplexAttributes:     ozonMedia.ComplexAttributes,
 	}
 
 	// Debug: Log the final ozonItem structure before adding to payload
 	log.Printf("[OZON DEBUG] Created ozonItem with %d images", len(ozonItem.Images))
 	log.Printf("[OZON DEBUG] ozonItem.Images: %v", ozonItem.Images)
 
-	if len(req.Sizes) > 0 && len(req.Sizes[0].Skus) > 0 {
-		ozonItem.Barcode = req.Sizes[0].Skus[0]
+	// Add required "Название модели" attribute (Model Name). Ozon merges offers with the same model name
+	// into one product card, so it must not depend on the generated title, which differs between variants.
+	modelName := req.GetModelName()
+	if modelName == "" {
+		modelName = ccaApiResponse.Title
 	}
-
-	// Add required "Название модели" attribute (Model Name)
 	ozonItem.Attributes = append(ozonItem.Attributes, entities.OzonProductAttribute{
-		ID:        9048, // Required "Название модели (для объединения в одну карточку)"
+		ID:        entities.OzonModelNameAttributeID, // Required "Название модели (для объединения в одну карточку)"
 		ComplexID: 0,
-		Values:    []entities.OzonProductAttributeValue{{Value: ccaApiResponse.Title}}, // Use title as model name
+		Values:    []entities.OzonProductAttributeValue{{Value: modelName}},
 	})
 
+	if req.Color != "" {
+		ozonItem.Attributes = append(ozonItem.Attributes, entities.OzonProductAttribute{
+			ID:        entities.OzonColorNameAttributeID,
+			ComplexID: 0,
+			Values:    []entities.OzonProductAttributeValue{{Value: req.Color}},
+		})
+	}
+
 	if req.Brand != "" {
 		ozonItem.Attributes = append(ozonItem.Attributes, entities.OzonProductAttribute{
-			ID:        85, // Standard Ozon ID for "Brand"
+			ID:        entities.OzonBrandAttributeID,
 			ComplexID: 0,
 			Values:    []entities.OzonProductAttributeValue{{Value: req.Brand}},
 		})
 	}
 
-	ozonPayload := entities.OzonProductImportRequest{Items: []entities.OzonProductImportItem{ozonItem}}
+	if ccaApiResponse.Description != "" {
+		ozonItem.Attributes = append(ozonItem.Attributes, entities.OzonProductAttribute{
+			ID:        entities.OzonAnnotationAttributeID,
+			ComplexID: 0,
+			Values:    []entities.OzonProductAttributeValue{{Value: ccaApiResponse.Description}},
+		})
+	}
 
-	// Debug: Log the final payload structure
-	log.Printf("[OZON DEBUG] Final payload items count: %d", len(ozonPayload.Items))
-	if len(ozonPayload.Items) > 0 {
-		log.Printf("[OZON DEBUG] Final payload item[0] images count: %d", len(ozonPayload.Items[0].Images))
-		log.Printf("[OZON DEBUG] Final payload item[0] images: %v", ozonPayload.Items[0].Images)
+	ozonItem.Attributes = append(ozonItem.Attributes, req.OzonAttributes...)
+
+	return ozonItem, nil
+}
+
+// sizeOffers splits the offer of card into one offer per size with its own offer_id, price, barcode and size.
+// With a single size the vendor code is kept as offer_id, so existing offers are updated rather than duplicated.
+func (ozs *ozonService) sizeOffers(ctx context.Context, card *entities.ProductCard, ozonItem entities.OzonProductImportItem, sizeValueIDs map[string]int64) []entities.OzonProductImportItem {
+	if len(card.Sizes) == 0 {
+		return []entities.OzonProductImportItem{ozonItem}
 	}
+	offers := make([]entities.OzonProductImportItem, len(card.Sizes))
+	offerIDs := ozonOfferIDs(card)
+	for i, size := range card.Sizes {
+		offer := ozonItem
+		offer.Attributes = append([]entities.OzonProductAttribute(nil), ozonItem.Attributes...)
+		offer.OfferID = offerIDs[i]
+		// Prefer Ozon-specific price, fallback to general price
+		if size.OzonPrice != nil && *size.OzonPrice > 0 {
+			offer.Price = fmt.Sprintf("%d", *size.OzonPrice)
+		} else if size.Price > 0 {
+			offer.Price = fmt.Sprintf("%d", size.Price)
+		}
+		if len(size.Skus) > 0 {
+			offer.Barcode = size.Skus[0]
+		}
+		if attr, ok := ozs.sizeAttribute(ctx, card, ozonItem, size, sizeValueIDs); ok {
+			offer.Attributes = append(offer.Attributes, attr)
+		}
+		log.Printf("[OZON DEBUG] Offer %s: price %s rubles, barcode %q", offer.OfferID, offer.Price, offer.Barcode)
+		offers[i] = offer
+	}
+	return offers
+}
 
-	log.Printf("Attempting to import product to Ozon with ClientID: %s", req.GetOzonApiClientId())
-	ozonResp, ozonErr := ozs.ozonClient.ImportProductsV3(ctx, req.GetOzonApiClientId(), req.GetOzonApiKey(), ozonPayload)
+// ozonOfferIDs derives the offer_id of every size of card: the vendor code and the tech size, upper-cased with
+// every run of other characters than letters and digits replaced by "-", so "s/m" and "S M" give the same offer_id.
+// Sizes whose offer_id is taken already get a numeric suffix. A card with a single size keeps the vendor code.
+func ozonOfferIDs(card *entities.ProductCard) []string {
+	offerIDs := make([]string, len(card.Sizes))
+	used := make(map[string]bool, len(card.Sizes))
+	for i, size := range card.Sizes {
+		offerID := card.VendorCode
+		if suffix := normalizeOfferIDPart(size.TechSize); len(card.Sizes) > 1 && suffix != "" {
+			offerID += "-" + suffix
+		}
+		unique := offerID
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s-%d", offerID, n)
+		}
+		used[unique] = true
+		offerIDs[i] = unique
+	}
+	return offerIDs
+}
 
-	var responseStringToStore string
-	if ozonErr != nil {
-		log.Printf("Error importing product to Ozon: %v", ozonErr)
-		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_import").Inc()
-		// Use a generic error structure for the JSON string
-		errorResponse := map[string]interface{}{"error": true, "errorText": ozonErr.Error()}
-		errBytes, _ := json.Marshal(errorResponse) // Ignore marshalling error for error response
-		responseStringToStore = string(errBytes)
-		ozonApiResponseJSON = &responseStringToStore
-		return ozonApiResponseJSON, ozonRequestAttempted, fmt.Errorf("ozon product import failed: %w", ozonErr)
-	} else {
-		log.Printf("Successfully called Ozon API. Response received.")
-		// Ozon's v3/product/import response doesn't have a top-level error field like WB.
-		// Errors are typically indicated by non-200 HTTP status, handled by the ozonClient.
-		// If specific task-level errors need to be parsed from the response, that logic would go here.
-		respBytes, _ := json.Marshal(ozonResp) // Ignore marshalling error for success response
-		responseStringToStore = string(respBytes)
+// normalizeOfferIDPart upper-cases s and replaces every run of other characters than letters and digits by "-".
+func normalizeOfferIDPart(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// WaitForOffers polls Ozon until the offers of req are created or the configured timeout expires and returns
+// the created ones, so that AssignBarcodes and SetInitialStock share one wait. It does not poll if req needs
+// neither barcodes nor an initial stock.
+func (ozs *ozonService) WaitForOffers(ctx context.Context, req *entities.ProductCard) ([]entities.OzonProductInfo, error) {
+	needed := req.InitialStock != nil && req.InitialStock.OzonWarehouseID != 0
+	var offerIDs []string
+	for _, card := range req.ExpandVariants() {
+		if len(card.Sizes) == 0 {
+			offerIDs = append(offerIDs, card.VendorCode)
+			needed = true
+		}
+		offerIDs = append(offerIDs, ozonOfferIDs(card)...)
+		for _, size := range card.Sizes {
+			needed = needed || len(size.Skus) == 0
+		}
 	}
-	ozonApiResponseJSON = &responseStringToStore
-	return ozonApiResponseJSON, ozonRequestAttempted, nil
+	if !needed {
+		return nil, nil
+	}
+	return ozs.waitForProducts(ctx, req, offerIDs)
+}
+
+// AssignBarcodes generates Ozon barcodes for the created products of req imported without one and returns them.
+func (ozs *ozonService) AssignBarcodes(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) ([]*entities.GeneratedBarcodes, error) {
+	pending := make(map[string]*entities.GeneratedBarcodes) // By offer ID
+	var offerIDs []string
+	for _, card := range req.ExpandVariants() {
+		if len(card.Sizes) == 0 {
+			pending[card.VendorCode] = &entities.GeneratedBarcodes{Marketplace: entities.MarketplaceOzon, VendorCode: card.VendorCode}
+			offerIDs = append(offerIDs, card.VendorCode)
+		}
+		cardOfferIDs := ozonOfferIDs(card)
+		for i, size := range card.Sizes {
+			if len(size.Skus) > 0 {
+				continue
+			}
+			offerID := cardOfferIDs[i]
+			pending[offerID] = &entities.GeneratedBarcodes{Marketplace: entities.MarketplaceOzon, VendorCode: card.VendorCode, TechSize: size.TechSize}
+			offerIDs = append(offerIDs, offerID)
+		}
+	}
+	if len(offerIDs) == 0 {
+		return nil, nil
+	}
+
+	var productIDs []string
+	for _, product := range products {
+		if pending[product.OfferID] != nil && len(product.Barcodes) == 0 {
+			productIDs = append(productIDs, strconv.FormatInt(product.ID, 10))
+		}
+	}
+	if len(productIDs) > 0 {
+		log.Printf("Generating Ozon barcodes for %d products", len(productIDs))
+		resp, err := ozs.ozonClient.GenerateBarcodes(ctx, req.GetOzonApiClientId(), req.GetOzonApiKey(), entities.OzonBarcodeGenerateRequest{ProductIDs: productIDs})
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_barcode_generate").Inc()
+			return nil, fmt.Errorf("failed to generate Ozon barcodes: %w", err)
+		}
+		for _, e := range resp.Errors {
+			log.Printf("Ozon could not generate a barcode for product %d: %s %s", e.ProductID, e.Code, e.Error)
+		}
+		// Generated barcodes are only returned by the product info
+		if products, err = ozs.getProducts(ctx, req, offerIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var generated []*entities.GeneratedBarcodes
+	for _, product := range products {
+		if g := pending[product.OfferID]; g != nil && len(product.Barcodes) > 0 {
+			g.Barcodes = product.Barcodes
+			generated = append(generated, g)
+		}
+	}
+	return generated, nil
+}
+
+// UpdatePrices sets prices of Ozon offers. Ozon applies prices synchronously and reports the result per offer.
+func (ozs *ozonService) UpdatePrices(ctx context.Context, clientID, apiKey string, prices []entities.OzonPrice) ([]entities.OzonPriceUpdateResult, error) {
+	for i := range prices {
+		if prices[i].CurrencyCode == "" {
+			prices[i].CurrencyCode = "RUB"
+		}
+	}
+	resp, err := ozs.ozonClient.ImportPrices(ctx, clientID, apiKey, entities.OzonImportPricesRequest{Prices: prices})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_import_prices").Inc()
+		return nil, fmt.Errorf("failed to update Ozon prices: %w", err)
+	}
+	for _, r := range resp.Result {
+		if !r.Updated {
+			log.Printf("Ozon did not update the price of offer %s: %+v", r.OfferID, r.Errors)
+		}
+	}
+	return resp.Result, nil
+}
+
+// ListWarehouses returns the warehouses of the seller on Ozon.
+func (ozs *ozonService) ListWarehouses(ctx context.Context, clientID, apiKey string) ([]entities.Warehouse, error) {
+	resp, err := ozs.ozonClient.ListWarehouses(ctx, clientID, apiKey)
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_warehouse_list").Inc()
+		return nil, fmt.Errorf("failed to list Ozon warehouses: %w", err)
+	}
+	warehouses := make([]entities.Warehouse, len(resp.Result))
+	for i, w := range resp.Result {
+		warehouses[i] = entities.Warehouse{Marketplace: entities.MarketplaceOzon, ID: w.WarehouseID, Name: w.Name}
+	}
+	return warehouses, nil
+}
+
+// SetStocks sets stocks of Ozon offers in seller warehouses and reports the result per offer.
+func (ozs *ozonService) SetStocks(ctx context.Context, clientID, apiKey string, stocks []entities.OzonStock) ([]entities.OzonStockUpdateResult, error) {
+	resp, err := ozs.ozonClient.SetStocks(ctx, clientID, apiKey, entities.OzonStocksRequest{Stocks: stocks})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_products_stocks").Inc()
+		return nil, fmt.Errorf("failed to set Ozon stocks: %w", err)
+	}
+	for _, r := range resp.Result {
+		if !r.Updated {
+			log.Printf("Ozon did not update the stock of offer %s in warehouse %d: %+v", r.OfferID, r.WarehouseID, r.Errors)
+		}
+	}
+	return resp.Result, nil
+}
+
+// GetStocks returns the available stock, present minus reserved, of the offers in the FBS warehouse.
+// Offers Ozon does not know are missing from the result, offers without stock in the warehouse have 0.
+func (ozs *ozonService) GetStocks(ctx context.Context, clientID, apiKey string, warehouseID int64, offerIDs []string) (map[string]int, error) {
+	amounts := make(map[string]int, len(offerIDs))
+	offerIDsBySKU := make(map[int64]string)
+	var skus []string
+	for start := 0; start < len(offerIDs); start += ozonStocksPageSize {
+		end := min(start+ozonStocksPageSize, len(offerIDs))
+		request := entities.OzonProductStocksRequest{
+			Filter: entities.OzonProductStocksFilter{OfferID: offerIDs[start:end], Visibility: "ALL"},
+			Limit:  ozonStocksPageSize,
+		}
+		resp, err := ozs.ozonClient.GetProductStocks(ctx, clientID, apiKey, request)
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_info_stocks").Inc()
+			return nil, fmt.Errorf("failed to get Ozon stocks: %w", err)
+		}
+		// The per warehouse stocks are only available by SKU
+		for _, item := range resp.Items {
+			amounts[item.OfferID] = 0
+			for _, s := range item.Stocks {
+				if s.Type == "fbs" && s.SKU != 0 {
+					offerIDsBySKU[s.SKU] = item.OfferID
+					skus = append(skus, strconv.FormatInt(s.SKU, 10))
+				}
+			}
+		}
+	}
+
+	for start := 0; start < len(skus); start += ozonWarehouseStocksBatchSize {
+		end := min(start+ozonWarehouseStocksBatchSize, len(skus))
+		resp, err := ozs.ozonClient.GetWarehouseStocks(ctx, clientID, apiKey, entities.OzonWarehouseStocksRequest{SKU: skus[start:end]})
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_info_stocks_by_warehouse").Inc()
+			return nil, fmt.Errorf("failed to get Ozon stocks in warehouse %d: %w", warehouseID, err)
+		}
+		for _, s := range resp.Result {
+			if offerID, ok := offerIDsBySKU[s.SKU]; ok && s.WarehouseID == warehouseID {
+				amounts[offerID] += s.Present - s.Reserved
+			}
+		}
+	}
+	return amounts, nil
+}
+
+// SetInitialStock sets the initial stock of the created products of req. Ozon only accepts stocks of created
+// products, so offers not created in time and offers Ozon did not update are reported in the error.
+func (ozs *ozonService) SetInitialStock(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) error {
+	if req.InitialStock == nil || req.InitialStock.OzonWarehouseID == 0 {
+		return nil
+	}
+	offers := 0
+	for _, card := range req.ExpandVariants() {
+		offers += max(len(card.Sizes), 1)
+	}
+	if len(products) == 0 {
+		return fmt.Errorf("ozon has not created any of the %d offers yet", offers)
+	}
+
+	stocks := make([]entities.OzonStock, len(products))
+	for i, product := range products {
+		stocks[i] = entities.OzonStock{OfferID: product.OfferID, Stock: req.InitialStock.Amount, WarehouseID: req.InitialStock.OzonWarehouseID}
+	}
+	results, err := ozs.SetStocks(ctx, req.GetOzonApiClientId(), req.GetOzonApiKey(), stocks)
+	if err != nil {
+		return err
+	}
+	var notUpdated []string
+	for _, r := range results {
+		if !r.Updated {
+			notUpdated = append(notUpdated, r.OfferID)
+		}
+	}
+	var errs []error
+	if len(products) < offers {
+		errs = append(errs, fmt.Errorf("ozon has created %d of %d offers in time, the stock of the rest is not set", len(products), offers))
+	}
+	if len(notUpdated) > 0 {
+		errs = append(errs, fmt.Errorf("ozon did not update the stock of offers %s", strings.Join(notUpdated, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// waitForProducts polls Ozon until all offers are created or the timeout expires and returns the created ones.
+func (ozs *ozonService) waitForProducts(ctx context.Context, req *entities.ProductCard, offerIDs []string) ([]entities.OzonProductInfo, error) {
+	deadline := time.Now().Add(ozs.barcodeOpts.Timeout)
+	for {
+		products, err := ozs.getProducts(ctx, req, offerIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == len(offerIDs) || !time.Now().Before(deadline) {
+			if len(products) < len(offerIDs) {
+				log.Printf("Ozon created %d of %d offers within %v, continuing with the created ones", len(products), len(offerIDs), ozs.barcodeOpts.Timeout)
+			}
+			return products, nil
+		}
+
+		timer := time.NewTimer(ozs.barcodeOpts.PollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (ozs *ozonService) getProducts(ctx context.Context, req *entities.ProductCard, offerIDs []string) ([]entities.OzonProductInfo, error) {
+	resp, err := ozs.ozonClient.GetProductInfoList(ctx, req.GetOzonApiClientId(), req.GetOzonApiKey(), entities.OzonProductInfoListRequest{OfferID: offerIDs})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_info_list").Inc()
+		return nil, fmt.Errorf("failed to get Ozon products: %w", err)
+	}
+	return resp.Items, nil
+}
+
+// sizeAttribute resolves the size against the Ozon dictionary of the size attribute in the category of the offer.
+// Sizes that cannot be resolved are left out, Ozon then reports the missing attribute itself. In dry-run mode
+// the dictionary is not searched and the size attribute is left out of the prepared request.
+func (ozs *ozonService) sizeAttribute(ctx context.Context, card *entities.ProductCard, ozonItem entities.OzonProductImportItem, size *entities.WBSize, sizeValueIDs map[string]int64) (entities.OzonProductAttribute, bool) {
+	value := size.WbSize
+	if value == "" {
+		value = size.TechSize
+	}
+	if value == "" || card.GetOzonApiClientId() == "" || card.GetOzonApiKey() == "" {
+		return entities.OzonProductAttribute{}, false
+	}
+	if card.IsDryRun() {
+		log.Printf("[OZON DEBUG] Dry-run: size %q is not looked up in the Ozon dictionary", value)
+		return entities.OzonProductAttribute{}, false
+	}
+
+	valueID, resolved := sizeValueIDs[value]
+	if !resolved {
+		var err error
+		valueID, err = ozs.dictionaryValueID(ctx, card.GetOzonApiClientId(), card.GetOzonApiKey(), ozonItem.DescriptionCategoryID, ozonItem.TypeID, entities.OzonSizeAttributeID, value)
+		if err != nil {
+			log.Printf("[OZON DEBUG] Failed to resolve size %q in the Ozon dictionary: %v", value, err)
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_attribute_values_search").Inc()
+			return entities.OzonProductAttribute{}, false
+		}
+		sizeValueIDs[value] = valueID
+	}
+	if valueID == 0 {
+		log.Printf("[OZON DEBUG] Size %q is not in the Ozon dictionary of category %d, type %d", value, ozonItem.DescriptionCategoryID, ozonItem.TypeID)
+		return entities.OzonProductAttribute{}, false
+	}
+	return entities.OzonProductAttribute{
+		ID:     entities.OzonSizeAttributeID,
+		Values: []entities.OzonProductAttributeValue{{DictionaryValueID: valueID, Value: value}},
+	}, true
+}
+
+// ozonFilledAttributes are filled by buildImportItem and sizeOffers, characteristics are never converted to them.
+var ozonFilledAttributes = map[int64]bool{
+	entities.OzonBrandAttributeID:      true,
+	entities.OzonAnnotationAttributeID: true,
+	entities.OzonModelNameAttributeID:  true,
+	entities.OzonColorNameAttributeID:  true,
+	entities.OzonSizeAttributeID:       true,
+}
+
+// ConvertCharacteristics converts characteristics of another marketplace to the attributes of the Ozon category
+// and type with the same name. Dictionary values are resolved against the Ozon dictionary, values it does not know
+// are left out. The names of characteristics that could not be converted are returned as unmapped.
+func (ozs *ozonService) ConvertCharacteristics(ctx context.Context, clientID, apiKey string, categoryID, typeID int64, characteristics []entities.ProductCharacteristic) ([]entities.OzonProductAttribute, []string, error) {
+	categoryAttributes, err := ozs.categoryAttributes(ctx, clientID, apiKey, categoryID, typeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	byName := make(map[string]entities.OzonCategoryAttribute, len(categoryAttributes))
+	for _, a := range categoryAttributes {
+		if !ozonFilledAttributes[a.ID] {
+			byName[strings.ToLower(strings.TrimSpace(a.Name))] = a
+		}
+	}
+
+	var attributes []entities.OzonProductAttribute
+	var unmapped []string
+	for _, c := range characteristics {
+		target, ok := byName[strings.ToLower(strings.TrimSpace(c.Name))]
+		values := c.Values
+		if !ok || len(values) == 0 {
+			unmapped = append(unmapped, c.Name)
+			continue
+		}
+		if !target.IsCollection {
+			values = values[:1]
+		}
+
+		attr := entities.OzonProductAttribute{ID: target.ID}
+		for _, value := range values {
+			if target.DictionaryID == 0 {
+				attr.Values = append(attr.Values, entities.OzonProductAttributeValue{Value: value})
+				continue
+			}
+			valueID, err := ozs.dictionaryValueID(ctx, clientID, apiKey, categoryID, typeID, target.ID, value)
+			if err != nil {
+				log.Printf("[OZON DEBUG] Failed to resolve %q of attribute %q in the Ozon dictionary: %v", value, target.Name, err)
+				metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_attribute_values_search").Inc()
+				continue
+			}
+			if valueID == 0 {
+				log.Printf("[OZON DEBUG] Value %q of attribute %q is not in the Ozon dictionary", value, target.Name)
+				continue
+			}
+			attr.Values = append(attr.Values, entities.OzonProductAttributeValue{DictionaryValueID: valueID, Value: value})
+		}
+		if len(attr.Values) == 0 {
+			unmapped = append(unmapped, c.Name)
+			continue
+		}
+		attributes = append(attributes, attr)
+	}
+	return attributes, unmapped, nil
+}
+
+// dictionaryValueID returns the ID of the dictionary value of the attribute equal to value, 0 if there is none.
+func (ozs *ozonService) dictionaryValueID(ctx context.Context, clientID, apiKey string, categoryID, typeID, attributeID int64, value string) (int64, error) {
+	resp, err := ozs.ozonClient.SearchAttributeValues(ctx, clientID, apiKey, entities.OzonAttributeValuesSearchRequest{
+		AttributeID:           attributeID,
+		DescriptionCategoryID: categoryID,
+		TypeID:                typeID,
+		Value:                 value,
+		Limit:                 50,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range resp.Result {
+		if strings.EqualFold(strings.TrimSpace(v.Value), value) {
+			return v.ID, nil
+		}
+	}
+	return 0, nil
+}
+
+// categoryAttributes returns the attributes of the Ozon category and type.
+func (ozs *ozonService) categoryAttributes(ctx context.Context, clientID, apiKey string, categoryID, typeID int64) ([]entities.OzonCategoryAttribute, error) {
+	resp, err := ozs.ozonClient.GetCategoryAttributes(ctx, clientID, apiKey, entities.OzonCategoryAttributesRequest{
+		DescriptionCategoryID: categoryID,
+		TypeID:                typeID,
+		Language:              "DEFAULT",
+	})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_category_attributes").Inc()
+		return nil, fmt.Errorf("failed to get attributes of Ozon category %d, type %d: %w", categoryID, typeID, err)
+	}
+	return resp.Result, nil
+}
+
+// GetProduct returns the product with offerID with its attributes, ErrOzonProductNotFound if there is none.
+// Attributes filled from card fields (brand, description, model, color and size) are not returned
+// as characteristics, neither are complex attributes such as videos.
+func (ozs *ozonService) GetProduct(ctx context.Context, clientID, apiKey, offerID string) (*entities.OzonProduct, error) {
+	infos, err := ozs.ozonClient.GetProductInfoList(ctx, clientID, apiKey, entities.OzonProductInfoListRequest{OfferID: []string{offerID}})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_info_list").Inc()
+		return nil, fmt.Errorf("failed to get Ozon product %s: %w", offerID, err)
+	}
+	product := &entities.OzonProduct{}
+	found := false
+	for _, info := range infos.Items {
+		if info.OfferID == offerID {
+			product.Info, found = info, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: offer_id %s", entities.ErrOzonProductNotFound, offerID)
+	}
+
+	details, err := ozs.ozonClient.GetProductAttributes(ctx, clientID, apiKey, entities.OzonProductAttributesRequest{
+		Filter: entities.OzonProductAttributesFilter{OfferID: []string{offerID}, Visibility: "ALL"},
+		Limit:  1,
+	})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("ozon_product_info_attributes").Inc()
+		return nil, fmt.Errorf("failed to get attributes of Ozon product %s: %w", offerID, err)
+	}
+	if len(details.Result) == 0 {
+		return nil, fmt.Errorf("%w: no attributes for offer_id %s", entities.ErrOzonProductNotFound, offerID)
+	}
+	product.Details = details.Result[0]
+
+	categoryAttributes, err := ozs.categoryAttributes(ctx, clientID, apiKey, product.Details.DescriptionCategoryID, product.Details.TypeID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(categoryAttributes))
+	for _, a := range categoryAttributes {
+		names[a.ID] = a.Name
+	}
+	for _, a := range product.Details.Attributes {
+		if ozonFilledAttributes[a.ID] || a.ID == entities.OzonColorAttributeID || a.ComplexID != 0 || names[a.ID] == "" {
+			continue
+		}
+		if values := product.Details.AttributeValues(a.ID); len(values) > 0 {
+			product.Characteristics = append(product.Characteristics, entities.ProductCharacteristic{Name: names[a.ID], Values: values})
+		}
+	}
+	return product, nil
+}
+
+// uploadMediaFiles uploads inline files to the file storage grouped by media kind,
+// so that every returned link keeps the kind of its source file.
+func (ozs *ozonService) uploadMediaFiles(ctx context.Context, files []*entities.WBClientMediaFile) ([]*entities.MediaLink, error) {
+	var kinds []entities.MediaKind
+	filesByKind := make(map[entities.MediaKind][]*entities.WBClientMediaFile)
+	for _, f := range files {
+		if _, ok := filesByKind[f.Kind]; !ok {
+			kinds = append(kinds, f.Kind)
+		}
+		filesByKind[f.Kind] = append(filesByKind[f.Kind], f)
+	}
+
+	var links []*entities.MediaLink
+	for _, kind := range kinds {
+		urls, err := ozs.fileUploadService.UploadWBMediaFiles(ctx, filesByKind[kind])
+		if err != nil {
+			return nil, err
+		}
+		for _, url := range urls {
+			links = append(links, &entities.MediaLink{URL: url, Name: path.Base(url), Kind: kind})
+		}
+	}
+	return links, nil
+}
+
+// buildOzonMedia distributes media links over the Ozon product fields:
+// the primary photo goes to primary_image, color swatches to color_image, 360-degree frames to images360,
+// and videos to complex attribute 100001 (or 100002 for a video cover flagged as primary).
+func buildOzonMedia(images []string, links []*entities.MediaLink) entities.OzonProductMedia {
+	media := entities.OzonProductMedia{Images: images}
+	for _, link := range links {
+		switch link.Kind {
+		case entities.MediaKindVideo:
+			if link.Primary {
+				media.ComplexAttributes = append(media.ComplexAttributes, entities.OzonComplexAttribute{
+					Attributes: []entities.OzonProductAttribute{{
+						ComplexID: entities.OzonVideoCoverComplexID,
+						ID:        entities.OzonVideoCoverURLAttributeID,
+						Values:    []entities.OzonProductAttributeValue{{Value: link.URL}},
+					}},
+				})
+				continue
+			}
+			media.ComplexAttributes = append(media.ComplexAttributes, entities.OzonComplexAttribute{
+				Attributes: []entities.OzonProductAttribute{
+					{
+						ComplexID: entities.OzonVideoComplexID,
+						ID:        entities.OzonVideoURLAttributeID,
+						Values:    []entities.OzonProductAttributeValue{{Value: link.URL}},
+					},
+					{
+						ComplexID: entities.OzonVideoComplexID,
+						ID:        entities.OzonVideoNameAttributeID,
+						Values:    []entities.OzonProductAttributeValue{{Value: link.Name}},
+					},
+				},
+			})
+		case entities.MediaKindImage360:
+			media.Images360 = append(media.Images360, link.URL)
+		case entities.MediaKindColorSwatch:
+			if media.ColorImage == "" {
+				media.ColorImage = link.URL
+			} else {
+				log.Printf("[OZON DEBUG] Ignoring extra color swatch %s: Ozon accepts a single color image", link.URL)
+			}
+		default:
+			if link.Primary && media.PrimaryImage == "" {
+				media.PrimaryImage = link.URL
+			} else {
+				media.Images = append(media.Images, link.URL)
+			}
+		}
+	}
+	return media
 }
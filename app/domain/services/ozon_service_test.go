@@ -0,0 +1,476 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeOzonClient struct {
+	searches  int
+	infoCalls int
+	generated []string
+	stocks    []entities.OzonStock
+}
+
+func (f *fakeOzonClient) ImportProductsV3(ctx context.Context, clientID, apiKey string, request entities.OzonProductImportRequest) (*entities.OzonProductImportResponse, error) {
+	return &entities.OzonProductImportResponse{}, nil
+}
+
+func (f *fakeOzonClient) SearchAttributeValues(ctx context.Context, clientID, apiKey string, request entities.OzonAttributeValuesSearchRequest) (*entities.OzonAttributeValuesSearchResponse, error) {
+	f.searches++
+	switch request.Value {
+	case "44":
+		return &entities.OzonAttributeValuesSearchResponse{Result: []entities.OzonAttributeValue{{ID: 970, Value: "44"}}}, nil
+	case "хлопок":
+		return &entities.OzonAttributeValuesSearchResponse{Result: []entities.OzonAttributeValue{{ID: 61, Value: "Хлопок"}}}, nil
+	}
+	return &entities.OzonAttributeValuesSearchResponse{}, nil
+}
+
+func (f *fakeOzonClient) GetCategoryAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonCategoryAttributesRequest) (*entities.OzonCategoryAttributesResponse, error) {
+	return &entities.OzonCategoryAttributesResponse{Result: []entities.OzonCategoryAttribute{
+		{ID: entities.OzonBrandAttributeID, Name: "Бренд", DictionaryID: 28732849},
+		{ID: 4496, Name: "Состав материала", IsCollection: true, DictionaryID: 1196},
+		{ID: 4389, Name: "Страна-изготовитель", DictionaryID: 1935},
+		{ID: 4180, Name: "Комплектация"},
+	}}, nil
+}
+
+func (f *fakeOzonClient) GetProductInfoList(ctx context.Context, clientID, apiKey string, request entities.OzonProductInfoListRequest) (*entities.OzonProductInfoListResponse, error) {
+	f.infoCalls++
+	if f.infoCalls == 1 {
+		// Not created yet
+		return &entities.OzonProductInfoListResponse{}, nil
+	}
+	var items []entities.OzonProductInfo
+	for i, offerID := range request.OfferID {
+		item := entities.OzonProductInfo{ID: int64(100 + i), OfferID: offerID}
+		if len(f.generated) > 0 {
+			item.Barcodes = []string{"OZN" + offerID}
+		}
+		items = append(items, item)
+	}
+	return &entities.OzonProductInfoListResponse{Items: items}, nil
+}
+
+func (f *fakeOzonClient) GetProductAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonProductAttributesRequest) (*entities.OzonProductAttributesResponse, error) {
+	return &entities.OzonProductAttributesResponse{Result: []entities.OzonProductDetails{{
+		OfferID:               request.Filter.OfferID[0],
+		DescriptionCategoryID: 17028922,
+		TypeID:                91565,
+		Attributes: []entities.OzonProductAttribute{
+			{ID: entities.OzonBrandAttributeID, Values: []entities.OzonProductAttributeValue{{Value: "Acme"}}},
+			{ID: 4496, Values: []entities.OzonProductAttributeValue{{DictionaryValueID: 61, Value: "Хлопок"}, {DictionaryValueID: 62, Value: "Лён"}}},
+			{ID: 4180, Values: []entities.OzonProductAttributeValue{{Value: "футболка"}}},
+			{ID: 21841, ComplexID: entities.OzonVideoComplexID, Values: []entities.OzonProductAttributeValue{{Value: "https://example.com/video.mp4"}}},
+		},
+	}}}, nil
+}
+
+func (f *fakeOzonClient) GenerateBarcodes(ctx context.Context, clientID, apiKey string, request entities.OzonBarcodeGenerateRequest) (*entities.OzonBarcodeGenerateResponse, error) {
+	f.generated = request.ProductIDs
+	return &entities.OzonBarcodeGenerateResponse{}, nil
+}
+
+func (f *fakeOzonClient) ImportPrices(ctx context.Context, clientID, apiKey string, request entities.OzonImportPricesRequest) (*entities.OzonImportPricesResponse, error) {
+	return &entities.OzonImportPricesResponse{}, nil
+}
+
+func (f *fakeOzonClient) ListWarehouses(ctx context.Context, clientID, apiKey string) (*entities.OzonWarehouseListResponse, error) {
+	return &entities.OzonWarehouseListResponse{}, nil
+}
+
+func (f *fakeOzonClient) GetProductStocks(ctx context.Context, clientID, apiKey string, request entities.OzonProductStocksRequest) (*entities.OzonProductStocksResponse, error) {
+	var items []entities.OzonProductStocks
+	for i, offerID := range request.Filter.OfferID {
+		if offerID == "UNKNOWN" {
+			continue
+		}
+		items = append(items, entities.OzonProductStocks{OfferID: offerID, Stocks: []entities.OzonProductStock{
+			{Type: "fbo", SKU: int64(500 + i), Present: 100},
+			{Type: "fbs", SKU: int64(900 + i), Present: 20},
+		}})
+	}
+	return &entities.OzonProductStocksResponse{Items: items}, nil
+}
+
+func (f *fakeOzonClient) GetWarehouseStocks(ctx context.Context, clientID, apiKey string, request entities.OzonWarehouseStocksRequest) (*entities.OzonWarehouseStocksResponse, error) {
+	// Every product has stock in warehouse 22 and in another FBS warehouse
+	var result []entities.OzonWarehouseStock
+	for _, sku := range request.SKU {
+		id, _ := strconv.ParseInt(sku, 10, 64)
+		result = append(result,
+			entities.OzonWarehouseStock{SKU: id, Present: 5, Reserved: 1, WarehouseID: 22},
+			entities.OzonWarehouseStock{SKU: id, Present: 15, Reserved: 0, WarehouseID: 23},
+		)
+	}
+	return &entities.OzonWarehouseStocksResponse{Result: result}, nil
+}
+
+func (f *fakeOzonClient) SetStocks(ctx context.Context, clientID, apiKey string, request entities.OzonStocksRequest) (*entities.OzonStocksResponse, error) {
+	f.stocks = request.Stocks
+	return &entities.OzonStocksResponse{}, nil
+}
+
+func TestOzonService_SizeOffers(t *testing.T) {
+	client := &fakeOzonClient{}
+	ozs := NewOzonService(OzonBarcodeOptions{}, client, nil)
+	ozonPrice := int32(3100)
+	card := &entities.ProductCard{
+		VendorCode:      "VC001",
+		OzonApiClientId: "client-id",
+		OzonApiKey:      "api-key",
+		Sizes: []*entities.WBSize{
+			{TechSize: "S", WbSize: "44", Price: 3000, Skus: []string{"2000000000011"}},
+			{TechSize: "M", WbSize: "46", Price: 3000, OzonPrice: &ozonPrice, Skus: []string{"2000000000028"}},
+		},
+	}
+	base := entities.OzonProductImportItem{OfferID: "VC001", Price: "100", DescriptionCategoryID: 17028922, TypeID: 91248}
+
+	sizeValueIDs := make(map[string]int64)
+	offers := ozs.sizeOffers(context.Background(), card, base, sizeValueIDs)
+	if len(offers) != 2 {
+		t.Fatalf("Expected an offer per size, got %d", len(offers))
+	}
+	if offers[0].OfferID != "VC001-S" || offers[0].Price != "3000" || offers[0].Barcode != "2000000000011" {
+		t.Errorf("Unexpected first offer: %+v", offers[0])
+	}
+	if offers[1].OfferID != "VC001-M" || offers[1].Price != "3100" || offers[1].Barcode != "2000000000028" {
+		t.Errorf("Unexpected second offer: %+v", offers[1])
+	}
+	if len(offers[0].Attributes) != 1 || offers[0].Attributes[0].Values[0].DictionaryValueID != 970 {
+		t.Errorf("Expected resolved size attribute, got %+v", offers[0].Attributes)
+	}
+	if len(offers[1].Attributes) != 0 {
+		t.Errorf("Unresolved size must be left out, got %+v", offers[1].Attributes)
+	}
+
+	// Sizes are resolved once per request
+	ozs.sizeOffers(context.Background(), card, base, sizeValueIDs)
+	if client.searches != 2 {
+		t.Errorf("Expected 2 dictionary searches, got %d", client.searches)
+	}
+}
+
+func TestOzonService_AssignBarcodes(t *testing.T) {
+	client := &fakeOzonClient{}
+	ozs := NewOzonService(OzonBarcodeOptions{PollInterval: time.Millisecond, Timeout: time.Second}, client, nil)
+	req := &entities.ProductCard{
+		VendorCode:      "VC001",
+		OzonApiClientId: "client-id",
+		OzonApiKey:      "api-key",
+		Sizes: []*entities.WBSize{
+			{TechSize: "S", Skus: []string{"2000000000011"}},
+			{TechSize: "M"},
+		},
+	}
+
+	products, err := ozs.WaitForOffers(context.Background(), req)
+	if err != nil {
+		t.Fatalf("WaitForOffers returned unexpected error: %v", err)
+	}
+	generated, err := ozs.AssignBarcodes(context.Background(), req, products)
+	if err != nil {
+		t.Fatalf("AssignBarcodes returned unexpected error: %v", err)
+	}
+	if len(client.generated) != 1 || client.generated[0] != "101" {
+		t.Errorf("Expected barcode generation for the size without SKUs only, got %v", client.generated)
+	}
+	if len(generated) != 1 || generated[0].TechSize != "M" || generated[0].Marketplace != entities.MarketplaceOzon ||
+		len(generated[0].Barcodes) != 1 || generated[0].Barcodes[0] != "OZNVC001-M" {
+		t.Errorf("Unexpected generated barcodes: %+v", generated)
+	}
+}
+
+func TestOzonService_SetInitialStock(t *testing.T) {
+	client := &fakeOzonClient{}
+	ozs := NewOzonService(OzonBarcodeOptions{PollInterval: time.Millisecond, Timeout: time.Second}, client, nil)
+	req := &entities.ProductCard{
+		VendorCode:      "VC001",
+		OzonApiClientId: "client-id",
+		OzonApiKey:      "api-key",
+		Sizes:           []*entities.WBSize{{TechSize: "S"}, {TechSize: "M"}},
+		InitialStock:    &entities.InitialStock{OzonWarehouseID: 22, Amount: 5},
+	}
+
+	products, err := ozs.WaitForOffers(context.Background(), req)
+	if err != nil {
+		t.Fatalf("WaitForOffers returned unexpected error: %v", err)
+	}
+	if _, err := ozs.AssignBarcodes(context.Background(), req, products); err != nil {
+		t.Fatalf("AssignBarcodes returned unexpected error: %v", err)
+	}
+	if err := ozs.SetInitialStock(context.Background(), req, products); err != nil {
+		t.Fatalf("SetInitialStock returned unexpected error: %v", err)
+	}
+	// One poll before the offers are created, one after and one for the generated barcodes
+	if client.infoCalls != 3 {
+		t.Errorf("Expected to wait for the offers once, got %d info calls", client.infoCalls)
+	}
+	if len(client.stocks) != 2 || client.stocks[0] != (entities.OzonStock{OfferID: "VC001-S", Stock: 5, WarehouseID: 22}) {
+		t.Errorf("Unexpected stocks: %+v", client.stocks)
+	}
+
+	t.Run("offers not created in time", func(t *testing.T) {
+		err := ozs.SetInitialStock(context.Background(), req, products[:1])
+		if err == nil || !strings.Contains(err.Error(), "1 of 2 offers") {
+			t.Errorf("Expected the missing offer to be reported, got %v", err)
+		}
+	})
+}
+
+func TestOzonService_GetStocks(t *testing.T) {
+	ozs := NewOzonService(OzonBarcodeOptions{}, &fakeOzonClient{}, nil)
+
+	amounts, err := ozs.GetStocks(context.Background(), "client-id", "api-key", 22, []string{"VC001-S", "UNKNOWN"})
+	if err != nil {
+		t.Fatalf("GetStocks returned unexpected error: %v", err)
+	}
+	if len(amounts) != 1 || amounts["VC001-S"] != 4 {
+		t.Errorf("Expected the available stock of warehouse 22 only, got %v", amounts)
+	}
+}
+
+func TestOzonService_ConvertCharacteristics(t *testing.T) {
+	client := &fakeOzonClient{}
+	ozs := NewOzonService(OzonBarcodeOptions{}, client, nil)
+
+	var characteristics []entities.ProductCharacteristic
+	for _, c := range []entities.WBCardCharacteristic{
+		{ID: 1, Name: "Состав материала", Value: []interface{}{"хлопок", "лён"}},
+		{ID: 2, Name: "Страна-изготовитель", Value: []interface{}{"Атлантида"}},
+		{ID: 3, Name: "комплектация ", Value: []interface{}{"футболка"}},
+		{ID: 4, Name: "Бренд", Value: "Acme"},
+		{ID: 5, Name: "Длина изделия", Value: float64(70)},
+	} {
+		characteristics = append(characteristics, entities.ProductCharacteristic{Name: c.Name, Values: c.Values()})
+	}
+	attributes, unmapped, err := ozs.ConvertCharacteristics(context.Background(), "client-id", "api-key", 17028922, 91565, characteristics)
+	if err != nil {
+		t.Fatalf("ConvertCharacteristics returned unexpected error: %v", err)
+	}
+
+	if len(attributes) != 2 {
+		t.Fatalf("Expected 2 attributes, got %+v", attributes)
+	}
+	// Values missing in the dictionary are left out
+	if attributes[0].ID != 4496 || len(attributes[0].Values) != 1 || attributes[0].Values[0].DictionaryValueID != 61 {
+		t.Errorf("Unexpected dictionary attribute: %+v", attributes[0])
+	}
+	if attributes[1].ID != 4180 || len(attributes[1].Values) != 1 || attributes[1].Values[0].Value != "футболка" {
+		t.Errorf("Unexpected text attribute: %+v", attributes[1])
+	}
+
+	// The brand is filled from the card, never from characteristics
+	want := []string{"Страна-изготовитель", "Бренд", "Длина изделия"}
+	if len(unmapped) != len(want) {
+		t.Fatalf("Expected unmapped %v, got %v", want, unmapped)
+	}
+	for i := range want {
+		if unmapped[i] != want[i] {
+			t.Errorf("Expected unmapped %v, got %v", want, unmapped)
+		}
+	}
+}
+
+func TestOzonService_GetProduct(t *testing.T) {
+	client := &fakeOzonClient{infoCalls: 1} // Products are created
+	ozs := NewOzonService(OzonBarcodeOptions{}, client, nil)
+
+	product, err := ozs.GetProduct(context.Background(), "client-id", "api-key", "VC001")
+	if err != nil {
+		t.Fatalf("GetProduct returned unexpected error: %v", err)
+	}
+	if product.Info.OfferID != "VC001" || product.Details.TypeID != 91565 {
+		t.Errorf("Unexpected product: %+v", product)
+	}
+	if brand := product.Details.AttributeValues(entities.OzonBrandAttributeID); len(brand) != 1 || brand[0] != "Acme" {
+		t.Errorf("Expected brand Acme, got %v", brand)
+	}
+
+	// The brand is a card field and videos are complex attributes, neither is a characteristic
+	if len(product.Characteristics) != 2 {
+		t.Fatalf("Expected 2 characteristics, got %+v", product.Characteristics)
+	}
+	if c := product.Characteristics[0]; c.Name != "Состав материала" || len(c.Values) != 2 || c.Values[1] != "Лён" {
+		t.Errorf("Unexpected characteristic: %+v", c)
+	}
+	if c := product.Characteristics[1]; c.Name != "Комплектация" || len(c.Values) != 1 {
+		t.Errorf("Unexpected characteristic: %+v", c)
+	}
+}
+
+func TestOzonService_GetProduct_NotFound(t *testing.T) {
+	ozs := NewOzonService(OzonBarcodeOptions{}, &fakeOzonClient{}, nil) // Not created yet
+
+	if _, err := ozs.GetProduct(context.Background(), "client-id", "api-key", "VC001"); !errors.Is(err, entities.ErrOzonProductNotFound) {
+		t.Errorf("Expected ErrOzonProductNotFound, got %v", err)
+	}
+}
+
+func TestOzonService_CreateCard_ModelName(t *testing.T) {
+	ozs := NewOzonService(OzonBarcodeOptions{}, &fakeOzonClient{}, nil)
+	subID, typeID := int32(17028922), int32(91565)
+	content := &entities.CardCraftAiGeneratedContent{Title: "Футболка хлопковая", SubID: &subID, TypeID: &typeID}
+	dimension, weight := int32(10), int32(200)
+	dryRun := true
+
+	tests := []struct {
+		name string
+		card entities.ProductCard
+		want map[string]string // Model name by offer ID
+	}{
+		{
+			name: "explicit model name",
+			card: entities.ProductCard{VendorCode: "VC001", ModelName: "Базовая футболка"},
+			want: map[string]string{"VC001": "Базовая футболка"},
+		},
+		{
+			name: "vendor code instead of the title",
+			card: entities.ProductCard{VendorCode: "VC001"},
+			want: map[string]string{"VC001": "VC001"},
+		},
+		{
+			name: "variants share the first vendor code",
+			card: entities.ProductCard{Variants: []*entities.ProductVariant{
+				{VendorCode: "VC001-RED", Color: "красный"},
+				{VendorCode: "VC001-BLUE", Color: "синий"},
+			}},
+			want: map[string]string{"VC001-RED": "VC001-RED", "VC001-BLUE": "VC001-RED"},
+		},
+		{
+			name: "variants share the explicit model name",
+			card: entities.ProductCard{ModelName: "Базовая футболка", Variants: []*entities.ProductVariant{
+				{VendorCode: "VC001-RED", Color: "красный"},
+				{VendorCode: "VC001-BLUE", Color: "синий"},
+			}},
+			want: map[string]string{"VC001-RED": "Базовая футболка", "VC001-BLUE": "Базовая футболка"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := tt.card
+			card.ProductTitle = "Футболка"
+			card.Ozon = true
+			card.DryRun = &dryRun
+			card.Dimensions = &entities.WBDimensions{Depth: &dimension, Width: &dimension, Height: &dimension, Weight: &weight}
+
+			_, prepared, _, err := ozs.CreateCard(context.Background(), &card, content)
+			if err != nil || prepared == nil {
+				t.Fatalf("CreateCard: %v", err)
+			}
+			var payload entities.OzonProductImportRequest
+			if err := json.Unmarshal([]byte(*prepared), &payload); err != nil {
+				t.Fatalf("Failed to parse the prepared request: %v", err)
+			}
+			if len(payload.Items) != len(tt.want) {
+				t.Fatalf("Expected %d offers, got %d", len(tt.want), len(payload.Items))
+			}
+			for _, item := range payload.Items {
+				var modelName string
+				for _, attribute := range item.Attributes {
+					if attribute.ID == entities.OzonModelNameAttributeID {
+						modelName = attribute.Values[0].Value
+					}
+				}
+				if modelName != tt.want[item.OfferID] {
+					t.Errorf("Expected model name %q for offer %s, got %q", tt.want[item.OfferID], item.OfferID, modelName)
+				}
+			}
+		})
+	}
+}
+
+func TestOzonService_CreateCard_VariantContent(t *testing.T) {
+	ozs := NewOzonService(OzonBarcodeOptions{}, &fakeOzonClient{}, nil)
+	subID, typeID := int32(17028922), int32(91565)
+	content := &entities.CardCraftAiGeneratedContent{Title: "Футболка хлопковая", Description: "Мягкая футболка из хлопка.", SubID: &subID, TypeID: &typeID}
+	dimension, weight := int32(10), int32(200)
+	dryRun := true
+	card := &entities.ProductCard{
+		Ozon:       true,
+		DryRun:     &dryRun,
+		Dimensions: &entities.WBDimensions{Depth: &dimension, Width: &dimension, Height: &dimension, Weight: &weight},
+		Variants: []*entities.ProductVariant{
+			{VendorCode: "VC001-RED", Color: "красный"},
+			{VendorCode: "VC001-BLUE", Color: "синий"},
+		},
+	}
+
+	_, prepared, _, err := ozs.CreateCard(context.Background(), card, content)
+	if err != nil || prepared == nil {
+		t.Fatalf("CreateCard: %v", err)
+	}
+	var payload entities.OzonProductImportRequest
+	if err := json.Unmarshal([]byte(*prepared), &payload); err != nil {
+		t.Fatalf("Failed to parse the prepared request: %v", err)
+	}
+	colors := map[string]string{"VC001-RED": "красный", "VC001-BLUE": "синий"}
+	for _, item := range payload.Items {
+		color := colors[item.OfferID]
+		if item.Name != "Футболка хлопковая, "+color {
+			t.Errorf("Expected the color in the name of offer %s, got %q", item.OfferID, item.Name)
+		}
+		for _, attribute := range item.Attributes {
+			if attribute.ID == entities.OzonAnnotationAttributeID && attribute.Values[0].Value != "Мягкая футболка из хлопка.\n\nЦвет: "+color+"." {
+				t.Errorf("Expected the color in the description of offer %s, got %q", item.OfferID, attribute.Values[0].Value)
+			}
+		}
+	}
+	if content.Title != "Футболка хлопковая" {
+		t.Errorf("Expected the shared content to be left as is, got title %q", content.Title)
+	}
+}
+
+func TestOzonOfferIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []string
+		want  []string
+	}{
+		{"single size keeps the vendor code", []string{"M"}, []string{"VC001"}},
+		{"tech sizes", []string{"S", "M"}, []string{"VC001-S", "VC001-M"}},
+		{"separators and casing", []string{"s/m", "L XL", "42 - 44"}, []string{"VC001-S-M", "VC001-L-XL", "VC001-42-44"}},
+		{"colliding sizes", []string{"S/M", "S-M", "s m"}, []string{"VC001-S-M", "VC001-S-M-2", "VC001-S-M-3"}},
+		{"sizes without tech size", []string{"", "", "M"}, []string{"VC001", "VC001-2", "VC001-M"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := &entities.ProductCard{VendorCode: "VC001"}
+			for _, techSize := range tt.sizes {
+				card.Sizes = append(card.Sizes, &entities.WBSize{TechSize: techSize})
+			}
+			got := ozonOfferIDs(card)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected offer IDs %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOzonService_SizeOffers_DryRunSkipsDictionary(t *testing.T) {
+	client := &fakeOzonClient{}
+	ozs := NewOzonService(OzonBarcodeOptions{}, client, nil)
+	dryRun := true
+	card := &entities.ProductCard{
+		VendorCode:      "VC001",
+		OzonApiClientId: "client-id",
+		OzonApiKey:      "api-key",
+		DryRun:          &dryRun,
+		Sizes:           []*entities.WBSize{{TechSize: "S", WbSize: "44"}, {TechSize: "M", WbSize: "46"}},
+	}
+
+	offers := ozs.sizeOffers(context.Background(), card, entities.OzonProductImportItem{OfferID: "VC001"}, make(map[string]int64))
+	if len(offers) != 2 {
+		t.Fatalf("Expected an offer per size, got %d", len(offers))
+	}
+	if client.searches != 0 {
+		t.Errorf("Expected no dictionary searches in dry-run mode, got %d", client.searches)
+	}
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"api/metrics"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type stockSyncStorage interface {
+	ListEnabledStockSyncSettings(ctx context.Context) ([]*entities.StockSyncSettings, error)
+	GetStockSyncMappings(ctx context.Context, apiKey string) ([]entities.StockSyncMapping, error)
+	SetStockSyncLastAmounts(ctx context.Context, apiKey string, amounts map[string]int) error
+	SaveStockSyncReport(ctx context.Context, report *entities.StockSyncReport) error
+	DeleteStockSyncReportsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type wbStockSyncer interface {
+	GetStocks(ctx context.Context, apiKey string, warehouseID int64, skus []string) (map[string]int, error)
+	KnownSkus(ctx context.Context, apiKey string, searches []string) (map[string]bool, error)
+	SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error
+}
+
+type ozonStockSyncer interface {
+	GetStocks(ctx context.Context, clientID, apiKey string, warehouseID int64, offerIDs []string) (map[string]int, error)
+	SetStocks(ctx context.Context, clientID, apiKey string, stocks []entities.OzonStock) ([]entities.OzonStockUpdateResult, error)
+}
+
+// StockSyncOptions configures the periodic stock sync.
+type StockSyncOptions struct {
+	Interval        time.Duration // 0 disables the periodic sync
+	AccountTimeout  time.Duration // Deadline of the sync of one account, 0 for none
+	ReportRetention time.Duration // Older reports are deleted after every run, except the latest one of each account; 0 keeps all
+}
+
+// StockSyncService copies stocks from the source marketplace of an account to the other one, so that
+// an item sold from one FBS warehouse on both marketplaces is not oversold.
+type StockSyncService struct {
+	storage stockSyncStorage
+	wb      wbStockSyncer
+	ozon    ozonStockSyncer
+	options StockSyncOptions
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	routines sync.WaitGroup
+}
+
+// NewStockSyncService creates a new StockSyncService instance.
+func NewStockSyncService(options StockSyncOptions, storage stockSyncStorage, wb wbStockSyncer, ozon ozonStockSyncer) *StockSyncService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StockSyncService{storage: storage, wb: wb, ozon: ozon, options: options, ctx: ctx, cancel: cancel}
+}
+
+// StartSyncRoutine periodically syncs the stocks of all enabled accounts until Stop is called.
+// It blocks, so run it in a goroutine.
+func (s *StockSyncService) StartSyncRoutine() {
+	if s.options.Interval <= 0 {
+		return
+	}
+	s.mu.Lock()
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.routines.Add(1)
+	s.mu.Unlock()
+	defer s.routines.Done()
+
+	ticker := time.NewTicker(s.options.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+		s.syncAll()
+	}
+}
+
+// Stop cancels the running sync and waits for the sync routine to exit.
+func (s *StockSyncService) Stop() {
+	// Cancel under mu so that no routine starts once Wait has started
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+	s.routines.Wait()
+}
+
+// syncAll syncs the enabled accounts one after another, each within the account timeout, and prunes old reports.
+func (s *StockSyncService) syncAll() {
+	accounts, err := s.storage.ListEnabledStockSyncSettings(s.ctx)
+	if err != nil {
+		log.Printf("[STOCK SYNC] Failed to list accounts: %v", err)
+		return
+	}
+	for i, settings := range accounts {
+		if s.ctx.Err() != nil {
+			log.Printf("[STOCK SYNC] Stopped, %d of %d accounts were not synced", len(accounts)-i, len(accounts))
+			return
+		}
+		s.syncAccount(settings)
+	}
+
+	if s.options.ReportRetention > 0 {
+		deleted, err := s.storage.DeleteStockSyncReportsBefore(s.ctx, time.Now().Add(-s.options.ReportRetention))
+		if err != nil {
+			log.Printf("[STOCK SYNC] Failed to delete old reports: %v", err)
+		} else if deleted > 0 {
+			log.Printf("[STOCK SYNC] Deleted %d reports older than %v", deleted, s.options.ReportRetention)
+		}
+	}
+}
+
+// syncAccount syncs the account within the account timeout.
+func (s *StockSyncService) syncAccount(settings *entities.StockSyncSettings) {
+	ctx := s.ctx
+	if s.options.AccountTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.options.AccountTimeout)
+		defer cancel()
+	}
+	s.Sync(ctx, settings, settings.DryRun)
+}
+
+// Sync copies the stocks of the mapped items of the account from the source to the target marketplace
+// and stores the report. In dry-run mode the changes are only reported.
+func (s *StockSyncService) Sync(ctx context.Context, settings *entities.StockSyncSettings, dryRun bool) *entities.StockSyncReport {
+	report := &entities.StockSyncReport{ApiKey: settings.ApiKey, Source: settings.Source, DryRun: dryRun, StartedAt: time.Now()}
+	if err := s.sync(ctx, settings, report); err != nil {
+		log.Printf("[STOCK SYNC] Sync from %s failed: %v", settings.Source, err)
+		report.Error = err.Error()
+	}
+	report.FinishedAt = time.Now()
+
+	for _, c := range report.Conflicts {
+		metrics.AppStockSyncConflictsTotal.WithLabelValues(string(c.Kind)).Inc()
+	}
+	log.Printf("[STOCK SYNC] Synced from %s (dry run: %t): %d changes, %d conflicts", settings.Source, dryRun, len(report.Changes), len(report.Conflicts))
+	// The report of a sync that ran out of time is saved as well
+	if err := s.storage.SaveStockSyncReport(context.WithoutCancel(ctx), report); err != nil {
+		log.Printf("[STOCK SYNC] Failed to save report: %v", err)
+	}
+	return report
+}
+
+func (s *StockSyncService) sync(ctx context.Context, settings *entities.StockSyncSettings, report *entities.StockSyncReport) error {
+	mappings, err := s.storage.GetStockSyncMappings(ctx, settings.ApiKey)
+	if err != nil {
+		return fmt.Errorf("failed to get mappings: %w", err)
+	}
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	wbSkus := make([]string, len(mappings))
+	offerIDs := make([]string, len(mappings))
+	for i, m := range mappings {
+		wbSkus[i] = m.WbSku
+		offerIDs[i] = m.OzonOfferID
+	}
+	wbStocks, err := s.wb.GetStocks(ctx, settings.WbApiKey, settings.WbWarehouseID, wbSkus)
+	if err != nil {
+		return err
+	}
+	knownSkus, err := s.knownWBSkus(ctx, settings, mappings, wbStocks)
+	if err != nil {
+		return err
+	}
+	ozonStocks, err := s.ozon.GetStocks(ctx, settings.OzonApiClientId, settings.OzonApiKey, settings.OzonWarehouseID, offerIDs)
+	if err != nil {
+		return err
+	}
+
+	synced := make(map[string]int) // Amounts on the target after the sync by WB sku
+	var changes []entities.StockSyncChange
+	for _, m := range mappings {
+		// WB does not list sizes without stock, a mapped size of a seller card missing there has none
+		wbAmount, onWB := wbStocks[m.WbSku]
+		onWB = onWB || knownSkus[m.WbSku]
+		ozonAmount, onOzon := ozonStocks[m.OzonOfferID]
+		source, target := wbAmount, ozonAmount
+		onSource, onTarget := onWB, onOzon
+		if settings.Source == entities.MarketplaceOzon {
+			source, target = ozonAmount, wbAmount
+			onSource, onTarget = onOzon, onWB
+		}
+
+		conflict := entities.StockSyncConflict{WbSku: m.WbSku, OzonOfferID: m.OzonOfferID, SourceAmount: source, TargetAmount: target}
+		switch {
+		case !onSource:
+			conflict.Kind = entities.StockSyncMissingInSource
+			report.Conflicts = append(report.Conflicts, conflict)
+			continue
+		case !onTarget:
+			conflict.Kind = entities.StockSyncMissingInTarget
+			report.Conflicts = append(report.Conflicts, conflict)
+			continue
+		case m.LastAmount != nil && target != *m.LastAmount && target != source:
+			// Someone changed the target since the last sync; the source is the truth, but the seller should know
+			conflict.Kind = entities.StockSyncTargetChanged
+			conflict.Message = fmt.Sprintf("changed from %d since the last sync", *m.LastAmount)
+			report.Conflicts = append(report.Conflicts, conflict)
+		}
+
+		if target == source {
+			synced[m.WbSku] = source
+			continue
+		}
+		changes = append(changes, entities.StockSyncChange{WbSku: m.WbSku, OzonOfferID: m.OzonOfferID, From: target, To: source})
+	}
+	report.Changes = changes
+	if report.DryRun {
+		return nil
+	}
+
+	if len(changes) > 0 {
+		for wbSku, amount := range s.write(ctx, settings, changes, report) {
+			synced[wbSku] = amount
+		}
+	}
+	if err := s.storage.SetStockSyncLastAmounts(ctx, settings.ApiKey, synced); err != nil {
+		return fmt.Errorf("failed to save synced amounts: %w", err)
+	}
+	return nil
+}
+
+// knownWBSkus looks up the mapped WB sizes without stock in the warehouse in the seller cards, by vendor code
+// where it is known and by barcode otherwise, and returns the barcodes found.
+func (s *StockSyncService) knownWBSkus(ctx context.Context, settings *entities.StockSyncSettings, mappings []entities.StockSyncMapping, wbStocks map[string]int) (map[string]bool, error) {
+	var searches []string
+	seen := make(map[string]bool)
+	for _, m := range mappings {
+		if _, ok := wbStocks[m.WbSku]; ok {
+			continue
+		}
+		search := m.VendorCode
+		if search == "" {
+			search = m.WbSku
+		}
+		if !seen[search] {
+			seen[search] = true
+			searches = append(searches, search)
+		}
+	}
+	if len(searches) == 0 {
+		return nil, nil
+	}
+	return s.wb.KnownSkus(ctx, settings.WbApiKey, searches)
+}
+
+// write sets the changed stocks on the target marketplace and returns the amounts written by WB sku.
+// Rejected changes are reported as conflicts.
+func (s *StockSyncService) write(ctx context.Context, settings *entities.StockSyncSettings, changes []entities.StockSyncChange, report *entities.StockSyncReport) map[string]int {
+	written := make(map[string]int, len(changes))
+	failed := func(c entities.StockSyncChange, message string) {
+		report.Conflicts = append(report.Conflicts, entities.StockSyncConflict{
+			WbSku: c.WbSku, OzonOfferID: c.OzonOfferID, Kind: entities.StockSyncUpdateFailed,
+			SourceAmount: c.To, TargetAmount: c.From, Message: message,
+		})
+	}
+
+	if settings.Target() == entities.MarketplaceWB {
+		stocks := make([]entities.WBStock, len(changes))
+		for i, c := range changes {
+			stocks[i] = entities.WBStock{Sku: c.WbSku, Amount: c.To}
+		}
+		// WB accepts or rejects the stocks of a warehouse as a whole
+		if err := s.wb.SetStocks(ctx, settings.WbApiKey, settings.WbWarehouseID, stocks); err != nil {
+			for _, c := range changes {
+				failed(c, err.Error())
+			}
+			return written
+		}
+		for _, c := range changes {
+			written[c.WbSku] = c.To
+		}
+		metrics.AppStockSyncChangesTotal.WithLabelValues(string(entities.MarketplaceWB)).Add(float64(len(changes)))
+		return written
+	}
+
+	stocks := make([]entities.OzonStock, len(changes))
+	for i, c := range changes {
+		stocks[i] = entities.OzonStock{OfferID: c.OzonOfferID, Stock: c.To, WarehouseID: settings.OzonWarehouseID}
+	}
+	results, err := s.ozon.SetStocks(ctx, settings.OzonApiClientId, settings.OzonApiKey, stocks)
+	if err != nil {
+		for _, c := range changes {
+			failed(c, err.Error())
+		}
+		return written
+	}
+	updated := make(map[string]bool, len(results))
+	messages := make(map[string]string)
+	for _, r := range results {
+		updated[r.OfferID] = r.Updated
+		if len(r.Errors) > 0 {
+			messages[r.OfferID] = r.Errors[0].Code + ": " + r.Errors[0].Message
+		}
+	}
+	for _, c := range changes {
+		if !updated[c.OzonOfferID] {
+			failed(c, messages[c.OzonOfferID])
+			continue
+		}
+		written[c.WbSku] = c.To
+	}
+	metrics.AppStockSyncChangesTotal.WithLabelValues(string(entities.MarketplaceOzon)).Add(float64(len(written)))
+	return written
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeStockSyncStorage struct {
+	accounts    []*entities.StockSyncSettings
+	mappings    []entities.StockSyncMapping
+	lastAmounts map[string]int
+	report      *entities.StockSyncReport
+	reports     chan *entities.StockSyncReport
+	pruned      chan time.Time
+}
+
+func (f *fakeStockSyncStorage) ListEnabledStockSyncSettings(ctx context.Context) ([]*entities.StockSyncSettings, error) {
+	return f.accounts, nil
+}
+
+func (f *fakeStockSyncStorage) DeleteStockSyncReportsBefore(ctx context.Context, before time.Time) (int64, error) {
+	select {
+	case f.pruned <- before:
+	default:
+	}
+	return 1, nil
+}
+
+func (f *fakeStockSyncStorage) GetStockSyncMappings(ctx context.Context, apiKey string) ([]entities.StockSyncMapping, error) {
+	return f.mappings, nil
+}
+
+func (f *fakeStockSyncStorage) SetStockSyncLastAmounts(ctx context.Context, apiKey string, amounts map[string]int) error {
+	f.lastAmounts = amounts
+	return nil
+}
+
+func (f *fakeStockSyncStorage) SaveStockSyncReport(ctx context.Context, report *entities.StockSyncReport) error {
+	if f.reports != nil {
+		select {
+		case f.reports <- report:
+		default:
+		}
+		return nil
+	}
+	f.report = report
+	return nil
+}
+
+type fakeStockSyncer struct {
+	amounts map[string]int
+	written []entities.OzonStock
+}
+
+func (f *fakeStockSyncer) GetStocks(ctx context.Context, clientID, apiKey string, warehouseID int64, offerIDs []string) (map[string]int, error) {
+	return f.amounts, nil
+}
+
+func (f *fakeStockSyncer) SetStocks(ctx context.Context, clientID, apiKey string, stocks []entities.OzonStock) ([]entities.OzonStockUpdateResult, error) {
+	f.written = stocks
+	results := make([]entities.OzonStockUpdateResult, len(stocks))
+	for i, s := range stocks {
+		results[i] = entities.OzonStockUpdateResult{OfferID: s.OfferID, Updated: true}
+	}
+	return results, nil
+}
+
+type fakeWBStockSyncer struct {
+	amounts  map[string]int
+	known    map[string]bool // Sizes of the seller cards by barcode
+	searches []string
+	block    bool // GetStocks waits for the context to be done
+}
+
+func (f *fakeWBStockSyncer) GetStocks(ctx context.Context, apiKey string, warehouseID int64, skus []string) (map[string]int, error) {
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.amounts, nil
+}
+
+func (f *fakeWBStockSyncer) KnownSkus(ctx context.Context, apiKey string, searches []string) (map[string]bool, error) {
+	f.searches = searches
+	return f.known, nil
+}
+
+func (f *fakeWBStockSyncer) SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error {
+	return nil
+}
+
+func TestStockSyncService_Sync(t *testing.T) {
+	lastAmount := 4
+	newFixture := func() (*fakeStockSyncStorage, *fakeStockSyncer, *StockSyncService) {
+		storage := &fakeStockSyncStorage{mappings: []entities.StockSyncMapping{
+			{WbSku: "111", OzonOfferID: "VC-S"},                          // Changed on WB
+			{WbSku: "222", OzonOfferID: "VC-M", LastAmount: &lastAmount}, // Sold on Ozon since the last sync
+			{WbSku: "333", OzonOfferID: "VC-L"},                          // Not on Ozon
+			{WbSku: "444", OzonOfferID: "VC-XL", VendorCode: "VC"},       // In sync, no WB stock
+			{WbSku: "555", OzonOfferID: "VC-XXL"},                        // Not on WB
+		}}
+		wb := &fakeWBStockSyncer{amounts: map[string]int{"111": 10, "222": 4, "333": 1}, known: map[string]bool{"444": true}}
+		ozon := &fakeStockSyncer{amounts: map[string]int{"VC-S": 7, "VC-M": 3, "VC-XL": 0, "VC-XXL": 2}}
+		return storage, ozon, NewStockSyncService(StockSyncOptions{}, storage, wb, ozon)
+	}
+	settings := &entities.StockSyncSettings{ApiKey: "key", Source: entities.MarketplaceWB, OzonWarehouseID: 22}
+
+	t.Run("Writes the source stocks", func(t *testing.T) {
+		storage, ozon, s := newFixture()
+		report := s.Sync(context.Background(), settings, false)
+
+		if report.Error != "" || storage.report != report {
+			t.Fatalf("Expected a stored report without error, got %+v", report)
+		}
+		if len(report.Changes) != 2 || report.Changes[0] != (entities.StockSyncChange{WbSku: "111", OzonOfferID: "VC-S", From: 7, To: 10}) {
+			t.Errorf("Unexpected changes: %+v", report.Changes)
+		}
+		if len(ozon.written) != 2 || ozon.written[1] != (entities.OzonStock{OfferID: "VC-M", Stock: 4, WarehouseID: 22}) {
+			t.Errorf("Unexpected stocks written: %+v", ozon.written)
+		}
+		kinds := map[entities.StockSyncConflictKind]string{}
+		for _, c := range report.Conflicts {
+			kinds[c.Kind] = c.WbSku
+		}
+		if len(report.Conflicts) != 3 || kinds[entities.StockSyncTargetChanged] != "222" || kinds[entities.StockSyncMissingInTarget] != "333" ||
+			kinds[entities.StockSyncMissingInSource] != "555" {
+			t.Errorf("Unexpected conflicts: %+v", report.Conflicts)
+		}
+		if len(storage.lastAmounts) != 3 || storage.lastAmounts["111"] != 10 || storage.lastAmounts["444"] != 0 {
+			t.Errorf("Unexpected synced amounts: %v", storage.lastAmounts)
+		}
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		storage, ozon, s := newFixture()
+		report := s.Sync(context.Background(), settings, true)
+
+		if !report.DryRun || len(report.Changes) != 2 {
+			t.Errorf("Expected the changes to be reported, got %+v", report)
+		}
+		if ozon.written != nil || storage.lastAmounts != nil {
+			t.Errorf("Expected nothing to be written in dry-run mode, got %+v and %v", ozon.written, storage.lastAmounts)
+		}
+	})
+}
+
+func TestStockSyncService_SyncRoutine(t *testing.T) {
+	storage := &fakeStockSyncStorage{
+		accounts: []*entities.StockSyncSettings{{ApiKey: "key", Source: entities.MarketplaceWB}},
+		mappings: []entities.StockSyncMapping{{WbSku: "111", OzonOfferID: "VC-S"}},
+		reports:  make(chan *entities.StockSyncReport, 100),
+		pruned:   make(chan time.Time, 1),
+	}
+	wb := &fakeWBStockSyncer{block: true}
+	s := NewStockSyncService(StockSyncOptions{Interval: time.Millisecond, AccountTimeout: 5 * time.Millisecond, ReportRetention: time.Hour},
+		storage, wb, &fakeStockSyncer{})
+
+	done := make(chan struct{})
+	go func() {
+		s.StartSyncRoutine()
+		close(done)
+	}()
+
+	select {
+	case report := <-storage.reports:
+		if !strings.Contains(report.Error, "deadline exceeded") {
+			t.Errorf("Expected the timed out sync to be reported, got %+v", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the account to be synced")
+	}
+	select {
+	case before := <-storage.pruned:
+		if time.Since(before) < time.Hour {
+			t.Errorf("Expected reports older than the retention to be pruned, got %v", before)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the account to time out and the reports to be pruned")
+	}
+	s.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to end the sync routine")
+	}
+}
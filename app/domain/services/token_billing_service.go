@@ -16,12 +16,40 @@ type balanceStorage interface {
 }
 
 type TokenBillingService struct {
-	counterClient tokenCounterClient
-	storage       balanceStorage
+	counterClient          tokenCounterClient
+	storage                balanceStorage
+	cacheHitBillingPercent int
 }
 
-func NewTokenBillingService(counterClient tokenCounterClient, storage balanceStorage) *TokenBillingService {
-	return &TokenBillingService{counterClient: counterClient, storage: storage}
+// NewTokenBillingService creates the billing service. Content served from the cache is billed
+// at cacheHitBillingPercent of its original token cost (0 makes cache hits free).
+func NewTokenBillingService(counterClient tokenCounterClient, storage balanceStorage, cacheHitBillingPercent int) *TokenBillingService {
+	return &TokenBillingService{counterClient: counterClient, storage: storage, cacheHitBillingPercent: cacheHitBillingPercent}
+}
+
+// ChargeForContent bills the tokens spent on the generated content and returns the charged amount.
+// Cache hits are billed at the reduced rate. Token usage fetched from the counter is stored in the content.
+func (s *TokenBillingService) ChargeForContent(ctx context.Context, apiKey string, content *entities.CardCraftAiGeneratedContent) (int, error) {
+	if apiKey == "" || content == nil {
+		return 0, nil
+	}
+	if content.FromCache {
+		if s.cacheHitBillingPercent <= 0 || content.TokenUsage == nil {
+			return 0, nil
+		}
+		return s.chargeCost(ctx, apiKey, *content.TokenUsage, s.cacheHitBillingPercent)
+	}
+	if content.TokenUsage == nil {
+		if content.SessionID == "" {
+			return 0, nil
+		}
+		data, err := s.counterClient.GetSessionData(ctx, content.SessionID)
+		if err != nil {
+			return 0, err
+		}
+		content.TokenUsage = data
+	}
+	return s.chargeCost(ctx, apiKey, *content.TokenUsage, 100)
 }
 
 func (s *TokenBillingService) UpdateBalanceForSession(ctx context.Context, apiKey, sessionID string) error {
@@ -32,18 +60,27 @@ func (s *TokenBillingService) UpdateBalanceForSession(ctx context.Context, apiKe
 	if err != nil {
 		return err
 	}
+	_, err = s.chargeCost(ctx, apiKey, *data, 100)
+	return err
+}
+
+// chargeCost deducts percent of the cost of the given token usage from the balance and returns the deducted amount.
+func (s *TokenBillingService) chargeCost(ctx context.Context, apiKey string, data entities.SessionData, percent int) (int, error) {
 	inputCost, err := s.storage.GetTokenCost(ctx, "input")
 	if err != nil {
-		return err
+		return 0, err
 	}
 	outputCost, err := s.storage.GetTokenCost(ctx, "output")
 	if err != nil {
-		return err
+		return 0, err
 	}
-	totalCost := data.TotalPromptTokens*inputCost + data.TotalCompletionTokens*outputCost
+	totalCost := (data.TotalPromptTokens*inputCost + data.TotalCompletionTokens*outputCost) * percent / 100
 	balance, err := s.storage.GetBalance(ctx, apiKey)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if err := s.storage.SetBalance(ctx, apiKey, balance-totalCost); err != nil {
+		return 0, err
 	}
-	return s.storage.SetBalance(ctx, apiKey, balance-totalCost)
+	return totalCost, nil
 }
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"testing"
+)
+
+type fakeBalanceStorage struct {
+	balance int
+}
+
+func (f *fakeBalanceStorage) GetBalance(ctx context.Context, apiKey string) (int, error) {
+	return f.balance, nil
+}
+
+func (f *fakeBalanceStorage) SetBalance(ctx context.Context, apiKey string, balance int) error {
+	f.balance = balance
+	return nil
+}
+
+func (f *fakeBalanceStorage) GetTokenCost(ctx context.Context, tokenType string) (int, error) {
+	if tokenType == "input" {
+		return 1, nil
+	}
+	return 2, nil
+}
+
+func TestTokenBillingService_ChargeForContent(t *testing.T) {
+	usage := &entities.SessionData{TotalPromptTokens: 100, TotalCompletionTokens: 50}
+	tests := []struct {
+		name           string
+		percent        int
+		content        *entities.CardCraftAiGeneratedContent
+		wantCharged    int
+		wantCounterHit bool
+	}{
+		{"generated with known usage", 10, &entities.CardCraftAiGeneratedContent{TokenUsage: usage}, 200, false},
+		{"generated with session", 10, &entities.CardCraftAiGeneratedContent{SessionID: "session"}, 200, true},
+		{"cache hit at reduced rate", 10, &entities.CardCraftAiGeneratedContent{FromCache: true, TokenUsage: usage}, 20, false},
+		{"free cache hit", 0, &entities.CardCraftAiGeneratedContent{FromCache: true, TokenUsage: usage}, 0, false},
+		{"cache hit without usage", 10, &entities.CardCraftAiGeneratedContent{FromCache: true}, 0, false},
+		{"no session", 10, &entities.CardCraftAiGeneratedContent{}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &fakeBalanceStorage{balance: 1000}
+			counter := &fakeTokenCounter{}
+			s := NewTokenBillingService(counter, storage, tt.percent)
+
+			charged, err := s.ChargeForContent(context.Background(), "key", tt.content)
+			if err != nil {
+				t.Fatalf("ChargeForContent: %v", err)
+			}
+			if charged != tt.wantCharged || storage.balance != 1000-tt.wantCharged {
+				t.Errorf("charged %d, balance %d, want %d charged", charged, storage.balance, tt.wantCharged)
+			}
+			if (counter.calls > 0) != tt.wantCounterHit {
+				t.Errorf("token counter called %d times", counter.calls)
+			}
+			if tt.wantCounterHit && tt.content.TokenUsage == nil {
+				t.Error("token usage from the counter was not stored in the content")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+type wbDirectoryCatalog interface {
+	SearchWBDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error)
+	FindWBDirectoryValue(ctx context.Context, directory entities.WBDirectory, subjectID int, value string) (*entities.WBDirectoryValue, error)
+}
+
+// WbAttributeValidationService checks the colors, countries, seasons and TNVED codes of generated attributes
+// against the mirrored WB directories, see entities.WBDirectoryAttributes.
+type WbAttributeValidationService struct {
+	catalog wbDirectoryCatalog
+}
+
+func NewWbAttributeValidationService(catalog wbDirectoryCatalog) *WbAttributeValidationService {
+	return &WbAttributeValidationService{catalog: catalog}
+}
+
+// FixAttributes returns a copy of the content whose directory attributes take the spelling of the mirrored WB
+// directory, dropping the values the directory does not have, along with a fixed violation per dropped value.
+// An attribute is dropped when none of its comma-separated values is known. Directories that are not mirrored
+// yet, TNVED codes of content without a WB subject and values that cannot be looked up are kept unchecked.
+func (s *WbAttributeValidationService) FixAttributes(ctx context.Context, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation) {
+	fixed := *content
+	if len(content.Attributes) == 0 {
+		return &fixed, nil
+	}
+	fixed.Attributes = make(map[string]string, len(content.Attributes))
+	names := make([]string, 0, len(content.Attributes))
+	for name, value := range content.Attributes {
+		fixed.Attributes[name] = value
+		names = append(names, name)
+	}
+	sort.Strings(names) // Stable order of the violations
+
+	var violations []entities.ContentViolation
+	mirrored := make(map[entities.WBDirectoryFilter]bool)
+	for _, name := range names {
+		directory, ok := entities.WBDirectoryAttributes[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			continue
+		}
+		filter := entities.WBDirectoryFilter{Directory: directory}
+		if directory == entities.WBDirectoryTnved {
+			if content.SubjectID == nil {
+				continue
+			}
+			filter.SubjectID = int(*content.SubjectID)
+		}
+		if _, checked := mirrored[filter]; !checked {
+			values, err := s.catalog.SearchWBDirectoryValues(ctx, entities.WBDirectoryFilter{Directory: directory, SubjectID: filter.SubjectID, Limit: 1})
+			if err != nil {
+				log.Printf("[WB CATALOG] Failed to check the mirror of directory %s, keeping attribute %s: %v", directory, name, err)
+				continue
+			}
+			mirrored[filter] = len(values) > 0
+		}
+		if !mirrored[filter] {
+			continue
+		}
+
+		var kept []string
+		for _, value := range strings.Split(content.Attributes[name], ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			known, err := s.match(ctx, filter, value)
+			if err != nil {
+				log.Printf("[WB CATALOG] Failed to look up %q in directory %s, keeping it: %v", value, directory, err)
+				kept = append(kept, value)
+				continue
+			}
+			if known == "" {
+				violations = append(violations, entities.ContentViolation{
+					Marketplace: entities.MarketplaceWB,
+					Field:       "attributes." + name,
+					Rule:        entities.ContentRuleUnknownValue,
+					Message:     fmt.Sprintf("%q is not in the WB %s directory", value, directory),
+					Fixed:       true,
+				})
+				continue
+			}
+			kept = append(kept, known)
+		}
+		if len(kept) == 0 {
+			delete(fixed.Attributes, name)
+			continue
+		}
+		fixed.Attributes[name] = strings.Join(kept, ", ")
+	}
+	return &fixed, violations
+}
+
+// match returns the mirrored value of the directory equal to value ignoring case, empty if there is none.
+func (s *WbAttributeValidationService) match(ctx context.Context, filter entities.WBDirectoryFilter, value string) (string, error) {
+	known, err := s.catalog.FindWBDirectoryValue(ctx, filter.Directory, filter.SubjectID, value)
+	if err != nil || known == nil {
+		return "", err
+	}
+	return known.Value, nil
+}
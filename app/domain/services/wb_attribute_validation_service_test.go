@@ -0,0 +1,100 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fakeWBDirectoryCatalog struct {
+	values []entities.WBDirectoryValue
+}
+
+func (f *fakeWBDirectoryCatalog) SearchWBDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error) {
+	var result []entities.WBDirectoryValue
+	for _, v := range f.values {
+		if v.Directory == filter.Directory && v.SubjectID == filter.SubjectID &&
+			strings.Contains(strings.ToLower(v.Value), strings.ToLower(filter.Query)) && len(result) < filter.Limit {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeWBDirectoryCatalog) FindWBDirectoryValue(ctx context.Context, directory entities.WBDirectory, subjectID int, value string) (*entities.WBDirectoryValue, error) {
+	for _, v := range f.values {
+		if v.Directory == directory && v.SubjectID == subjectID && strings.EqualFold(v.Value, value) {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestWbAttributeValidationService_FixAttributes(t *testing.T) {
+	s := NewWbAttributeValidationService(&fakeWBDirectoryCatalog{values: []entities.WBDirectoryValue{
+		{Directory: entities.WBDirectoryColors, Value: "красный"},
+		{Directory: entities.WBDirectoryColors, Value: "темно-красный"},
+		{Directory: entities.WBDirectoryCountries, Value: "Россия"},
+		{Directory: entities.WBDirectoryTnved, Value: "6109100000", SubjectID: 192},
+	}})
+	subjectID := int32(192)
+	content := &entities.CardCraftAiGeneratedContent{
+		SubjectID: &subjectID,
+		Attributes: map[string]string{
+			"Цвет":                "Красный, алый",
+			"Страна производства": "Атлантида",
+			"Сезон":               "лето", // Not mirrored
+			"ТНВЭД":               "6109100000",
+			"Материал":            "хлопок",
+		},
+	}
+
+	fixed, violations := s.FixAttributes(context.Background(), content)
+
+	want := map[string]string{"Цвет": "красный", "Сезон": "лето", "ТНВЭД": "6109100000", "Материал": "хлопок"}
+	if len(fixed.Attributes) != len(want) {
+		t.Errorf("Expected attributes %v, got %v", want, fixed.Attributes)
+	}
+	for name, value := range want {
+		if fixed.Attributes[name] != value {
+			t.Errorf("Expected %s %q, got %q", name, value, fixed.Attributes[name])
+		}
+	}
+	if content.Attributes["Цвет"] != "Красный, алый" {
+		t.Error("The original content was modified")
+	}
+	if len(violations) != 2 || violations[0].Field != "attributes.Страна производства" || violations[1].Field != "attributes.Цвет" {
+		t.Fatalf("Expected violations of the country and the color, got %+v", violations)
+	}
+	for _, v := range violations {
+		if v.Rule != entities.ContentRuleUnknownValue || !v.Fixed || v.Marketplace != entities.MarketplaceWB {
+			t.Errorf("Unexpected violation %+v", v)
+		}
+	}
+
+	t.Run("TNVED without subject", func(t *testing.T) {
+		fixed, violations := s.FixAttributes(context.Background(), &entities.CardCraftAiGeneratedContent{
+			Attributes: map[string]string{"ТНВЭД": "0000000000"},
+		})
+		if fixed.Attributes["ТНВЭД"] != "0000000000" || len(violations) != 0 {
+			t.Errorf("Expected the TNVED code to stay unchecked, got %v and %+v", fixed.Attributes, violations)
+		}
+	})
+
+	t.Run("Exact value among many similar ones", func(t *testing.T) {
+		catalog := &fakeWBDirectoryCatalog{}
+		for i := 0; i < 150; i++ {
+			catalog.values = append(catalog.values, entities.WBDirectoryValue{Directory: entities.WBDirectoryColors, Value: fmt.Sprintf("красный %03d", i)})
+		}
+		catalog.values = append(catalog.values, entities.WBDirectoryValue{Directory: entities.WBDirectoryColors, Value: "красный"})
+
+		fixed, violations := NewWbAttributeValidationService(catalog).FixAttributes(context.Background(), &entities.CardCraftAiGeneratedContent{
+			Attributes: map[string]string{"Цвет": "КРАСНЫЙ"},
+		})
+		if fixed.Attributes["Цвет"] != "красный" || len(violations) != 0 {
+			t.Errorf("Expected the color to be found, got %v and %+v", fixed.Attributes, violations)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type wbCatalogClient interface {
+	GetParentCategories(ctx context.Context, apiKey, locale string) ([]entities.WBParentCategory, error)
+	GetSubjects(ctx context.Context, apiKey, locale string, limit, offset int) ([]entities.WBSubject, error)
+	GetColors(ctx context.Context, apiKey, locale string) ([]entities.WBColor, error)
+	GetKinds(ctx context.Context, apiKey, locale string) ([]string, error)
+	GetCountries(ctx context.Context, apiKey, locale string) ([]entities.WBCountry, error)
+	GetSeasons(ctx context.Context, apiKey, locale string) ([]string, error)
+	GetVatRates(ctx context.Context, apiKey, locale string) ([]string, error)
+	GetTnved(ctx context.Context, apiKey, locale string, subjectID int) ([]entities.WBTnved, error)
+}
+
+type wbCatalogStorage interface {
+	ReplaceWBParentCategories(ctx context.Context, categories []entities.WBParentCategory) error
+	ReplaceWBSubjects(ctx context.Context, subjects []entities.WBSubject) error
+	ReplaceWBDirectory(ctx context.Context, directory entities.WBDirectory, values []entities.WBDirectoryValue) error
+	ListWBSubjectsForTnvedSync(ctx context.Context, limit int) ([]int, error)
+	ReplaceWBTnved(ctx context.Context, subjectID int, codes []entities.WBTnved) error
+}
+
+// wbSubjectsPage is the number of subjects requested at once, the maximum of WB
+const wbSubjectsPage = 1000
+
+// WbCatalogSyncOptions configures the mirror of the WB category tree and directories.
+type WbCatalogSyncOptions struct {
+	ApiKey              string // WB API key of the service used to read the catalog, empty disables the sync
+	Locale              string // Language of the names: ru, en or zh
+	TnvedSubjectsPerRun int    // TNVED codes are requested per subject, the least recently synced subjects first
+}
+
+// WbCatalogSyncService mirrors the WB parent categories, subjects and characteristic directories into the
+// storage, so they can be searched without calling WB.
+type WbCatalogSyncService struct {
+	client  wbCatalogClient
+	storage wbCatalogStorage
+	options WbCatalogSyncOptions
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	routines sync.WaitGroup
+}
+
+// NewWbCatalogSyncService creates a new WbCatalogSyncService instance.
+func NewWbCatalogSyncService(options WbCatalogSyncOptions, client wbCatalogClient, storage wbCatalogStorage) *WbCatalogSyncService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WbCatalogSyncService{client: client, storage: storage, options: options, ctx: ctx, cancel: cancel}
+}
+
+// StartSyncRoutine syncs the catalog at once and then periodically until Stop is called.
+// It blocks, so run it in a goroutine.
+func (s *WbCatalogSyncService) StartSyncRoutine(interval time.Duration) {
+	if interval <= 0 || s.options.ApiKey == "" {
+		return
+	}
+	s.mu.Lock()
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.routines.Add(1)
+	s.mu.Unlock()
+	defer s.routines.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(s.ctx); err != nil {
+			log.Printf("[WB CATALOG] Sync failed: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop cancels the running sync and waits for the sync routine to exit.
+func (s *WbCatalogSyncService) Stop() {
+	// Cancel under mu so that no routine starts once Wait has started
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+	s.routines.Wait()
+}
+
+// Sync mirrors the category tree, the directories and the TNVED codes of the next subjects. The parts are
+// synced independently, so a failed category tree still lets the directories and the TNVED codes of the mirrored
+// subjects sync; their errors are joined. A directory that fails or comes back empty keeps its previous values.
+func (s *WbCatalogSyncService) Sync(ctx context.Context) error {
+	started := time.Now()
+	var errs []error
+	if err := s.syncCategories(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	directories := []struct {
+		directory entities.WBDirectory
+		get       func() ([]entities.WBDirectoryValue, error)
+	}{
+		{entities.WBDirectoryColors, func() ([]entities.WBDirectoryValue, error) {
+			colors, err := s.client.GetColors(ctx, s.options.ApiKey, s.options.Locale)
+			values := make([]entities.WBDirectoryValue, len(colors))
+			for i, c := range colors {
+				values[i] = entities.WBDirectoryValue{Value: c.Name, Description: c.ParentName}
+			}
+			return values, err
+		}},
+		{entities.WBDirectoryKinds, s.stringDirectory(ctx, s.client.GetKinds)},
+		{entities.WBDirectoryCountries, func() ([]entities.WBDirectoryValue, error) {
+			countries, err := s.client.GetCountries(ctx, s.options.ApiKey, s.options.Locale)
+			values := make([]entities.WBDirectoryValue, len(countries))
+			for i, c := range countries {
+				values[i] = entities.WBDirectoryValue{Value: c.Name, Description: c.FullName}
+			}
+			return values, err
+		}},
+		{entities.WBDirectorySeasons, s.stringDirectory(ctx, s.client.GetSeasons)},
+		{entities.WBDirectoryVat, s.stringDirectory(ctx, s.client.GetVatRates)},
+	}
+	for _, d := range directories {
+		values, err := d.get()
+		if err != nil {
+			log.Printf("[WB CATALOG] Failed to get directory %s: %v", d.directory, err)
+			continue
+		}
+		if len(values) == 0 {
+			log.Printf("[WB CATALOG] Directory %s is empty, keeping the previous values", d.directory)
+			continue
+		}
+		if err := s.storage.ReplaceWBDirectory(ctx, d.directory, values); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save directory %s: %w", d.directory, err))
+		}
+	}
+
+	tnvedSubjects, err := s.syncTnved(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	log.Printf("[WB CATALOG] Synced in %s with %d errors, TNVED codes of %d subjects", time.Since(started).Round(time.Second), len(errs), tnvedSubjects)
+	return errors.Join(errs...)
+}
+
+func (s *WbCatalogSyncService) stringDirectory(ctx context.Context, get func(ctx context.Context, apiKey, locale string) ([]string, error)) func() ([]entities.WBDirectoryValue, error) {
+	return func() ([]entities.WBDirectoryValue, error) {
+		names, err := get(ctx, s.options.ApiKey, s.options.Locale)
+		values := make([]entities.WBDirectoryValue, len(names))
+		for i, value := range names {
+			values[i] = entities.WBDirectoryValue{Value: value}
+		}
+		return values, err
+	}
+}
+
+// syncCategories mirrors the parent categories and all subjects. Subjects are only replaced when every page
+// was read, so a failed sync never drops subjects.
+func (s *WbCatalogSyncService) syncCategories(ctx context.Context) error {
+	parents, err := s.client.GetParentCategories(ctx, s.options.ApiKey, s.options.Locale)
+	if err != nil {
+		return fmt.Errorf("failed to get parent categories: %w", err)
+	}
+	var subjects []entities.WBSubject
+	for offset := 0; ; offset += wbSubjectsPage {
+		page, err := s.client.GetSubjects(ctx, s.options.ApiKey, s.options.Locale, wbSubjectsPage, offset)
+		if err != nil {
+			return fmt.Errorf("failed to get subjects from offset %d: %w", offset, err)
+		}
+		subjects = append(subjects, page...)
+		if len(page) < wbSubjectsPage {
+			break
+		}
+	}
+	if len(parents) == 0 || len(subjects) == 0 {
+		return fmt.Errorf("WB returned %d parent categories and %d subjects, keeping the previous ones", len(parents), len(subjects))
+	}
+
+	if err := s.storage.ReplaceWBParentCategories(ctx, parents); err != nil {
+		return fmt.Errorf("failed to save parent categories: %w", err)
+	}
+	if err := s.storage.ReplaceWBSubjects(ctx, subjects); err != nil {
+		return fmt.Errorf("failed to save subjects: %w", err)
+	}
+	return nil
+}
+
+// syncTnved mirrors the TNVED codes of the subjects synced least recently and returns their number.
+// A subject that fails is retried in the next run.
+func (s *WbCatalogSyncService) syncTnved(ctx context.Context) (int, error) {
+	if s.options.TnvedSubjectsPerRun <= 0 {
+		return 0, nil
+	}
+	subjectIDs, err := s.storage.ListWBSubjectsForTnvedSync(ctx, s.options.TnvedSubjectsPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list subjects for TNVED sync: %w", err)
+	}
+	synced := 0
+	for _, subjectID := range subjectIDs {
+		codes, err := s.client.GetTnved(ctx, s.options.ApiKey, s.options.Locale, subjectID)
+		if err != nil {
+			log.Printf("[WB CATALOG] Failed to get TNVED codes of subject %d: %v", subjectID, err)
+			continue
+		}
+		if err := s.storage.ReplaceWBTnved(ctx, subjectID, codes); err != nil {
+			return synced, fmt.Errorf("failed to save TNVED codes of subject %d: %w", subjectID, err)
+		}
+		synced++
+	}
+	return synced, nil
+}
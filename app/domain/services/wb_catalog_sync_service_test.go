@@ -0,0 +1,170 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeWBCatalogClient struct {
+	subjects   []entities.WBSubject
+	parentsErr error
+}
+
+func (f *fakeWBCatalogClient) GetParentCategories(ctx context.Context, apiKey, locale string) ([]entities.WBParentCategory, error) {
+	if f.parentsErr != nil {
+		return nil, f.parentsErr
+	}
+	return []entities.WBParentCategory{{ID: 479, Name: "Электроника", IsVisible: true}}, nil
+}
+
+func (f *fakeWBCatalogClient) GetSubjects(ctx context.Context, apiKey, locale string, limit, offset int) ([]entities.WBSubject, error) {
+	if offset >= len(f.subjects) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(f.subjects) {
+		end = len(f.subjects)
+	}
+	return f.subjects[offset:end], nil
+}
+
+func (f *fakeWBCatalogClient) GetColors(ctx context.Context, apiKey, locale string) ([]entities.WBColor, error) {
+	return []entities.WBColor{{Name: "персиковый мелок", ParentName: "оранжевый"}}, nil
+}
+
+func (f *fakeWBCatalogClient) GetKinds(ctx context.Context, apiKey, locale string) ([]string, error) {
+	return []string{"Мужской", "Женский"}, nil
+}
+
+func (f *fakeWBCatalogClient) GetCountries(ctx context.Context, apiKey, locale string) ([]entities.WBCountry, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (f *fakeWBCatalogClient) GetSeasons(ctx context.Context, apiKey, locale string) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeWBCatalogClient) GetVatRates(ctx context.Context, apiKey, locale string) ([]string, error) {
+	return []string{"20", "Без НДС"}, nil
+}
+
+func (f *fakeWBCatalogClient) GetTnved(ctx context.Context, apiKey, locale string, subjectID int) ([]entities.WBTnved, error) {
+	return []entities.WBTnved{{Tnved: "6106903000", IsKiz: true}}, nil
+}
+
+type fakeWBCatalogStorage struct {
+	subjects    []entities.WBSubject
+	directories map[entities.WBDirectory][]entities.WBDirectoryValue
+	tnved       map[int][]entities.WBTnved
+}
+
+func (f *fakeWBCatalogStorage) ReplaceWBParentCategories(ctx context.Context, categories []entities.WBParentCategory) error {
+	return nil
+}
+
+func (f *fakeWBCatalogStorage) ReplaceWBSubjects(ctx context.Context, subjects []entities.WBSubject) error {
+	f.subjects = subjects
+	return nil
+}
+
+func (f *fakeWBCatalogStorage) ReplaceWBDirectory(ctx context.Context, directory entities.WBDirectory, values []entities.WBDirectoryValue) error {
+	f.directories[directory] = values
+	return nil
+}
+
+func (f *fakeWBCatalogStorage) ListWBSubjectsForTnvedSync(ctx context.Context, limit int) ([]int, error) {
+	var ids []int
+	for _, s := range f.subjects {
+		if _, ok := f.tnved[s.SubjectID]; !ok && len(ids) < limit {
+			ids = append(ids, s.SubjectID)
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeWBCatalogStorage) ReplaceWBTnved(ctx context.Context, subjectID int, codes []entities.WBTnved) error {
+	f.tnved[subjectID] = codes
+	return nil
+}
+
+func TestWbCatalogSyncService_Sync(t *testing.T) {
+	client := &fakeWBCatalogClient{}
+	for i := 0; i < wbSubjectsPage+5; i++ {
+		client.subjects = append(client.subjects, entities.WBSubject{SubjectID: i + 1, SubjectName: "subject", ParentID: 479})
+	}
+	storage := &fakeWBCatalogStorage{
+		directories: map[entities.WBDirectory][]entities.WBDirectoryValue{
+			entities.WBDirectoryCountries: {{Value: "Россия"}},
+		},
+		tnved: map[int][]entities.WBTnved{},
+	}
+	s := NewWbCatalogSyncService(WbCatalogSyncOptions{ApiKey: "key", Locale: "ru", TnvedSubjectsPerRun: 3}, client, storage)
+
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(storage.subjects) != wbSubjectsPage+5 {
+		t.Errorf("saved %d subjects, want all %d pages", len(storage.subjects), wbSubjectsPage+5)
+	}
+	if colors := storage.directories[entities.WBDirectoryColors]; len(colors) != 1 || colors[0].Description != "оранжевый" {
+		t.Errorf("colors = %+v, want the color with its basic color", colors)
+	}
+	if kinds := storage.directories[entities.WBDirectoryKinds]; len(kinds) != 2 {
+		t.Errorf("kinds = %+v, want 2", kinds)
+	}
+	if countries := storage.directories[entities.WBDirectoryCountries]; len(countries) != 1 || countries[0].Value != "Россия" {
+		t.Errorf("countries = %+v, want the previous values kept after a failure", countries)
+	}
+	if _, ok := storage.directories[entities.WBDirectorySeasons]; ok {
+		t.Error("empty seasons replaced the directory")
+	}
+	if len(storage.tnved) != 3 {
+		t.Errorf("synced TNVED codes of %d subjects, want 3 per run", len(storage.tnved))
+	}
+}
+
+func TestWbCatalogSyncService_SyncAfterFailedCategories(t *testing.T) {
+	client := &fakeWBCatalogClient{parentsErr: errors.New("unavailable")}
+	storage := &fakeWBCatalogStorage{
+		subjects:    []entities.WBSubject{{SubjectID: 1, SubjectName: "subject", ParentID: 479}},
+		directories: map[entities.WBDirectory][]entities.WBDirectoryValue{},
+		tnved:       map[int][]entities.WBTnved{},
+	}
+	s := NewWbCatalogSyncService(WbCatalogSyncOptions{ApiKey: "key", Locale: "ru", TnvedSubjectsPerRun: 3}, client, storage)
+
+	if err := s.Sync(context.Background()); err == nil {
+		t.Fatal("Expected the failed category tree to be reported")
+	}
+	if len(storage.subjects) != 1 {
+		t.Errorf("Expected the mirrored subjects to be kept, got %d", len(storage.subjects))
+	}
+	if colors := storage.directories[entities.WBDirectoryColors]; len(colors) != 1 {
+		t.Errorf("Expected the directories to be synced, got colors %+v", colors)
+	}
+	if len(storage.tnved) != 1 {
+		t.Errorf("Expected the TNVED codes of the mirrored subject to be synced, got %d subjects", len(storage.tnved))
+	}
+}
+
+func TestWbCatalogSyncService_StopEndsSyncRoutine(t *testing.T) {
+	storage := &fakeWBCatalogStorage{directories: map[entities.WBDirectory][]entities.WBDirectoryValue{}, tnved: map[int][]entities.WBTnved{}}
+	s := NewWbCatalogSyncService(WbCatalogSyncOptions{ApiKey: "key", Locale: "ru"}, &fakeWBCatalogClient{}, storage)
+
+	done := make(chan struct{})
+	go func() {
+		s.StartSyncRoutine(time.Hour)
+		close(done)
+	}()
+	s.Stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to end the sync routine")
+	}
+
+	// A routine started after Stop returns at once
+	s.StartSyncRoutine(time.Hour)
+}
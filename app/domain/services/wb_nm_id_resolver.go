@@ -0,0 +1,253 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"api/metrics"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// NmIDResolverOptions configures the background search of nmIDs for cards WB has not created yet,
+// whose media and prices can only be set by nmID.
+type NmIDResolverOptions struct {
+	BaseDelay       time.Duration // Delay before the first poll, doubled after every poll without result
+	MaxDelay        time.Duration // Upper bound of the delay between polls
+	Timeout         time.Duration // Pending media is dropped if the card does not appear in time
+	MaxLookupErrors int           // Pending media is dropped after this many failed lookups in a row, 5 by default
+	MaxPendingJobs  int           // Cards are not queued while this many are pending, 1000 by default
+}
+
+// errNmIDResolverFull is returned when a card cannot be queued because too many are pending or the resolver is stopped.
+var errNmIDResolverFull = errors.New("too many WB cards are waiting for their nmID")
+
+// errNmIDResolverStopped is returned when a background task is started after the resolver is stopped.
+var errNmIDResolverStopped = errors.New("WB background tasks are stopped")
+
+// wbMediaJob is media and price waiting for the nmID of a card that WB is still creating.
+type wbMediaJob struct {
+	apiKey       string
+	vendorCode   string
+	files        []entities.WBClientMediaFile // Inline files, moved to storedFiles before the job is queued
+	storedFiles  []wbStoredMediaFile
+	links        []string
+	price        int // 0 if the price is not set
+	discount     int // Percent
+	warehouseID  int64
+	stocks       []entities.WBStock // Initial stock in warehouseID
+	deadline     time.Time
+	lookupErrors int    // Failed lookups in a row
+	release      func() // Frees what the job keeps outside memory, nil if nothing
+}
+
+// wbStoredMediaFile is an inline media file of a queued job, kept in the file storage instead of memory.
+type wbStoredMediaFile struct {
+	mediaID     string
+	filename    string
+	photoNumber int32
+	kind        entities.MediaKind
+}
+
+// finish releases what the job keeps outside memory once it is done or dropped.
+func (job *wbMediaJob) finish() {
+	if job.release != nil {
+		job.release()
+	}
+}
+
+// nmIDResolver polls WB for the cards of pending media jobs with exponential backoff, one poller per seller,
+// and hands each job to complete as soon as its nmID appears. Jobs are kept in memory only, so the ones
+// still pending when the resolver is stopped are dropped and reported.
+type nmIDResolver struct {
+	opts     NmIDResolverOptions
+	ctx      context.Context
+	cancel   context.CancelFunc
+	lookup   func(ctx context.Context, apiKey, vendorCode string) (int, error)
+	complete func(ctx context.Context, job *wbMediaJob, nmID int) error
+
+	mu      sync.Mutex
+	pending map[string][]*wbMediaJob // By seller API key
+	jobs    int
+	workers sync.WaitGroup // Pollers and tracked background tasks
+}
+
+func newNmIDResolver(ctx context.Context, opts NmIDResolverOptions, lookup func(ctx context.Context, apiKey, vendorCode string) (int, error), complete func(ctx context.Context, job *wbMediaJob, nmID int) error) *nmIDResolver {
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = time.Second
+	}
+	if opts.MaxDelay < opts.BaseDelay {
+		opts.MaxDelay = opts.BaseDelay
+	}
+	if opts.MaxLookupErrors <= 0 {
+		opts.MaxLookupErrors = 5
+	}
+	if opts.MaxPendingJobs <= 0 {
+		opts.MaxPendingJobs = 1000
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &nmIDResolver{
+		opts:     opts,
+		ctx:      ctx,
+		cancel:   cancel,
+		lookup:   lookup,
+		complete: complete,
+		pending:  make(map[string][]*wbMediaJob),
+	}
+}
+
+// enqueue adds the job and starts the poller of its seller if it is not running yet.
+func (r *nmIDResolver) enqueue(job *wbMediaJob) error {
+	job.deadline = time.Now().Add(r.opts.Timeout)
+
+	r.mu.Lock()
+	if r.ctx.Err() != nil || r.jobs >= r.opts.MaxPendingJobs {
+		r.mu.Unlock()
+		metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("nm_id_queue_full").Inc()
+		return errNmIDResolverFull
+	}
+	_, running := r.pending[job.apiKey]
+	r.pending[job.apiKey] = append(r.pending[job.apiKey], job)
+	r.jobs++
+	if !running {
+		r.workers.Add(1)
+	}
+	r.mu.Unlock()
+
+	metrics.AppWBMediaPendingJobs.Inc()
+	log.Printf("[NMID RESOLVER] Media and price for vendor code %s are pending until WB creates the card", job.vendorCode)
+	if !running {
+		go r.poll(job.apiKey)
+	}
+	return nil
+}
+
+// track runs task in the background with the resolver context, so that stop cancels and waits for it,
+// and reports its failure under the given metric operation.
+func (r *nmIDResolver) track(vendorCode, operation string, task func(ctx context.Context) error) error {
+	r.mu.Lock()
+	if r.ctx.Err() != nil {
+		r.mu.Unlock()
+		metrics.AppWBMediaOperationErrorsTotal.WithLabelValues(operation).Inc()
+		return errNmIDResolverStopped
+	}
+	r.workers.Add(1)
+	r.mu.Unlock()
+
+	go func() {
+		defer r.workers.Done()
+		if err := task(r.ctx); err != nil {
+			log.Printf("[NMID RESOLVER] Background %s of vendor code %s failed: %v", operation, vendorCode, err)
+			metrics.AppWBMediaOperationErrorsTotal.WithLabelValues(operation).Inc()
+		}
+	}()
+	return nil
+}
+
+// stop cancels the pollers and tracked tasks, waits for them to exit and drops the jobs that are still pending.
+func (r *nmIDResolver) stop() {
+	// Cancel under mu so that no poller or task is added once Wait has started
+	r.mu.Lock()
+	r.cancel()
+	r.mu.Unlock()
+	r.workers.Wait()
+
+	r.mu.Lock()
+	var dropped []*wbMediaJob
+	for apiKey, jobs := range r.pending {
+		for _, job := range jobs {
+			log.Printf("[NMID RESOLVER] Shutting down, dropping pending media and price of vendor code %s", job.vendorCode)
+			metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("nm_id_shutdown").Inc()
+		}
+		metrics.AppWBMediaPendingJobs.Sub(float64(len(jobs)))
+		dropped = append(dropped, jobs...)
+		delete(r.pending, apiKey)
+	}
+	r.jobs = 0
+	r.mu.Unlock()
+
+	for _, job := range dropped {
+		job.finish()
+	}
+}
+
+// poll resolves the pending jobs of one seller and exits when none are left or the context is done.
+// Jobs of a stopped poller are left to stop.
+func (r *nmIDResolver) poll(apiKey string) {
+	defer r.workers.Done()
+	delay := r.opts.BaseDelay
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		r.mu.Lock()
+		jobs := append([]*wbMediaJob(nil), r.pending[apiKey]...)
+		r.mu.Unlock()
+
+		done := make(map[*wbMediaJob]bool)
+		for _, job := range jobs {
+			if r.ctx.Err() != nil {
+				break
+			}
+			nmID, err := r.lookup(r.ctx, apiKey, job.vendorCode)
+			switch {
+			case err != nil:
+				log.Printf("[NMID RESOLVER] Failed to look up vendor code %s: %v", job.vendorCode, err)
+				if job.lookupErrors++; job.lookupErrors >= r.opts.MaxLookupErrors {
+					log.Printf("[NMID RESOLVER] Looking up vendor code %s failed %d times in a row, dropping pending media and price", job.vendorCode, job.lookupErrors)
+					metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("nm_id_lookup").Inc()
+					done[job] = true
+					continue
+				}
+			case nmID != 0:
+				log.Printf("[NMID RESOLVER] Found nmID %d for vendor code %s, completing the card", nmID, job.vendorCode)
+				if err := r.complete(r.ctx, job, nmID); err != nil {
+					log.Printf("[NMID RESOLVER] Failed to complete the card with vendor code %s: %v", job.vendorCode, err)
+					metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("deferred_complete").Inc()
+				}
+				done[job] = true
+				continue
+			default:
+				job.lookupErrors = 0
+			}
+			if time.Now().After(job.deadline) {
+				log.Printf("[NMID RESOLVER] Card with vendor code %s did not appear within %v, dropping pending media and price", job.vendorCode, r.opts.Timeout)
+				metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("nm_id_timeout").Inc()
+				done[job] = true
+			}
+		}
+
+		r.mu.Lock()
+		// Jobs enqueued while polling are kept for the next round
+		var remaining []*wbMediaJob
+		for _, job := range r.pending[apiKey] {
+			if !done[job] {
+				remaining = append(remaining, job)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(r.pending, apiKey)
+		} else {
+			r.pending[apiKey] = remaining
+		}
+		r.jobs -= len(done)
+		r.mu.Unlock()
+		metrics.AppWBMediaPendingJobs.Sub(float64(len(done)))
+		for job := range done {
+			job.finish()
+		}
+
+		if len(remaining) == 0 {
+			return
+		}
+		if delay *= 2; delay > r.opts.MaxDelay {
+			delay = r.opts.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNmIDResolver_UploadsWhenCardAppears(t *testing.T) {
+	var lookups int32
+	uploaded := make(chan int, 1)
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Timeout: time.Minute},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) {
+			// The card appears on the third poll
+			if atomic.AddInt32(&lookups, 1) < 3 {
+				return 0, nil
+			}
+			return 42, nil
+		},
+		func(ctx context.Context, job *wbMediaJob, nmID int) error {
+			uploaded <- nmID
+			return nil
+		})
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	select {
+	case nmID := <-uploaded:
+		if nmID != 42 {
+			t.Errorf("Expected upload for nmID 42, got %d", nmID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Pending media was not uploaded")
+	}
+	waitForNoPending(t, r)
+}
+
+func TestNmIDResolver_DropsJobAfterTimeout(t *testing.T) {
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, Timeout: 5 * time.Millisecond},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) { return 0, nil },
+		func(ctx context.Context, job *wbMediaJob, nmID int) error {
+			t.Error("Media must not be uploaded for a card that never appears")
+			return nil
+		})
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitForNoPending(t, r)
+}
+
+func TestNmIDResolver_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := newNmIDResolver(ctx, NmIDResolverOptions{BaseDelay: time.Hour, Timeout: time.Hour},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) {
+			t.Error("Lookup must not run after the context is cancelled")
+			return 0, nil
+		},
+		func(ctx context.Context, job *wbMediaJob, nmID int) error { return nil })
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestNmIDResolver_DropsJobAfterLookupErrors(t *testing.T) {
+	var lookups int32
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, Timeout: time.Hour, MaxLookupErrors: 3},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) {
+			atomic.AddInt32(&lookups, 1)
+			return 0, errors.New("WB is unavailable")
+		},
+		func(ctx context.Context, job *wbMediaJob, nmID int) error {
+			t.Error("Media must not be uploaded for a card that was never found")
+			return nil
+		})
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitForNoPending(t, r)
+	if n := atomic.LoadInt32(&lookups); n != 3 {
+		t.Errorf("Expected the job to be dropped after 3 failed lookups, got %d", n)
+	}
+}
+
+func TestNmIDResolver_RejectsJobsWhenFull(t *testing.T) {
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{BaseDelay: time.Hour, Timeout: time.Hour, MaxPendingJobs: 1},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) { return 0, nil },
+		func(ctx context.Context, job *wbMediaJob, nmID int) error { return nil })
+	defer r.stop()
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if err := r.enqueue(&wbMediaJob{apiKey: "other", vendorCode: "VC002"}); !errors.Is(err, errNmIDResolverFull) {
+		t.Errorf("Expected errNmIDResolverFull, got %v", err)
+	}
+}
+
+func TestNmIDResolver_StopDropsPendingJobs(t *testing.T) {
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{BaseDelay: time.Hour, Timeout: time.Hour},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) {
+			t.Error("Lookup must not run after the resolver is stopped")
+			return 0, nil
+		},
+		func(ctx context.Context, job *wbMediaJob, nmID int) error { return nil })
+
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC001"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	r.stop()
+	if len(r.pending) != 0 || r.jobs != 0 {
+		t.Errorf("Expected no pending jobs after stop, got %d", r.jobs)
+	}
+	if err := r.enqueue(&wbMediaJob{apiKey: "key", vendorCode: "VC002"}); !errors.Is(err, errNmIDResolverFull) {
+		t.Errorf("Expected a stopped resolver to reject jobs, got %v", err)
+	}
+}
+
+func TestNmIDResolver_StopWaitsForTrackedTasks(t *testing.T) {
+	r := newNmIDResolver(context.Background(), NmIDResolverOptions{Timeout: time.Hour},
+		func(ctx context.Context, apiKey, vendorCode string) (int, error) { return 0, nil },
+		func(ctx context.Context, job *wbMediaJob, nmID int) error { return nil })
+
+	var finished int32
+	err := r.track("VC001", "deferred_price", func(ctx context.Context) error {
+		<-ctx.Done()
+		atomic.StoreInt32(&finished, 1)
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("track: %v", err)
+	}
+
+	r.stop()
+	if atomic.LoadInt32(&finished) != 1 {
+		t.Error("Expected stop to cancel and wait for the tracked task")
+	}
+	err = r.track("VC002", "deferred_price", func(ctx context.Context) error {
+		t.Error("Tasks must not start after stop")
+		return nil
+	})
+	if !errors.Is(err, errNmIDResolverStopped) {
+		t.Errorf("Expected errNmIDResolverStopped, got %v", err)
+	}
+}
+
+func waitForNoPending(t *testing.T, r *nmIDResolver) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		n := len(r.pending)
+		r.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Pending jobs were not cleared")
+}
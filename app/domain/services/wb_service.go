@@ -3,120 +3,430 @@ package services
 import (
 	"api/app/domain/entities"
 	"api/metrics"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
 )
 
 type wbClient interface {
+	ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error
 	UploadWBCard(ctx context.Context, wbPayload entities.WBCardUploadPayload, apiKey string) (*entities.WBCardUploadResponse, error)
+	AddWBCardVariants(ctx context.Context, payload entities.WBCardAddPayload, apiKey string) (*entities.WBCardUploadResponse, error)
 	UploadMediaFiles(ctx context.Context, apiKey string, nmID string, files []entities.WBClientMediaFile) ([]entities.WBMediaUploadResult, error)
 	SaveMediaByLinks(ctx context.Context, apiKey string, payload entities.WBSaveMediaPayload) (*entities.WBMediaGenericResponse, error)
-	GetCardList(ctx context.Context, apiKey string, listReq entities.WBGetCardListRequest) (*entities.WBGetCardListResponse, error)
+	GenerateBarcodes(ctx context.Context, apiKey string, count int) ([]string, error)
+	GetSubjectCharacteristics(ctx context.Context, apiKey string, subjectID int) ([]entities.WBSubjectCharacteristic, error)
+}
+
+type wbPricesClient interface {
+	UploadPrices(ctx context.Context, apiKey string, goods []entities.WBPriceGood) (int64, error)
+	GetPriceTask(ctx context.Context, apiKey string, uploadID int64) (*entities.WBPriceTask, error)
+	GetGoodPrices(ctx context.Context, apiKey string, nmID int) (*entities.WBGoodPrices, error)
+}
+
+type wbStocksClient interface {
+	ListWarehouses(ctx context.Context, apiKey string) ([]entities.WBWarehouse, error)
+	GetStocks(ctx context.Context, apiKey string, warehouseID int64, skus []string) ([]entities.WBStock, error)
+	SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error
+}
+
+// wbPendingMediaStorage keeps the inline media files of cards WB has not created yet.
+type wbPendingMediaStorage interface {
+	SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error)
+	ReadMedia(ctx context.Context, mediaID string) ([]byte, error)
+	DeleteMedia(ctx context.Context, mediaID string) error
+}
+
+// wbStocksBatchSize is the maximum number of barcodes WB accepts in one stocks query
+const wbStocksBatchSize = 1000
+
+// WbPricesOptions configures waiting for WB to process price uploads.
+type WbPricesOptions struct {
+	PollInterval time.Duration // Delay between checks of the upload state
+	Timeout      time.Duration // Uploads not processed in time are reported as pending
 }
 
 type WbService struct {
-	wbApiGetCardListMaxAttempts int
-	wbClient                    wbClient
+	wbClient     wbClient
+	pricesClient wbPricesClient
+	pricesOpts   WbPricesOptions
+	stocksClient wbStocksClient
+	mediaStorage wbPendingMediaStorage
+	nmIDResolver *nmIDResolver
 }
 
-func NewWbService(wbApiGetCardListMaxAttempts int, wbClient wbClient) *WbService {
-	return &WbService{
-		wbApiGetCardListMaxAttempts: wbApiGetCardListMaxAttempts,
-		wbClient:                    wbClient,
+// NewWbService creates the WB service. Media, prices and stocks of cards that WB has not created yet are set
+// in the background once the card appears, see NmIDResolverOptions; their inline media files wait in mediaStorage.
+func NewWbService(resolverOpts NmIDResolverOptions, pricesOpts WbPricesOptions, wbClient wbClient, pricesClient wbPricesClient, stocksClient wbStocksClient, mediaStorage wbPendingMediaStorage) *WbService {
+	if pricesOpts.PollInterval <= 0 {
+		pricesOpts.PollInterval = time.Second
 	}
+	wbs := &WbService{wbClient: wbClient, pricesClient: pricesClient, pricesOpts: pricesOpts, stocksClient: stocksClient, mediaStorage: mediaStorage}
+	wbs.nmIDResolver = newNmIDResolver(context.Background(), resolverOpts, wbs.findNmID, wbs.completeJob)
+	return wbs
 }
 
-func (wbs *WbService) AddMedia(ctx context.Context, req *entities.ProductCard) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse, error) {
-	var protoMediaUploadResponses []*entities.WbMediaUploadIndividualResponse
-	var protoMediaSaveResponse *entities.WbMediaSaveByLinksResponse
+// Stop stops setting media, prices and stocks of cards in the background, the pending ones are dropped.
+func (wbs *WbService) Stop() {
+	wbs.nmIDResolver.stop()
+}
 
-	// If no media operations are requested, return early.
-	if len(req.GetWbMediaToUploadFiles()) == 0 && len(req.GetWbMediaToSaveLinks()) == 0 {
-		return nil, nil, nil
+// completeJob sets the media, stock and price of a card WB created after AddMedia returned
+// and returns what failed.
+func (wbs *WbService) completeJob(ctx context.Context, job *wbMediaJob, nmID int) error {
+	var errs []error
+	uploadResponses, saveResponse := wbs.uploadMedia(ctx, job, nmID)
+	for _, r := range uploadResponses {
+		if r.ErrorMessage != nil {
+			errs = append(errs, fmt.Errorf("media file %d: %s", r.PhotoNumber, *r.ErrorMessage))
+		}
+	}
+	if saveResponse != nil && saveResponse.ErrorMessage != nil {
+		errs = append(errs, fmt.Errorf("media links: %s", *saveResponse.ErrorMessage))
+	}
+	if err := wbs.setInitialStock(ctx, job); err != nil {
+		errs = append(errs, err)
+	}
+	if err := wbs.setPrice(ctx, job, nmID); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// AddMedia uploads the WB media of the card and sets its price, which WB ignores in the card payload,
+// and its initial stock. If WB has not created the card yet, this is done in the background once its nmID
+// appears and pending is true.
+// The price is always set in the background since WB processes price uploads asynchronously, Stop waits for it.
+func (wbs *WbService) AddMedia(ctx context.Context, req *entities.ProductCard) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse, bool, error) {
+	// Collect links to save: plain links first, then resolved media in photo number order.
+	// WB has no place for 360-degree sets, color swatches and video covers, those are only used for Ozon.
+	linksToSave := append([]string{}, req.GetWbMediaToSaveLinks()...)
+	for _, link := range req.GetMediaLinks() {
+		switch {
+		case link.IsOzonVideoCover():
+			log.Printf("Skipping video cover %s for Wildberries: not supported by WB", link.Name)
+		case link.Kind == entities.MediaKindPhoto, link.Kind == entities.MediaKindVideo:
+			linksToSave = append(linksToSave, link.URL)
+		default:
+			log.Printf("Skipping media %s of kind %d for Wildberries: not supported by WB", link.Name, link.Kind)
+		}
+	}
+
+	price := cardWBPrice(req)
+	stocks := cardWBStocks(req)
+
+	// If no media operations, no price and no stock are requested, return early.
+	if len(req.GetWbMediaToUploadFiles()) == 0 && len(linksToSave) == 0 && price == 0 && len(stocks) == 0 {
+		return nil, nil, false, nil
 	}
 
 	apiKey := req.GetWbApiKey()
 	vendorCode := req.GetVendorCode()
 
 	if apiKey == "" {
-		log.Printf("Wildberries API key not provided, skipping media operations for vendor code %s.", vendorCode)
-		return nil, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wildberries API key is required for media operations"))
+		log.Printf("Wildberries API key not provided, skipping media, price and stock operations for vendor code %s.", vendorCode)
+		return nil, nil, false, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wildberries API key is required for media, price and stock operations"))
 	}
 
 	if vendorCode == "" {
-		log.Printf("Vendor code not provided, skipping media operations.") // Should be caught by initial validation, but good to check.
-		return nil, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("vendor_code is required for Wildberries media operations"))
+		log.Printf("Vendor code not provided, skipping media, price and stock operations.") // Should be caught by initial validation, but good to check.
+		return nil, nil, false, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("vendor_code is required for Wildberries media, price and stock operations"))
 	}
 
-	var foundNmID int
+	job := &wbMediaJob{apiKey: apiKey, vendorCode: vendorCode, links: linksToSave, price: price, discount: req.WbDiscount, stocks: stocks}
+	if len(stocks) > 0 {
+		job.warehouseID = req.InitialStock.WbWarehouseID
+	}
+	for _, f := range req.GetWbMediaToUploadFiles() {
+		photoNumber := f.PhotoNumber
+		if f.Kind == entities.MediaKindVideo {
+			// WB requires X-Photo-Number 1 for videos regardless of their position.
+			photoNumber = 1
+		}
+		job.files = append(job.files, entities.WBClientMediaFile{
+			Filename:    f.Filename,
+			Content:     f.Content,
+			PhotoNumber: photoNumber,
+			Kind:        f.Kind,
+		})
+	}
+
+	// WB creates cards asynchronously, a freshly uploaded card is usually not listed yet
+	foundNmID, err := wbs.findNmID(ctx, apiKey, vendorCode)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if foundNmID == 0 {
+		if err := wbs.storeJobFiles(ctx, job); err != nil {
+			return nil, nil, false, connect.NewError(connect.CodeInternal, fmt.Errorf("media, price and stock of vendor code %s are not set: %w", vendorCode, err))
+		}
+		if err := wbs.nmIDResolver.enqueue(job); err != nil {
+			job.finish()
+			return nil, nil, false, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("media, price and stock of vendor code %s are not set: %w", vendorCode, err))
+		}
+		return nil, nil, true, nil
+	}
+
+	uploadResponses, saveResponse := wbs.uploadMedia(ctx, job, foundNmID)
+	if err := wbs.setInitialStock(ctx, job); err != nil {
+		log.Print(err)
+	}
+	err = wbs.nmIDResolver.track(job.vendorCode, "deferred_price", func(ctx context.Context) error {
+		return wbs.setPrice(ctx, job, foundNmID)
+	})
+	if err != nil {
+		log.Printf("Failed to set WB price of nmID %d: %v", foundNmID, err)
+	}
+	return uploadResponses, saveResponse, false, nil
+}
 
-	const retryDelay = 5 * time.Second
+// storeJobFiles moves the inline files of a job that waits for its card to the file storage, so queued jobs
+// do not keep the file contents in memory. The stored files are deleted when the job finishes.
+func (wbs *WbService) storeJobFiles(ctx context.Context, job *wbMediaJob) error {
+	if len(job.files) == 0 {
+		return nil
+	}
+	job.release = func() { wbs.deleteJobFiles(job) }
+	for _, f := range job.files {
+		stored, err := wbs.mediaStorage.SaveStream(ctx, f.Filename, "", bytes.NewReader(f.Content))
+		if err != nil {
+			job.finish()
+			return fmt.Errorf("failed to store media file %s: %w", f.Filename, err)
+		}
+		job.storedFiles = append(job.storedFiles, wbStoredMediaFile{mediaID: stored.ID, filename: f.Filename, photoNumber: f.PhotoNumber, kind: f.Kind})
+	}
+	job.files = nil
+	return nil
+}
 
-	for attempt := 1; attempt <= wbs.wbApiGetCardListMaxAttempts; attempt++ {
-		log.Printf("Attempt %d to find nmID for vendor code %s", attempt, vendorCode)
-		filterWithPhoto := 0 // Only cards with no photos
-		listReqPayload := entities.WBGetCardListRequest{
-			Settings: entities.WBGetCardListRequestSettings{
-				Filter: &entities.WBGetCardListRequestFilter{
-					WithPhoto: &filterWithPhoto,
-				},
-			},
+// deleteJobFiles deletes the stored inline files of job.
+func (wbs *WbService) deleteJobFiles(job *wbMediaJob) {
+	for _, f := range job.storedFiles {
+		if err := wbs.mediaStorage.DeleteMedia(context.Background(), f.mediaID); err != nil {
+			log.Printf("Failed to delete stored media file %s of vendor code %s: %v", f.mediaID, job.vendorCode, err)
 		}
+	}
+	job.storedFiles = nil
+}
 
-		wbCardsResp, err := wbs.wbClient.GetCardList(ctx, apiKey, listReqPayload)
+// uploadJobFiles uploads the inline files of job to the card with nmID. Stored files are read and uploaded
+// one at a time.
+func (wbs *WbService) uploadJobFiles(ctx context.Context, job *wbMediaJob, nmID string) ([]entities.WBMediaUploadResult, error) {
+	if len(job.storedFiles) == 0 {
+		return wbs.wbClient.UploadMediaFiles(ctx, job.apiKey, nmID, job.files)
+	}
+	var results []entities.WBMediaUploadResult
+	for _, f := range job.storedFiles {
+		content, err := wbs.mediaStorage.ReadMedia(ctx, f.mediaID)
 		if err != nil {
-			log.Printf("Error getting card list from WB (attempt %d) for vendor code %s: %v", attempt, vendorCode, err)
-			metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_get_card_list").Inc()
-			if attempt == wbs.wbApiGetCardListMaxAttempts {
-				return nil, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to get card list from WB after %d attempts for vendor code %s: %w", wbs.wbApiGetCardListMaxAttempts, vendorCode, err))
-			}
-			time.Sleep(retryDelay)
+			results = append(results, entities.WBMediaUploadResult{PhotoNumber: f.photoNumber, Error: fmt.Errorf("failed to read stored media file %s: %w", f.filename, err)})
 			continue
 		}
+		file := entities.WBClientMediaFile{Filename: f.filename, Content: content, PhotoNumber: f.photoNumber, Kind: f.kind}
+		fileResults, err := wbs.wbClient.UploadMediaFiles(ctx, job.apiKey, nmID, []entities.WBClientMediaFile{file})
+		results = append(results, fileResults...)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
 
-		for _, card := range wbCardsResp.Cards {
-			fmt.Printf("Card: %s - %d\n", card.VendorCode, card.NmID)
-			if card.VendorCode == vendorCode {
-				foundNmID = card.NmID
-				log.Printf("Found nmID %d for vendor code %s", foundNmID, vendorCode)
-				break
+// cardWBStocks returns the initial stock of every size of the card that has a barcode, WB identifies
+// stocks by barcode. Barcodes are generated for new sizes before the card is created.
+func cardWBStocks(card *entities.ProductCard) []entities.WBStock {
+	if card.InitialStock == nil || card.InitialStock.WbWarehouseID == 0 {
+		return nil
+	}
+	var stocks []entities.WBStock
+	for _, s := range card.Sizes {
+		if len(s.Skus) == 0 {
+			log.Printf("Size %s of vendor code %s has no barcode, skipping its WB stock", s.TechSize, card.VendorCode)
+			continue
+		}
+		stocks = append(stocks, entities.WBStock{Sku: s.Skus[0], Amount: card.InitialStock.Amount})
+	}
+	return stocks
+}
+
+// setInitialStock sets the stocks of job in its warehouse.
+func (wbs *WbService) setInitialStock(ctx context.Context, job *wbMediaJob) error {
+	if len(job.stocks) == 0 {
+		return nil
+	}
+	if err := wbs.SetStocks(ctx, job.apiKey, job.warehouseID, job.stocks); err != nil {
+		return fmt.Errorf("failed to set initial WB stock of vendor code %s: %w", job.vendorCode, err)
+	}
+	return nil
+}
+
+// ListWarehouses returns the warehouses of the seller on WB.
+func (wbs *WbService) ListWarehouses(ctx context.Context, apiKey string) ([]entities.Warehouse, error) {
+	wbWarehouses, err := wbs.stocksClient.ListWarehouses(ctx, apiKey)
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_warehouses").Inc()
+		return nil, fmt.Errorf("failed to list WB warehouses: %w", err)
+	}
+	warehouses := make([]entities.Warehouse, len(wbWarehouses))
+	for i, w := range wbWarehouses {
+		warehouses[i] = entities.Warehouse{Marketplace: entities.MarketplaceWB, ID: w.ID, Name: w.Name}
+	}
+	return warehouses, nil
+}
+
+// GetStocks returns the stocks of sizes, identified by their barcodes, in a seller warehouse.
+// WB does not list sizes without stock, so they are missing from the result.
+func (wbs *WbService) GetStocks(ctx context.Context, apiKey string, warehouseID int64, skus []string) (map[string]int, error) {
+	amounts := make(map[string]int, len(skus))
+	for start := 0; start < len(skus); start += wbStocksBatchSize {
+		end := start + wbStocksBatchSize
+		if end > len(skus) {
+			end = len(skus)
+		}
+		stocks, err := wbs.stocksClient.GetStocks(ctx, apiKey, warehouseID, skus[start:end])
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_stocks_get").Inc()
+			return nil, fmt.Errorf("failed to get WB stocks in warehouse %d: %w", warehouseID, err)
+		}
+		for _, s := range stocks {
+			amounts[s.Sku] = s.Amount
+		}
+	}
+	return amounts, nil
+}
+
+// KnownSkus returns the size barcodes of the seller cards found by the searches, WB textSearch matches vendor
+// codes and barcodes. It tells sizes without stock, which WB does not list in a warehouse, from unknown barcodes.
+func (wbs *WbService) KnownSkus(ctx context.Context, apiKey string, searches []string) (map[string]bool, error) {
+	known := make(map[string]bool)
+	withPhoto := -1 // All cards
+	for _, search := range searches {
+		filter := &entities.WBGetCardListRequestFilter{TextSearch: search, WithPhoto: &withPhoto}
+		err := wbs.wbClient.ListCards(ctx, apiKey, filter, 0, func(cards []entities.WBCardDefinition) (bool, error) {
+			for _, card := range cards {
+				for _, size := range card.Sizes {
+					for _, sku := range size.Skus {
+						known[sku] = true
+					}
+				}
 			}
+			return true, nil
+		})
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_get_card_list").Inc()
+			return nil, fmt.Errorf("failed to search WB cards for %s: %w", search, err)
 		}
+	}
+	return known, nil
+}
+
+// SetStocks sets the stocks of sizes, identified by their barcodes, in a seller warehouse.
+func (wbs *WbService) SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error {
+	if err := wbs.stocksClient.SetStocks(ctx, apiKey, warehouseID, stocks); err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_stocks").Inc()
+		return fmt.Errorf("failed to set WB stocks in warehouse %d: %w", warehouseID, err)
+	}
+	return nil
+}
 
-		if foundNmID != 0 {
-			break // Found nmID, exit retry loop
+// cardWBPrice returns the price of the card for WB, which sets a single price per nmID. Sizes are expected
+// to share it, the highest one is used otherwise. 0 means no price is given.
+func cardWBPrice(card *entities.ProductCard) int {
+	var price int
+	for _, s := range card.Sizes {
+		sizePrice := s.Price
+		if s.WbPrice != nil {
+			sizePrice = int(*s.WbPrice)
+		}
+		if price != 0 && sizePrice != price {
+			log.Printf("Sizes of vendor code %s have different WB prices, using the highest one", card.VendorCode)
 		}
+		if sizePrice > price {
+			price = sizePrice
+		}
+	}
+	return price
+}
+
+// setPrice sets the price and discount of job on the card with nmID and waits for WB to process it.
+func (wbs *WbService) setPrice(ctx context.Context, job *wbMediaJob, nmID int) error {
+	if job.price == 0 {
+		return nil
+	}
+	task, uploadID, err := wbs.UpdatePrices(ctx, job.apiKey, []entities.WBPriceGood{{NmID: nmID, Price: job.price, Discount: job.discount}})
+	switch {
+	case err != nil:
+		return fmt.Errorf("failed to set WB price of nmID %d: %w", nmID, err)
+	case task == nil:
+		log.Printf("WB price upload %d for nmID %d is still being processed", uploadID, nmID)
+	case task.Status != entities.WBPriceTaskProcessed:
+		return fmt.Errorf("WB price upload %d for nmID %d finished with status %d: %s", uploadID, nmID, task.Status, task.ErrorText)
+	}
+	return nil
+}
+
+// UpdatePrices uploads prices and discounts and waits until WB processes them. The task is nil
+// if WB has not processed the upload within the configured timeout.
+func (wbs *WbService) UpdatePrices(ctx context.Context, apiKey string, goods []entities.WBPriceGood) (*entities.WBPriceTask, int64, error) {
+	uploadID, err := wbs.pricesClient.UploadPrices(ctx, apiKey, goods)
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_prices_upload").Inc()
+		return nil, 0, fmt.Errorf("failed to upload WB prices: %w", err)
+	}
 
-		if attempt < wbs.wbApiGetCardListMaxAttempts {
-			log.Printf("Card with vendor code %s not found in attempt %d. Retrying in %v...", vendorCode, attempt, retryDelay)
-			time.Sleep(retryDelay)
+	deadline := time.Now().Add(wbs.pricesOpts.Timeout)
+	for {
+		task, err := wbs.pricesClient.GetPriceTask(ctx, apiKey, uploadID)
+		if err != nil {
+			metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_prices_task").Inc()
+			return nil, uploadID, fmt.Errorf("failed to get WB price upload %d: %w", uploadID, err)
+		}
+		if task != nil || !time.Now().Before(deadline) {
+			return task, uploadID, nil
+		}
+
+		timer := time.NewTimer(wbs.pricesOpts.PollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, uploadID, ctx.Err()
 		}
 	}
+}
 
-	if foundNmID == 0 {
-		log.Printf("Failed to find nmID for vendor code %s after %d attempts.", vendorCode, wbs.wbApiGetCardListMaxAttempts)
-		return nil, nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("card with vendor code '%s' not found on Wildberries after %d attempts", vendorCode, wbs.wbApiGetCardListMaxAttempts))
+// GetPrices returns the prices of the sizes of the card with nmID, nil if WB has none.
+func (wbs *WbService) GetPrices(ctx context.Context, apiKey string, nmID int) (*entities.WBGoodPrices, error) {
+	prices, err := wbs.pricesClient.GetGoodPrices(ctx, apiKey, nmID)
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_goods_prices").Inc()
+		return nil, fmt.Errorf("failed to get WB prices of nmID %d: %w", nmID, err)
 	}
+	return prices, nil
+}
+
+// uploadMedia uploads the files and saves the links of job to the card with nmID.
+func (wbs *WbService) uploadMedia(ctx context.Context, job *wbMediaJob, foundNmID int) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse) {
+	var protoMediaUploadResponses []*entities.WbMediaUploadIndividualResponse
+	var protoMediaSaveResponse *entities.WbMediaSaveByLinksResponse
+	apiKey := job.apiKey
+	linksToSave := job.links
 
 	// Handle file uploads
-	if len(req.GetWbMediaToUploadFiles()) > 0 {
-		log.Printf("Attempting to upload %d media files to Wildberries for nmID %d.", len(req.GetWbMediaToUploadFiles()), foundNmID)
-		clientMediaFiles := make([]entities.WBClientMediaFile, len(req.WbMediaToUploadFiles))
-		for i, f := range req.WbMediaToUploadFiles {
-			metrics.AppWBMediaOperationsTotal.WithLabelValues("upload_file").Inc()
-			clientMediaFiles[i] = entities.WBClientMediaFile{
-				Filename:    f.Filename,
-				Content:     f.Content,
-				PhotoNumber: f.PhotoNumber,
-			}
-		}
+	if files := len(job.files) + len(job.storedFiles); files > 0 {
+		log.Printf("Attempting to upload %d media files to Wildberries for nmID %d.", files, foundNmID)
+		metrics.AppWBMediaOperationsTotal.WithLabelValues("upload_file").Add(float64(files))
 
-		uploadResults, err := wbs.wbClient.UploadMediaFiles(ctx, apiKey, fmt.Sprintf("%d", foundNmID), clientMediaFiles)
+		uploadResults, err := wbs.uploadJobFiles(ctx, job, fmt.Sprintf("%d", foundNmID))
 		if err != nil {
 			// This error is for the whole operation, e.g., context cancellation before starting.
 			metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("upload_file_batch").Inc() // A general error for the batch
@@ -127,6 +437,7 @@ func (wbs *WbService) AddMedia(ctx context.Context, req *entities.ProductCard) (
 
 		for _, res := range uploadResults {
 			protoResp := &entities.WbMediaUploadIndividualResponse{
+				VendorCode:  job.vendorCode,
 				PhotoNumber: res.PhotoNumber,
 			}
 			if res.Response != nil {
@@ -160,16 +471,16 @@ func (wbs *WbService) AddMedia(ctx context.Context, req *entities.ProductCard) (
 	}
 
 	// Handle save media by links
-	if len(req.GetWbMediaToSaveLinks()) > 0 {
+	if len(linksToSave) > 0 {
 		metrics.AppWBMediaOperationsTotal.WithLabelValues("save_by_link").Inc()
-		log.Printf("Attempting to save %d media links to Wildberries for nmID %d.", len(req.GetWbMediaToSaveLinks()), foundNmID)
+		log.Printf("Attempting to save %d media links to Wildberries for nmID %d.", len(linksToSave), foundNmID)
 		payload := entities.WBSaveMediaPayload{
 			NmID: foundNmID,
-			Data: req.WbMediaToSaveLinks,
+			Data: linksToSave,
 		}
 		saveResp, saveErr := wbs.wbClient.SaveMediaByLinks(ctx, apiKey, payload)
 
-		protoSaveResp := &entities.WbMediaSaveByLinksResponse{}
+		protoSaveResp := &entities.WbMediaSaveByLinksResponse{VendorCode: job.vendorCode}
 		if saveResp != nil {
 			if saveResp.Error {
 				metrics.AppWBMediaOperationErrorsTotal.WithLabelValues("save_by_link").Inc()
@@ -200,7 +511,7 @@ func (wbs *WbService) AddMedia(ctx context.Context, req *entities.ProductCard) (
 		}
 	}
 
-	return protoMediaUploadResponses, protoMediaSaveResponse, nil
+	return protoMediaUploadResponses, protoMediaSaveResponse
 }
 
 func (wbs *WbService) CreateCard(ctx context.Context, req *entities.ProductCard, aiGeneretedContent *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error) {
@@ -217,30 +528,14 @@ func (wbs *WbService) CreateCard(ctx context.Context, req *entities.ProductCard,
 		wbDimensions.WeightBrutto = req.Dimensions.WeightBrutto
 	}
 
-	// Prepare WB payload (common for all WB-related scenarios)
-	wbVariant := entities.WBVariant{
-		VendorCode:  req.VendorCode,
-		Brand:       req.Brand,
-		Title:       aiGeneretedContent.Title,       // Use Title from CardCraftAiAPIResponse
-		Description: aiGeneretedContent.Description, // Use Description from CardCraftAiAPIResponse
-		Dimensions:  wbDimensions,
-		Sizes:       make([]entities.WBSize, len(req.Sizes)),
-	}
-	for i, s := range req.Sizes {
-		// Determine price for WildBerries - prefer WB-specific price, fallback to general price
-		var price int
-		if s.WbPrice != nil {
-			price = int(*s.WbPrice)
-		} else {
-			price = s.Price
-		}
-
-		wbVariant.Sizes[i] = entities.WBSize{
-			TechSize: s.TechSize,
-			WbSize:   s.WbSize,
-			Price:    price,
-			Skus:     s.Skus,
+	// Prepare WB payload (common for all WB-related scenarios), one variant per color of the product
+	var wbVariants []entities.WBVariant
+	for _, card := range req.ExpandVariants() {
+		content := aiGeneretedContent
+		if len(req.Variants) > 0 {
+			content = entities.DefaultWbContentConstraints().VariantContent(aiGeneretedContent, card.Color)
 		}
+		wbVariants = append(wbVariants, buildWBVariant(card, content, wbDimensions))
 	}
 
 	var subjectIDValue int32
@@ -254,17 +549,28 @@ func (wbs *WbService) CreateCard(ctx context.Context, req *entities.ProductCard,
 	}
 	wbPayload := entities.WBCardUploadPayload{entities.WBCardRequestItem{
 		SubjectID: subjectIDValue,
-		Variants:  []entities.WBVariant{wbVariant},
+		Variants:  wbVariants,
 	}}
+	// With an imtID the product becomes a new variant of the existing card group instead of a separate card
+	var wbAddPayload *entities.WBCardAddPayload
+	if req.ImtID != 0 {
+		wbAddPayload = &entities.WBCardAddPayload{ImtID: req.ImtID, CardsToAdd: wbVariants}
+	}
 
 	// Determine if an actual API call to Wildberries will be attempted
-	attemptAPICall := req.GetWb() && req.GetWbApiKey() != ""
+	attemptAPICall := req.GetWb() && !req.IsDryRun() && req.GetWbApiKey() != ""
 	wbRequestAttempted = &attemptAPICall
 
 	if attemptAPICall {
 		// Scenario: wb=true AND API key is provided. Make the API call.
 		log.Printf("Attempting to upload card to Wildberries with provided API key.")
-		wbResp, wbUploadErr := wbs.wbClient.UploadWBCard(ctx, wbPayload, req.GetWbApiKey())
+		var wbResp *entities.WBCardUploadResponse
+		var wbUploadErr error
+		if wbAddPayload != nil {
+			wbResp, wbUploadErr = wbs.wbClient.AddWBCardVariants(ctx, *wbAddPayload, req.GetWbApiKey())
+		} else {
+			wbResp, wbUploadErr = wbs.wbClient.UploadWBCard(ctx, wbPayload, req.GetWbApiKey())
+		}
 		var responseStringToStore string
 
 		if wbUploadErr != nil {
@@ -316,16 +622,22 @@ func (wbs *WbService) CreateCard(ctx context.Context, req *entities.ProductCard,
 		wbApiResponseJSON = &responseStringToStore
 	} else {
 		// Scenario: API call will NOT be made.
-		// This happens if wb=false, OR if wb=true but no API key is provided.
+		// This happens if wb=false, in dry-run mode, OR if wb=true but no API key is provided.
 		// In this case, populate wb_prepared_request_json.
 
-		if req.GetWb() { // wb=true, but API key was empty (handled by !attemptAPICall)
+		if req.GetWb() && req.IsDryRun() {
+			log.Printf("wb=true in dry-run mode. Populating wb_prepared_request_json without sending it.")
+		} else if req.GetWb() { // wb=true, but API key was empty (handled by !attemptAPICall)
 			log.Printf("wb=true, but API key not provided. Populating wb_prepared_request_json.")
 		} else { // wb=false
 			log.Printf("wb=false. Populating wb_prepared_request_json.")
 		}
 
-		preparedBytes, err := json.Marshal(wbPayload)
+		var preparedPayload interface{} = wbPayload
+		if wbAddPayload != nil {
+			preparedPayload = wbAddPayload
+		}
+		preparedBytes, err := json.Marshal(preparedPayload)
 		if err != nil {
 			log.Printf("Error marshalling WB prepared request: %v", err)
 			errMsg := fmt.Sprintf("{\"error\":true,\"errorText\":\"Failed to marshal prepared WB request: %s\"}", err.Error())
@@ -341,3 +653,181 @@ func (wbs *WbService) CreateCard(ctx context.Context, req *entities.ProductCard,
 
 	return wbApiResponseJSON, wbPreparedRequestJSON, wbRequestAttempted, nil
 }
+
+// GenerateMissingBarcodes generates a WB barcode for every size of req without SKUs and returns them. req is left
+// as is, the barcodes are assigned with ProductCard.WithBarcodes, so that the same barcode is sent to both marketplaces.
+func (wbs *WbService) GenerateMissingBarcodes(ctx context.Context, req *entities.ProductCard) ([]*entities.GeneratedBarcodes, error) {
+	var generated []*entities.GeneratedBarcodes
+	for _, card := range req.ExpandVariants() {
+		for _, size := range card.Sizes {
+			if len(size.Skus) == 0 {
+				generated = append(generated, &entities.GeneratedBarcodes{
+					Marketplace: entities.MarketplaceWB,
+					VendorCode:  card.VendorCode,
+					TechSize:    size.TechSize,
+				})
+			}
+		}
+	}
+	if len(generated) == 0 {
+		return nil, nil
+	}
+
+	log.Printf("Generating %d WB barcodes for sizes without SKUs", len(generated))
+	barcodes, err := wbs.wbClient.GenerateBarcodes(ctx, req.GetWbApiKey(), len(generated))
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_barcodes").Inc()
+		return nil, fmt.Errorf("failed to generate WB barcodes: %w", err)
+	}
+	if len(barcodes) < len(generated) {
+		return nil, fmt.Errorf("WB generated %d barcodes, %d were requested", len(barcodes), len(generated))
+	}
+	for i, g := range generated {
+		g.Barcodes = []string{barcodes[i]}
+	}
+	return generated, nil
+}
+
+// buildWBVariant prepares the WB variant of card with the generated content.
+func buildWBVariant(card *entities.ProductCard, aiGeneretedContent *entities.CardCraftAiGeneratedContent, wbDimensions entities.WBDimensions) entities.WBVariant {
+	wbVariant := entities.WBVariant{
+		VendorCode:  card.VendorCode,
+		Brand:       card.Brand,
+		Title:       aiGeneretedContent.Title,       // Use Title from CardCraftAiAPIResponse
+		Description: aiGeneretedContent.Description, // Use Description from CardCraftAiAPIResponse
+		Dimensions:  wbDimensions,
+		Sizes:       make([]entities.WBSize, len(card.Sizes)),
+	}
+	for i, s := range card.Sizes {
+		// Determine price for WildBerries - prefer WB-specific price, fallback to general price
+		var price int
+		if s.WbPrice != nil {
+			price = int(*s.WbPrice)
+		} else {
+			price = s.Price
+		}
+
+		wbVariant.Sizes[i] = entities.WBSize{
+			TechSize: s.TechSize,
+			WbSize:   s.WbSize,
+			Price:    price,
+			Skus:     s.Skus,
+		}
+	}
+	if card.Color != "" {
+		wbVariant.Characteristics = append(wbVariant.Characteristics, entities.WBCharacteristic{
+			ID:    entities.WBColorCharacteristicID,
+			Value: []string{card.Color},
+		})
+	}
+	wbVariant.Characteristics = append(wbVariant.Characteristics, card.WbCharacteristics...)
+	return wbVariant
+}
+
+// ConvertCharacteristics converts characteristics of another marketplace to the characteristics of the WB subject
+// with the same name. Numeric characteristics take the first value that is a number, list characteristics at most
+// as many values as WB allows. The color is filled from the card and never converted. The names of
+// characteristics that could not be converted are returned as unmapped.
+func (wbs *WbService) ConvertCharacteristics(ctx context.Context, apiKey string, subjectID int, characteristics []entities.ProductCharacteristic) ([]entities.WBCharacteristic, []string, error) {
+	subjectCharacteristics, err := wbs.wbClient.GetSubjectCharacteristics(ctx, apiKey, subjectID)
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_subject_charcs").Inc()
+		return nil, nil, fmt.Errorf("failed to get characteristics of WB subject %d: %w", subjectID, err)
+	}
+	byName := make(map[string]entities.WBSubjectCharacteristic, len(subjectCharacteristics))
+	for _, c := range subjectCharacteristics {
+		if c.CharcID != entities.WBColorCharacteristicID {
+			byName[strings.ToLower(strings.TrimSpace(c.Name))] = c
+		}
+	}
+
+	var converted []entities.WBCharacteristic
+	var unmapped []string
+	for _, c := range characteristics {
+		target, ok := byName[strings.ToLower(strings.TrimSpace(c.Name))]
+		if !ok || len(c.Values) == 0 {
+			unmapped = append(unmapped, c.Name)
+			continue
+		}
+		switch target.CharcType {
+		case entities.WBCharcTypeNumber:
+			number, err := strconv.ParseFloat(strings.Replace(c.Values[0], ",", ".", 1), 64)
+			if err != nil {
+				unmapped = append(unmapped, c.Name)
+				continue
+			}
+			converted = append(converted, entities.WBCharacteristic{ID: target.CharcID, Value: number})
+		case entities.WBCharcTypeStrings:
+			values := c.Values
+			if target.MaxCount > 0 && len(values) > target.MaxCount {
+				values = values[:target.MaxCount]
+			}
+			converted = append(converted, entities.WBCharacteristic{ID: target.CharcID, Value: values})
+		default:
+			unmapped = append(unmapped, c.Name)
+		}
+	}
+	return converted, unmapped, nil
+}
+
+// ListCards streams all cards of the seller matching filter to handle page by page.
+func (wbs *WbService) ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error {
+	if err := wbs.wbClient.ListCards(ctx, apiKey, filter, pageSize, handle); err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_get_card_list").Inc()
+		return err
+	}
+	return nil
+}
+
+// GetCard returns the card of the seller with nmID, ErrWBCardNotFound if there is none.
+func (wbs *WbService) GetCard(ctx context.Context, apiKey string, nmID int) (*entities.WBCardDefinition, error) {
+	withPhoto := -1
+	filter := &entities.WBGetCardListRequestFilter{TextSearch: strconv.Itoa(nmID), WithPhoto: &withPhoto}
+
+	var found *entities.WBCardDefinition
+	err := wbs.wbClient.ListCards(ctx, apiKey, filter, 0, func(cards []entities.WBCardDefinition) (bool, error) {
+		for i := range cards {
+			// textSearch also matches vendor codes and titles containing the number
+			if cards[i].NmID == nmID {
+				found = &cards[i]
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_get_card_list").Inc()
+		return nil, fmt.Errorf("failed to get card list from WB for nmID %d: %w", nmID, err)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: nmID %d", entities.ErrWBCardNotFound, nmID)
+	}
+	return found, nil
+}
+
+// findNmID looks the card up by vendor code through all pages of the text search
+// and returns 0 if WB has no such card yet.
+func (wbs *WbService) findNmID(ctx context.Context, apiKey, vendorCode string) (int, error) {
+	withPhoto := -1 // All cards, the card may already have photos from an earlier attempt
+	filter := &entities.WBGetCardListRequestFilter{TextSearch: vendorCode, WithPhoto: &withPhoto}
+
+	var foundNmID int
+	err := wbs.wbClient.ListCards(ctx, apiKey, filter, 0, func(cards []entities.WBCardDefinition) (bool, error) {
+		for _, card := range cards {
+			// textSearch also matches titles and partial vendor codes
+			if card.VendorCode == vendorCode {
+				foundNmID = card.NmID
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		metrics.AppExternalAPIErrorsTotal.WithLabelValues("wb_get_card_list").Inc()
+		return 0, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to get card list from WB for vendor code %s: %w", vendorCode, err))
+	}
+	if foundNmID != 0 {
+		log.Printf("Found nmID %d for vendor code %s", foundNmID, vendorCode)
+	}
+	return foundNmID, nil
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWbService_CreateCard_Variants(t *testing.T) {
+	wbs := NewWbService(NmIDResolverOptions{Timeout: time.Minute}, WbPricesOptions{}, nil, nil, nil, nil)
+	dryRun := true
+	subjectID := int32(105)
+	wbPrice := int32(2500)
+	req := &entities.ProductCard{
+		Wb:     true,
+		DryRun: &dryRun,
+		Brand:  "Brand",
+		Variants: []*entities.ProductVariant{
+			{VendorCode: "VC-RED", Color: "красный", Sizes: []*entities.WBSize{{TechSize: "42", Price: 2000, Skus: []string{"111"}}}},
+			{VendorCode: "VC-BLUE", Color: "синий", Sizes: []*entities.WBSize{{TechSize: "42", Price: 2000, WbPrice: &wbPrice, Skus: []string{"222"}}}},
+		},
+	}
+	content := &entities.CardCraftAiGeneratedContent{Title: "Кроссовки", Description: "Описание", SubjectID: &subjectID}
+
+	_, prepared, attempted, err := wbs.CreateCard(context.Background(), req, content)
+	if err != nil {
+		t.Fatalf("CreateCard returned unexpected error: %v", err)
+	}
+	if attempted == nil || *attempted {
+		t.Error("Expected no WB call in dry-run mode")
+	}
+
+	var payload entities.WBCardUploadPayload
+	if err := json.Unmarshal([]byte(*prepared), &payload); err != nil {
+		t.Fatalf("Failed to decode prepared request: %v", err)
+	}
+	if len(payload) != 1 || len(payload[0].Variants) != 2 {
+		t.Fatalf("Expected one card with two variants, got %+v", payload)
+	}
+	blue := payload[0].Variants[1]
+	if blue.VendorCode != "VC-BLUE" || blue.Title != "Кроссовки, синий" || blue.Sizes[0].Price != 2500 {
+		t.Errorf("Unexpected second variant: %+v", blue)
+	}
+	if len(blue.Characteristics) != 1 || blue.Characteristics[0].ID != entities.WBColorCharacteristicID {
+		t.Errorf("Expected color characteristic, got %+v", blue.Characteristics)
+	}
+}
+
+// fakeWBCharcsClient serves subject characteristics; the other client methods are not used.
+type fakeWBCharcsClient struct {
+	wbClient
+}
+
+func (f *fakeWBCharcsClient) GetSubjectCharacteristics(ctx context.Context, apiKey string, subjectID int) ([]entities.WBSubjectCharacteristic, error) {
+	return []entities.WBSubjectCharacteristic{
+		{CharcID: entities.WBColorCharacteristicID, Name: "Цвет", CharcType: entities.WBCharcTypeStrings},
+		{CharcID: 12, Name: "Состав", MaxCount: 1, CharcType: entities.WBCharcTypeStrings},
+		{CharcID: 13, Name: "Длина изделия", UnitName: "см", CharcType: entities.WBCharcTypeNumber},
+		{CharcID: 14, Name: "Вес", CharcType: entities.WBCharcTypeNumber},
+	}, nil
+}
+
+func TestWbService_ConvertCharacteristics(t *testing.T) {
+	wbs := NewWbService(NmIDResolverOptions{Timeout: time.Minute}, WbPricesOptions{}, &fakeWBCharcsClient{}, nil, nil, nil)
+
+	converted, unmapped, err := wbs.ConvertCharacteristics(context.Background(), "api-key", 105, []entities.ProductCharacteristic{
+		{Name: "состав", Values: []string{"Хлопок", "Лён"}},
+		{Name: "Длина изделия", Values: []string{"70,5"}},
+		{Name: "Вес", Values: []string{"легкий"}},
+		{Name: "Цвет", Values: []string{"черный"}},
+		{Name: "Сезон", Values: []string{"лето"}},
+	})
+	if err != nil {
+		t.Fatalf("ConvertCharacteristics returned unexpected error: %v", err)
+	}
+
+	if len(converted) != 2 {
+		t.Fatalf("Expected 2 characteristics, got %+v", converted)
+	}
+	if values, ok := converted[0].Value.([]string); converted[0].ID != 12 || !ok || len(values) != 1 || values[0] != "Хлопок" {
+		t.Errorf("Expected values cut to the WB maximum, got %+v", converted[0])
+	}
+	if converted[1].ID != 13 || converted[1].Value != 70.5 {
+		t.Errorf("Expected a number, got %+v", converted[1])
+	}
+
+	// The color is set from the card
+	want := []string{"Вес", "Цвет", "Сезон"}
+	if len(unmapped) != len(want) {
+		t.Fatalf("Expected unmapped %v, got %v", want, unmapped)
+	}
+	for i := range want {
+		if unmapped[i] != want[i] {
+			t.Errorf("Expected unmapped %v, got %v", want, unmapped)
+		}
+	}
+}
+
+// fakeWBMediaClient lists a created card and records the saved media links.
+type fakeWBMediaClient struct {
+	wbClient
+	saved *entities.WBSaveMediaPayload
+}
+
+func (f *fakeWBMediaClient) ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error {
+	_, err := handle([]entities.WBCardDefinition{{NmID: 123, VendorCode: filter.TextSearch}})
+	return err
+}
+
+func (f *fakeWBMediaClient) SaveMediaByLinks(ctx context.Context, apiKey string, payload entities.WBSaveMediaPayload) (*entities.WBMediaGenericResponse, error) {
+	f.saved = &payload
+	return &entities.WBMediaGenericResponse{}, nil
+}
+
+func TestWbService_AddMedia_SkipsOzonOnlyMedia(t *testing.T) {
+	client := &fakeWBMediaClient{}
+	wbs := NewWbService(NmIDResolverOptions{Timeout: time.Minute}, WbPricesOptions{}, client, nil, nil, nil)
+	req := &entities.ProductCard{
+		WbApiKey:   "api-key",
+		VendorCode: "VC-1",
+		MediaLinks: []*entities.MediaLink{
+			{URL: "https://files.example.com/photo.jpg", Kind: entities.MediaKindPhoto, Primary: true},
+			{URL: "https://files.example.com/video.mp4", Kind: entities.MediaKindVideo},
+			{URL: "https://files.example.com/cover.mp4", Kind: entities.MediaKindVideo, Primary: true},
+			{URL: "https://files.example.com/360.jpg", Kind: entities.MediaKindImage360},
+		},
+	}
+
+	if _, _, pending, err := wbs.AddMedia(context.Background(), req); err != nil || pending {
+		t.Fatalf("AddMedia returned pending %t, error %v", pending, err)
+	}
+	if client.saved == nil {
+		t.Fatal("Expected media links to be saved")
+	}
+	want := []string{"https://files.example.com/photo.jpg", "https://files.example.com/video.mp4"}
+	if len(client.saved.Data) != len(want) || client.saved.Data[0] != want[0] || client.saved.Data[1] != want[1] {
+		t.Errorf("Expected only the photo and the video to be saved, got %v", client.saved.Data)
+	}
+}
+
+// fakeWBBarcodesClient generates barcodes; the other client methods are not used.
+type fakeWBBarcodesClient struct {
+	wbClient
+}
+
+func (f *fakeWBBarcodesClient) GenerateBarcodes(ctx context.Context, apiKey string, count int) ([]string, error) {
+	barcodes := make([]string, count)
+	for i := range barcodes {
+		barcodes[i] = fmt.Sprintf("20000000000%d", i)
+	}
+	return barcodes, nil
+}
+
+func TestWbService_GenerateMissingBarcodes(t *testing.T) {
+	wbs := NewWbService(NmIDResolverOptions{Timeout: time.Minute}, WbPricesOptions{}, &fakeWBBarcodesClient{}, nil, nil, nil)
+	req := &entities.ProductCard{
+		WbApiKey: "key",
+		Variants: []*entities.ProductVariant{
+			{VendorCode: "VC-RED", Sizes: []*entities.WBSize{{TechSize: "S", Skus: []string{"111"}}, {TechSize: "M"}}},
+			{VendorCode: "VC-BLUE", Sizes: []*entities.WBSize{{TechSize: "S"}}},
+		},
+	}
+
+	generated, err := wbs.GenerateMissingBarcodes(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GenerateMissingBarcodes: %v", err)
+	}
+	if len(generated) != 2 || generated[0].VendorCode != "VC-RED" || generated[0].TechSize != "M" || generated[1].VendorCode != "VC-BLUE" {
+		t.Fatalf("Expected barcodes for the two sizes without SKUs only, got %+v", generated)
+	}
+	if len(req.Variants[0].Sizes[1].Skus) != 0 || len(req.Variants[1].Sizes[0].Skus) != 0 {
+		t.Error("Expected the request to be left as is")
+	}
+
+	card := req.WithBarcodes(generated)
+	if got := card.Variants[0].Sizes; got[0].Skus[0] != "111" || got[1].Skus[0] != generated[0].Barcodes[0] {
+		t.Errorf("Expected the seller's barcode to be kept and the generated one to be assigned, got %v and %v", got[0].Skus, got[1].Skus)
+	}
+	if card.Variants[1].Sizes[0].Skus[0] != generated[1].Barcodes[0] {
+		t.Errorf("Expected the generated barcode of VC-BLUE, got %v", card.Variants[1].Sizes[0].Skus)
+	}
+	if len(req.Variants[0].Sizes[1].Skus) != 0 {
+		t.Error("Expected WithBarcodes to leave the request as is")
+	}
+}
+
+// fakeWBPendingClient does not list the card yet.
+type fakeWBPendingClient struct {
+	wbClient
+}
+
+func (f *fakeWBPendingClient) ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error {
+	_, err := handle(nil)
+	return err
+}
+
+// fakeWBPendingMediaStorage keeps stored files in a map.
+type fakeWBPendingMediaStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (f *fakeWBPendingMediaStorage) SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := fmt.Sprintf("%d_%s", len(f.files), filename)
+	f.files[id] = content
+	return &entities.MediaFile{ID: id, Filename: filename, Size: int64(len(content))}, nil
+}
+
+func (f *fakeWBPendingMediaStorage) ReadMedia(ctx context.Context, mediaID string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.files[mediaID], nil
+}
+
+func (f *fakeWBPendingMediaStorage) DeleteMedia(ctx context.Context, mediaID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.files, mediaID)
+	return nil
+}
+
+func TestWbService_AddMedia_StoresFilesOfPendingCards(t *testing.T) {
+	storage := &fakeWBPendingMediaStorage{files: map[string][]byte{}}
+	wbs := NewWbService(NmIDResolverOptions{BaseDelay: time.Hour, Timeout: time.Hour}, WbPricesOptions{}, &fakeWBPendingClient{}, nil, nil, storage)
+	req := &entities.ProductCard{
+		WbApiKey:   "api-key",
+		VendorCode: "VC-1",
+		WbMediaToUploadFiles: []*entities.WBClientMediaFile{
+			{Filename: "photo.jpg", Content: []byte("jpeg data"), PhotoNumber: 1, Kind: entities.MediaKindPhoto},
+		},
+	}
+
+	if _, _, pending, err := wbs.AddMedia(context.Background(), req); err != nil || !pending {
+		t.Fatalf("AddMedia returned pending %t, error %v", pending, err)
+	}
+	storage.mu.Lock()
+	stored := len(storage.files)
+	storage.mu.Unlock()
+	if stored != 1 {
+		t.Fatalf("Expected the inline file to be stored while the card is pending, got %d files", stored)
+	}
+	wbs.nmIDResolver.mu.Lock()
+	job := wbs.nmIDResolver.pending["api-key"][0]
+	wbs.nmIDResolver.mu.Unlock()
+	if len(job.files) != 0 || len(job.storedFiles) != 1 {
+		t.Errorf("Expected the job to keep only the stored file, got %d inline and %d stored", len(job.files), len(job.storedFiles))
+	}
+
+	wbs.Stop()
+	if len(storage.files) != 0 {
+		t.Errorf("Expected the stored file to be deleted when the job is dropped, got %d files", len(storage.files))
+	}
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+)
+
+type wbCatalogStorage interface {
+	ListWBParentCategories(ctx context.Context) ([]entities.WBParentCategory, error)
+	SearchWBSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error)
+	SearchWBDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error)
+}
+
+const (
+	defaultCatalogPage = 50
+	maxCatalogPage     = 1000
+)
+
+// CatalogUsecase searches the local mirror of the WB category tree and directories.
+type CatalogUsecase struct {
+	storage wbCatalogStorage
+}
+
+func NewCatalogUsecase(storage wbCatalogStorage) *CatalogUsecase {
+	return &CatalogUsecase{storage: storage}
+}
+
+// ListParentCategories returns all WB parent categories.
+func (uc *CatalogUsecase) ListParentCategories(ctx context.Context) ([]entities.WBParentCategory, error) {
+	categories, err := uc.storage.ListWBParentCategories(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list WB parent categories: %w", err))
+	}
+	return categories, nil
+}
+
+// SearchSubjects returns the WB subjects whose name contains the query.
+func (uc *CatalogUsecase) SearchSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error) {
+	var err error
+	if filter.Limit, err = catalogPageLimit(filter.Limit, filter.Offset); err != nil {
+		return nil, err
+	}
+	subjects, err := uc.storage.SearchWBSubjects(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search WB subjects: %w", err))
+	}
+	return subjects, nil
+}
+
+// SearchDirectoryValues returns the values of the WB directory containing the query. TNVED codes are
+// searched within a subject.
+func (uc *CatalogUsecase) SearchDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error) {
+	switch filter.Directory {
+	case entities.WBDirectoryColors, entities.WBDirectoryKinds, entities.WBDirectoryCountries, entities.WBDirectorySeasons, entities.WBDirectoryVat:
+	case entities.WBDirectoryTnved:
+		if filter.SubjectID <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("subject_id is required for TNVED codes"))
+		}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("directory is required"))
+	}
+	var err error
+	if filter.Limit, err = catalogPageLimit(filter.Limit, filter.Offset); err != nil {
+		return nil, err
+	}
+	values, err := uc.storage.SearchWBDirectoryValues(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search WB directory %s: %w", filter.Directory, err))
+	}
+	return values, nil
+}
+
+// catalogPageLimit returns the page size of a search, defaultCatalogPage if unset.
+func catalogPageLimit(limit, offset int) (int, error) {
+	if limit < 0 || offset < 0 {
+		return 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("limit and offset must not be negative"))
+	}
+	if limit == 0 {
+		return defaultCatalogPage, nil
+	}
+	if limit > maxCatalogPage {
+		return maxCatalogPage, nil
+	}
+	return limit, nil
+}
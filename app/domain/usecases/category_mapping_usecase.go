@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+)
+
+type categoryMappingStorage interface {
+	ListCategoryMappings(ctx context.Context, filter entities.CategoryMappingFilter) ([]entities.CategoryMapping, error)
+	OverrideCategoryMapping(ctx context.Context, mapping *entities.CategoryMapping) error
+	ResetCategoryMappingOverride(ctx context.Context, subjectID int32) error
+}
+
+// maxCategoryMappingsPage limits the number of category mappings listed at once
+const maxCategoryMappingsPage = 500
+
+// CategoryMappingUsecase lists the mappings between WB subjects and Ozon categories and lets content managers
+// override them. The mappings are shared by all accounts, so only the editor API keys may override them.
+type CategoryMappingUsecase struct {
+	storage       categoryMappingStorage
+	editorApiKeys map[string]bool
+}
+
+func NewCategoryMappingUsecase(storage categoryMappingStorage, editorApiKeys []string) *CategoryMappingUsecase {
+	editors := make(map[string]bool, len(editorApiKeys))
+	for _, apiKey := range editorApiKeys {
+		editors[apiKey] = true
+	}
+	return &CategoryMappingUsecase{storage: storage, editorApiKeys: editors}
+}
+
+// ListCategoryMappings returns the mappings matching the filter, at most maxCategoryMappingsPage at once.
+func (uc *CategoryMappingUsecase) ListCategoryMappings(ctx context.Context, filter entities.CategoryMappingFilter) ([]entities.CategoryMapping, error) {
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("limit and offset must not be negative"))
+	}
+	if filter.Limit == 0 || filter.Limit > maxCategoryMappingsPage {
+		filter.Limit = maxCategoryMappingsPage
+	}
+	mappings, err := uc.storage.ListCategoryMappings(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list category mappings: %w", err))
+	}
+	return mappings, nil
+}
+
+// OverrideCategoryMapping makes the pair the mapping of its WB subject for all future cards, or with reset
+// removes the override of the WB subject, so the pairs resolved by CardCraftAI are used again.
+func (uc *CategoryMappingUsecase) OverrideCategoryMapping(ctx context.Context, apiKey string, mapping *entities.CategoryMapping, reset bool) error {
+	if !uc.editorApiKeys[apiKey] {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("the API key may not override category mappings"))
+	}
+	if mapping.WbSubjectID <= 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_subject_id is required"))
+	}
+
+	if reset {
+		if err := uc.storage.ResetCategoryMappingOverride(ctx, mapping.WbSubjectID); err != nil {
+			if errors.Is(err, entities.ErrCategoryMappingNotFound) {
+				return connect.NewError(connect.CodeNotFound, fmt.Errorf("WB subject %d has no override", mapping.WbSubjectID))
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to reset category mapping: %w", err))
+		}
+		return nil
+	}
+
+	if mapping.OzonDescriptionCategoryID <= 0 || mapping.OzonTypeID <= 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_description_category_id and ozon_type_id are required"))
+	}
+	if err := uc.storage.OverrideCategoryMapping(ctx, mapping); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to override category mapping: %w", err))
+	}
+	return nil
+}
@@ -2,27 +2,58 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"api/app/domain/entities"
 	"api/metrics" // For accessing Prometheus metrics
 	"log"
+
+	"connectrpc.com/connect"
 )
 
 type wbService interface {
 	CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error)
-	AddMedia(ctx context.Context, req *entities.ProductCard) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse, error)
+	AddMedia(ctx context.Context, req *entities.ProductCard) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse, bool, error)
+	GenerateMissingBarcodes(ctx context.Context, req *entities.ProductCard) ([]*entities.GeneratedBarcodes, error)
 }
 
 type ozonService interface {
-	CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *bool, error)
+	CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error)
+	WaitForOffers(ctx context.Context, req *entities.ProductCard) ([]entities.OzonProductInfo, error)
+	AssignBarcodes(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) ([]*entities.GeneratedBarcodes, error)
+	SetInitialStock(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) error
 }
 
 type cardCraftAiService interface {
-	GetCardContent(ctx context.Context, cardCraftAiAPIRequest entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error)
+	GetCardContentVariants(ctx context.Context, apiKey string, cardCraftAiAPIRequest entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error)
 }
 
 type tokenBillingService interface {
-	UpdateBalanceForSession(ctx context.Context, apiKey, sessionID string) error
+	ChargeForContent(ctx context.Context, apiKey string, content *entities.CardCraftAiGeneratedContent) (int, error)
+}
+
+type variantPublicationStorage interface {
+	MarkContentVariantPublished(ctx context.Context, id string, publications []entities.ContentVariantPublication) error
+}
+
+type contentVariantStorage interface {
+	variantPublicationStorage
+	SaveContentVariants(ctx context.Context, variants []*entities.ContentVariant) error
+}
+
+type contentValidator interface {
+	Validate(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) []entities.ContentViolation
+	Fix(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation)
+}
+
+type attributeValidator interface {
+	FixAttributes(ctx context.Context, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation)
+}
+
+type mediaResolver interface {
+	ResolveMedia(ctx context.Context, refs []*entities.MediaReference) ([]*entities.MediaLink, error)
 }
 
 type CreateCardUsecase struct {
@@ -30,14 +61,24 @@ type CreateCardUsecase struct {
 	wbService           wbService
 	ozonService         ozonService
 	tokenBillingService tokenBillingService
+	mediaResolver       mediaResolver
+	variantStorage      contentVariantStorage
+	contentValidator    contentValidator
+	attributeValidator  attributeValidator
+	dryRunByDefault     bool
 }
 
-func NewCreateCardUsecase(cardCraftAiService cardCraftAiService, wbService wbService, ozonService ozonService, tokenBillingService tokenBillingService) *CreateCardUsecase {
+func NewCreateCardUsecase(cardCraftAiService cardCraftAiService, wbService wbService, ozonService ozonService, tokenBillingService tokenBillingService, mediaResolver mediaResolver, variantStorage contentVariantStorage, contentValidator contentValidator, attributeValidator attributeValidator, dryRunByDefault bool) *CreateCardUsecase {
 	return &CreateCardUsecase{
 		cardCraftAiService:  cardCraftAiService,
 		wbService:           wbService,
 		ozonService:         ozonService,
 		tokenBillingService: tokenBillingService,
+		mediaResolver:       mediaResolver,
+		variantStorage:      variantStorage,
+		contentValidator:    contentValidator,
+		attributeValidator:  attributeValidator,
+		dryRunByDefault:     dryRunByDefault,
 	}
 }
 
@@ -45,20 +86,215 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 
 	var createProductCardResult entities.CreateProductCardResult
 
+	if req.DryRun == nil {
+		req.DryRun = &uc.dryRunByDefault
+	}
+
+	// Resolve media uploaded beforehand into public links before spending tokens on content generation.
+	// Both marketplaces fetch media by link, so the referenced files are never loaded into memory.
+	if err := resolveCardMedia(ctx, uc.mediaResolver, &req); err != nil {
+		if errors.Is(err, entities.ErrMediaNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve media: %w", err))
+	}
+
 	// Generate content for the card (sujects and optionaly seo content: title, description, attributes)
-	cardCraftAiGeneratedContent, err := uc.cardCraftAiService.GetCardContent(ctx, req)
+	variantsCount := req.ContentVariants
+	if variantsCount < 1 {
+		variantsCount = 1
+	}
+	contents, err := uc.cardCraftAiService.GetCardContentVariants(ctx, apiKey, req, variantsCount)
 	if err != nil {
 		metrics.AppExternalAPIErrorsTotal.WithLabelValues("card_craft_ai_content").Inc()
 		return nil, err
 	}
+
+	// Regenerate the published variant once if it breaks the constraints of the target marketplaces
+	var regenerationCost int
+	if req.ContentValidation == entities.ContentValidationRegenerate {
+		contents[0], regenerationCost = uc.regenerateInvalidContent(ctx, apiKey, req, contents[0])
+	}
+	cardCraftAiGeneratedContent := contents[0]
 	createProductCardResult.CardCraftAiGeneratedContent = cardCraftAiGeneratedContent
 
-	if err := uc.tokenBillingService.UpdateBalanceForSession(ctx, apiKey, cardCraftAiGeneratedContent.SessionID); err != nil {
-		log.Printf("failed to update balance: %v", err)
+	variants := make([]*entities.ContentVariant, len(contents))
+	inlineMediaFiles := len(req.WbMediaToUploadFiles)
+	for _, v := range req.Variants {
+		inlineMediaFiles += len(v.WbMediaToUploadFiles)
 	}
+	for i, content := range contents {
+		cost, err := uc.tokenBillingService.ChargeForContent(ctx, apiKey, content)
+		if err != nil {
+			log.Printf("failed to update balance: %v", err)
+		}
+		if i == 0 {
+			cost += regenerationCost
+		}
+		variants[i] = &entities.ContentVariant{
+			Index:       i,
+			ApiKey:      apiKey,
+			Content:     *content,
+			ProductCard: publishableProductCard(req),
+			Cost:        cost,
+
+			InlineMediaFiles: inlineMediaFiles,
+		}
+	}
+	// Store the variants, so that any of them can be published later without regenerating
+	if err := uc.variantStorage.SaveContentVariants(ctx, variants); err != nil {
+		log.Printf("failed to store content variants: %v", err)
+	}
+	createProductCardResult.ContentVariants = variants
 
 	metrics.AppCardCreationsTotal.Inc() // Core content generation successful
 
+	wbContent, ozonContent, violations, err := prepareMarketplaceContent(ctx, uc.contentValidator, uc.attributeValidator, &req, cardCraftAiGeneratedContent, req.ContentValidation)
+	createProductCardResult.ContentViolations = violations
+	if err != nil {
+		return nil, err
+	}
+
+	published := publishCard(ctx, uc.wbService, uc.ozonService, &req, wbContent, ozonContent, &createProductCardResult)
+	markVariantPublished(ctx, uc.variantStorage, variants[0], &req, published)
+
+	return &createProductCardResult, nil
+}
+
+// resolveCardMedia resolves the media references of the card and of its variants into public links.
+func resolveCardMedia(ctx context.Context, resolver mediaResolver, req *entities.ProductCard) error {
+	req.MediaLinks = nil
+	if len(req.GetMedia()) > 0 {
+		mediaLinks, err := resolver.ResolveMedia(ctx, req.GetMedia())
+		if err != nil {
+			return err
+		}
+		req.MediaLinks = mediaLinks
+	}
+	for _, variant := range req.Variants {
+		variant.MediaLinks = nil
+		if len(variant.Media) == 0 {
+			continue
+		}
+		mediaLinks, err := resolver.ResolveMedia(ctx, variant.Media)
+		if err != nil {
+			return fmt.Errorf("variant %s: %w", variant.VendorCode, err)
+		}
+		variant.MediaLinks = mediaLinks
+	}
+	return nil
+}
+
+// markVariantPublished records the cards the content variant was created with on the given marketplaces.
+func markVariantPublished(ctx context.Context, storage variantPublicationStorage, variant *entities.ContentVariant, req *entities.ProductCard, marketplaces []entities.Marketplace) {
+	if variant.ID == "" {
+		return // The variant was not stored
+	}
+	var publications []entities.ContentVariantPublication
+	for _, marketplace := range marketplaces {
+		for _, card := range req.ExpandVariants() {
+			publications = append(publications, entities.ContentVariantPublication{Marketplace: marketplace, VendorCode: card.VendorCode})
+		}
+	}
+	if len(publications) == 0 {
+		return
+	}
+	if err := storage.MarkContentVariantPublished(ctx, variant.ID, publications); err != nil {
+		log.Printf("failed to mark content variant %s as published: %v", variant.ID, err)
+	}
+}
+
+// regenerateInvalidContent asks the generator for new content when the given one breaks the constraints
+// of the requested marketplaces or has WB attribute values missing from the WB directories, passing the
+// violations as feedback. The discarded content is still billed
+// and its cost is returned. The original content is kept if regeneration fails.
+func (uc *CreateCardUsecase) regenerateInvalidContent(ctx context.Context, apiKey string, req entities.ProductCard, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, int) {
+	var violations []entities.ContentViolation
+	for _, marketplace := range targetMarketplaces(&req) {
+		violations = append(violations, uc.contentValidator.Validate(marketplace, req.Brand, content)...)
+		if marketplace == entities.MarketplaceWB {
+			_, attributeViolations := uc.attributeValidator.FixAttributes(ctx, content)
+			violations = append(violations, attributeViolations...)
+		}
+	}
+	if len(violations) == 0 {
+		return content, 0
+	}
+
+	feedback := make([]string, len(violations))
+	for i, v := range violations {
+		feedback[i] = fmt.Sprintf("%s %s: %s", v.Marketplace, v.Field, v.Message)
+	}
+	log.Printf("Content breaks %d marketplace constraints, regenerating", len(violations))
+
+	req.ForceRegenerate = true
+	req.ContentFeedback = strings.Join(feedback, "; ")
+	req.SetCategoriesFrom(content)
+	regenerated, err := uc.cardCraftAiService.GetCardContentVariants(ctx, apiKey, req, 1)
+	if err != nil {
+		log.Printf("failed to regenerate content, keeping the original one: %v", err)
+		return content, 0
+	}
+
+	cost, err := uc.tokenBillingService.ChargeForContent(ctx, apiKey, content)
+	if err != nil {
+		log.Printf("failed to update balance: %v", err)
+	}
+	return regenerated[0], cost
+}
+
+// targetMarketplaces returns the marketplaces the card is published to.
+func targetMarketplaces(req *entities.ProductCard) []entities.Marketplace {
+	var marketplaces []entities.Marketplace
+	if req.GetWb() {
+		marketplaces = append(marketplaces, entities.MarketplaceWB)
+	}
+	if req.GetOzon() {
+		marketplaces = append(marketplaces, entities.MarketplaceOzon)
+	}
+	return marketplaces
+}
+
+// prepareMarketplaceContent adapts the content to the constraints of every target marketplace and checks the WB
+// attributes against the mirrored WB directories. In reject mode any violation fails with ContentValidationError before a marketplace is called;
+// otherwise violations are fixed and reported. Content for a marketplace that is not targeted is returned as is.
+func prepareMarketplaceContent(ctx context.Context, validator contentValidator, attributes attributeValidator, req *entities.ProductCard, content *entities.CardCraftAiGeneratedContent, mode entities.ContentValidationMode) (*entities.CardCraftAiGeneratedContent, *entities.CardCraftAiGeneratedContent, []entities.ContentViolation, error) {
+	wbContent, ozonContent := content, content
+	var violations []entities.ContentViolation
+	for _, marketplace := range targetMarketplaces(req) {
+		fixed, marketplaceViolations := validator.Fix(marketplace, req.Brand, content)
+		violations = append(violations, marketplaceViolations...)
+		switch marketplace {
+		case entities.MarketplaceWB:
+			var attributeViolations []entities.ContentViolation
+			wbContent, attributeViolations = attributes.FixAttributes(ctx, fixed)
+			violations = append(violations, attributeViolations...)
+		case entities.MarketplaceOzon:
+			ozonContent = fixed
+		}
+	}
+
+	var unfixable bool
+	for i := range violations {
+		if mode == entities.ContentValidationReject {
+			violations[i].Fixed = false
+		}
+		if !violations[i].Fixed {
+			unfixable = true
+		}
+	}
+	if unfixable {
+		return nil, nil, violations, connect.NewError(connect.CodeInvalidArgument, &entities.ContentValidationError{Violations: violations})
+	}
+	if len(violations) > 0 {
+		log.Printf("Fixed %d marketplace content constraint violations", len(violations))
+	}
+	return wbContent, ozonContent, violations, nil
+}
+
+// publishCard creates the card in WB and Ozon with the content prepared for each of them and adds WB media,
+// filling the marketplace fields of the result.
+func publishCard(ctx context.Context, wbService wbService, ozonService ozonService, req *entities.ProductCard, wbContent, ozonContent *entities.CardCraftAiGeneratedContent, result *entities.CreateProductCardResult) []entities.Marketplace {
 	// Create cards in WB and Ozon in parallel since they are independent
 	type wbResult struct {
 		apiResponseJSON     *string
@@ -68,9 +304,20 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 	}
 
 	type ozonResult struct {
-		apiResponseJSON  *string
-		requestAttempted *bool
-		err              error
+		apiResponseJSON     *string
+		preparedRequestJSON *string
+		requestAttempted    *bool
+		err                 error
+	}
+
+	// Barcodes generated by WB are sent to Ozon as well, so they are generated before the cards are created
+	if req.GetWb() && !req.IsDryRun() && req.GetWbApiKey() != "" {
+		generated, err := wbService.GenerateMissingBarcodes(ctx, req)
+		if err != nil {
+			log.Printf("Error generating WB barcodes, creating the card without them: %v", err)
+		}
+		result.GeneratedBarcodes = append(result.GeneratedBarcodes, generated...)
+		req = req.WithBarcodes(generated)
 	}
 
 	wbChan := make(chan wbResult, 1)
@@ -78,7 +325,7 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 
 	// Create card in Wildberries (parallel)
 	go func() {
-		wbApiResponseJSON, wbPreparedRequestJSON, wbRequestAttempted, wbErr := uc.wbService.CreateCard(ctx, &req, cardCraftAiGeneratedContent)
+		wbApiResponseJSON, wbPreparedRequestJSON, wbRequestAttempted, wbErr := wbService.CreateCard(ctx, req, wbContent)
 		wbChan <- wbResult{
 			apiResponseJSON:     wbApiResponseJSON,
 			preparedRequestJSON: wbPreparedRequestJSON,
@@ -92,7 +339,7 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 		log.Printf("Starting Ozon card creation for product: %s", req.ProductTitle)
 		log.Printf("Ozon enabled: %t, ClientID: %s, ApiKey length: %d", req.Ozon, req.OzonApiClientId, len(req.OzonApiKey))
 
-		ozonApiResponseJSON, ozonRequestAttempted, ozonErr := uc.ozonService.CreateCard(ctx, &req, cardCraftAiGeneratedContent)
+		ozonApiResponseJSON, ozonPreparedRequestJSON, ozonRequestAttempted, ozonErr := ozonService.CreateCard(ctx, req, ozonContent)
 
 		log.Printf("Ozon card creation completed - attempted: %v, error: %v", ozonRequestAttempted, ozonErr)
 		if ozonApiResponseJSON != nil {
@@ -102,9 +349,10 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 		}
 
 		ozonChan <- ozonResult{
-			apiResponseJSON:  ozonApiResponseJSON,
-			requestAttempted: ozonRequestAttempted,
-			err:              ozonErr,
+			apiResponseJSON:     ozonApiResponseJSON,
+			preparedRequestJSON: ozonPreparedRequestJSON,
+			requestAttempted:    ozonRequestAttempted,
+			err:                 ozonErr,
 		}
 	}()
 
@@ -113,13 +361,15 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 	ozonRes := <-ozonChan
 
 	// Set WB results
-	createProductCardResult.WbApiResponseJson = wbRes.apiResponseJSON
-	createProductCardResult.WbPreparedRequestJson = wbRes.preparedRequestJSON
-	createProductCardResult.WbRequestAttempted = wbRes.requestAttempted
+	result.WbApiResponseJson = wbRes.apiResponseJSON
+	result.WbPreparedRequestJson = wbRes.preparedRequestJSON
+	result.WbRequestAttempted = wbRes.requestAttempted
 
 	// Set Ozon results
-	createProductCardResult.OzonApiResponseJson = ozonRes.apiResponseJSON
-	createProductCardResult.OzonRequestAttempted = ozonRes.requestAttempted
+	result.OzonApiResponseJson = ozonRes.apiResponseJSON
+	result.OzonRequestAttempted = ozonRes.requestAttempted
+	result.OzonPreparedRequestJson = ozonRes.preparedRequestJSON
+	result.DryRun = req.IsDryRun()
 
 	// Log errors but don't stop execution (marketplace integrations are independent)
 	if wbRes.err != nil {
@@ -127,6 +377,29 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 	}
 	if ozonRes.err != nil {
 		log.Printf("Error in Ozon card creation: %v", ozonRes.err)
+		var ozonErr *entities.OzonError
+		if errors.As(ozonRes.err, &ozonErr) {
+			result.OzonError = ozonErr
+		}
+	}
+
+	// Offers that still have no barcode get one from Ozon once it has created them, then their initial stock
+	if ozonRes.requestAttempted != nil && *ozonRes.requestAttempted && ozonRes.err == nil {
+		products, err := ozonService.WaitForOffers(ctx, req)
+		if err != nil {
+			log.Printf("Error waiting for Ozon to create the offers: %v", err)
+		} else {
+			generated, barcodesErr := ozonService.AssignBarcodes(ctx, req, products)
+			if barcodesErr != nil {
+				log.Printf("Error generating Ozon barcodes: %v", barcodesErr)
+			}
+			result.GeneratedBarcodes = append(result.GeneratedBarcodes, generated...)
+			err = ozonService.SetInitialStock(ctx, req, products)
+		}
+		if err != nil && req.InitialStock != nil && req.InitialStock.OzonWarehouseID != 0 {
+			log.Printf("Error setting initial Ozon stock: %v", err)
+			result.OzonInitialStockError = err.Error()
+		}
 	}
 
 	// Handle media uploads and saves - only if WB card creation was attempted and successful
@@ -141,37 +414,73 @@ func (uc *CreateCardUsecase) CreateProductCard(ctx context.Context, apiKey strin
 	}
 
 	if shouldAttemptMedia {
-		wbMediaUploadResponses, wbMediaSaveResponse, mediaErr := uc.wbService.AddMedia(ctx, &req)
-		if mediaErr != nil {
-			log.Printf("Error in Wildberries media operations: %v", mediaErr)
-			// Don't return error here, media operations are not critical for the main flow
-		} else {
-			// Handle upload responses - could be nil if no uploads were attempted
-			for _, response := range wbMediaUploadResponses {
-				createProductCardResult.WbMediaUploadResponses = append(createProductCardResult.WbMediaUploadResponses, &entities.WbMediaUploadIndividualResponse{
-					PhotoNumber:  response.PhotoNumber,
-					ResponseJson: response.ResponseJson,
-					ErrorMessage: response.ErrorMessage,
-				})
-			}
-
-			// Handle save response - could be nil if no save by links were attempted
-			if wbMediaSaveResponse != nil {
-				createProductCardResult.WbMediaSaveResponse = &entities.WbMediaSaveByLinksResponse{
-					ResponseJson: wbMediaSaveResponse.ResponseJson,
-					ErrorMessage: wbMediaSaveResponse.ErrorMessage,
-				}
-			} else {
-				// Initialize with empty response if nil
-				createProductCardResult.WbMediaSaveResponse = &entities.WbMediaSaveByLinksResponse{}
-			}
+		// Every variant of a multi-variant product is a separate WB card with its own nmID, media and price
+		for _, card := range req.ExpandVariants() {
+			addCardMedia(ctx, wbService, card, result)
+		}
+		if len(result.WbMediaSaveResponses) > 0 {
+			result.WbMediaSaveResponse = result.WbMediaSaveResponses[0]
 		}
 	}
 
 	// Initialize empty responses when media operations are skipped or failed
-	if !shouldAttemptMedia || createProductCardResult.WbMediaSaveResponse == nil {
-		createProductCardResult.WbMediaSaveResponse = &entities.WbMediaSaveByLinksResponse{}
+	if !shouldAttemptMedia || result.WbMediaSaveResponse == nil {
+		result.WbMediaSaveResponse = &entities.WbMediaSaveByLinksResponse{}
 	}
 
-	return &createProductCardResult, nil
+	var published []entities.Marketplace
+	if shouldAttemptMedia {
+		published = append(published, entities.MarketplaceWB)
+	}
+	if ozonRes.requestAttempted != nil && *ozonRes.requestAttempted && ozonRes.err == nil {
+		published = append(published, entities.MarketplaceOzon)
+	}
+	return published
+}
+
+// addCardMedia adds the WB media and price of one card, appending the media responses to result.
+func addCardMedia(ctx context.Context, wbService wbService, card *entities.ProductCard, result *entities.CreateProductCardResult) {
+	wbMediaUploadResponses, wbMediaSaveResponse, mediaPending, mediaErr := wbService.AddMedia(ctx, card)
+	if mediaErr != nil {
+		log.Printf("Error in Wildberries media operations for vendor code %s: %v", card.VendorCode, mediaErr)
+		// Don't return error here, media operations are not critical for the main flow, but report them
+		errMsg := mediaErr.Error()
+		result.WbMediaSaveResponses = append(result.WbMediaSaveResponses, &entities.WbMediaSaveByLinksResponse{VendorCode: card.VendorCode, ErrorMessage: &errMsg})
+		return
+	}
+	if mediaPending {
+		log.Printf("WB card %s is not created yet, media and price will be set in the background", card.VendorCode)
+		result.WbMediaPending = true
+		return
+	}
+	// Upload responses could be nil if no uploads were attempted
+	result.WbMediaUploadResponses = append(result.WbMediaUploadResponses, wbMediaUploadResponses...)
+	// Save response could be nil if no save by links were attempted
+	if wbMediaSaveResponse != nil {
+		result.WbMediaSaveResponses = append(result.WbMediaSaveResponses, wbMediaSaveResponse)
+	}
+}
+
+// publishableProductCard strips marketplace credentials and inline media bytes from the card before it is stored.
+// Media links expire with the uploaded files, so only the media references are kept and resolved on publishing.
+func publishableProductCard(req entities.ProductCard) entities.ProductCard {
+	req.WbApiKey = ""
+	req.OzonApiClientId = ""
+	req.OzonApiKey = ""
+	req.WbMediaToUploadFiles = nil
+	req.MediaLinks = nil
+	variants := make([]*entities.ProductVariant, len(req.Variants))
+	for i, v := range req.Variants {
+		variant := *v
+		variant.WbMediaToUploadFiles = nil
+		variant.MediaLinks = nil
+		variants[i] = &variant
+	}
+	if len(variants) > 0 {
+		req.Variants = variants
+	}
+	req.ForceRegenerate = false
+	req.ContentFeedback = ""
+	req.DryRun = nil
+	return req
 }
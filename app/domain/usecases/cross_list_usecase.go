@@ -0,0 +1,387 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+
+	"connectrpc.com/connect"
+)
+
+type crossListWbService interface {
+	wbService
+	GetCard(ctx context.Context, apiKey string, nmID int) (*entities.WBCardDefinition, error)
+	GetPrices(ctx context.Context, apiKey string, nmID int) (*entities.WBGoodPrices, error)
+	ConvertCharacteristics(ctx context.Context, apiKey string, subjectID int, characteristics []entities.ProductCharacteristic) ([]entities.WBCharacteristic, []string, error)
+}
+
+type crossListOzonService interface {
+	ozonService
+	GetProduct(ctx context.Context, clientID, apiKey, offerID string) (*entities.OzonProduct, error)
+	ConvertCharacteristics(ctx context.Context, clientID, apiKey string, categoryID, typeID int64, characteristics []entities.ProductCharacteristic) ([]entities.OzonProductAttribute, []string, error)
+}
+
+type categoryMapper interface {
+	MapCategories(ctx context.Context, card *entities.ProductCard) *entities.CategoryMapping
+	RecordCategories(ctx context.Context, req entities.ProductCard, contents []*entities.CardCraftAiGeneratedContent)
+}
+
+// CrossListUsecase copies existing cards of one marketplace to the other, keeping their content.
+type CrossListUsecase struct {
+	cardCraftAiService cardCraftAiService
+	categoryMapper     categoryMapper
+	wbService          crossListWbService
+	ozonService        crossListOzonService
+	contentValidator   contentValidator
+	attributeValidator attributeValidator
+	dryRunByDefault    bool
+}
+
+func NewCrossListUsecase(cardCraftAiService cardCraftAiService, categoryMapper categoryMapper, wbService crossListWbService, ozonService crossListOzonService, contentValidator contentValidator, attributeValidator attributeValidator, dryRunByDefault bool) *CrossListUsecase {
+	return &CrossListUsecase{
+		cardCraftAiService: cardCraftAiService,
+		categoryMapper:     categoryMapper,
+		wbService:          wbService,
+		ozonService:        ozonService,
+		contentValidator:   contentValidator,
+		attributeValidator: attributeValidator,
+		dryRunByDefault:    dryRunByDefault,
+	}
+}
+
+// CrossList creates an Ozon product from the WB card with the requested nmID, or a WB card from the Ozon product
+// with the requested offer ID. The title, description, media, dimensions and sizes are taken over, fixed only where
+// they break the constraints of the target; the target category is taken from the category mappings or resolved
+// by CardCraftAI unless the caller supplied it.
+func (uc *CrossListUsecase) CrossList(ctx context.Context, apiKey string, req entities.CrossListRequest) (*entities.CrossListResult, error) {
+	if req.DryRun == nil {
+		req.DryRun = &uc.dryRunByDefault
+	}
+
+	var card *entities.ProductCard
+	var characteristics []entities.ProductCharacteristic
+	var err error
+	if req.OfferID != "" {
+		card, characteristics, err = uc.readOzonProduct(ctx, req)
+	} else {
+		card, characteristics, err = uc.readWBCard(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	card.WbApiKey = req.WbApiKey
+	card.OzonApiClientId = req.OzonApiClientId
+	card.OzonApiKey = req.OzonApiKey
+	card.DryRun = req.DryRun
+
+	content := &entities.CardCraftAiGeneratedContent{Title: card.ProductTitle, Description: card.ProductDescription}
+	if err := uc.resolveCategory(ctx, apiKey, card, content); err != nil {
+		return nil, err
+	}
+
+	result := &entities.CrossListResult{VendorCode: card.VendorCode}
+	if card.Wb {
+		card.WbCharacteristics, result.UnmappedCharacteristics, err = uc.wbService.ConvertCharacteristics(ctx, req.WbApiKey, int(*content.SubjectID), characteristics)
+	} else {
+		card.OzonAttributes, result.UnmappedCharacteristics, err = uc.ozonService.ConvertCharacteristics(ctx, req.OzonApiClientId, req.OzonApiKey, int64(*content.SubID), int64(*content.TypeID), characteristics)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	if len(result.UnmappedCharacteristics) > 0 {
+		log.Printf("%d characteristics of %s have no counterpart on the target: %v", len(result.UnmappedCharacteristics), card.VendorCode, result.UnmappedCharacteristics)
+	}
+
+	wbContent, ozonContent, violations, err := prepareMarketplaceContent(ctx, uc.contentValidator, uc.attributeValidator, card, content, entities.ContentValidationTruncate)
+	result.ContentViolations = violations
+	if err != nil {
+		return nil, err
+	}
+	result.CardCraftAiGeneratedContent = content
+	publishCard(ctx, uc.wbService, uc.ozonService, card, wbContent, ozonContent, &result.CreateProductCardResult)
+	return result, nil
+}
+
+// readWBCard converts the WB card with the requested nmID to a card published to Ozon only.
+func (uc *CrossListUsecase) readWBCard(ctx context.Context, req entities.CrossListRequest) (*entities.ProductCard, []entities.ProductCharacteristic, error) {
+	wbCard, err := uc.wbService.GetCard(ctx, req.WbApiKey, req.NmID)
+	if err != nil {
+		if errors.Is(err, entities.ErrWBCardNotFound) {
+			return nil, nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	var prices *entities.WBGoodPrices
+	if req.Price == 0 {
+		prices, err = uc.wbService.GetPrices(ctx, req.WbApiKey, req.NmID)
+		if err != nil {
+			return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+		}
+		if prices == nil || len(prices.Sizes) == 0 {
+			return nil, nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("nmID %d has no WB price, pass the Ozon price", req.NmID))
+		}
+	}
+
+	card := productCardFromWB(wbCard, prices, req.Price)
+	card.SubId = req.DescriptionCategoryID
+	card.TypeId = req.TypeID
+
+	var characteristics []entities.ProductCharacteristic
+	for _, c := range wbCard.Characteristics {
+		if c.ID == entities.WBColorCharacteristicID {
+			continue // Set from the card color
+		}
+		if values := c.Values(); len(values) > 0 {
+			characteristics = append(characteristics, entities.ProductCharacteristic{Name: c.Name, Values: values})
+		}
+	}
+	log.Printf("Cross-listing WB nmID %d (%s) to Ozon", req.NmID, wbCard.SubjectName)
+	return card, characteristics, nil
+}
+
+// readOzonProduct converts the Ozon product with the requested offer ID to a card published to WB only.
+func (uc *CrossListUsecase) readOzonProduct(ctx context.Context, req entities.CrossListRequest) (*entities.ProductCard, []entities.ProductCharacteristic, error) {
+	product, err := uc.ozonService.GetProduct(ctx, req.OzonApiClientId, req.OzonApiKey, req.OfferID)
+	if err != nil {
+		if errors.Is(err, entities.ErrOzonProductNotFound) {
+			return nil, nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	card, err := productCardFromOzon(product, req.Price)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	card.SubjectId = req.SubjectID
+	log.Printf("Cross-listing Ozon offer %s (category %d, type %d) to WB", req.OfferID, product.Details.DescriptionCategoryID, product.Details.TypeID)
+	return card, product.Characteristics, nil
+}
+
+// resolveCategory sets the target category of the card on content when the caller did not supply it: from the
+// mapping of the source category if there is a trusted one, otherwise from CardCraftAI. CardCraftAI has no
+// classification-only call, so the content it generates along with the category is discarded and not charged.
+func (uc *CrossListUsecase) resolveCategory(ctx context.Context, apiKey string, card *entities.ProductCard, content *entities.CardCraftAiGeneratedContent) error {
+	target := entities.MarketplaceOzon
+	if card.Wb {
+		target = entities.MarketplaceWB
+	}
+	source := "caller"
+	var mapping *entities.CategoryMapping
+	if target == entities.MarketplaceWB && card.SubjectId == 0 || target == entities.MarketplaceOzon && (card.SubId == 0 || card.TypeId == 0) {
+		req := *card
+		req.ResolveCategories = []entities.Marketplace{target}
+		if mapping = uc.categoryMapper.MapCategories(ctx, &req); mapping != nil {
+			card.SubjectId, card.SubId, card.TypeId = req.SubjectId, req.SubId, req.TypeId
+			source = "mapping"
+		} else {
+			contents, err := uc.cardCraftAiService.GetCardContentVariants(ctx, apiKey, req, 1)
+			if err != nil {
+				return err
+			}
+			uc.categoryMapper.RecordCategories(ctx, req, contents)
+			card.SetCategoriesFrom(contents[0])
+			content.SessionID, content.Provider = contents[0].SessionID, contents[0].Provider
+			content.ParentName, content.SubjectName = contents[0].ParentName, contents[0].SubjectName
+			content.RootName, content.SubName, content.TypeName = contents[0].RootName, contents[0].SubName, contents[0].TypeName
+			source = "CardCraftAI"
+		}
+	}
+
+	if target == entities.MarketplaceWB {
+		if card.SubjectId == 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("no WB subject was found for %s, pass subject_id", card.VendorCode))
+		}
+		content.SubjectID = &card.SubjectId
+		if card.ParentId != 0 {
+			content.ParentID = &card.ParentId
+		}
+		if mapping != nil {
+			mapping.SetNamesOn(content)
+		}
+		log.Printf("WB subject of %s: %d (from %s)", card.VendorCode, card.SubjectId, source)
+		return nil
+	}
+	if card.SubId == 0 || card.TypeId == 0 {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("no Ozon category was found for %s, pass description_category_id and type_id", card.VendorCode))
+	}
+	content.SubID, content.TypeID = &card.SubId, &card.TypeId
+	if card.RootId != 0 {
+		content.RootID = &card.RootId
+	}
+	if mapping != nil {
+		mapping.SetNamesOn(content)
+	}
+	log.Printf("Ozon category of %s: %d, type %d (from %s)", card.VendorCode, card.SubId, card.TypeId, source)
+	return nil
+}
+
+// productCardFromWB converts a WB card to a product card published to Ozon only. Sizes get the fixed price
+// or, if it is zero, their WB price with discount.
+func productCardFromWB(wbCard *entities.WBCardDefinition, prices *entities.WBGoodPrices, price int) *entities.ProductCard {
+	card := &entities.ProductCard{
+		ProductTitle:       wbCard.Title,
+		ProductDescription: wbCard.Description,
+		SubjectId:          int32(wbCard.SubjectID),
+		Ozon:               true,
+		VendorCode:         wbCard.VendorCode,
+		Brand:              wbCard.Brand,
+	}
+	for _, photo := range wbCard.Photos {
+		card.WbMediaToSaveLinks = append(card.WbMediaToSaveLinks, photo.Big)
+	}
+	if wbCard.Video != "" {
+		card.MediaLinks = append(card.MediaLinks, &entities.MediaLink{URL: wbCard.Video, Kind: entities.MediaKindVideo})
+	}
+	for _, c := range wbCard.Characteristics {
+		if c.ID == entities.WBColorCharacteristicID {
+			if colors := c.Values(); len(colors) > 0 {
+				card.Color = colors[0]
+			}
+		}
+	}
+
+	// WB keeps dimensions in centimeters and the weight in kilograms, which may be unset on older cards
+	if d := wbCard.Dimensions; d != nil {
+		card.Dimensions = &entities.WBDimensions{
+			Length:        d.Length,
+			Depth:         d.Length,
+			Width:         d.Width,
+			Height:        d.Height,
+			DimensionUnit: "cm",
+		}
+		if d.WeightBrutto != nil {
+			weight := int32(math.Round(*d.WeightBrutto * 1000))
+			card.Dimensions.Weight, card.Dimensions.WeightUnit = &weight, "g"
+		}
+	}
+
+	sizePrices := make(map[string]int)
+	var defaultPrice int
+	if prices != nil {
+		for _, s := range prices.Sizes {
+			sizePrices[s.TechSizeName] = int(math.Round(s.DiscountedPrice))
+		}
+		defaultPrice = int(math.Round(prices.Sizes[0].DiscountedPrice))
+	}
+	for _, s := range wbCard.Sizes {
+		size := &entities.WBSize{TechSize: s.TechSize, WbSize: s.WbSize, Skus: s.Skus, Price: price}
+		if size.Price == 0 {
+			size.Price = defaultPrice
+			if p, ok := sizePrices[s.TechSize]; ok {
+				size.Price = p
+			}
+		}
+		// WB gives cards without sizes a single size "0"
+		if len(wbCard.Sizes) == 1 && s.TechSize == "0" {
+			size.TechSize = ""
+		}
+		card.Sizes = append(card.Sizes, size)
+	}
+	return card
+}
+
+// productCardFromOzon converts an Ozon product to a product card published to WB only. An Ozon product is one
+// size, so the card gets a single size with the Ozon barcodes, and the fixed price or, if it is zero, the Ozon
+// price before discount together with the discount. Sized products merged with other offers into one Ozon model
+// are rejected, since copying them one by one would split the sizes of the model into separate WB cards.
+func productCardFromOzon(product *entities.OzonProduct, price int) (*entities.ProductCard, error) {
+	details := &product.Details
+	first := func(id int64) string {
+		if values := details.AttributeValues(id); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	if count := product.Info.ModelInfo.Count; count > 1 && first(entities.OzonSizeAttributeID) != "" {
+		return nil, fmt.Errorf("offer %s is a size of Ozon model %d with %d offers, multi-size models can't be copied to WB",
+			details.OfferID, product.Info.ModelInfo.ModelID, count)
+	}
+	card := &entities.ProductCard{
+		ProductTitle:       details.Name,
+		ProductDescription: first(entities.OzonAnnotationAttributeID),
+		SubId:              int32(details.DescriptionCategoryID),
+		TypeId:             int32(details.TypeID),
+		Wb:                 true,
+		VendorCode:         details.OfferID,
+		Brand:              first(entities.OzonBrandAttributeID),
+		Color:              first(entities.OzonColorAttributeID),
+	}
+	if details.PrimaryImage != "" {
+		card.WbMediaToSaveLinks = append(card.WbMediaToSaveLinks, details.PrimaryImage)
+	}
+	for _, image := range details.Images {
+		if image != details.PrimaryImage {
+			card.WbMediaToSaveLinks = append(card.WbMediaToSaveLinks, image)
+		}
+	}
+
+	dimensions, err := wbDimensionsFromOzon(details)
+	if err != nil {
+		return nil, err
+	}
+	card.Dimensions = dimensions
+
+	size := &entities.WBSize{Skus: details.Barcodes, Price: price}
+	if value := first(entities.OzonSizeAttributeID); value != "" {
+		size.TechSize, size.WbSize = value, value
+	}
+	if size.Price == 0 {
+		current, _ := strconv.ParseFloat(product.Info.Price, 64)
+		old, _ := strconv.ParseFloat(product.Info.OldPrice, 64)
+		size.Price = int(math.Round(current))
+		if old > current && current > 0 {
+			size.Price = int(math.Round(old))
+			card.WbDiscount = int(math.Round((old - current) * 100 / old))
+		}
+		if size.Price == 0 {
+			return nil, fmt.Errorf("offer %s has no Ozon price, pass the WB price", details.OfferID)
+		}
+	}
+	card.Sizes = []*entities.WBSize{size}
+	return card, nil
+}
+
+// wbDimensionsFromOzon converts the Ozon package dimensions to centimeters and the weight to kilograms.
+func wbDimensionsFromOzon(details *entities.OzonProductDetails) (*entities.WBDimensions, error) {
+	var toCm float64
+	switch details.DimensionUnit {
+	case "mm":
+		toCm = 0.1
+	case "cm":
+		toCm = 1
+	case "in":
+		toCm = 2.54
+	default:
+		return nil, fmt.Errorf("offer %s has unknown dimension unit %q", details.OfferID, details.DimensionUnit)
+	}
+	var toKg float64
+	switch details.WeightUnit {
+	case "g":
+		toKg = 0.001
+	case "kg":
+		toKg = 1
+	case "lb":
+		toKg = 0.45359237
+	default:
+		return nil, fmt.Errorf("offer %s has unknown weight unit %q", details.OfferID, details.WeightUnit)
+	}
+
+	// WB takes whole centimeters, anything smaller than one is rounded up to one
+	cm := func(value int32) *int32 {
+		converted := int32(math.Max(1, math.Round(float64(value)*toCm)))
+		return &converted
+	}
+	weight := math.Round(float64(details.Weight)*toKg*1000) / 1000
+	return &entities.WBDimensions{
+		Length:       cm(details.Depth),
+		Width:        cm(details.Width),
+		Height:       cm(details.Height),
+		WeightBrutto: &weight,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"testing"
+)
+
+func TestProductCardFromWB_Dimensions(t *testing.T) {
+	length, width, height := int32(30), int32(20), int32(10)
+	weight := 0.45
+
+	t.Run("with weight", func(t *testing.T) {
+		card := productCardFromWB(&entities.WBCardDefinition{
+			Dimensions: &entities.WBDimensions{Length: &length, Width: &width, Height: &height, WeightBrutto: &weight},
+		}, nil, 1000)
+		d := card.Dimensions
+		if d == nil || *d.Depth != 30 || *d.Width != 20 || *d.Height != 10 || d.DimensionUnit != "cm" || d.Weight == nil || *d.Weight != 450 || d.WeightUnit != "g" {
+			t.Errorf("Unexpected dimensions: %+v", d)
+		}
+	})
+
+	t.Run("without weight", func(t *testing.T) {
+		card := productCardFromWB(&entities.WBCardDefinition{
+			Dimensions: &entities.WBDimensions{Length: &length, Width: &width, Height: &height},
+		}, nil, 1000)
+		d := card.Dimensions
+		if d == nil || *d.Depth != 30 || *d.Width != 20 || *d.Height != 10 || d.Weight != nil {
+			t.Errorf("Expected the sizes to be kept without a weight, got %+v", d)
+		}
+	})
+}
+
+func TestProductCardFromOzon_Models(t *testing.T) {
+	product := func(count int64, size string) *entities.OzonProduct {
+		p := &entities.OzonProduct{
+			Info: entities.OzonProductInfo{Price: "1000", ModelInfo: entities.OzonModelInfo{ModelID: 7, Count: count}},
+			Details: entities.OzonProductDetails{
+				OfferID: "offer-1", Barcodes: []string{"2000000000001"},
+				Depth: 300, Width: 200, Height: 100, DimensionUnit: "mm", Weight: 450, WeightUnit: "g",
+			},
+		}
+		if size != "" {
+			p.Details.Attributes = []entities.OzonProductAttribute{{ID: entities.OzonSizeAttributeID, Values: []entities.OzonProductAttributeValue{{Value: size}}}}
+		}
+		return p
+	}
+
+	t.Run("multi-size model", func(t *testing.T) {
+		if _, err := productCardFromOzon(product(3, "42"), 0); err == nil {
+			t.Fatal("Expected an error for a size of a multi-size model")
+		}
+	})
+
+	t.Run("single offer", func(t *testing.T) {
+		card, err := productCardFromOzon(product(1, "42"), 0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(card.Sizes) != 1 || card.Sizes[0].TechSize != "42" || card.Sizes[0].Price != 1000 {
+			t.Errorf("Unexpected sizes: %+v", card.Sizes)
+		}
+	})
+
+	t.Run("model without sizes", func(t *testing.T) {
+		if _, err := productCardFromOzon(product(2, ""), 0); err != nil {
+			t.Errorf("Expected a model of colors to be copied, got %v", err)
+		}
+	})
+}
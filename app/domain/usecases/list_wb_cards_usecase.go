@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+)
+
+type wbCardLister interface {
+	ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error
+}
+
+type ListWBCardsUsecase struct {
+	wbCardLister wbCardLister
+}
+
+func NewListWBCardsUsecase(wbCardLister wbCardLister) *ListWBCardsUsecase {
+	return &ListWBCardsUsecase{wbCardLister: wbCardLister}
+}
+
+// ListWBCards passes all WB cards of the seller matching filter to send, one page per call.
+// Listing stops at the first error returned by send.
+func (uc *ListWBCardsUsecase) ListWBCards(ctx context.Context, wbApiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, send func(cards []entities.WBCardDefinition) error) error {
+	if wbApiKey == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key is required"))
+	}
+	return uc.wbCardLister.ListCards(ctx, wbApiKey, filter, pageSize, func(cards []entities.WBCardDefinition) (bool, error) {
+		if err := send(cards); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
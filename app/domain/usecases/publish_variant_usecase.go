@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type publishedVariantStorage interface {
+	variantPublicationStorage
+	GetContentVariant(ctx context.Context, apiKey, id string) (*entities.ContentVariant, error)
+}
+
+// PublishVariantUsecase pushes a stored content variant to the marketplaces without regenerating it.
+type PublishVariantUsecase struct {
+	variantStorage     publishedVariantStorage
+	wbService          wbService
+	ozonService        ozonService
+	contentValidator   contentValidator
+	attributeValidator attributeValidator
+	mediaResolver      mediaResolver
+	dryRunByDefault    bool
+}
+
+func NewPublishVariantUsecase(variantStorage publishedVariantStorage, wbService wbService, ozonService ozonService, contentValidator contentValidator, attributeValidator attributeValidator, mediaResolver mediaResolver, dryRunByDefault bool) *PublishVariantUsecase {
+	return &PublishVariantUsecase{
+		variantStorage:     variantStorage,
+		wbService:          wbService,
+		ozonService:        ozonService,
+		contentValidator:   contentValidator,
+		attributeValidator: attributeValidator,
+		mediaResolver:      mediaResolver,
+		dryRunByDefault:    dryRunByDefault,
+	}
+}
+
+func (uc *PublishVariantUsecase) PublishVariant(ctx context.Context, apiKey string, req entities.PublishVariantRequest) (*entities.CreateProductCardResult, error) {
+	variant, err := uc.variantStorage.GetContentVariant(ctx, apiKey, req.VariantID)
+	if err != nil {
+		if errors.Is(err, entities.ErrContentVariantNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get content variant: %w", err))
+	}
+	log.Printf("Publishing content variant %s (%d of generation %s) - WB: %t, Ozon: %t", variant.ID, variant.Index, variant.GenerationID, req.Wb, req.Ozon)
+
+	// Inline files are not stored and uploaded media expires, a card without its media is not published
+	if variant.InlineMediaFiles > 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %d inline media files of the card are not stored, upload them with MediaService and create the card again",
+			entities.ErrContentVariantMediaMissing, variant.InlineMediaFiles))
+	}
+	productCard := variant.ProductCard
+	if err := resolveCardMedia(ctx, uc.mediaResolver, &productCard); err != nil {
+		if errors.Is(err, entities.ErrMediaNotFound) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%w: %v", entities.ErrContentVariantMediaMissing, err))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to resolve media: %w", err))
+	}
+	productCard.Wb = req.Wb
+	productCard.Ozon = req.Ozon
+	productCard.WbApiKey = req.WbApiKey
+	productCard.OzonApiClientId = req.OzonApiClientId
+	productCard.OzonApiKey = req.OzonApiKey
+	productCard.DryRun = req.DryRun
+	if productCard.DryRun == nil {
+		productCard.DryRun = &uc.dryRunByDefault
+	}
+
+	result := entities.CreateProductCardResult{
+		CardCraftAiGeneratedContent: &variant.Content,
+		ContentVariants:             []*entities.ContentVariant{variant},
+	}
+
+	// The variant is published as generated, so regeneration is not possible and behaves like truncation
+	wbContent, ozonContent, violations, err := prepareMarketplaceContent(ctx, uc.contentValidator, uc.attributeValidator, &productCard, &variant.Content, req.ContentValidation)
+	result.ContentViolations = violations
+	if err != nil {
+		return nil, err
+	}
+	published := publishCard(ctx, uc.wbService, uc.ozonService, &productCard, wbContent, ozonContent, &result)
+	markVariantPublished(ctx, uc.variantStorage, variant, &productCard, published)
+
+	return &result, nil
+}
@@ -0,0 +1,319 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+)
+
+type fakeContentVariantStorage struct {
+	saved        []*entities.ContentVariant
+	publications map[string][]entities.ContentVariantPublication
+}
+
+func (f *fakeContentVariantStorage) SaveContentVariants(ctx context.Context, variants []*entities.ContentVariant) error {
+	for i, v := range variants {
+		v.ID = "variant-" + string(rune('a'+i))
+	}
+	f.saved = append(f.saved, variants...)
+	return nil
+}
+
+func (f *fakeContentVariantStorage) GetContentVariant(ctx context.Context, apiKey, id string) (*entities.ContentVariant, error) {
+	for _, v := range f.saved {
+		if v.ID == id {
+			variant := *v
+			return &variant, nil
+		}
+	}
+	return nil, entities.ErrContentVariantNotFound
+}
+
+func (f *fakeContentVariantStorage) MarkContentVariantPublished(ctx context.Context, id string, publications []entities.ContentVariantPublication) error {
+	if f.publications == nil {
+		f.publications = map[string][]entities.ContentVariantPublication{}
+	}
+	f.publications[id] = append(f.publications[id], publications...)
+	return nil
+}
+
+type fakeWBService struct {
+	created  []*entities.ProductCard
+	barcodes []*entities.GeneratedBarcodes
+}
+
+func (f *fakeWBService) CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error) {
+	card := *req
+	f.created = append(f.created, &card)
+	attempted := req.GetWb() && !req.IsDryRun()
+	return nil, nil, &attempted, nil
+}
+
+func (f *fakeWBService) AddMedia(ctx context.Context, req *entities.ProductCard) ([]*entities.WbMediaUploadIndividualResponse, *entities.WbMediaSaveByLinksResponse, bool, error) {
+	return nil, &entities.WbMediaSaveByLinksResponse{VendorCode: req.VendorCode}, false, nil
+}
+
+func (f *fakeWBService) GenerateMissingBarcodes(ctx context.Context, req *entities.ProductCard) ([]*entities.GeneratedBarcodes, error) {
+	return f.barcodes, nil
+}
+
+type fakeOzonService struct {
+	created   []*entities.ProductCard
+	attempted bool
+	stockErr  error
+}
+
+func (f *fakeOzonService) CreateCard(ctx context.Context, req *entities.ProductCard, ccaApiResponse *entities.CardCraftAiGeneratedContent) (*string, *string, *bool, error) {
+	f.created = append(f.created, req)
+	attempted := f.attempted
+	return nil, nil, &attempted, nil
+}
+
+func (f *fakeOzonService) WaitForOffers(ctx context.Context, req *entities.ProductCard) ([]entities.OzonProductInfo, error) {
+	return nil, nil
+}
+
+func (f *fakeOzonService) AssignBarcodes(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) ([]*entities.GeneratedBarcodes, error) {
+	return nil, nil
+}
+
+func (f *fakeOzonService) SetInitialStock(ctx context.Context, req *entities.ProductCard, products []entities.OzonProductInfo) error {
+	return f.stockErr
+}
+
+type fakeMediaResolver struct {
+	expired map[string]bool
+}
+
+func (f *fakeMediaResolver) ResolveMedia(ctx context.Context, refs []*entities.MediaReference) ([]*entities.MediaLink, error) {
+	links := make([]*entities.MediaLink, len(refs))
+	for i, ref := range refs {
+		if f.expired[ref.MediaID] {
+			return nil, entities.ErrMediaNotFound
+		}
+		links[i] = &entities.MediaLink{Name: ref.MediaID, URL: "https://files.example.com/" + ref.MediaID}
+	}
+	return links, nil
+}
+
+type fakeContentValidator struct{}
+
+func (f *fakeContentValidator) Validate(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) []entities.ContentViolation {
+	return nil
+}
+
+func (f *fakeContentValidator) Fix(marketplace entities.Marketplace, brand string, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation) {
+	return content, nil
+}
+
+type fakeAttributeValidator struct {
+	violations []entities.ContentViolation
+}
+
+func (f *fakeAttributeValidator) FixAttributes(ctx context.Context, content *entities.CardCraftAiGeneratedContent) (*entities.CardCraftAiGeneratedContent, []entities.ContentViolation) {
+	return content, f.violations
+}
+
+type fakeCardCraftAiService struct{}
+
+func (f *fakeCardCraftAiService) GetCardContentVariants(ctx context.Context, apiKey string, req entities.ProductCard, count int) ([]*entities.CardCraftAiGeneratedContent, error) {
+	contents := make([]*entities.CardCraftAiGeneratedContent, count)
+	for i := range contents {
+		contents[i] = &entities.CardCraftAiGeneratedContent{Title: "Футболка"}
+	}
+	return contents, nil
+}
+
+type fakeTokenBillingService struct{}
+
+func (f *fakeTokenBillingService) ChargeForContent(ctx context.Context, apiKey string, content *entities.CardCraftAiGeneratedContent) (int, error) {
+	return 10, nil
+}
+
+func TestCreateCardUsecase_StoresPublishableVariants(t *testing.T) {
+	storage := &fakeContentVariantStorage{}
+	uc := NewCreateCardUsecase(&fakeCardCraftAiService{}, &fakeWBService{}, &fakeOzonService{}, &fakeTokenBillingService{},
+		&fakeMediaResolver{}, storage, &fakeContentValidator{}, &fakeAttributeValidator{}, true)
+
+	_, err := uc.CreateProductCard(context.Background(), "key", entities.ProductCard{
+		VendorCode:           "tshirt",
+		WbApiKey:             "wb-secret",
+		OzonApiKey:           "ozon-secret",
+		ContentVariants:      2,
+		Media:                []*entities.MediaReference{{MediaID: "photo"}},
+		WbMediaToUploadFiles: []*entities.WBClientMediaFile{{}},
+	})
+	if err != nil {
+		t.Fatalf("CreateProductCard: %v", err)
+	}
+	if len(storage.saved) != 2 {
+		t.Fatalf("Expected 2 stored variants, got %d", len(storage.saved))
+	}
+	for _, v := range storage.saved {
+		card := v.ProductCard
+		if card.WbApiKey != "" || card.OzonApiKey != "" {
+			t.Errorf("Expected credentials to be stripped, got %q and %q", card.WbApiKey, card.OzonApiKey)
+		}
+		if card.WbMediaToUploadFiles != nil || card.MediaLinks != nil {
+			t.Errorf("Expected inline media and media links to be stripped, got %d files and %d links", len(card.WbMediaToUploadFiles), len(card.MediaLinks))
+		}
+		if len(card.Media) != 1 || v.InlineMediaFiles != 1 {
+			t.Errorf("Expected the media reference and 1 inline file to be recorded, got %d and %d", len(card.Media), v.InlineMediaFiles)
+		}
+	}
+	if len(storage.publications) != 0 {
+		t.Errorf("Expected dry runs not to be recorded as publications, got %v", storage.publications)
+	}
+}
+
+func TestPublishVariantUsecase_PublishVariant(t *testing.T) {
+	storedVariant := func(card entities.ProductCard, inlineMediaFiles int) *fakeContentVariantStorage {
+		return &fakeContentVariantStorage{saved: []*entities.ContentVariant{
+			{ID: "variant", ProductCard: card, InlineMediaFiles: inlineMediaFiles},
+		}}
+	}
+	dryRun := false
+	card := entities.ProductCard{
+		VendorCode: "tshirt",
+		Media:      []*entities.MediaReference{{MediaID: "photo"}},
+		Variants: []*entities.ProductVariant{
+			{VendorCode: "tshirt-red", Color: "красный", Media: []*entities.MediaReference{{MediaID: "red"}}},
+		},
+	}
+
+	t.Run("re-resolves media and records the publication", func(t *testing.T) {
+		storage := storedVariant(card, 0)
+		wb := &fakeWBService{}
+		uc := NewPublishVariantUsecase(storage, wb, &fakeOzonService{}, &fakeContentValidator{}, &fakeAttributeValidator{}, &fakeMediaResolver{}, true)
+
+		_, err := uc.PublishVariant(context.Background(), "key", entities.PublishVariantRequest{VariantID: "variant", Wb: true, WbApiKey: "wb", DryRun: &dryRun})
+		if err != nil {
+			t.Fatalf("PublishVariant: %v", err)
+		}
+		if len(wb.created) != 1 || len(wb.created[0].MediaLinks) != 1 || len(wb.created[0].Variants[0].MediaLinks) != 1 {
+			t.Fatalf("Expected the card to be created with freshly resolved media, got %+v", wb.created)
+		}
+		publications := storage.publications["variant"]
+		if len(publications) != 1 || publications[0].Marketplace != entities.MarketplaceWB || publications[0].VendorCode != "tshirt-red" {
+			t.Errorf("Expected the WB card tshirt-red to be recorded, got %+v", publications)
+		}
+	})
+
+	t.Run("refuses expired media", func(t *testing.T) {
+		storage := storedVariant(card, 0)
+		wb := &fakeWBService{}
+		uc := NewPublishVariantUsecase(storage, wb, &fakeOzonService{}, &fakeContentValidator{}, &fakeAttributeValidator{}, &fakeMediaResolver{expired: map[string]bool{"red": true}}, true)
+
+		_, err := uc.PublishVariant(context.Background(), "key", entities.PublishVariantRequest{VariantID: "variant", Wb: true, WbApiKey: "wb", DryRun: &dryRun})
+		if connect.CodeOf(err) != connect.CodeFailedPrecondition || !errors.Is(err, entities.ErrContentVariantMediaMissing) {
+			t.Errorf("Expected FailedPrecondition with ErrContentVariantMediaMissing, got %v", err)
+		}
+		if len(wb.created) != 0 || len(storage.publications) != 0 {
+			t.Errorf("Expected nothing to be published, got %d cards", len(wb.created))
+		}
+	})
+
+	t.Run("refuses inline media", func(t *testing.T) {
+		uc := NewPublishVariantUsecase(storedVariant(card, 2), &fakeWBService{}, &fakeOzonService{}, &fakeContentValidator{}, &fakeAttributeValidator{}, &fakeMediaResolver{}, true)
+
+		_, err := uc.PublishVariant(context.Background(), "key", entities.PublishVariantRequest{VariantID: "variant", Wb: true, WbApiKey: "wb", DryRun: &dryRun})
+		if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+			t.Errorf("Expected FailedPrecondition, got %v", err)
+		}
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		uc := NewPublishVariantUsecase(&fakeContentVariantStorage{}, &fakeWBService{}, &fakeOzonService{}, &fakeContentValidator{}, &fakeAttributeValidator{}, &fakeMediaResolver{}, true)
+
+		_, err := uc.PublishVariant(context.Background(), "key", entities.PublishVariantRequest{VariantID: "missing"})
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			t.Errorf("Expected NotFound, got %v", err)
+		}
+	})
+}
+
+func TestPublishCard_SendsGeneratedBarcodesToBothMarketplaces(t *testing.T) {
+	wb := &fakeWBService{barcodes: []*entities.GeneratedBarcodes{
+		{Marketplace: entities.MarketplaceWB, VendorCode: "VC001", TechSize: "M", Barcodes: []string{"2000000000028"}},
+	}}
+	ozon := &fakeOzonService{}
+	dryRun := false
+	req := &entities.ProductCard{
+		VendorCode: "VC001",
+		Wb:         true,
+		WbApiKey:   "wb",
+		Ozon:       true,
+		DryRun:     &dryRun,
+		Sizes:      []*entities.WBSize{{TechSize: "S", Skus: []string{"2000000000011"}}, {TechSize: "M"}},
+	}
+	var result entities.CreateProductCardResult
+
+	publishCard(context.Background(), wb, ozon, req, &entities.CardCraftAiGeneratedContent{}, &entities.CardCraftAiGeneratedContent{}, &result)
+
+	if len(result.GeneratedBarcodes) != 1 {
+		t.Errorf("Expected only the generated barcode to be reported, got %+v", result.GeneratedBarcodes)
+	}
+	for name, cards := range map[string][]*entities.ProductCard{"WB": wb.created, "Ozon": ozon.created} {
+		if len(cards) != 1 || len(cards[0].Sizes[1].Skus) != 1 || cards[0].Sizes[1].Skus[0] != "2000000000028" {
+			t.Errorf("Expected the generated barcode to be sent to %s", name)
+		}
+	}
+	if len(req.Sizes[1].Skus) != 0 {
+		t.Errorf("Expected the request to be left as is, got %v", req.Sizes[1].Skus)
+	}
+}
+
+func TestPublishCard_ReportsFailedInitialOzonStock(t *testing.T) {
+	ozon := &fakeOzonService{attempted: true, stockErr: errors.New("ozon did not update the stock of offers VC001")}
+	dryRun := false
+	req := &entities.ProductCard{
+		VendorCode:   "VC001",
+		Ozon:         true,
+		DryRun:       &dryRun,
+		InitialStock: &entities.InitialStock{OzonWarehouseID: 22, Amount: 5},
+	}
+	var result entities.CreateProductCardResult
+
+	publishCard(context.Background(), &fakeWBService{}, ozon, req, &entities.CardCraftAiGeneratedContent{}, &entities.CardCraftAiGeneratedContent{}, &result)
+
+	if result.OzonInitialStockError != ozon.stockErr.Error() {
+		t.Errorf("Expected the stock error to be reported, got %q", result.OzonInitialStockError)
+	}
+}
+
+func TestPrepareMarketplaceContent_UnknownWBAttributeValues(t *testing.T) {
+	attributes := &fakeAttributeValidator{violations: []entities.ContentViolation{{
+		Marketplace: entities.MarketplaceWB, Field: "attributes.Цвет", Rule: entities.ContentRuleUnknownValue, Fixed: true,
+	}}}
+	content := &entities.CardCraftAiGeneratedContent{Title: "Футболка", Attributes: map[string]string{"Цвет": "алый"}}
+
+	t.Run("truncate", func(t *testing.T) {
+		_, _, violations, err := prepareMarketplaceContent(context.Background(), &fakeContentValidator{}, attributes,
+			&entities.ProductCard{Wb: true}, content, entities.ContentValidationTruncate)
+		if err != nil {
+			t.Fatalf("Expected the dropped value to be reported, got %v", err)
+		}
+		if len(violations) != 1 || violations[0].Rule != entities.ContentRuleUnknownValue {
+			t.Errorf("Expected the unknown value violation, got %+v", violations)
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		_, _, _, err := prepareMarketplaceContent(context.Background(), &fakeContentValidator{}, attributes,
+			&entities.ProductCard{Wb: true}, content, entities.ContentValidationReject)
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("Ozon only", func(t *testing.T) {
+		_, _, violations, err := prepareMarketplaceContent(context.Background(), &fakeContentValidator{}, attributes,
+			&entities.ProductCard{Ozon: true}, content, entities.ContentValidationReject)
+		if err != nil || len(violations) != 0 {
+			t.Errorf("Expected WB attributes to be skipped for Ozon, got %+v, %v", violations, err)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+)
+
+type stockSyncSettingsStorage interface {
+	SaveStockSyncSettings(ctx context.Context, settings *entities.StockSyncSettings) error
+	GetStockSyncSettings(ctx context.Context, apiKey string) (*entities.StockSyncSettings, error)
+	ReplaceStockSyncMappings(ctx context.Context, apiKey string, mappings []entities.StockSyncMapping) error
+	GetLatestStockSyncReport(ctx context.Context, apiKey string) (*entities.StockSyncReport, error)
+}
+
+type stockSyncer interface {
+	Sync(ctx context.Context, settings *entities.StockSyncSettings, dryRun bool) *entities.StockSyncReport
+}
+
+type StockSyncUsecase struct {
+	storage stockSyncSettingsStorage
+	syncer  stockSyncer
+}
+
+func NewStockSyncUsecase(storage stockSyncSettingsStorage, syncer stockSyncer) *StockSyncUsecase {
+	return &StockSyncUsecase{storage: storage, syncer: syncer}
+}
+
+// SaveSettings configures the stock sync of the account. Both marketplaces need credentials and a warehouse.
+func (uc *StockSyncUsecase) SaveSettings(ctx context.Context, settings *entities.StockSyncSettings) error {
+	if settings.Source != entities.MarketplaceWB && settings.Source != entities.MarketplaceOzon {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("source must be WB or Ozon"))
+	}
+	if settings.WbApiKey == "" || settings.WbWarehouseID == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key and wb_warehouse_id are required"))
+	}
+	if settings.OzonApiClientId == "" || settings.OzonApiKey == "" || settings.OzonWarehouseID == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_api_client_id, ozon_api_key and ozon_warehouse_id are required"))
+	}
+	if err := uc.storage.SaveStockSyncSettings(ctx, settings); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save stock sync settings: %w", err))
+	}
+	return nil
+}
+
+// SetMappings replaces the items synced for the account.
+func (uc *StockSyncUsecase) SetMappings(ctx context.Context, apiKey string, mappings []entities.StockSyncMapping) error {
+	wbSkus := make(map[string]bool, len(mappings))
+	offerIDs := make(map[string]bool, len(mappings))
+	for _, m := range mappings {
+		if m.WbSku == "" || m.OzonOfferID == "" {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_sku and ozon_offer_id are required for every mapping"))
+		}
+		if wbSkus[m.WbSku] || offerIDs[m.OzonOfferID] {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_sku %s or ozon_offer_id %s is mapped twice", m.WbSku, m.OzonOfferID))
+		}
+		wbSkus[m.WbSku] = true
+		offerIDs[m.OzonOfferID] = true
+	}
+	if err := uc.storage.ReplaceStockSyncMappings(ctx, apiKey, mappings); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save stock sync mappings: %w", err))
+	}
+	return nil
+}
+
+// Run syncs the stocks of the account now. A nil dryRun uses the dry-run setting of the account.
+func (uc *StockSyncUsecase) Run(ctx context.Context, apiKey string, dryRun *bool) (*entities.StockSyncReport, error) {
+	settings, err := uc.getSettings(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	useDryRun := settings.DryRun
+	if dryRun != nil {
+		useDryRun = *dryRun
+	}
+	return uc.syncer.Sync(ctx, settings, useDryRun), nil
+}
+
+// LatestReport returns the report of the last sync of the account, nil if it has not been synced yet.
+func (uc *StockSyncUsecase) LatestReport(ctx context.Context, apiKey string) (*entities.StockSyncReport, error) {
+	if _, err := uc.getSettings(ctx, apiKey); err != nil {
+		return nil, err
+	}
+	report, err := uc.storage.GetLatestStockSyncReport(ctx, apiKey)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get stock sync report: %w", err))
+	}
+	return report, nil
+}
+
+func (uc *StockSyncUsecase) getSettings(ctx context.Context, apiKey string) (*entities.StockSyncSettings, error) {
+	settings, err := uc.storage.GetStockSyncSettings(ctx, apiKey)
+	if errors.Is(err, entities.ErrStockSyncNotConfigured) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get stock sync settings: %w", err))
+	}
+	return settings, nil
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"log"
+	"sync"
+)
+
+type wbStockService interface {
+	ListWarehouses(ctx context.Context, apiKey string) ([]entities.Warehouse, error)
+	SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error
+}
+
+type ozonStockService interface {
+	ListWarehouses(ctx context.Context, clientID, apiKey string) ([]entities.Warehouse, error)
+	SetStocks(ctx context.Context, clientID, apiKey string, stocks []entities.OzonStock) ([]entities.OzonStockUpdateResult, error)
+}
+
+type StockUsecase struct {
+	wbStockService   wbStockService
+	ozonStockService ozonStockService
+}
+
+func NewStockUsecase(wbStockService wbStockService, ozonStockService ozonStockService) *StockUsecase {
+	return &StockUsecase{wbStockService: wbStockService, ozonStockService: ozonStockService}
+}
+
+// ListWarehouses lists the warehouses of every marketplace with credentials in req concurrently.
+func (uc *StockUsecase) ListWarehouses(ctx context.Context, req *entities.StockRequest) *entities.WarehouseListResult {
+	result := &entities.WarehouseListResult{}
+	var wbWarehouses, ozonWarehouses []entities.Warehouse
+	var wg sync.WaitGroup
+
+	if req.WbApiKey != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if wbWarehouses, err = uc.wbStockService.ListWarehouses(ctx, req.WbApiKey); err != nil {
+				log.Printf("Failed to list WB warehouses: %v", err)
+				result.WbError = err.Error()
+			}
+		}()
+	}
+
+	if req.OzonApiClientId != "" && req.OzonApiKey != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if ozonWarehouses, err = uc.ozonStockService.ListWarehouses(ctx, req.OzonApiClientId, req.OzonApiKey); err != nil {
+				log.Printf("Failed to list Ozon warehouses: %v", err)
+				result.OzonError = err.Error()
+			}
+		}()
+	}
+
+	wg.Wait()
+	result.Warehouses = append(wbWarehouses, ozonWarehouses...)
+	return result
+}
+
+// SetStocks sets the stocks on both marketplaces concurrently. WB stocks are sent per warehouse;
+// the first failing warehouse stops the WB update and is reported in the result.
+func (uc *StockUsecase) SetStocks(ctx context.Context, req *entities.StockRequest) *entities.StockUpdateResult {
+	result := &entities.StockUpdateResult{}
+	var wg sync.WaitGroup
+
+	if len(req.WbStocks) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var warehouseIDs []int64
+			byWarehouse := make(map[int64][]entities.WBStock)
+			for _, s := range req.WbStocks {
+				if _, ok := byWarehouse[s.WarehouseID]; !ok {
+					warehouseIDs = append(warehouseIDs, s.WarehouseID)
+				}
+				byWarehouse[s.WarehouseID] = append(byWarehouse[s.WarehouseID], s.WBStock)
+			}
+			for _, warehouseID := range warehouseIDs {
+				if err := uc.wbStockService.SetStocks(ctx, req.WbApiKey, warehouseID, byWarehouse[warehouseID]); err != nil {
+					log.Printf("Failed to set WB stocks: %v", err)
+					result.WbError = err.Error()
+					return
+				}
+			}
+		}()
+	}
+
+	if len(req.OzonStocks) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := uc.ozonStockService.SetStocks(ctx, req.OzonApiClientId, req.OzonApiKey, req.OzonStocks)
+			result.OzonResults = results
+			if err != nil {
+				log.Printf("Failed to set Ozon stocks: %v", err)
+				result.OzonError = err.Error()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return result
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type fakeWBStockService struct {
+	mu         sync.Mutex
+	warehouses []entities.Warehouse
+	listErr    error
+	failOn     int64 // Warehouse whose stocks fail to be set
+	set        map[int64][]entities.WBStock
+}
+
+func (f *fakeWBStockService) ListWarehouses(ctx context.Context, apiKey string) ([]entities.Warehouse, error) {
+	return f.warehouses, f.listErr
+}
+
+func (f *fakeWBStockService) SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if warehouseID == f.failOn {
+		return errors.New("WB rejected the stocks")
+	}
+	if f.set == nil {
+		f.set = map[int64][]entities.WBStock{}
+	}
+	f.set[warehouseID] = append(f.set[warehouseID], stocks...)
+	return nil
+}
+
+type fakeOzonStockService struct {
+	warehouses []entities.Warehouse
+	listErr    error
+	stocks     []entities.OzonStock
+}
+
+func (f *fakeOzonStockService) ListWarehouses(ctx context.Context, clientID, apiKey string) ([]entities.Warehouse, error) {
+	return f.warehouses, f.listErr
+}
+
+func (f *fakeOzonStockService) SetStocks(ctx context.Context, clientID, apiKey string, stocks []entities.OzonStock) ([]entities.OzonStockUpdateResult, error) {
+	f.stocks = stocks
+	results := make([]entities.OzonStockUpdateResult, len(stocks))
+	for i, s := range stocks {
+		results[i] = entities.OzonStockUpdateResult{OfferID: s.OfferID, WarehouseID: s.WarehouseID, Updated: true}
+	}
+	return results, nil
+}
+
+func TestStockUsecase_ListWarehouses(t *testing.T) {
+	wb := &fakeWBStockService{warehouses: []entities.Warehouse{{Marketplace: entities.MarketplaceWB, ID: 1, Name: "Склад"}}}
+	ozon := &fakeOzonStockService{listErr: errors.New("ozon is unavailable")}
+	uc := NewStockUsecase(wb, ozon)
+
+	result := uc.ListWarehouses(context.Background(), &entities.StockRequest{WbApiKey: "wb", OzonApiClientId: "client", OzonApiKey: "ozon"})
+	if len(result.Warehouses) != 1 || result.Warehouses[0].ID != 1 {
+		t.Errorf("Expected the WB warehouse, got %+v", result.Warehouses)
+	}
+	if result.WbError != "" || result.OzonError != "ozon is unavailable" {
+		t.Errorf("Expected only the Ozon error, got %q and %q", result.WbError, result.OzonError)
+	}
+
+	result = uc.ListWarehouses(context.Background(), &entities.StockRequest{OzonApiClientId: "client"})
+	if len(result.Warehouses) != 0 || result.OzonError != "" {
+		t.Errorf("Expected marketplaces without credentials to be skipped, got %+v", result)
+	}
+}
+
+func TestStockUsecase_SetStocks(t *testing.T) {
+	req := &entities.StockRequest{
+		WbApiKey: "wb",
+		WbStocks: []entities.WBWarehouseStock{
+			{WarehouseID: 1, WBStock: entities.WBStock{Sku: "2000000000011", Amount: 3}},
+			{WarehouseID: 2, WBStock: entities.WBStock{Sku: "2000000000028", Amount: 4}},
+			{WarehouseID: 1, WBStock: entities.WBStock{Sku: "2000000000035", Amount: 5}},
+		},
+		OzonApiClientId: "client",
+		OzonApiKey:      "ozon",
+		OzonStocks:      []entities.OzonStock{{OfferID: "VC001", Stock: 6, WarehouseID: 22}},
+	}
+
+	t.Run("groups WB stocks by warehouse", func(t *testing.T) {
+		wb := &fakeWBStockService{}
+		ozon := &fakeOzonStockService{}
+		result := NewStockUsecase(wb, ozon).SetStocks(context.Background(), req)
+
+		if result.WbError != "" || result.OzonError != "" {
+			t.Fatalf("Expected no errors, got %q and %q", result.WbError, result.OzonError)
+		}
+		if len(wb.set[1]) != 2 || len(wb.set[2]) != 1 {
+			t.Errorf("Expected 2 stocks in warehouse 1 and 1 in warehouse 2, got %+v", wb.set)
+		}
+		if len(ozon.stocks) != 1 || len(result.OzonResults) != 1 || !result.OzonResults[0].Updated {
+			t.Errorf("Expected the Ozon stock to be set and reported, got %+v", result.OzonResults)
+		}
+	})
+
+	t.Run("reports the failing WB warehouse", func(t *testing.T) {
+		wb := &fakeWBStockService{failOn: 1}
+		ozon := &fakeOzonStockService{}
+		result := NewStockUsecase(wb, ozon).SetStocks(context.Background(), req)
+
+		if result.WbError == "" {
+			t.Error("Expected the WB error to be reported")
+		}
+		if len(wb.set) != 0 {
+			t.Errorf("Expected the WB update to stop at the failing warehouse, got %+v", wb.set)
+		}
+		if len(ozon.stocks) != 1 || result.OzonError != "" {
+			t.Errorf("Expected the Ozon stocks to be set regardless, got %+v and %q", ozon.stocks, result.OzonError)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"log"
+	"sync"
+)
+
+type wbPriceUpdater interface {
+	UpdatePrices(ctx context.Context, apiKey string, goods []entities.WBPriceGood) (*entities.WBPriceTask, int64, error)
+}
+
+type ozonPriceUpdater interface {
+	UpdatePrices(ctx context.Context, clientID, apiKey string, prices []entities.OzonPrice) ([]entities.OzonPriceUpdateResult, error)
+}
+
+type UpdatePricesUsecase struct {
+	wbPriceUpdater   wbPriceUpdater
+	ozonPriceUpdater ozonPriceUpdater
+}
+
+func NewUpdatePricesUsecase(wbPriceUpdater wbPriceUpdater, ozonPriceUpdater ozonPriceUpdater) *UpdatePricesUsecase {
+	return &UpdatePricesUsecase{wbPriceUpdater: wbPriceUpdater, ozonPriceUpdater: ozonPriceUpdater}
+}
+
+// UpdatePrices sets the prices on both marketplaces concurrently. Marketplace errors are reported
+// in the result so that a failure on one marketplace does not hide the outcome on the other.
+func (uc *UpdatePricesUsecase) UpdatePrices(ctx context.Context, req *entities.PriceUpdateRequest) *entities.PriceUpdateResult {
+	result := &entities.PriceUpdateResult{}
+	var wg sync.WaitGroup
+
+	if len(req.WbPrices) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task, uploadID, err := uc.wbPriceUpdater.UpdatePrices(ctx, req.WbApiKey, req.WbPrices)
+			result.WbUploadID = uploadID
+			result.WbTask = task
+			if err != nil {
+				log.Printf("Failed to update WB prices: %v", err)
+				result.WbError = err.Error()
+			}
+		}()
+	}
+
+	if len(req.OzonPrices) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := uc.ozonPriceUpdater.UpdatePrices(ctx, req.OzonApiClientId, req.OzonApiKey, req.OzonPrices)
+			result.OzonResults = results
+			if err != nil {
+				log.Printf("Failed to update Ozon prices: %v", err)
+				result.OzonError = err.Error()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return result
+}
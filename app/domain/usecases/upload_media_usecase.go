@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"io"
+)
+
+type mediaUploadService interface {
+	UploadMediaStream(ctx context.Context, filename, contentType string, content io.Reader) (*entities.MediaFile, error)
+}
+
+type UploadMediaUsecase struct {
+	mediaUploadService mediaUploadService
+}
+
+func NewUploadMediaUsecase(mediaUploadService mediaUploadService) *UploadMediaUsecase {
+	return &UploadMediaUsecase{mediaUploadService: mediaUploadService}
+}
+
+// UploadMedia stores a single media file streamed by the client and returns its ID for later use in CreateRequest.
+func (uc *UploadMediaUsecase) UploadMedia(ctx context.Context, filename, contentType string, content io.Reader) (*entities.MediaFile, error) {
+	return uc.mediaUploadService.UploadMediaStream(ctx, filename, contentType, content)
+}
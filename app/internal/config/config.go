@@ -12,23 +12,97 @@ type Config struct {
 	IsDebug bool `env:"IS_DEBUG" env-default:"false"`
 
 	CardCraftAi struct {
-		URL  string `env:"CARD_CRAFT_AI_API_URL" env-required:"true"`
-		Port int    `env:"CARD_CRAFT_AI_PORT" env-default:"8080"`
+		URL                     string `env:"CARD_CRAFT_AI_API_URL" env-required:"true"`
+		Port                    int    `env:"CARD_CRAFT_AI_PORT" env-default:"8080"`
+		SessionTimeoutSeconds   int    `env:"CARD_CRAFT_AI_SESSION_TIMEOUT_SECONDS" env-default:"10"`
+		RunTimeoutSeconds       int    `env:"CARD_CRAFT_AI_RUN_TIMEOUT_SECONDS" env-default:"180"`
+		MaxRetries              int    `env:"CARD_CRAFT_AI_MAX_RETRIES" env-default:"2"`
+		RetryBaseDelayMs        int    `env:"CARD_CRAFT_AI_RETRY_BASE_DELAY_MS" env-default:"500"`
+		RetryMaxDelayMs         int    `env:"CARD_CRAFT_AI_RETRY_MAX_DELAY_MS" env-default:"5000"`
+		BreakerFailureThreshold int    `env:"CARD_CRAFT_AI_BREAKER_FAILURE_THRESHOLD" env-default:"5"`
+		BreakerOpenSeconds      int    `env:"CARD_CRAFT_AI_BREAKER_OPEN_SECONDS" env-default:"30"`
+		CacheTTLHours           int    `env:"CARD_CRAFT_AI_CACHE_TTL_HOURS" env-default:"24"`
+		CacheHitBillingPercent  int    `env:"CARD_CRAFT_AI_CACHE_HIT_BILLING_PERCENT" env-default:"0"`
+		ProxyURL                string `env:"CARD_CRAFT_AI_PROXY_URL" env-default:""`
+	}
+	Content struct {
+		DefaultProvider string   `env:"CONTENT_DEFAULT_PROVIDER" env-default:"card_craft_ai"`
+		WbStopWords     []string `env:"CONTENT_WB_STOP_WORDS" env-separator:","`
+		OzonStopWords   []string `env:"CONTENT_OZON_STOP_WORDS" env-separator:","`
+	}
+	OpenAi struct {
+		BaseURL        string `env:"OPENAI_BASE_URL" env-default:"https://api.openai.com"`
+		APIKey         string `env:"OPENAI_API_KEY" env-default:""`
+		Model          string `env:"OPENAI_MODEL" env-default:"gpt-4o-mini"`
+		TimeoutSeconds int    `env:"OPENAI_TIMEOUT_SECONDS" env-default:"120"`
+		PromptTemplate string `env:"OPENAI_PROMPT_TEMPLATE" env-default:"card_content"`
+		ProxyURL       string `env:"OPENAI_PROXY_URL" env-default:""`
 	}
 	WB struct {
-		GetCardListMaxAttempts int `env:"WB_GET_CARD_LIST_MAX_ATTEMPTS" env-default:"3"`
+		NmIDResolveBaseDelaySeconds   int    `env:"WB_NM_ID_RESOLVE_BASE_DELAY_SECONDS" env-default:"5"`
+		NmIDResolveMaxDelaySeconds    int    `env:"WB_NM_ID_RESOLVE_MAX_DELAY_SECONDS" env-default:"60"`
+		NmIDResolveTimeoutMinutes     int    `env:"WB_NM_ID_RESOLVE_TIMEOUT_MINUTES" env-default:"30"`
+		NmIDResolveMaxPendingJobs     int    `env:"WB_NM_ID_RESOLVE_MAX_PENDING_JOBS" env-default:"1000"`
+		APIURL                        string `env:"WB_API_URL" env-default:"https://content-api.wildberries.ru"`
+		TimeoutSeconds                int    `env:"WB_TIMEOUT_SECONDS" env-default:"60"`
+		ProxyURL                      string `env:"WB_PROXY_URL" env-default:""`
+		RateLimitPerMinute            int    `env:"WB_RATE_LIMIT_PER_MINUTE" env-default:"100"`
+		RateLimitBurst                int    `env:"WB_RATE_LIMIT_BURST" env-default:"5"`
+		MaxRetries                    int    `env:"WB_MAX_RETRIES" env-default:"3"`
+		RetryBaseDelayMs              int    `env:"WB_RETRY_BASE_DELAY_MS" env-default:"1000"`
+		RetryMaxDelayMs               int    `env:"WB_RETRY_MAX_DELAY_MS" env-default:"30000"`
+		PricesAPIURL                  string `env:"WB_PRICES_API_URL" env-default:"https://discounts-prices-api.wildberries.ru"`
+		PricesRateLimitPerMinute      int    `env:"WB_PRICES_RATE_LIMIT_PER_MINUTE" env-default:"100"`
+		PricesRateLimitBurst          int    `env:"WB_PRICES_RATE_LIMIT_BURST" env-default:"10"`
+		PricesTaskPollSeconds         int    `env:"WB_PRICES_TASK_POLL_SECONDS" env-default:"2"`
+		PricesTaskTimeoutSeconds      int    `env:"WB_PRICES_TASK_TIMEOUT_SECONDS" env-default:"60"`
+		MarketplaceAPIURL             string `env:"WB_MARKETPLACE_API_URL" env-default:"https://marketplace-api.wildberries.ru"`
+		MarketplaceRateLimitPerMinute int    `env:"WB_MARKETPLACE_RATE_LIMIT_PER_MINUTE" env-default:"300"`
+		MarketplaceRateLimitBurst     int    `env:"WB_MARKETPLACE_RATE_LIMIT_BURST" env-default:"20"`
+	}
+	Ozon struct {
+		APIURL                string `env:"OZON_API_URL" env-default:"https://api-seller.ozon.ru"`
+		TimeoutSeconds        int    `env:"OZON_TIMEOUT_SECONDS" env-default:"60"`
+		ProxyURL              string `env:"OZON_PROXY_URL" env-default:""`
+		RateLimitPerMinute    int    `env:"OZON_RATE_LIMIT_PER_MINUTE" env-default:"3000"`
+		RateLimitBurst        int    `env:"OZON_RATE_LIMIT_BURST" env-default:"10"`
+		MaxRetries            int    `env:"OZON_MAX_RETRIES" env-default:"3"`
+		RetryBaseDelayMs      int    `env:"OZON_RETRY_BASE_DELAY_MS" env-default:"500"`
+		RetryMaxDelayMs       int    `env:"OZON_RETRY_MAX_DELAY_MS" env-default:"10000"`
+		BarcodePollSeconds    int    `env:"OZON_BARCODE_POLL_SECONDS" env-default:"2"`
+		BarcodeTimeoutSeconds int    `env:"OZON_BARCODE_TIMEOUT_SECONDS" env-default:"30"`
+	}
+	CategoryMapping struct {
+		MinResolutions int      `env:"CATEGORY_MAPPING_MIN_RESOLUTIONS" env-default:"3"`   // Resolutions of a pair before it replaces CardCraftAI
+		MinConfidence  float64  `env:"CATEGORY_MAPPING_MIN_CONFIDENCE" env-default:"0.8"`  // Share of the resolutions of the known category that gave the pair
+		EditorApiKeys  []string `env:"CATEGORY_MAPPING_EDITOR_API_KEYS" env-separator:","` // API keys of content managers allowed to override mappings
+	}
+	WbCatalog struct {
+		SyncIntervalHours   int    `env:"WB_CATALOG_SYNC_INTERVAL_HOURS" env-default:"24"` // 0 disables the sync
+		ApiKey              string `env:"WB_CATALOG_API_KEY" env-default:""`               // WB API key used to read the catalog, empty disables the sync
+		Locale              string `env:"WB_CATALOG_LOCALE" env-default:"ru"`
+		TnvedSubjectsPerRun int    `env:"WB_CATALOG_TNVED_SUBJECTS_PER_RUN" env-default:"500"`
+	}
+	StockSync struct {
+		IntervalMinutes       int    `env:"STOCK_SYNC_INTERVAL_MINUTES" env-default:"15"`       // 0 disables the periodic sync
+		AccountTimeoutMinutes int    `env:"STOCK_SYNC_ACCOUNT_TIMEOUT_MINUTES" env-default:"5"` // Deadline of the sync of one account
+		ReportRetentionDays   int    `env:"STOCK_SYNC_REPORT_RETENTION_DAYS" env-default:"30"`  // 0 keeps all reports
+		EncryptionKey         string `env:"STOCK_SYNC_ENCRYPTION_KEY" env-default:""`           // Base64-encoded 32-byte key the marketplace API keys are encrypted with
 	}
 	TokenCounter struct {
-		APIURL string `env:"TOKEN_COUNTER_API_URL" env-required:"true"`
-		Port   int    `env:"TOKEN_COUNTER_PORT" env-default:"8080"`
+		APIURL         string `env:"TOKEN_COUNTER_API_URL" env-required:"true"`
+		Port           int    `env:"TOKEN_COUNTER_PORT" env-default:"8080"`
+		TimeoutSeconds int    `env:"TOKEN_COUNTER_TIMEOUT_SECONDS" env-default:"10"`
+		ProxyURL       string `env:"TOKEN_COUNTER_PROXY_URL" env-default:""`
 	}
 	HTTP struct {
 		Port int `env:"PORT" env-default:"8080"`
 	}
 	FileStorage struct {
-		UploadDir  string `env:"FILE_STORAGE_UPLOAD_DIR" env-default:"./uploads"`
-		TTLMinutes int    `env:"FILE_STORAGE_TTL_MINUTES" env-default:"10"`
-		BaseURL    string `env:"FILE_STORAGE_BASE_URL" env-required:"true"`
+		UploadDir   string `env:"FILE_STORAGE_UPLOAD_DIR" env-default:"./uploads"`
+		TTLMinutes  int    `env:"FILE_STORAGE_TTL_MINUTES" env-default:"10"`
+		BaseURL     string `env:"FILE_STORAGE_BASE_URL" env-required:"true"`
+		MaxUploadMB int    `env:"FILE_STORAGE_MAX_UPLOAD_MB" env-default:"20"`
 	}
 	PostgreSQL struct {
 		Database string `env:"PG_DATABASE" env-required:"true"`
@@ -41,6 +115,9 @@ type Config struct {
 		SecretKey        string `env:"TINKOFF_SECRET_KEY" env-required:"true"`
 		TerminalKey      string `env:"TINKOFF_TERMINAL_KEY" env-required:"true"`
 		TelegramBotToken string `env:"TELEGRAM_BOT_TOKEN" env-default:""`
+		APIURL           string `env:"TINKOFF_API_URL" env-default:"https://securepay.tinkoff.ru"`
+		TimeoutSeconds   int    `env:"TINKOFF_TIMEOUT_SECONDS" env-default:"30"`
+		ProxyURL         string `env:"TINKOFF_PROXY_URL" env-default:""`
 	}
 }
 
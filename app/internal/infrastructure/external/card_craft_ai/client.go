@@ -2,6 +2,8 @@ package card_craft_ai
 
 import (
 	"api/app/domain/entities"
+	"api/app/internal/infrastructure/external/resilience"
+	"api/metrics"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,17 +11,40 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
 )
 
+// Options configures timeouts and retries of CardCraftAI calls.
+type Options struct {
+	SessionTimeout time.Duration // Timeout of a single POST /v1/sessions attempt
+	RunTimeout     time.Duration // Timeout of a single POST /run attempt
+	MaxRetries     int           // Retries on 5xx and connection errors, 0 disables retries
+	RetryBaseDelay time.Duration // Base delay of the jittered exponential backoff
+	RetryMaxDelay  time.Duration // Upper bound of a single backoff delay
+}
+
 type CardCraftAiClient struct {
 	cardCraftAiAPIURL string
 	getSessionURL     string
+	httpClient        *http.Client
+	opts              Options
+	breaker           *resilience.CircuitBreaker
 }
 
-func NewCardCraftAiClient(cardCraftAiAPIURL string) *CardCraftAiClient {
+// NewCardCraftAiClient creates the CardCraftAI client. Per-call timeouts come from opts,
+// httpClient provides the transport; nil selects the default one.
+func NewCardCraftAiClient(cardCraftAiAPIURL string, opts Options, breaker *resilience.CircuitBreaker, httpClient *http.Client) *CardCraftAiClient {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 	return &CardCraftAiClient{
 		cardCraftAiAPIURL: cardCraftAiAPIURL,
 		getSessionURL:     fmt.Sprintf("%s/v1/sessions", cardCraftAiAPIURL),
+		httpClient:        httpClient,
+		opts:              opts,
+		breaker:           breaker,
 	}
 }
 
@@ -28,32 +53,23 @@ func (c *CardCraftAiClient) GetSessionID(ctx context.Context) (string, error) {
 	sessionURL := c.getSessionURL
 	log.Printf("Requesting session from: %s", sessionURL)
 
-	// Create request for session
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", sessionURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create session request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute the session request
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to call session API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	statusCode, respBody, err := c.doWithRetry(ctx, "card_craft_ai_session", c.opts.SessionTimeout, func(ctx context.Context) (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", sessionURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create session request: %w", err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		return httpReq, nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to read session response body: %w", err)
+		return "", err
 	}
 
-	log.Printf("Session API response status: %d, body: %s", resp.StatusCode, string(respBody))
+	log.Printf("Session API response status: %d, body: %s", statusCode, string(respBody))
 
 	// Check HTTP status
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("session API returned status %d: %s", resp.StatusCode, string(respBody))
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("session API returned status %d: %s", statusCode, string(respBody))
 	}
 
 	// Parse session response
@@ -68,18 +84,45 @@ func (c *CardCraftAiClient) GetSessionID(ctx context.Context) (string, error) {
 	return sessionResp.SessionID, nil
 }
 
-func (c *CardCraftAiClient) GetCardContent(ctx context.Context, sessionID string, productCard entities.ProductCard) (*entities.CardCraftAiGeneratedContent, error) {
+func (c *CardCraftAiClient) GetCardContent(ctx context.Context, sessionID string, cardCraftAiRequest entities.CardCraftAiRequest) (*entities.CardCraftAiGeneratedContent, error) {
 	log.Printf("Got session ID: %s", sessionID)
 
+	productCard := cardCraftAiRequest.ProductCard
+	sizes := make([]map[string]interface{}, len(productCard.Sizes))
+	for i, s := range productCard.Sizes {
+		sizes[i] = map[string]interface{}{
+			"tech_size": s.TechSize,
+			"wb_size":   s.WbSize,
+		}
+	}
+
 	cardCraftAiAPIRequest := map[string]interface{}{
 		"product_title":       productCard.ProductTitle,
 		"product_description": productCard.ProductDescription,
 		"session_id":          sessionID,
 		"parent_id":           productCard.ParentId,
 		"subject_id":          productCard.SubjectId,
+		"root_id":             productCard.RootId,
+		"sub_id":              productCard.SubId,
+		"type_id":             productCard.TypeId,
 		"translate":           productCard.Translate,
-		"ozon":                productCard.Ozon,
+		"wb":                  cardCraftAiRequest.ResolveWbCategory,
+		"ozon":                cardCraftAiRequest.ResolveOzonCategory,
 		"generate_content":    productCard.GenerateContent,
+		"brand":               productCard.Brand,
+		"vendor_code":         productCard.VendorCode,
+		"sizes":               sizes,
+	}
+	if productCard.Dimensions != nil {
+		cardCraftAiAPIRequest["dimensions"] = productCard.Dimensions
+	}
+	if productCard.ContentVariant > 0 {
+		// Alternative content requested, CardCraftAI should word it differently from the first variant
+		cardCraftAiAPIRequest["variant"] = productCard.ContentVariant
+	}
+	if productCard.ContentFeedback != "" {
+		// Marketplace constraints the previous generation broke
+		cardCraftAiAPIRequest["feedback"] = productCard.ContentFeedback
 	}
 	// Marshal request to JSON
 	reqBody, err := json.Marshal(cardCraftAiAPIRequest)
@@ -91,29 +134,21 @@ func (c *CardCraftAiClient) GetCardContent(ctx context.Context, sessionID string
 	productCardURL := fmt.Sprintf("%s/run", c.cardCraftAiAPIURL)
 
 	// Make request to Python API with session ID in body
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", productCardURL, bytes.NewBuffer(reqBody))
+	statusCode, respBody, err := c.doWithRetry(ctx, "card_craft_ai_run", c.opts.RunTimeout, func(ctx context.Context) (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", productCardURL, bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		return httpReq, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute the request
-	resp, err := http.DefaultClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call CardCraftAI API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	// Check HTTP status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("CardCraftAI API returned status %d: %s", resp.StatusCode, string(respBody))
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("CardCraftAI API returned status %d: %s", statusCode, string(respBody))
 	}
 
 	// Parse CardCraftAI API response
@@ -151,11 +186,75 @@ func (c *CardCraftAiClient) GetCardContent(ctx context.Context, sessionID string
 		RootName:    cardCraftAiResp.RootName,
 		SubID:       cardCraftAiResp.SubID,
 		SubName:     cardCraftAiResp.SubName,
+		SessionID:   sessionID,
 	}
 
 	return response, nil
 }
 
+// doWithRetry executes the request built by newReq, retrying 5xx responses and connection errors
+// with jittered backoff. Every attempt has its own timeout and passes through the circuit breaker,
+// which makes calls fail fast with connect.CodeUnavailable while CardCraftAI is down.
+func (c *CardCraftAiClient) doWithRetry(ctx context.Context, apiName string, timeout time.Duration, newReq func(ctx context.Context) (*http.Request, error)) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			return 0, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("CardCraftAI is unavailable: %w", err))
+		}
+
+		statusCode, respBody, err := c.do(ctx, timeout, newReq)
+		if err == nil && statusCode < http.StatusInternalServerError {
+			c.breaker.Success()
+			return statusCode, respBody, nil
+		}
+		if err != nil && ctx.Err() != nil {
+			// The caller canceled or timed out, not CardCraftAI; the timeout of the attempt is still a failure
+			c.breaker.Abandon()
+			return 0, nil, ctx.Err()
+		}
+		c.breaker.Failure()
+
+		if err == nil {
+			err = fmt.Errorf("CardCraftAI API returned status %d: %s", statusCode, string(respBody))
+		}
+		if attempt >= c.opts.MaxRetries || ctx.Err() != nil {
+			return 0, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to call CardCraftAI API after %d attempts: %w", attempt+1, err))
+		}
+
+		delay := resilience.Backoff(attempt, c.opts.RetryBaseDelay, c.opts.RetryMaxDelay)
+		log.Printf("CardCraftAI call %s failed (attempt %d/%d): %v. Retrying in %v", apiName, attempt+1, c.opts.MaxRetries+1, err, delay)
+		metrics.AppExternalAPIRetriesTotal.WithLabelValues(apiName).Inc()
+		if err := resilience.Sleep(ctx, delay); err != nil {
+			return 0, nil, err
+		}
+	}
+}
+
+// do executes a single attempt bounded by timeout and returns the status code and body.
+func (c *CardCraftAiClient) do(ctx context.Context, timeout time.Duration, newReq func(ctx context.Context) (*http.Request, error)) (int, []byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	httpReq, err := newReq(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to call CardCraftAI API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.StatusCode, respBody, nil
+}
+
 // safeInt32Value safely dereferences an *int32 pointer
 func safeInt32Value(ptr *int32) interface{} {
 	if ptr == nil {
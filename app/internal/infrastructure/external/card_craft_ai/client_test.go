@@ -0,0 +1,95 @@
+package card_craft_ai
+
+import (
+	"api/app/domain/entities"
+	"api/app/internal/infrastructure/external/resilience"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCardCraftAiClient_CallerCancelDoesNotOpenBreaker(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	breaker := resilience.NewCircuitBreaker("card_craft_ai_test_cancel", 1, time.Minute)
+	client := NewCardCraftAiClient(server.URL, Options{MaxRetries: 2, RetryBaseDelay: time.Millisecond, RetryMaxDelay: time.Millisecond}, breaker, server.Client())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetSessionID(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline of the caller, got %v", err)
+	}
+	if breaker.State() != resilience.BreakerClosed {
+		t.Errorf("Expected breaker to stay closed, got %s", breaker.State())
+	}
+}
+
+func TestCardCraftAiClient_AttemptTimeoutOpensBreaker(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	breaker := resilience.NewCircuitBreaker("card_craft_ai_test_timeout", 1, time.Minute)
+	client := NewCardCraftAiClient(server.URL, Options{SessionTimeout: 20 * time.Millisecond, MaxRetries: 2, RetryBaseDelay: time.Millisecond, RetryMaxDelay: time.Millisecond}, breaker, server.Client())
+
+	if _, err := client.GetSessionID(context.Background()); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if breaker.State() != resilience.BreakerOpen {
+		t.Errorf("Expected breaker to open after a timed out attempt, got %s", breaker.State())
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected the open breaker to stop retries, got %d calls", calls.Load())
+	}
+}
+
+func TestCardCraftAiClient_GetCardContent_ResolveFlags(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Write([]byte(`{"title":"Футболка"}`))
+	}))
+	defer server.Close()
+
+	client := NewCardCraftAiClient(server.URL, Options{}, nil, server.Client())
+	tests := []struct {
+		resolveWb, resolveOzon bool
+	}{
+		{true, false},
+		{false, true},
+		{true, true},
+		{false, false},
+	}
+	for _, tt := range tests {
+		_, err := client.GetCardContent(context.Background(), "session", entities.CardCraftAiRequest{
+			ProductCard:         entities.ProductCard{ProductTitle: "Футболка", Ozon: !tt.resolveOzon},
+			ResolveWbCategory:   tt.resolveWb,
+			ResolveOzonCategory: tt.resolveOzon,
+		})
+		if err != nil {
+			t.Fatalf("GetCardContent: %v", err)
+		}
+		// The flags carry the categories to resolve, not the marketplaces the card is created on
+		if body["wb"] != tt.resolveWb || body["ozon"] != tt.resolveOzon {
+			t.Errorf("wb = %v, ozon = %v, want %t, %t", body["wb"], body["ozon"], tt.resolveWb, tt.resolveOzon)
+		}
+	}
+}
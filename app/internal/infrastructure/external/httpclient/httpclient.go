@@ -0,0 +1,27 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Options configures an HTTP client for an external API.
+type Options struct {
+	Timeout  time.Duration // Whole request timeout, 0 for none
+	ProxyURL string        // Proxy for all requests, empty to use HTTP_PROXY/HTTPS_PROXY from the environment
+}
+
+// New creates an HTTP client with its own transport, so settings of one external API never leak into another.
+func New(opts Options) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", opts.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return &http.Client{Timeout: opts.Timeout, Transport: transport}, nil
+}
@@ -0,0 +1,84 @@
+package openai
+
+import (
+	"api/app/domain/entities"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Client talks to an OpenAI-compatible chat completions API (OpenAI, vLLM, Ollama, local stand-ins).
+type Client struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewClient creates a new chat completions client. An empty apiKey skips the Authorization header
+// and a nil httpClient selects a client without timeout.
+func NewClient(baseURL, apiKey, model string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: httpClient,
+	}
+}
+
+// CreateChatCompletion generates a completion for the given messages.
+// Corresponds to POST /v1/chat/completions
+func (c *Client) CreateChatCompletion(ctx context.Context, request entities.ChatCompletionRequest) (*entities.ChatCompletionResponse, error) {
+	if request.Model == "" {
+		request.Model = c.model
+	}
+
+	payloadBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal chat completion request: %w", err)
+	}
+
+	completionURL := fmt.Sprintf("%s/v1/chat/completions", c.baseURL)
+	log.Printf("Requesting chat completion: %s, model: %s, messages: %d", completionURL, request.Model, len(request.Messages))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, completionURL, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat completion request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call chat completion API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chat completion response body: %w", err)
+	}
+
+	log.Printf("Chat completion API response status: %d", resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chat completion API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var completionResp entities.ChatCompletionResponse
+	if err := json.Unmarshal(respBody, &completionResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chat completion response: %w. Body: %s", err, string(respBody))
+	}
+	return &completionResp, nil
+}
@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_CreateChatCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Method != http.MethodPost {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req entities.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Model != "gpt-4o-mini" || len(req.Messages) != 2 || req.ResponseFormat.Type != "json_object" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		w.Write([]byte(`{"id":"cmpl-1","choices":[{"index":0,"message":{"role":"assistant","content":"{\"title\":\"Футболка\"}"}}],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "secret", "gpt-4o-mini", server.Client())
+	resp, err := client.CreateChatCompletion(context.Background(), entities.ChatCompletionRequest{
+		Messages:       []entities.ChatMessage{{Role: "system", Content: "system"}, {Role: "user", Content: "user"}},
+		ResponseFormat: &entities.ChatResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion: %v", err)
+	}
+	if len(resp.Choices) != 1 || resp.Choices[0].Message.Content != `{"title":"Футболка"}` || resp.Usage.TotalTokens != 15 {
+		t.Errorf("Unexpected response %+v", resp)
+	}
+}
+
+func TestClient_CreateChatCompletion_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Expected no Authorization header without an API key")
+		}
+		http.Error(w, `{"error":{"message":"model not found"}}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "missing", server.Client())
+	_, err := client.CreateChatCompletion(context.Background(), entities.ChatCompletionRequest{})
+	if err == nil || !strings.Contains(err.Error(), "status 404") || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("Expected the status and body in the error, got %v", err)
+	}
+}
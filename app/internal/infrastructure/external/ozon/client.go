@@ -2,6 +2,8 @@ package ozon
 
 import (
 	"api/app/domain/entities"
+	"api/app/internal/infrastructure/external/resilience"
+	"api/metrics"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,20 +11,51 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
 )
 
+// ozonAPIHost is the default base URL for Ozon Seller API.
 const ozonAPIHost = "https://api-seller.ozon.ru"
 
+// Options configures rate limiting and retries of Ozon calls.
+type Options struct {
+	RequestsPerMinute int           // Requests allowed per Client-Id and minute, 0 disables client-side limiting
+	Burst             int           // Requests per Client-Id that may be sent at once
+	MaxRetries        int           // Retries on 429, 5xx and connection errors, 0 disables retries
+	RetryBaseDelay    time.Duration // Base delay of the jittered exponential backoff
+	RetryMaxDelay     time.Duration // Upper bound of a single backoff delay
+}
+
 // Client manages communication with the Ozon Seller API.
 type Client struct {
+	baseURL    string
 	httpClient *http.Client
+	opts       Options
+	limiter    *resilience.RateLimiter
 }
 
-// NewClient creates a new Ozon API client.
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{},
+// NewClient creates a new Ozon API client. An empty baseURL selects the production host
+// and a nil httpClient a client without timeout. The rate limiter is shared by all requests of the client.
+func NewClient(baseURL string, opts Options, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = ozonAPIHost
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		opts:       opts,
+	}
+	if opts.RequestsPerMinute > 0 {
+		c.limiter = resilience.NewRateLimiter(opts.RequestsPerMinute, opts.Burst)
 	}
+	return c
 }
 
 // ImportProductsV3 creates or updates products on Ozon.
@@ -34,18 +67,202 @@ func (c *Client) ImportProductsV3(ctx context.Context, clientID, apiKey string,
 	if apiKey == "" {
 		return nil, fmt.Errorf("ozon Api-Key is required")
 	}
-	return nil, nil
+
 	payloadBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal Ozon product import request: %w", err)
 	}
 
-	importURL := fmt.Sprintf("%s/v3/product/import", ozonAPIHost)
+	importURL := fmt.Sprintf("%s/v3/product/import", c.baseURL)
 	log.Printf("Importing products to Ozon: %s, Payload: %s", importURL, string(payloadBytes))
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, importURL, bytes.NewBuffer(payloadBytes))
+	respBody, err := c.post(ctx, "ozon_product_import", importURL, clientID, apiKey, payloadBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Ozon product import request: %w", err)
+		return nil, err
+	}
+
+	var ozonResp entities.OzonProductImportResponse
+	if err := json.Unmarshal(respBody, &ozonResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Ozon product import response: %w. Body: %s", err, string(respBody))
+	}
+
+	return &ozonResp, nil
+}
+
+// SearchAttributeValues searches the dictionary of an attribute in the given category and type.
+// Corresponds to POST /v1/description-category/attribute/values/search
+func (c *Client) SearchAttributeValues(ctx context.Context, clientID, apiKey string, request entities.OzonAttributeValuesSearchRequest) (*entities.OzonAttributeValuesSearchResponse, error) {
+	var ozonResp entities.OzonAttributeValuesSearchResponse
+	if err := c.postJSON(ctx, "ozon_attribute_values_search", "/v1/description-category/attribute/values/search", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GetCategoryAttributes returns the attributes of the given category and type.
+// Corresponds to POST /v1/description-category/attribute
+func (c *Client) GetCategoryAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonCategoryAttributesRequest) (*entities.OzonCategoryAttributesResponse, error) {
+	var ozonResp entities.OzonCategoryAttributesResponse
+	if err := c.postJSON(ctx, "ozon_category_attributes", "/v1/description-category/attribute", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GetProductInfoList returns the products with the given offer IDs that Ozon has created.
+// Corresponds to POST /v3/product/info/list
+func (c *Client) GetProductInfoList(ctx context.Context, clientID, apiKey string, request entities.OzonProductInfoListRequest) (*entities.OzonProductInfoListResponse, error) {
+	var ozonResp entities.OzonProductInfoListResponse
+	if err := c.postJSON(ctx, "ozon_product_info_list", "/v3/product/info/list", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GetProductAttributes returns the products matching the filter with their attributes, dimensions and images.
+// Corresponds to POST /v4/product/info/attributes
+func (c *Client) GetProductAttributes(ctx context.Context, clientID, apiKey string, request entities.OzonProductAttributesRequest) (*entities.OzonProductAttributesResponse, error) {
+	var ozonResp entities.OzonProductAttributesResponse
+	if err := c.postJSON(ctx, "ozon_product_info_attributes", "/v4/product/info/attributes", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GenerateBarcodes generates barcodes for products that have none.
+// Corresponds to POST /v1/barcode/generate
+func (c *Client) GenerateBarcodes(ctx context.Context, clientID, apiKey string, request entities.OzonBarcodeGenerateRequest) (*entities.OzonBarcodeGenerateResponse, error) {
+	var ozonResp entities.OzonBarcodeGenerateResponse
+	if err := c.postJSON(ctx, "ozon_barcode_generate", "/v1/barcode/generate", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// ImportPrices updates prices of offers.
+// Corresponds to POST /v1/product/import/prices
+func (c *Client) ImportPrices(ctx context.Context, clientID, apiKey string, request entities.OzonImportPricesRequest) (*entities.OzonImportPricesResponse, error) {
+	var ozonResp entities.OzonImportPricesResponse
+	if err := c.postJSON(ctx, "ozon_import_prices", "/v1/product/import/prices", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// ListWarehouses returns the warehouses of the seller.
+// Corresponds to POST /v1/warehouse/list
+func (c *Client) ListWarehouses(ctx context.Context, clientID, apiKey string) (*entities.OzonWarehouseListResponse, error) {
+	var ozonResp entities.OzonWarehouseListResponse
+	if err := c.postJSON(ctx, "ozon_warehouse_list", "/v1/warehouse/list", clientID, apiKey, struct{}{}, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// SetStocks sets stocks of offers in seller warehouses.
+// Corresponds to POST /v2/products/stocks
+func (c *Client) SetStocks(ctx context.Context, clientID, apiKey string, request entities.OzonStocksRequest) (*entities.OzonStocksResponse, error) {
+	var ozonResp entities.OzonStocksResponse
+	if err := c.postJSON(ctx, "ozon_products_stocks", "/v2/products/stocks", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GetProductStocks returns the stocks of products.
+// Corresponds to POST /v4/product/info/stocks
+func (c *Client) GetProductStocks(ctx context.Context, clientID, apiKey string, request entities.OzonProductStocksRequest) (*entities.OzonProductStocksResponse, error) {
+	var ozonResp entities.OzonProductStocksResponse
+	if err := c.postJSON(ctx, "ozon_product_info_stocks", "/v4/product/info/stocks", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// GetWarehouseStocks returns the FBS stocks of products per seller warehouse.
+// Corresponds to POST /v1/product/info/stocks-by-warehouse/fbs
+func (c *Client) GetWarehouseStocks(ctx context.Context, clientID, apiKey string, request entities.OzonWarehouseStocksRequest) (*entities.OzonWarehouseStocksResponse, error) {
+	var ozonResp entities.OzonWarehouseStocksResponse
+	if err := c.postJSON(ctx, "ozon_product_info_stocks_by_warehouse", "/v1/product/info/stocks-by-warehouse/fbs", clientID, apiKey, request, &ozonResp); err != nil {
+		return nil, err
+	}
+	return &ozonResp, nil
+}
+
+// postJSON sends request to path and decodes the response into response.
+func (c *Client) postJSON(ctx context.Context, apiName, path, clientID, apiKey string, request, response interface{}) error {
+	if clientID == "" {
+		return fmt.Errorf("ozon Client-Id is required")
+	}
+	if apiKey == "" {
+		return fmt.Errorf("ozon Api-Key is required")
+	}
+
+	payloadBytes, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Ozon %s request: %w", path, err)
+	}
+	respBody, err := c.post(ctx, apiName, c.baseURL+path, clientID, apiKey, payloadBytes)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, response); err != nil {
+		return fmt.Errorf("failed to unmarshal Ozon %s response: %w. Body: %s", path, err, string(respBody))
+	}
+	return nil
+}
+
+// post sends a JSON request once the rate limiter of clientID allows it and returns the body of a 200 response.
+// 429, 5xx and connection errors are retried; other statuses are returned as a connect error
+// wrapping *entities.OzonError.
+func (c *Client) post(ctx context.Context, apiName, url, clientID, apiKey string, payload []byte) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			waited, err := c.limiter.Wait(ctx, clientID)
+			metrics.AppExternalAPIRateLimitWaitSeconds.WithLabelValues(apiName).Observe(waited.Seconds())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		statusCode, header, respBody, err := c.do(ctx, url, clientID, apiKey, payload)
+		if err == nil {
+			log.Printf("Ozon %s API response status: %d, body: %s", apiName, statusCode, string(respBody))
+			if statusCode == http.StatusOK {
+				return respBody, nil
+			}
+			err = parseError(statusCode, respBody)
+		}
+
+		retryable := statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+		delay := resilience.Backoff(attempt, c.opts.RetryBaseDelay, c.opts.RetryMaxDelay)
+		if statusCode == http.StatusTooManyRequests {
+			metrics.AppExternalAPIRateLimitedTotal.WithLabelValues(apiName).Inc()
+			if seconds, convErr := strconv.Atoi(header.Get("Retry-After")); convErr == nil && seconds >= 0 {
+				delay = time.Duration(seconds) * time.Second
+			}
+			if c.limiter != nil {
+				// Hold back every request of this seller, not only the one that got throttled
+				c.limiter.Pause(clientID, delay)
+			}
+		}
+		if !retryable || attempt >= c.opts.MaxRetries || ctx.Err() != nil {
+			return nil, err
+		}
+
+		log.Printf("Ozon call %s failed (attempt %d/%d): %v. Retrying in %v", apiName, attempt+1, c.opts.MaxRetries+1, err, delay)
+		metrics.AppExternalAPIRetriesTotal.WithLabelValues(apiName).Inc()
+		if err := resilience.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// do executes a single attempt and returns the status code, headers and body.
+func (c *Client) do(ctx context.Context, url, clientID, apiKey string, payload []byte) (int, http.Header, []byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create Ozon request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -54,25 +271,54 @@ func (c *Client) ImportProductsV3(ctx context.Context, clientID, apiKey string,
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call Ozon product import API: %w", err)
+		return 0, nil, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to call Ozon API: %w", err))
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Ozon product import response body: %w", err)
+		return 0, nil, nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to read Ozon response body: %w", err))
 	}
+	return resp.StatusCode, resp.Header, respBody, nil
+}
 
-	log.Printf("Ozon product import API response status: %d, body: %s", resp.StatusCode, string(respBody))
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ozon product import API returned status %d: %s", resp.StatusCode, string(respBody))
+// parseError converts a non-200 Ozon response into a connect error wrapping *entities.OzonError.
+// Bodies that are not an Ozon error object are kept as the message.
+func parseError(statusCode int, body []byte) error {
+	ozonErr := &entities.OzonError{}
+	if err := json.Unmarshal(body, ozonErr); err != nil || (ozonErr.Message == "" && ozonErr.Code == nil) {
+		ozonErr = &entities.OzonError{Message: strings.TrimSpace(string(body))}
 	}
+	ozonErr.HTTPStatus = statusCode
+	return connect.NewError(connectCode(ozonErr), ozonErr)
+}
 
-	var ozonResp entities.OzonProductImportResponse
-	if err := json.Unmarshal(respBody, &ozonResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal Ozon product import response: %w. Body: %s", err, string(respBody))
+// connectCode maps an Ozon error to a connect code. Ozon uses gRPC status codes in the body;
+// the HTTP status is used when the body carries none.
+func connectCode(ozonErr *entities.OzonError) connect.Code {
+	if code, err := strconv.Atoi(ozonErr.CodeString()); err == nil && code > 0 && code <= int(connect.CodeUnauthenticated) {
+		switch connect.Code(code) {
+		case connect.CodeUnauthenticated, connect.CodePermissionDenied:
+			return connect.CodePermissionDenied
+		default:
+			return connect.Code(code)
+		}
 	}
 
-	return &ozonResp, nil
+	switch {
+	case ozonErr.HTTPStatus == http.StatusBadRequest:
+		return connect.CodeInvalidArgument
+	case ozonErr.HTTPStatus == http.StatusUnauthorized, ozonErr.HTTPStatus == http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case ozonErr.HTTPStatus == http.StatusNotFound:
+		return connect.CodeNotFound
+	case ozonErr.HTTPStatus == http.StatusConflict:
+		return connect.CodeAborted
+	case ozonErr.HTTPStatus == http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case ozonErr.HTTPStatus >= http.StatusInternalServerError:
+		return connect.CodeUnavailable
+	default:
+		return connect.CodeUnknown
+	}
 }
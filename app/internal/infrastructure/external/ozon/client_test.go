@@ -0,0 +1,289 @@
+package ozon
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+)
+
+func TestClient_ImportProductsV3(t *testing.T) {
+	ctx := context.Background()
+	request := entities.OzonProductImportRequest{Items: []entities.OzonProductImportItem{{Name: "Кроссовки", OfferID: "VC001", Price: "1000"}}}
+
+	t.Run("Credentials missing", func(t *testing.T) {
+		client := NewClient("", Options{}, nil)
+		if _, err := client.ImportProductsV3(ctx, "", "api-key", request); err == nil {
+			t.Error("Expected an error for missing Client-Id, got nil")
+		}
+		if _, err := client.ImportProductsV3(ctx, "client-id", "", request); err == nil {
+			t.Error("Expected an error for missing Api-Key, got nil")
+		}
+	})
+
+	t.Run("Successful import", func(t *testing.T) {
+		var received entities.OzonProductImportRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v3/product/import" {
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			if r.Header.Get("Client-Id") != "client-id" || r.Header.Get("Api-Key") != "api-key" {
+				t.Errorf("Unexpected credentials: Client-Id %q, Api-Key %q", r.Header.Get("Client-Id"), r.Header.Get("Api-Key"))
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			w.Write([]byte(`{"result":{"task_id":172549793}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, Options{}, server.Client())
+		resp, err := client.ImportProductsV3(ctx, "client-id", "api-key", request)
+		if err != nil {
+			t.Fatalf("ImportProductsV3 returned unexpected error: %v", err)
+		}
+		if resp == nil || resp.Result.TaskID != 172549793 {
+			t.Errorf("Expected task ID in response, got %+v", resp)
+		}
+		if len(received.Items) != 1 || received.Items[0].OfferID != "VC001" {
+			t.Errorf("Import payload was not sent as prepared: %+v", received)
+		}
+	})
+
+	t.Run("Marketplace error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":7,"message":"Api-key is deactivated"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, Options{}, server.Client())
+		_, err := client.ImportProductsV3(ctx, "client-id", "api-key", request)
+		if err == nil {
+			t.Fatal("Expected an error for non-200 status, got nil")
+		}
+		if !strings.Contains(err.Error(), "deactivated") {
+			t.Errorf("Expected error to contain the response body, got: %s", err.Error())
+		}
+		var ozonErr *entities.OzonError
+		if !errors.As(err, &ozonErr) || ozonErr.CodeString() != "7" || ozonErr.HTTPStatus != http.StatusForbidden {
+			t.Errorf("Expected parsed OzonError with code 7, got %#v", ozonErr)
+		}
+		if connect.CodeOf(err) != connect.CodePermissionDenied {
+			t.Errorf("Expected PermissionDenied, got %v", connect.CodeOf(err))
+		}
+	})
+
+	t.Run("Retries on 429 and 5xx", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			switch calls {
+			case 1:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"code":8,"message":"Too many requests"}`))
+			case 2:
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"code":13,"message":"internal error"}`))
+			default:
+				w.Write([]byte(`{"result":{"task_id":1}}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, Options{RequestsPerMinute: 6000, Burst: 5, MaxRetries: 2}, server.Client())
+		if _, err := client.ImportProductsV3(ctx, "client-id", "api-key", request); err != nil {
+			t.Fatalf("ImportProductsV3 returned unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("Does not retry client errors", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":3,"message":"invalid offer_id"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, Options{MaxRetries: 2}, server.Client())
+		_, err := client.ImportProductsV3(ctx, "client-id", "api-key", request)
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 call, got %d", calls)
+		}
+	})
+}
+
+func TestClient_SearchAttributeValues(t *testing.T) {
+	var received entities.OzonAttributeValuesSearchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/description-category/attribute/values/search" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"result":[{"id":970,"value":"44"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{}, server.Client())
+	resp, err := client.SearchAttributeValues(context.Background(), "client-id", "api-key", entities.OzonAttributeValuesSearchRequest{
+		AttributeID: entities.OzonSizeAttributeID, DescriptionCategoryID: 1, TypeID: 2, Value: "44", Limit: 50,
+	})
+	if err != nil {
+		t.Fatalf("SearchAttributeValues returned unexpected error: %v", err)
+	}
+	if len(resp.Result) != 1 || resp.Result[0].ID != 970 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if received.AttributeID != entities.OzonSizeAttributeID || received.Value != "44" {
+		t.Errorf("Search request was not sent as prepared: %+v", received)
+	}
+}
+
+func TestClient_ImportPrices(t *testing.T) {
+	var received entities.OzonImportPricesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/product/import/prices" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"result":[{"product_id":1386,"offer_id":"VC001","updated":true,"errors":[]},{"product_id":0,"offer_id":"VC002","updated":false,"errors":[{"code":"NOT_FOUND","message":"product not found"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{}, server.Client())
+	resp, err := client.ImportPrices(context.Background(), "client-id", "api-key", entities.OzonImportPricesRequest{Prices: []entities.OzonPrice{
+		{OfferID: "VC001", Price: "1000", OldPrice: "1200", CurrencyCode: "RUB"},
+		{OfferID: "VC002", Price: "500", CurrencyCode: "RUB"},
+	}})
+	if err != nil {
+		t.Fatalf("ImportPrices returned unexpected error: %v", err)
+	}
+	if len(resp.Result) != 2 || !resp.Result[0].Updated || resp.Result[1].Updated || resp.Result[1].Errors[0].Code != "NOT_FOUND" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if len(received.Prices) != 2 || received.Prices[0].OldPrice != "1200" {
+		t.Errorf("Prices were not sent as prepared: %+v", received.Prices)
+	}
+}
+
+func TestClient_SetStocks(t *testing.T) {
+	var received entities.OzonStocksRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/products/stocks" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"result":[{"warehouse_id":22,"product_id":1386,"offer_id":"VC001","updated":true,"errors":[]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{}, server.Client())
+	resp, err := client.SetStocks(context.Background(), "client-id", "api-key", entities.OzonStocksRequest{Stocks: []entities.OzonStock{
+		{OfferID: "VC001", Stock: 5, WarehouseID: 22},
+	}})
+	if err != nil {
+		t.Fatalf("SetStocks returned unexpected error: %v", err)
+	}
+	if len(resp.Result) != 1 || !resp.Result[0].Updated || resp.Result[0].ProductID != 1386 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if len(received.Stocks) != 1 || received.Stocks[0].WarehouseID != 22 || received.Stocks[0].Stock != 5 {
+		t.Errorf("Stocks were not sent as prepared: %+v", received.Stocks)
+	}
+}
+
+func TestClient_GetWarehouseStocks(t *testing.T) {
+	var received entities.OzonWarehouseStocksRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/product/info/stocks-by-warehouse/fbs" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"result":[{"sku":9001,"fbs_sku":9002,"product_id":1386,"present":7,"reserved":2,"warehouse_id":22,"warehouse_name":"Склад"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{}, server.Client())
+	resp, err := client.GetWarehouseStocks(context.Background(), "client-id", "api-key", entities.OzonWarehouseStocksRequest{SKU: []string{"9001"}})
+	if err != nil {
+		t.Fatalf("GetWarehouseStocks returned unexpected error: %v", err)
+	}
+	if len(resp.Result) != 1 || resp.Result[0].WarehouseID != 22 || resp.Result[0].Present != 7 || resp.Result[0].Reserved != 2 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if len(received.SKU) != 1 || received.SKU[0] != "9001" {
+		t.Errorf("SKUs were not sent as prepared: %+v", received.SKU)
+	}
+}
+
+func TestClient_GetProductAttributes(t *testing.T) {
+	var received entities.OzonProductAttributesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/product/info/attributes" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"result":[{"id":1386,"offer_id":"VC001","name":"Футболка","barcodes":["4600000000011"],"description_category_id":17028922,"type_id":91565,"depth":300,"width":200,"height":20,"dimension_unit":"mm","weight":250,"weight_unit":"g","primary_image":"https://cdn.example.com/1.jpg","images":["https://cdn.example.com/2.jpg"],"attributes":[{"id":85,"complex_id":0,"values":[{"dictionary_value_id":971,"value":"Acme"}]}]}],"total":1,"last_id":""}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{}, server.Client())
+	resp, err := client.GetProductAttributes(context.Background(), "client-id", "api-key", entities.OzonProductAttributesRequest{
+		Filter: entities.OzonProductAttributesFilter{OfferID: []string{"VC001"}, Visibility: "ALL"},
+		Limit:  1,
+	})
+	if err != nil {
+		t.Fatalf("GetProductAttributes returned unexpected error: %v", err)
+	}
+	if len(resp.Result) != 1 || resp.Result[0].DimensionUnit != "mm" || resp.Result[0].AttributeValues(entities.OzonBrandAttributeID)[0] != "Acme" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+	if len(received.Filter.OfferID) != 1 || received.Filter.Visibility != "ALL" {
+		t.Errorf("Filter was not sent as prepared: %+v", received.Filter)
+	}
+}
+
+func TestConnectCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  entities.OzonError
+		want connect.Code
+	}{
+		{"gRPC code in body", entities.OzonError{Code: float64(5), HTTPStatus: http.StatusNotFound}, connect.CodeNotFound},
+		{"Unauthenticated is permission denied", entities.OzonError{Code: float64(16), HTTPStatus: http.StatusUnauthorized}, connect.CodePermissionDenied},
+		{"Text code falls back to status", entities.OzonError{Code: "BAD_REQUEST", HTTPStatus: http.StatusBadRequest}, connect.CodeInvalidArgument},
+		{"Rate limited", entities.OzonError{HTTPStatus: http.StatusTooManyRequests}, connect.CodeResourceExhausted},
+		{"Server error", entities.OzonError{HTTPStatus: http.StatusBadGateway}, connect.CodeUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := connectCode(&tt.err); got != tt.want {
+				t.Errorf("connectCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
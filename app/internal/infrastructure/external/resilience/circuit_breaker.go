@@ -0,0 +1,147 @@
+package resilience
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"api/metrics"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow while the breaker rejects calls.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker, exported as a gauge value.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Calls pass through
+	BreakerHalfOpen                     // A single probe call is allowed
+	BreakerOpen                         // Calls fail fast
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+// CircuitBreaker stops calls to an external service after consecutive failures
+// and lets a single probe through once the open period has passed.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	openDuration     time.Duration
+
+	mu            sync.Mutex
+	state         BreakerState
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+	now           func() time.Time
+}
+
+// NewCircuitBreaker creates a closed breaker. A non-positive failureThreshold disables the breaker.
+func NewCircuitBreaker(name string, failureThreshold int, openDuration time.Duration) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+	}
+	metrics.AppCircuitBreakerState.WithLabelValues(name).Set(float64(BreakerClosed))
+	return cb
+}
+
+// Allow reports whether a call may proceed. It returns ErrCircuitOpen while the breaker is open
+// or while the half-open probe is still in flight.
+func (cb *CircuitBreaker) Allow() error {
+	if cb == nil || cb.failureThreshold <= 0 {
+		return nil
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case BreakerOpen:
+		if cb.now().Sub(cb.openedAt) < cb.openDuration {
+			return ErrCircuitOpen
+		}
+		cb.setState(BreakerHalfOpen)
+		cb.probeInFlight = true
+		return nil
+	case BreakerHalfOpen:
+		if cb.probeInFlight {
+			return ErrCircuitOpen
+		}
+		cb.probeInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful call and closes the breaker.
+func (cb *CircuitBreaker) Success() {
+	if cb == nil || cb.failureThreshold <= 0 {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.probeInFlight = false
+	if cb.state != BreakerClosed {
+		cb.setState(BreakerClosed)
+	}
+}
+
+// Failure records a failed call and opens the breaker once the threshold is reached
+// or when the half-open probe fails.
+func (cb *CircuitBreaker) Failure() {
+	if cb == nil || cb.failureThreshold <= 0 {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.probeInFlight = false
+	if cb.state == BreakerHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.openedAt = cb.now()
+		if cb.state != BreakerOpen {
+			cb.setState(BreakerOpen)
+		}
+	}
+}
+
+// Abandon records a call its caller gave up on, e.g. by canceling the context. It says nothing about the
+// health of the service, so it is not counted as a failure; a half-open breaker lets the next probe through.
+func (cb *CircuitBreaker) Abandon() {
+	if cb == nil || cb.failureThreshold <= 0 {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probeInFlight = false
+}
+
+// State returns the current breaker state.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+func (cb *CircuitBreaker) setState(state BreakerState) {
+	log.Printf("[CIRCUIT BREAKER] %s: %s -> %s (consecutive failures: %d)", cb.name, cb.state, state, cb.failures)
+	cb.state = state
+	metrics.AppCircuitBreakerState.WithLabelValues(cb.name).Set(float64(state))
+}
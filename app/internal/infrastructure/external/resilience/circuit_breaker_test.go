@@ -0,0 +1,93 @@
+package resilience
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAfterThresholdAndRecovers(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker("test", 2, 10*time.Second)
+	cb.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := cb.Allow(); err != nil {
+			t.Fatalf("Allow() on closed breaker returned %v", err)
+		}
+		cb.Failure()
+	}
+	if cb.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to be open after 2 failures, got %s", cb.State())
+	}
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen while open, got %v", err)
+	}
+
+	now = now.Add(11 * time.Second)
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed after open duration, got %v", err)
+	}
+	if cb.State() != BreakerHalfOpen {
+		t.Fatalf("Expected half-open state, got %s", cb.State())
+	}
+	if err := cb.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected second call to be rejected while probe is in flight, got %v", err)
+	}
+
+	cb.Success()
+	if cb.State() != BreakerClosed {
+		t.Fatalf("Expected breaker to close after successful probe, got %s", cb.State())
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker("test_probe", 1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	cb.Failure()
+	now = now.Add(2 * time.Second)
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	cb.Failure()
+	if cb.State() != BreakerOpen {
+		t.Fatalf("Expected breaker to reopen after failed probe, got %s", cb.State())
+	}
+}
+
+func TestBackoff_RespectsMaxDelay(t *testing.T) {
+	for attempt := 0; attempt < 70; attempt++ {
+		if d := Backoff(attempt, 100*time.Millisecond, time.Second); d < 0 || d > time.Second {
+			t.Fatalf("Backoff(%d) = %v, expected within [0, 1s]", attempt, d)
+		}
+	}
+}
+
+func TestCircuitBreaker_AbandonIsNotAFailure(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker("test_abandon", 1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Allow() on closed breaker returned %v", err)
+	}
+	cb.Abandon()
+	if cb.State() != BreakerClosed {
+		t.Fatalf("Expected breaker to stay closed after an abandoned call, got %s", cb.State())
+	}
+
+	cb.Failure()
+	now = now.Add(2 * time.Second)
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	cb.Abandon()
+	if cb.State() != BreakerHalfOpen {
+		t.Fatalf("Expected breaker to stay half-open after an abandoned probe, got %s", cb.State())
+	}
+	if err := cb.Allow(); err != nil {
+		t.Fatalf("Expected the next probe to be allowed after an abandoned one, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiter with a separate bucket per key (e.g. per seller API key),
+// shared by all requests going through it.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Tokens added per second
+	burst   float64
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens      float64
+	updatedAt   time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute requests per key on average
+// with bursts of up to burst requests.
+func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    float64(requestsPerMinute) / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request for key may be sent and returns the time spent waiting.
+func (l *RateLimiter) Wait(ctx context.Context, key string) (time.Duration, error) {
+	delay := l.reserve(key)
+	if err := Sleep(ctx, delay); err != nil {
+		l.release(key)
+		return delay, err
+	}
+	return delay, nil
+}
+
+// Pause holds back all requests for key for d, e.g. after the server answered 429 with a retry delay.
+func (l *RateLimiter) Pause(key string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key)
+	if until := l.now().Add(d); until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	// The server's view of the budget is exhausted, do not let a burst through once the pause ends
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
+
+// reserve takes a token from the bucket of key and returns how long the caller has to wait for it.
+func (l *RateLimiter) reserve(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep()
+	b := l.bucket(key)
+	b.tokens--
+
+	var delay time.Duration
+	if b.tokens < 0 && l.rate > 0 {
+		delay = time.Duration(-b.tokens / l.rate * float64(time.Second))
+	}
+	if pause := b.pausedUntil.Sub(l.now()); pause > delay {
+		delay = pause
+	}
+	return delay
+}
+
+// release returns a reserved token that was not used.
+func (l *RateLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key)
+	if b.tokens++; b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+}
+
+// sweep drops the buckets that have been full and unpaused for longer than the refill window, i.e. the time an
+// empty bucket takes to refill, so that keys which stopped sending requests do not stay in memory. A dropped bucket
+// is recreated full, the same state it was in. Runs at most once per refill window. Must be called with mu held.
+func (l *RateLimiter) sweep() {
+	if l.rate <= 0 {
+		return // Buckets never refill, dropping one would hand out a new burst
+	}
+	now := l.now()
+	window := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.sweptAt) < window {
+		return
+	}
+	l.sweptAt = now
+
+	for key, b := range l.buckets {
+		fullAt := b.updatedAt.Add(time.Duration((l.burst - b.tokens) / l.rate * float64(time.Second)))
+		if now.Sub(fullAt) > window && now.After(b.pausedUntil) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// bucket returns the refilled bucket of key. Must be called with mu held.
+func (l *RateLimiter) bucket(key string) *tokenBucket {
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, updatedAt: now}
+		l.buckets[key] = b
+		return b
+	}
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.updatedAt = now
+	}
+	return b
+}
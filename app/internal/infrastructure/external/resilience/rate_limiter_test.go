@@ -0,0 +1,97 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_BurstThenRefill(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(60, 2) // One token per second
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if d := l.reserve("key"); d != 0 {
+			t.Fatalf("Expected request %d of the burst to pass immediately, got delay %v", i+1, d)
+		}
+	}
+	if d := l.reserve("key"); d != time.Second {
+		t.Fatalf("Expected third request to wait 1s, got %v", d)
+	}
+	if d := l.reserve("other"); d != 0 {
+		t.Fatalf("Expected a different key to have its own bucket, got delay %v", d)
+	}
+
+	now = now.Add(3 * time.Second)
+	if d := l.reserve("key"); d != 0 {
+		t.Fatalf("Expected bucket to refill after 3s, got delay %v", d)
+	}
+}
+
+func TestRateLimiter_Pause(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(600, 5)
+	l.now = func() time.Time { return now }
+
+	l.Pause("key", 10*time.Second)
+	if d := l.reserve("key"); d != 10*time.Second {
+		t.Fatalf("Expected request to wait for the pause, got %v", d)
+	}
+	if d := l.reserve("other"); d != 0 {
+		t.Fatalf("Expected pause to affect only its key, got delay %v", d)
+	}
+
+	now = now.Add(11 * time.Second)
+	if d := l.reserve("key"); d != 0 {
+		t.Fatalf("Expected request to pass after the pause, got delay %v", d)
+	}
+}
+
+func TestRateLimiter_WaitReleasesTokenOnCancel(t *testing.T) {
+	l := NewRateLimiter(60, 1)
+	if _, err := l.Wait(context.Background(), "key"); err != nil {
+		t.Fatalf("Expected first request to pass, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Wait(ctx, "key"); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	// The cancelled request must not push later requests further back
+	if d := l.reserve("key"); d > time.Second {
+		t.Fatalf("Expected cancelled reservation to be released, got delay %v", d)
+	}
+}
+
+func TestRateLimiter_EvictsIdleBuckets(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(60, 2) // Refills in 2s
+	l.now = func() time.Time { return now }
+
+	l.reserve("idle")
+	l.reserve("paused")
+	l.Pause("paused", time.Minute)
+
+	now = now.Add(3 * time.Second) // "idle" is full for 2s, not longer than the window yet
+	l.reserve("active")
+	if _, ok := l.buckets["idle"]; !ok {
+		t.Fatal("Expected the bucket to be kept within the refill window")
+	}
+
+	now = now.Add(2 * time.Second)
+	l.reserve("active")
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("Expected the idle full bucket to be evicted")
+	}
+	if _, ok := l.buckets["paused"]; !ok {
+		t.Error("Expected the paused bucket to be kept")
+	}
+	if _, ok := l.buckets["active"]; !ok {
+		t.Error("Expected the active bucket to be kept")
+	}
+	if d := l.reserve("idle"); d != 0 {
+		t.Fatalf("Expected an evicted key to start with a full bucket, got delay %v", d)
+	}
+}
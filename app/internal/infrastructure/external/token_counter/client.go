@@ -14,8 +14,11 @@ type Client struct {
 	httpClient *http.Client
 }
 
-func NewClient(baseURL string) *Client {
-	return &Client{baseURL: baseURL, httpClient: http.DefaultClient}
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{baseURL: baseURL, httpClient: httpClient}
 }
 
 func (c *Client) GetSessionData(ctx context.Context, sessionID string) (*entities.SessionData, error) {
@@ -0,0 +1,144 @@
+package wb
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// maxSubjectsPage is the largest page of GET /content/v2/object/all
+const maxSubjectsPage = 1000
+
+// GetParentCategories returns all parent categories of WB subjects.
+// Corresponds to GET /content/v2/object/parent/all
+func (c *WBClient) GetParentCategories(ctx context.Context, apiKey, locale string) ([]entities.WBParentCategory, error) {
+	var categories []entities.WBParentCategory
+	err := c.getDirectory(ctx, apiKey, "wb_parent_categories", "/content/v2/object/parent/all", localeQuery(locale), &categories)
+	return categories, err
+}
+
+// GetSubjects returns a page of at most limit WB subjects, 1000 at most.
+// Corresponds to GET /content/v2/object/all
+func (c *WBClient) GetSubjects(ctx context.Context, apiKey, locale string, limit, offset int) ([]entities.WBSubject, error) {
+	if limit <= 0 || limit > maxSubjectsPage {
+		limit = maxSubjectsPage
+	}
+	query := localeQuery(locale)
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+	var subjects []entities.WBSubject
+	err := c.getDirectory(ctx, apiKey, "wb_subjects", "/content/v2/object/all", query, &subjects)
+	return subjects, err
+}
+
+// GetColors returns the values of the characteristic "Цвет".
+// Corresponds to GET /content/v2/directory/colors
+func (c *WBClient) GetColors(ctx context.Context, apiKey, locale string) ([]entities.WBColor, error) {
+	var colors []entities.WBColor
+	err := c.getDirectory(ctx, apiKey, "wb_directory_colors", "/content/v2/directory/colors", localeQuery(locale), &colors)
+	return colors, err
+}
+
+// GetKinds returns the values of the characteristic "Пол".
+// Corresponds to GET /content/v2/directory/kinds
+func (c *WBClient) GetKinds(ctx context.Context, apiKey, locale string) ([]string, error) {
+	var kinds []string
+	err := c.getDirectory(ctx, apiKey, "wb_directory_kinds", "/content/v2/directory/kinds", localeQuery(locale), &kinds)
+	return kinds, err
+}
+
+// GetCountries returns the values of the characteristic "Страна производства".
+// Corresponds to GET /content/v2/directory/countries
+func (c *WBClient) GetCountries(ctx context.Context, apiKey, locale string) ([]entities.WBCountry, error) {
+	var countries []entities.WBCountry
+	err := c.getDirectory(ctx, apiKey, "wb_directory_countries", "/content/v2/directory/countries", localeQuery(locale), &countries)
+	return countries, err
+}
+
+// GetSeasons returns the values of the characteristic "Сезон".
+// Corresponds to GET /content/v2/directory/seasons
+func (c *WBClient) GetSeasons(ctx context.Context, apiKey, locale string) ([]string, error) {
+	var seasons []string
+	err := c.getDirectory(ctx, apiKey, "wb_directory_seasons", "/content/v2/directory/seasons", localeQuery(locale), &seasons)
+	return seasons, err
+}
+
+// GetVatRates returns the values of the characteristic "Ставка НДС".
+// Corresponds to GET /content/v2/directory/vat
+func (c *WBClient) GetVatRates(ctx context.Context, apiKey, locale string) ([]string, error) {
+	var rates []string
+	// WB requires the locale of this method
+	if locale == "" {
+		locale = "ru"
+	}
+	err := c.getDirectory(ctx, apiKey, "wb_directory_vat", "/content/v2/directory/vat", localeQuery(locale), &rates)
+	return rates, err
+}
+
+// GetTnved returns the TNVED codes allowed for the WB subject.
+// Corresponds to GET /content/v2/directory/tnved
+func (c *WBClient) GetTnved(ctx context.Context, apiKey, locale string, subjectID int) ([]entities.WBTnved, error) {
+	query := localeQuery(locale)
+	query.Set("subjectID", strconv.Itoa(subjectID))
+	var codes []entities.WBTnved
+	err := c.getDirectory(ctx, apiKey, "wb_directory_tnved", "/content/v2/directory/tnved", query, &codes)
+	return codes, err
+}
+
+func localeQuery(locale string) url.Values {
+	query := url.Values{}
+	if locale != "" {
+		query.Set("locale", locale)
+	}
+	return query
+}
+
+// getDirectory calls a category or directory method of the content API and decodes its data into data.
+func (c *WBClient) getDirectory(ctx context.Context, apiKey, apiName, path string, query url.Values, data interface{}) error {
+	if apiKey == "" {
+		return fmt.Errorf("wildberries API key is required for %s", path)
+	}
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Wildberries %s request: %w", path, err)
+	}
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, apiName, apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to call Wildberries %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Wildberries %s response body: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("wildberries %s returned status %d: %s", path, resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBDirectoryResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return fmt.Errorf("failed to unmarshal Wildberries %s response: %w", path, err)
+	}
+	if wbResp.Error {
+		return fmt.Errorf("wildberries %s returned error: %s", path, wbResp.ErrorText)
+	}
+	if len(wbResp.Data) == 0 || string(wbResp.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(wbResp.Data, data); err != nil {
+		return fmt.Errorf("failed to unmarshal Wildberries %s data: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package wb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestWBClient_GetSubjects(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/content/v2/object/all" || query.Get("limit") != "1000" || query.Get("offset") != "2000" || query.Get("locale") != "ru" {
+			t.Errorf("Unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":[{"subjectID":2560,"parentID":479,"subjectName":"3D очки","parentName":"Электроника"}],"error":false,"errorText":"","additionalErrors":null}`))
+	})
+
+	subjects, err := client.GetSubjects(context.Background(), "test-api-key", "ru", 0, 2000)
+	if err != nil {
+		t.Fatalf("GetSubjects returned unexpected error: %v", err)
+	}
+	if len(subjects) != 1 || subjects[0].SubjectID != 2560 || subjects[0].ParentName != "Электроника" {
+		t.Errorf("Unexpected subjects: %+v", subjects)
+	}
+}
+
+func TestWBClient_GetVatRates(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/content/v2/directory/vat" || r.URL.Query().Get("locale") != "ru" {
+			t.Errorf("Unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":["0","10","20","Без НДС"],"error":false,"errorText":"","additionalErrors":null}`))
+	})
+
+	rates, err := client.GetVatRates(context.Background(), "test-api-key", "")
+	if err != nil {
+		t.Fatalf("GetVatRates returned unexpected error: %v", err)
+	}
+	if len(rates) != 4 || rates[3] != "Без НДС" {
+		t.Errorf("Unexpected VAT rates: %v", rates)
+	}
+}
+
+func TestWBClient_GetTnved_Error(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("subjectID") != "105" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":null,"error":true,"errorText":"subject not found","additionalErrors":null}`))
+	})
+
+	if _, err := client.GetTnved(context.Background(), "test-api-key", "", 105); err == nil {
+		t.Fatal("GetTnved returned no error for an error response")
+	}
+}
@@ -2,6 +2,8 @@ package wb
 
 import (
 	"api/app/domain/entities"
+	"api/app/internal/infrastructure/external/resilience"
+	"api/metrics"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -10,28 +12,69 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// wildberriesAPIHost is the base URL for Wildberries content API.
+// wildberriesAPIHost is the default base URL for Wildberries content API.
 const wildberriesAPIHost = "https://content-api.wildberries.ru"
 
+// Options configures rate limiting and 429 retries of Wildberries calls.
+type Options struct {
+	RequestsPerMinute int           // Requests allowed per API key and minute, 0 disables client-side limiting
+	Burst             int           // Requests per API key that may be sent at once
+	MaxRetries        int           // Retries on 429 Too Many Requests, 0 disables retries
+	RetryBaseDelay    time.Duration // Base backoff delay when WB sends no X-Ratelimit-Retry header
+	RetryMaxDelay     time.Duration // Upper bound of a single backoff delay
+}
+
 type WBClient struct {
-	// apiKey string // API key is now passed directly to UploadWBCard
+	baseURL    string
+	httpClient *http.Client
+	opts       Options
+	limiter    *resilience.RateLimiter
 }
 
-func NewWBClient() *WBClient {
-	return &WBClient{
-		// apiKey: apiKey, // No longer stored in client
+// NewWBClient creates a Wildberries content API client. An empty baseURL selects the production host
+// and a nil httpClient a client without timeout. The rate limiter is shared by all requests of the client.
+func NewWBClient(baseURL string, opts Options, httpClient *http.Client) *WBClient {
+	if baseURL == "" {
+		baseURL = wildberriesAPIHost
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
 	}
+	c := &WBClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		opts:       opts,
+	}
+	if opts.RequestsPerMinute > 0 {
+		c.limiter = resilience.NewRateLimiter(opts.RequestsPerMinute, opts.Burst)
+	}
+	return c
 }
 
+// UploadWBCard creates new cards.
+// Corresponds to POST /content/v2/cards/upload
 func (c *WBClient) UploadWBCard(ctx context.Context, wbPayload entities.WBCardUploadPayload, apiKey string) (*entities.WBCardUploadResponse, error) {
+	return c.postCards(ctx, "wb_card_upload", "/content/v2/cards/upload", wbPayload, apiKey)
+}
+
+// AddWBCardVariants creates new cards joined to the existing card group payload.ImtID.
+// Corresponds to POST /content/v2/cards/upload/add
+func (c *WBClient) AddWBCardVariants(ctx context.Context, payload entities.WBCardAddPayload, apiKey string) (*entities.WBCardUploadResponse, error) {
+	return c.postCards(ctx, "wb_card_upload_add", "/content/v2/cards/upload/add", payload, apiKey)
+}
+
+// postCards sends a card creation payload; both card creation endpoints share the response format.
+func (c *WBClient) postCards(ctx context.Context, apiName, path string, wbPayload interface{}, apiKey string) (*entities.WBCardUploadResponse, error) {
 	payloadBytes, err := json.Marshal(wbPayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal Wildberries card payload: %w", err)
 	}
-	return nil, nil
-	uploadURL := fmt.Sprintf("%s/content/v2/cards/upload", wildberriesAPIHost)
+	uploadURL := c.baseURL + path
 	log.Printf("Uploading card to Wildberries: %s, Payload: %s", uploadURL, string(payloadBytes))
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", uploadURL, bytes.NewBuffer(payloadBytes))
@@ -48,7 +91,7 @@ func (c *WBClient) UploadWBCard(ctx context.Context, wbPayload entities.WBCardUp
 		return nil, fmt.Errorf("wildberries API key is required for uploading card")
 	}
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := c.do(httpReq, apiName, apiKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Wildberries card upload API: %w", err)
 	}
@@ -80,7 +123,7 @@ func (c *WBClient) UploadMediaFiles(ctx context.Context, apiKey string, nmID str
 		return nil, fmt.Errorf("wildberries API key is required for uploading media files")
 	}
 
-	uploadURL := fmt.Sprintf("%s/content/v3/media/file", wildberriesAPIHost)
+	uploadURL := fmt.Sprintf("%s/content/v3/media/file", c.baseURL)
 	var results []entities.WBMediaUploadResult
 
 	for _, file := range files {
@@ -122,8 +165,11 @@ func (c *WBClient) UploadMediaFiles(ctx context.Context, apiKey string, nmID str
 		httpReq.Header.Set("X-Photo-Number", fmt.Sprintf("%d", file.PhotoNumber))
 		httpReq.Header.Set("Content-Type", writer.FormDataContentType())
 
-		resp, err := http.DefaultClient.Do(httpReq)
+		resp, err := c.do(httpReq, "wb_media_upload", apiKey)
 		if err != nil {
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
 			results = append(results, entities.WBMediaUploadResult{PhotoNumber: file.PhotoNumber, Error: fmt.Errorf("failed to call Wildberries media file upload API: %w", err)})
 			continue
 		}
@@ -163,7 +209,7 @@ func (c *WBClient) SaveMediaByLinks(ctx context.Context, apiKey string, payload
 		return nil, fmt.Errorf("failed to marshal Wildberries save media payload: %w", err)
 	}
 
-	saveURL := fmt.Sprintf("%s/content/v3/media/save", wildberriesAPIHost)
+	saveURL := fmt.Sprintf("%s/content/v3/media/save", c.baseURL)
 	log.Printf("Saving media by links to Wildberries: %s, Payload: %s", saveURL, string(payloadBytes))
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", saveURL, bytes.NewBuffer(payloadBytes))
@@ -176,7 +222,7 @@ func (c *WBClient) SaveMediaByLinks(ctx context.Context, apiKey string, payload
 
 	// Response handling is identical to UploadWBCard, so we can reuse that logic or a helper
 	// For now, duplicating the common parts:
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := c.do(httpReq, "wb_media_save", apiKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Wildberries save media by links API: %w", err)
 	}
@@ -203,6 +249,87 @@ func (c *WBClient) SaveMediaByLinks(ctx context.Context, apiKey string, payload
 	return &wbResp, nil
 }
 
+// GenerateBarcodes generates count new barcodes for the seller.
+// Corresponds to POST /content/v2/barcodes
+func (c *WBClient) GenerateBarcodes(ctx context.Context, apiKey string, count int) ([]string, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for generating barcodes")
+	}
+	payloadBytes, err := json.Marshal(entities.WBBarcodesRequest{Count: count})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Wildberries barcodes request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/content/v2/barcodes", bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries barcodes request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_barcodes", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries barcodes API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries barcodes response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries barcodes API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBBarcodesResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries barcodes response: %w", err)
+	}
+	if wbResp.Error {
+		return nil, fmt.Errorf("wildberries barcodes API returned error: %s", wbResp.ErrorText)
+	}
+	if len(wbResp.Data) != count {
+		return nil, fmt.Errorf("wildberries barcodes API returned %d barcodes, requested %d", len(wbResp.Data), count)
+	}
+	return wbResp.Data, nil
+}
+
+// GetSubjectCharacteristics returns the characteristics of cards of the subject.
+// Corresponds to GET /content/v2/object/charcs/{subjectId}
+func (c *WBClient) GetSubjectCharacteristics(ctx context.Context, apiKey string, subjectID int) ([]entities.WBSubjectCharacteristic, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for getting subject characteristics")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/content/v2/object/charcs/%d", c.baseURL, subjectID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries subject characteristics request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_subject_charcs", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries subject characteristics API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries subject characteristics response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries subject characteristics API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBSubjectCharacteristicsResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries subject characteristics response: %w", err)
+	}
+	if wbResp.Error {
+		return nil, fmt.Errorf("wildberries subject characteristics API returned error: %s", wbResp.ErrorText)
+	}
+	return wbResp.Data, nil
+}
+
 // GetCardList retrieves a list of cards from Wildberries.
 // Corresponds to POST /content/v2/get/cards/list
 func (c *WBClient) GetCardList(ctx context.Context, apiKey string, listReq entities.WBGetCardListRequest) (*entities.WBGetCardListResponse, error) {
@@ -215,7 +342,7 @@ func (c *WBClient) GetCardList(ctx context.Context, apiKey string, listReq entit
 		return nil, fmt.Errorf("failed to marshal Wildberries get card list payload: %w", err)
 	}
 
-	listURL := fmt.Sprintf("%s/content/v2/get/cards/list", wildberriesAPIHost)
+	listURL := fmt.Sprintf("%s/content/v2/get/cards/list", c.baseURL)
 	log.Printf("Getting card list from Wildberries: %s, Payload: %s", listURL, string(payloadBytes))
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", listURL, bytes.NewBuffer(payloadBytes))
@@ -226,7 +353,7 @@ func (c *WBClient) GetCardList(ctx context.Context, apiKey string, listReq entit
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Authorization", apiKey)
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	resp, err := c.do(httpReq, "wb_get_card_list", apiKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Wildberries get card list API: %w", err)
 	}
@@ -251,3 +378,101 @@ func (c *WBClient) GetCardList(ctx context.Context, apiKey string, listReq entit
 	}
 	return &wbResp, nil
 }
+
+// maxCardListPageSize is the largest page returned by /content/v2/get/cards/list.
+const maxCardListPageSize = 100
+
+// ListCards walks all cards matching filter using the updatedAt/nmID cursor and passes each page to handle.
+// pageSize outside 1..100 selects 100. The walk ends after the last page, on error or when handle returns false.
+func (c *WBClient) ListCards(ctx context.Context, apiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, handle func(cards []entities.WBCardDefinition) (bool, error)) error {
+	if pageSize <= 0 || pageSize > maxCardListPageSize {
+		pageSize = maxCardListPageSize
+	}
+
+	listReq := entities.WBGetCardListRequest{
+		Settings: entities.WBGetCardListRequestSettings{
+			Filter: filter,
+			Cursor: entities.WBGetCardListRequestCursor{Limit: pageSize},
+		},
+	}
+	for {
+		resp, err := c.GetCardList(ctx, apiKey, listReq)
+		if err != nil {
+			return err
+		}
+		if len(resp.Cards) > 0 {
+			more, err := handle(resp.Cards)
+			if err != nil || !more {
+				return err
+			}
+		}
+
+		// WB signals the last page with fewer cards than requested
+		if resp.Cursor.Total < pageSize || len(resp.Cards) == 0 || resp.Cursor.UpdatedAt == nil || resp.Cursor.NmID == nil {
+			return nil
+		}
+		listReq.Settings.Cursor.UpdatedAt = resp.Cursor.UpdatedAt
+		listReq.Settings.Cursor.NmID = resp.Cursor.NmID
+	}
+}
+
+// do sends httpReq once the rate limiter of apiKey allows it and retries on 429 Too Many Requests,
+// honoring the X-Ratelimit-Retry header. The request body must be replayable (GetBody set).
+// After the last retry the 429 response is returned to the caller like any other status.
+func (c *WBClient) do(httpReq *http.Request, apiName, apiKey string) (*http.Response, error) {
+	ctx := httpReq.Context()
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			waited, err := c.limiter.Wait(ctx, apiKey)
+			metrics.AppExternalAPIRateLimitWaitSeconds.WithLabelValues(apiName).Observe(waited.Seconds())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		attemptReq := httpReq
+		if attempt > 0 {
+			attemptReq = httpReq.Clone(ctx)
+			if httpReq.GetBody != nil {
+				body, err := httpReq.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind Wildberries request body: %w", err)
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := c.httpClient.Do(attemptReq)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		metrics.AppExternalAPIRateLimitedTotal.WithLabelValues(apiName).Inc()
+		delay := c.retryDelay(resp.Header, attempt)
+		if c.limiter != nil {
+			// Hold back every request of this seller, not only the one that got throttled
+			c.limiter.Pause(apiKey, delay)
+		}
+		if attempt >= c.opts.MaxRetries {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		log.Printf("Wildberries call %s throttled (attempt %d/%d). Retrying in %v", apiName, attempt+1, c.opts.MaxRetries+1, delay)
+		metrics.AppExternalAPIRetriesTotal.WithLabelValues(apiName).Inc()
+		if err := resilience.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay returns the delay requested by WB in X-Ratelimit-Retry (or Retry-After) seconds,
+// falling back to jittered exponential backoff.
+func (c *WBClient) retryDelay(header http.Header, attempt int) time.Duration {
+	for _, name := range []string{"X-Ratelimit-Retry", "Retry-After"} {
+		if seconds, err := strconv.Atoi(header.Get(name)); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return resilience.Backoff(attempt, c.opts.RetryBaseDelay, c.opts.RetryMaxDelay)
+}
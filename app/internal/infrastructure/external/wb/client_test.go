@@ -3,15 +3,37 @@ package wb
 import (
 	"api/app/domain/entities"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
 
+// newTestServer starts a WB API stand-in and returns a client pointed at it.
+func newTestServer(t *testing.T, handler http.HandlerFunc) *WBClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewWBClient(server.URL, Options{}, server.Client())
+}
+
 func TestNewWBClient(t *testing.T) {
-	client := NewWBClient()
+	client := NewWBClient("", Options{}, nil)
 	if client == nil {
 		t.Fatal("NewWBClient returned nil")
 	}
+	if client.baseURL != wildberriesAPIHost {
+		t.Errorf("Expected default base URL %s, got %s", wildberriesAPIHost, client.baseURL)
+	}
+	if client.httpClient == nil {
+		t.Error("Expected default HTTP client, got nil")
+	}
+
+	client = NewWBClient("http://localhost:9999/", Options{}, nil)
+	if client.baseURL != "http://localhost:9999" {
+		t.Errorf("Expected configured base URL without trailing slash, got %s", client.baseURL)
+	}
 }
 
 func TestWBClient_UploadWBCard(t *testing.T) {
@@ -22,7 +44,9 @@ func TestWBClient_UploadWBCard(t *testing.T) {
 	}
 
 	t.Run("API key missing", func(t *testing.T) {
-		client := NewWBClient()
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("Request must not be sent without API key")
+		})
 		_, err := client.UploadWBCard(ctx, payload, "") // Empty API key
 		if err == nil {
 			t.Fatal("Expected an error for missing API key, got nil")
@@ -33,15 +57,73 @@ func TestWBClient_UploadWBCard(t *testing.T) {
 	})
 
 	t.Run("With valid API key", func(t *testing.T) {
-		client := NewWBClient()
-		// We expect an error because we're not actually hitting a real WB server
+		var received entities.WBCardUploadPayload
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/content/v2/cards/upload" {
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			if got := r.Header.Get("Authorization"); got != apiKey {
+				t.Errorf("Expected Authorization %q, got %q", apiKey, got)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			w.Write([]byte(`{"data":null,"error":false,"errorText":"","additionalErrors":null}`))
+		})
+
+		resp, err := client.UploadWBCard(ctx, payload, apiKey)
+		if err != nil {
+			t.Fatalf("UploadWBCard returned unexpected error: %v", err)
+		}
+		if resp == nil || resp.Error {
+			t.Errorf("Expected successful response, got %+v", resp)
+		}
+		if len(received) != 1 || received[0].SubjectID != 123 || received[0].Variants[0].VendorCode != "VC001" {
+			t.Errorf("Card payload was not sent as prepared: %+v", received)
+		}
+	})
+
+	t.Run("Marketplace error status", func(t *testing.T) {
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":true,"errorText":"Invalid subject"}`))
+		})
+
 		_, err := client.UploadWBCard(ctx, payload, apiKey)
 		if err == nil {
-			t.Error("Expected an error when calling real API endpoint, got nil")
+			t.Fatal("Expected an error for non-200 status, got nil")
+		}
+		if !strings.Contains(err.Error(), "Invalid subject") {
+			t.Errorf("Expected error to contain the response body, got: %s", err.Error())
 		}
 	})
 }
 
+func TestWBClient_AddWBCardVariants(t *testing.T) {
+	var received map[string]json.RawMessage
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/content/v2/cards/upload/add" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"data":null,"error":false,"errorText":"","additionalErrors":null}`))
+	})
+
+	payload := entities.WBCardAddPayload{ImtID: 987654321, CardsToAdd: []entities.WBVariant{{VendorCode: "VC002"}}}
+	resp, err := client.AddWBCardVariants(context.Background(), payload, "test-api-key")
+	if err != nil {
+		t.Fatalf("AddWBCardVariants returned unexpected error: %v", err)
+	}
+	if resp == nil || resp.Error {
+		t.Errorf("Expected successful response, got %+v", resp)
+	}
+	if string(received["imtID"]) != "987654321" || !strings.Contains(string(received["cardsToAdd"]), "VC002") {
+		t.Errorf("Add payload was not sent as prepared: %v", received)
+	}
+}
+
 func TestWBClient_UploadMediaFiles(t *testing.T) {
 	ctx := context.Background()
 	apiKey := "test-api-key"
@@ -52,7 +134,7 @@ func TestWBClient_UploadMediaFiles(t *testing.T) {
 	}
 
 	t.Run("API key missing", func(t *testing.T) {
-		client := NewWBClient()
+		client := NewWBClient("", Options{}, nil)
 		_, err := client.UploadMediaFiles(ctx, "", nmID, files) // Empty API key
 		if err == nil {
 			t.Fatal("Expected an error for missing API key, got nil")
@@ -66,7 +148,9 @@ func TestWBClient_UploadMediaFiles(t *testing.T) {
 		cancelledCtx, cancel := context.WithCancel(ctx)
 		cancel() // Cancel context immediately
 
-		client := NewWBClient()
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("Request must not be sent with cancelled context")
+		})
 		results, err := client.UploadMediaFiles(cancelledCtx, apiKey, nmID, files)
 		if err == nil {
 			t.Fatal("Expected context cancellation error, got nil")
@@ -81,23 +165,42 @@ func TestWBClient_UploadMediaFiles(t *testing.T) {
 	})
 
 	t.Run("With valid parameters", func(t *testing.T) {
-		client := NewWBClient()
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/content/v3/media/file" {
+				t.Errorf("Unexpected path %s", r.URL.Path)
+			}
+			if r.Header.Get("X-Nm-Id") != nmID {
+				t.Errorf("Expected X-Nm-Id %s, got %s", nmID, r.Header.Get("X-Nm-Id"))
+			}
+			file, header, err := r.FormFile("uploadfile")
+			if err != nil {
+				t.Errorf("Failed to read uploaded file: %v", err)
+				return
+			}
+			file.Close()
+			// The second photo is rejected to check that results are reported per file
+			if r.Header.Get("X-Photo-Number") == "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"data":null,"error":true,"errorText":"Unsupported format ` + header.Filename + `"}`))
+				return
+			}
+			w.Write([]byte(`{"data":null,"error":false,"errorText":""}`))
+		})
+
 		// UploadMediaFiles processes each file individually and returns results with errors
 		// rather than returning a Go error when HTTP calls fail
 		results, err := client.UploadMediaFiles(ctx, apiKey, nmID, files)
 		if err != nil {
 			t.Fatalf("UploadMediaFiles returned unexpected error: %v", err)
 		}
-		// We expect results for each file, but they should contain individual errors
-		// due to unauthorized API calls (since we're using test credentials)
 		if len(results) != len(files) {
-			t.Errorf("Expected %d results, got %d", len(files), len(results))
+			t.Fatalf("Expected %d results, got %d", len(files), len(results))
 		}
-		// Each result should have an error since we're not using valid credentials
-		for i, result := range results {
-			if result.Error == nil {
-				t.Errorf("Expected error in result %d, got nil", i)
-			}
+		if results[0].Error != nil || results[0].Response == nil {
+			t.Errorf("Expected successful result for photo 1, got %+v", results[0])
+		}
+		if results[1].Error == nil || !strings.Contains(results[1].Error.Error(), "photo2.png") {
+			t.Errorf("Expected error for photo 2, got %+v", results[1])
 		}
 	})
 }
@@ -107,11 +210,64 @@ func TestWBClient_SaveMediaByLinks(t *testing.T) {
 	apiKey := "test-api-key"
 	payload := entities.WBSaveMediaPayload{NmID: 123, Data: []string{"http://example.com/img.jpg"}}
 
-	client := NewWBClient()
-	// We expect an error because we're not actually hitting a real WB server
-	_, err := client.SaveMediaByLinks(ctx, apiKey, payload)
-	if err == nil {
-		t.Error("Expected an error when calling real API endpoint, got nil")
+	var received entities.WBSaveMediaPayload
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/content/v3/media/save" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"data":null,"error":false,"errorText":""}`))
+	})
+
+	resp, err := client.SaveMediaByLinks(ctx, apiKey, payload)
+	if err != nil {
+		t.Fatalf("SaveMediaByLinks returned unexpected error: %v", err)
+	}
+	if resp == nil || resp.Error {
+		t.Errorf("Expected successful response, got %+v", resp)
+	}
+	if received.NmID != 123 || len(received.Data) != 1 {
+		t.Errorf("Unexpected payload %+v", received)
+	}
+}
+
+func TestWBClient_GenerateBarcodes(t *testing.T) {
+	var received entities.WBBarcodesRequest
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/content/v2/barcodes" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"data":["5032781145187","5032781145188"],"error":false,"errorText":"","additionalErrors":""}`))
+	})
+
+	barcodes, err := client.GenerateBarcodes(context.Background(), "test-api-key", 2)
+	if err != nil {
+		t.Fatalf("GenerateBarcodes returned unexpected error: %v", err)
+	}
+	if received.Count != 2 || len(barcodes) != 2 || barcodes[0] != "5032781145187" {
+		t.Errorf("Unexpected barcodes %v for count %d", barcodes, received.Count)
+	}
+}
+
+func TestWBClient_GetSubjectCharacteristics(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/content/v2/object/charcs/105" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[{"charcID":14177449,"subjectName":"Футболки","subjectID":105,"name":"Цвет","required":false,"unitName":"","maxCount":5,"popular":true,"charcType":1}],"error":false,"errorText":"","additionalErrors":null}`))
+	})
+
+	charcs, err := client.GetSubjectCharacteristics(context.Background(), "test-api-key", 105)
+	if err != nil {
+		t.Fatalf("GetSubjectCharacteristics returned unexpected error: %v", err)
+	}
+	if len(charcs) != 1 || charcs[0].CharcID != entities.WBColorCharacteristicID || charcs[0].MaxCount != 5 || charcs[0].CharcType != entities.WBCharcTypeStrings {
+		t.Errorf("Unexpected characteristics: %+v", charcs)
 	}
 }
 
@@ -122,10 +278,140 @@ func TestWBClient_GetCardList(t *testing.T) {
 		Cursor: entities.WBGetCardListRequestCursor{Limit: 10},
 	}}
 
-	client := NewWBClient()
-	// We expect an error because we're not actually hitting a real WB server
-	_, err := client.GetCardList(ctx, apiKey, payload)
-	if err == nil {
-		t.Error("Expected an error when calling real API endpoint, got nil")
+	t.Run("API key missing", func(t *testing.T) {
+		client := NewWBClient("", Options{}, nil)
+		if _, err := client.GetCardList(ctx, "", payload); err == nil {
+			t.Error("Expected an error for missing API key, got nil")
+		}
+	})
+
+	t.Run("Cards found", func(t *testing.T) {
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/content/v2/get/cards/list" {
+				t.Errorf("Unexpected path %s", r.URL.Path)
+			}
+			w.Write([]byte(`{"cards":[{"nmID":1001,"vendorCode":"VC001"}],"cursor":{"updatedAt":"2024-01-01T00:00:00Z","nmID":1001,"total":1}}`))
+		})
+
+		resp, err := client.GetCardList(ctx, apiKey, payload)
+		if err != nil {
+			t.Fatalf("GetCardList returned unexpected error: %v", err)
+		}
+		if len(resp.Cards) != 1 || resp.Cards[0].NmID != 1001 || resp.Cards[0].VendorCode != "VC001" {
+			t.Errorf("Unexpected cards %+v", resp.Cards)
+		}
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		if _, err := client.GetCardList(ctx, apiKey, payload); err == nil {
+			t.Error("Expected an error for non-200 status, got nil")
+		}
+	})
+}
+
+func TestWBClient_RateLimitRetry(t *testing.T) {
+	ctx := context.Background()
+	apiKey := "test-api-key"
+	payload := entities.WBGetCardListRequest{}
+
+	t.Run("Retries after X-Ratelimit-Retry", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			var body entities.WBGetCardListRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Request body was not replayed on attempt %d: %v", calls, err)
+			}
+			if calls == 1 {
+				w.Header().Set("X-Ratelimit-Retry", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write([]byte(`{"cards":[],"cursor":{"total":0}}`))
+		}))
+		defer server.Close()
+
+		client := NewWBClient(server.URL, Options{RequestsPerMinute: 6000, Burst: 5, MaxRetries: 2}, server.Client())
+		if _, err := client.GetCardList(ctx, apiKey, payload); err != nil {
+			t.Fatalf("GetCardList returned unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", calls)
+		}
+	})
+
+	t.Run("Gives up after max retries", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("X-Ratelimit-Retry", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := NewWBClient(server.URL, Options{MaxRetries: 1}, server.Client())
+		_, err := client.GetCardList(ctx, apiKey, payload)
+		if err == nil || !strings.Contains(err.Error(), "429") {
+			t.Fatalf("Expected 429 error after retries, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", calls)
+		}
+	})
+}
+
+func TestWBClient_ListCards(t *testing.T) {
+	ctx := context.Background()
+	apiKey := "test-api-key"
+
+	var requests []entities.WBGetCardListRequest
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req entities.WBGetCardListRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		requests = append(requests, req)
+		if req.Settings.Cursor.NmID == nil {
+			w.Write([]byte(`{"cards":[{"nmID":1,"vendorCode":"A"},{"nmID":2,"vendorCode":"B"}],"cursor":{"updatedAt":"2024-01-02T00:00:00Z","nmID":2,"total":2}}`))
+			return
+		}
+		w.Write([]byte(`{"cards":[{"nmID":3,"vendorCode":"C"}],"cursor":{"updatedAt":"2024-01-01T00:00:00Z","nmID":3,"total":1}}`))
+	})
+
+	filter := &entities.WBGetCardListRequestFilter{TextSearch: "VC"}
+	var nmIDs []int
+	err := client.ListCards(ctx, apiKey, filter, 2, func(cards []entities.WBCardDefinition) (bool, error) {
+		for _, card := range cards {
+			nmIDs = append(nmIDs, card.NmID)
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("ListCards returned unexpected error: %v", err)
+	}
+	if len(nmIDs) != 3 {
+		t.Fatalf("Expected cards of both pages, got %v", nmIDs)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 page requests, got %d", len(requests))
+	}
+	second := requests[1].Settings
+	if second.Cursor.NmID == nil || *second.Cursor.NmID != 2 || second.Cursor.UpdatedAt == nil || *second.Cursor.UpdatedAt != "2024-01-02T00:00:00Z" {
+		t.Errorf("Expected cursor of the first page in the second request, got %+v", second.Cursor)
+	}
+	if second.Filter == nil || second.Filter.TextSearch != "VC" || second.Cursor.Limit != 2 {
+		t.Errorf("Expected filter and limit to be kept across pages, got %+v", second)
+	}
+
+	requests = nil
+	err = client.ListCards(ctx, apiKey, filter, 2, func(cards []entities.WBCardDefinition) (bool, error) {
+		return false, nil
+	})
+	if err != nil || len(requests) != 1 {
+		t.Errorf("Expected listing to stop after the first page, got err %v and %d requests", err, len(requests))
 	}
 }
@@ -0,0 +1,135 @@
+package wb
+
+import (
+	"api/app/domain/entities"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// wildberriesMarketplaceAPIHost is the default base URL for Wildberries marketplace API.
+const wildberriesMarketplaceAPIHost = "https://marketplace-api.wildberries.ru"
+
+// MarketplaceClient is a client of the Wildberries marketplace API managing seller warehouses and their stocks.
+// It is rate limited separately from the content API.
+type MarketplaceClient struct {
+	*WBClient
+}
+
+// NewMarketplaceClient creates a Wildberries marketplace API client. An empty baseURL selects the production host.
+func NewMarketplaceClient(baseURL string, opts Options, httpClient *http.Client) *MarketplaceClient {
+	if baseURL == "" {
+		baseURL = wildberriesMarketplaceAPIHost
+	}
+	return &MarketplaceClient{WBClient: NewWBClient(baseURL, opts, httpClient)}
+}
+
+// ListWarehouses returns the warehouses of the seller.
+// Corresponds to GET /api/v3/warehouses
+func (c *MarketplaceClient) ListWarehouses(ctx context.Context, apiKey string) ([]entities.WBWarehouse, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for listing warehouses")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/v3/warehouses", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries warehouses request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_warehouses", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries warehouses API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries warehouses response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries warehouses API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var warehouses []entities.WBWarehouse
+	if err := json.Unmarshal(respBody, &warehouses); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries warehouses response: %w", err)
+	}
+	return warehouses, nil
+}
+
+// GetStocks returns the stocks of sizes, identified by their barcodes, in a seller warehouse.
+// Sizes without stock are not returned.
+// Corresponds to POST /api/v3/stocks/{warehouseId}
+func (c *MarketplaceClient) GetStocks(ctx context.Context, apiKey string, warehouseID int64, skus []string) ([]entities.WBStock, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for getting stocks")
+	}
+	payloadBytes, err := json.Marshal(entities.WBStocksQuery{Skus: skus})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Wildberries stocks query: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/v3/stocks/%d", c.baseURL, warehouseID), bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries stocks query: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_stocks_get", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries stocks API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries stocks response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries stocks API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBStocksResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries stocks response: %w", err)
+	}
+	return wbResp.Stocks, nil
+}
+
+// SetStocks sets the stocks of sizes, identified by their barcodes, in a seller warehouse.
+// Corresponds to PUT /api/v3/stocks/{warehouseId}
+func (c *MarketplaceClient) SetStocks(ctx context.Context, apiKey string, warehouseID int64, stocks []entities.WBStock) error {
+	if apiKey == "" {
+		return fmt.Errorf("wildberries API key is required for setting stocks")
+	}
+	payloadBytes, err := json.Marshal(entities.WBStocksRequest{Stocks: stocks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Wildberries stocks payload: %w", err)
+	}
+	stocksURL := fmt.Sprintf("%s/api/v3/stocks/%d", c.baseURL, warehouseID)
+	log.Printf("Setting stocks on Wildberries: %s, Payload: %s", stocksURL, string(payloadBytes))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "PUT", stocksURL, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create Wildberries stocks request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_stocks", apiKey)
+	if err != nil {
+		return fmt.Errorf("failed to call Wildberries stocks API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 204 No Content on success, 409 lists the sizes that could not be updated
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("wildberries stocks API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
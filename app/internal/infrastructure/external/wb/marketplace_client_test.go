@@ -0,0 +1,93 @@
+package wb
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMarketplaceTestServer(t *testing.T, handler http.HandlerFunc) *MarketplaceClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewMarketplaceClient(server.URL, Options{}, server.Client())
+}
+
+func TestMarketplaceClient_ListWarehouses(t *testing.T) {
+	client := newMarketplaceTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v3/warehouses" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"name":"Склад Коледино","officeId":15,"id":507,"cargoType":1,"deliveryType":1}]`))
+	})
+
+	warehouses, err := client.ListWarehouses(context.Background(), "test-api-key")
+	if err != nil {
+		t.Fatalf("ListWarehouses returned unexpected error: %v", err)
+	}
+	if len(warehouses) != 1 || warehouses[0].ID != 507 || warehouses[0].Name != "Склад Коледино" {
+		t.Errorf("Unexpected warehouses: %+v", warehouses)
+	}
+}
+
+func TestMarketplaceClient_GetStocks(t *testing.T) {
+	var received entities.WBStocksQuery
+	client := newMarketplaceTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/stocks/507" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		w.Write([]byte(`{"stocks":[{"sku":"2000000000011","amount":7}]}`))
+	})
+
+	stocks, err := client.GetStocks(context.Background(), "test-api-key", 507, []string{"2000000000011", "2000000000028"})
+	if err != nil {
+		t.Fatalf("GetStocks returned unexpected error: %v", err)
+	}
+	if len(received.Skus) != 2 {
+		t.Errorf("Expected 2 skus in the query, got %v", received.Skus)
+	}
+	if len(stocks) != 1 || stocks[0] != (entities.WBStock{Sku: "2000000000011", Amount: 7}) {
+		t.Errorf("Unexpected stocks: %+v", stocks)
+	}
+}
+
+func TestMarketplaceClient_SetStocks(t *testing.T) {
+	ctx := context.Background()
+	stocks := []entities.WBStock{{Sku: "2000000000011", Amount: 7}}
+
+	t.Run("Updated", func(t *testing.T) {
+		var received entities.WBStocksRequest
+		client := newMarketplaceTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/api/v3/stocks/507" {
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		if err := client.SetStocks(ctx, "test-api-key", 507, stocks); err != nil {
+			t.Fatalf("SetStocks returned unexpected error: %v", err)
+		}
+		if len(received.Stocks) != 1 || received.Stocks[0] != stocks[0] {
+			t.Errorf("Stocks were not sent as prepared: %+v", received.Stocks)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		client := newMarketplaceTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`[{"code":"NotFound","data":[{"sku":"2000000000011","amount":7}],"message":"Товар не найден"}]`))
+		})
+		if err := client.SetStocks(ctx, "test-api-key", 507, stocks); err == nil {
+			t.Error("Expected an error for rejected stocks, got nil")
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package wb
+
+import (
+	"api/app/domain/entities"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// wildberriesPricesAPIHost is the default base URL for Wildberries prices and discounts API.
+const wildberriesPricesAPIHost = "https://discounts-prices-api.wildberries.ru"
+
+// PricesClient is a client of the Wildberries prices and discounts API. It is rate limited separately
+// from the content API, since WB limits every category of methods on its own.
+type PricesClient struct {
+	*WBClient
+}
+
+// NewPricesClient creates a Wildberries prices API client. An empty baseURL selects the production host.
+func NewPricesClient(baseURL string, opts Options, httpClient *http.Client) *PricesClient {
+	if baseURL == "" {
+		baseURL = wildberriesPricesAPIHost
+	}
+	return &PricesClient{WBClient: NewWBClient(baseURL, opts, httpClient)}
+}
+
+// UploadPrices creates a task setting prices and discounts of the goods and returns its upload ID.
+// An identical task that already exists is not an error, its ID is returned.
+// Corresponds to POST /api/v2/upload/task
+func (c *PricesClient) UploadPrices(ctx context.Context, apiKey string, goods []entities.WBPriceGood) (int64, error) {
+	if apiKey == "" {
+		return 0, fmt.Errorf("wildberries API key is required for uploading prices")
+	}
+	payloadBytes, err := json.Marshal(entities.WBPriceTaskRequest{Data: goods})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal Wildberries prices payload: %w", err)
+	}
+	uploadURL := c.baseURL + "/api/v2/upload/task"
+	log.Printf("Uploading prices to Wildberries: %s, Payload: %s", uploadURL, string(payloadBytes))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", uploadURL, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Wildberries prices upload request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_prices_upload", apiKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call Wildberries prices upload API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read Wildberries prices upload response body: %w", err)
+	}
+	log.Printf("Wildberries prices upload API response status: %d, body: %s", resp.StatusCode, string(respBody))
+
+	// 208 is returned for a task that already exists
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAlreadyReported {
+		return 0, fmt.Errorf("wildberries prices upload API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBPriceTaskResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal Wildberries prices upload response: %w", err)
+	}
+	if wbResp.Error {
+		return 0, fmt.Errorf("wildberries prices upload API returned error: %s", wbResp.ErrorText)
+	}
+	return wbResp.Data.ID, nil
+}
+
+// GetPriceTask returns the state of a processed price upload, nil while WB is still processing it.
+// Corresponds to GET /api/v2/history/tasks
+func (c *PricesClient) GetPriceTask(ctx context.Context, apiKey string, uploadID int64) (*entities.WBPriceTask, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for getting price tasks")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v2/history/tasks?uploadID=%d", c.baseURL, uploadID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries price task request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_prices_task", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries price task API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries price task response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries price task API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBPriceTaskHistoryResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries price task response: %w", err)
+	}
+	if wbResp.Data == nil || wbResp.Data.UploadID == 0 {
+		return nil, nil
+	}
+	wbResp.Data.ErrorText = wbResp.ErrorText
+	return wbResp.Data, nil
+}
+
+// GetGoodPrices returns the prices of the sizes of the card with nmID, nil if WB has no prices for it.
+// Corresponds to GET /api/v2/list/goods/filter
+func (c *PricesClient) GetGoodPrices(ctx context.Context, apiKey string, nmID int) (*entities.WBGoodPrices, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("wildberries API key is required for getting prices")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/v2/list/goods/filter?limit=1&filterNmID=%d", c.baseURL, nmID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Wildberries goods prices request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", apiKey)
+
+	resp, err := c.do(httpReq, "wb_goods_prices", apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Wildberries goods prices API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wildberries goods prices response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wildberries goods prices API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var wbResp entities.WBGoodsPriceListResponse
+	if err := json.Unmarshal(respBody, &wbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Wildberries goods prices response: %w", err)
+	}
+	if wbResp.Error {
+		return nil, fmt.Errorf("wildberries goods prices API returned error: %s", wbResp.ErrorText)
+	}
+	for i := range wbResp.Data.ListGoods {
+		if wbResp.Data.ListGoods[i].NmID == nmID {
+			return &wbResp.Data.ListGoods[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,127 @@
+package wb
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPricesTestServer(t *testing.T, handler http.HandlerFunc) *PricesClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewPricesClient(server.URL, Options{}, server.Client())
+}
+
+func TestPricesClient_UploadPrices(t *testing.T) {
+	ctx := context.Background()
+	goods := []entities.WBPriceGood{{NmID: 123, Price: 2500, Discount: 15}}
+
+	t.Run("API key missing", func(t *testing.T) {
+		client := NewPricesClient("", Options{}, nil)
+		if _, err := client.UploadPrices(ctx, "", goods); err == nil {
+			t.Error("Expected an error for missing API key, got nil")
+		}
+	})
+
+	for _, status := range []int{http.StatusOK, http.StatusAlreadyReported} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var received entities.WBPriceTaskRequest
+			client := newPricesTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v2/upload/task" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("Failed to decode request body: %v", err)
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"data":{"id":777,"alreadyExists":false},"error":false,"errorText":""}`))
+			})
+
+			uploadID, err := client.UploadPrices(ctx, "test-api-key", goods)
+			if err != nil {
+				t.Fatalf("UploadPrices returned unexpected error: %v", err)
+			}
+			if uploadID != 777 {
+				t.Errorf("Expected upload ID 777, got %d", uploadID)
+			}
+			if len(received.Data) != 1 || received.Data[0] != goods[0] {
+				t.Errorf("Prices were not sent as prepared: %+v", received.Data)
+			}
+		})
+	}
+
+	t.Run("Rejected", func(t *testing.T) {
+		client := newPricesTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"data":null,"error":true,"errorText":"Invalid data"}`))
+		})
+		if _, err := client.UploadPrices(ctx, "test-api-key", goods); err == nil {
+			t.Error("Expected an error for a rejected upload, got nil")
+		}
+	})
+}
+
+func TestPricesClient_GetPriceTask(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Processed", func(t *testing.T) {
+		client := newPricesTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v2/history/tasks" || r.URL.Query().Get("uploadID") != "777" {
+				t.Errorf("Unexpected request %s", r.URL)
+			}
+			w.Write([]byte(`{"data":{"uploadID":777,"status":5,"overAllGoodsNumber":2,"successGoodsNumber":1},"error":false,"errorText":"price is too low"}`))
+		})
+
+		task, err := client.GetPriceTask(ctx, "test-api-key", 777)
+		if err != nil {
+			t.Fatalf("GetPriceTask returned unexpected error: %v", err)
+		}
+		if task == nil || task.Status != entities.WBPriceTaskPartiallyFailed || task.SuccessGoodsNumber != 1 || task.ErrorText != "price is too low" {
+			t.Errorf("Unexpected task: %+v", task)
+		}
+	})
+
+	t.Run("Still processing", func(t *testing.T) {
+		client := newPricesTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":null,"error":false,"errorText":""}`))
+		})
+
+		task, err := client.GetPriceTask(ctx, "test-api-key", 777)
+		if err != nil {
+			t.Fatalf("GetPriceTask returned unexpected error: %v", err)
+		}
+		if task != nil {
+			t.Errorf("Expected no task while the upload is processed, got %+v", task)
+		}
+	})
+}
+
+func TestPricesClient_GetGoodPrices(t *testing.T) {
+	ctx := context.Background()
+	client := newPricesTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/list/goods/filter" || r.URL.Query().Get("filterNmID") == "" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"data":{"listGoods":[{"nmID":123,"vendorCode":"VC001","sizes":[{"sizeID":1,"price":2500,"discountedPrice":2125.5,"techSizeName":"S"}],"currencyIsoCode4217":"RUB","discount":15}]},"error":false,"errorText":""}`))
+	})
+
+	prices, err := client.GetGoodPrices(ctx, "test-api-key", 123)
+	if err != nil {
+		t.Fatalf("GetGoodPrices returned unexpected error: %v", err)
+	}
+	if prices == nil || len(prices.Sizes) != 1 || prices.Sizes[0].DiscountedPrice != 2125.5 || prices.Discount != 15 {
+		t.Errorf("Unexpected prices: %+v", prices)
+	}
+
+	prices, err = client.GetGoodPrices(ctx, "test-api-key", 456)
+	if err != nil {
+		t.Fatalf("GetGoodPrices returned unexpected error: %v", err)
+	}
+	if prices != nil {
+		t.Errorf("Expected no prices for another nmID, got %+v", prices)
+	}
+}
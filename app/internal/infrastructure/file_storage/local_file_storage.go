@@ -4,7 +4,9 @@ import (
 	"api/app/domain/entities"
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -70,6 +72,63 @@ func (lfs *LocalFileStorage) UploadFiles(ctx context.Context, files []entities.F
 	return results, nil
 }
 
+// SaveStream writes the content of r to a new file without buffering it in memory.
+func (lfs *LocalFileStorage) SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error) {
+	uniqueFilename, err := lfs.generateUniqueFilename(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate unique filename: %w", err)
+	}
+
+	filePath := filepath.Join(lfs.uploadDir, uniqueFilename)
+	f, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file on disk: %w", err)
+	}
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to write file to disk: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to close file on disk: %w", err)
+	}
+
+	publicURL := fmt.Sprintf("%s/%s", lfs.baseURL, uniqueFilename)
+	log.Printf("Successfully streamed file: %s -> %s", filename, publicURL)
+
+	return &entities.MediaFile{
+		ID:          uniqueFilename,
+		Filename:    filename,
+		ContentType: contentType,
+		URL:         publicURL,
+		Size:        size,
+	}, nil
+}
+
+// GetMedia looks up a previously stored file by its media ID.
+func (lfs *LocalFileStorage) GetMedia(ctx context.Context, mediaID string) (*entities.MediaFile, error) {
+	if mediaID == "" || mediaID != filepath.Base(mediaID) || strings.HasPrefix(mediaID, ".") {
+		return nil, fmt.Errorf("%w: invalid media id %q", entities.ErrMediaNotFound, mediaID)
+	}
+
+	info, err := os.Stat(filepath.Join(lfs.uploadDir, mediaID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", entities.ErrMediaNotFound, mediaID)
+		}
+		return nil, fmt.Errorf("failed to stat media %s: %w", mediaID, err)
+	}
+
+	return &entities.MediaFile{
+		ID:   mediaID,
+		URL:  fmt.Sprintf("%s/%s", lfs.baseURL, mediaID),
+		Size: info.Size(),
+	}, nil
+}
+
 // generateUniqueFilename generates a unique filename using timestamp and random bytes
 func (lfs *LocalFileStorage) generateUniqueFilename(originalFilename string) (string, error) {
 	// Extract file extension
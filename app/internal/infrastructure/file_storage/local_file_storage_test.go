@@ -0,0 +1,45 @@
+package file_storage
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalFileStorage_SaveStreamAndGetMedia(t *testing.T) {
+	dir := t.TempDir()
+	storage := NewLocalFileStorage(dir, "https://files.example.com/")
+
+	mediaFile, err := storage.SaveStream(context.Background(), "my photo.jpg", "image/jpeg", strings.NewReader("jpeg data"))
+	if err != nil {
+		t.Fatalf("SaveStream: %v", err)
+	}
+	if mediaFile.Size != 9 || !strings.HasSuffix(mediaFile.ID, "_my_photo.jpg") {
+		t.Errorf("media file = %+v, want 9 bytes under a unique name", mediaFile)
+	}
+	if mediaFile.URL != "https://files.example.com/"+mediaFile.ID {
+		t.Errorf("URL = %s, want the base URL with the media ID", mediaFile.URL)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, mediaFile.ID)); err != nil || string(content) != "jpeg data" {
+		t.Errorf("stored content = %q, %v", content, err)
+	}
+
+	found, err := storage.GetMedia(context.Background(), mediaFile.ID)
+	if err != nil || found.URL != mediaFile.URL || found.Size != 9 {
+		t.Errorf("GetMedia = %+v, %v, want the stored file", found, err)
+	}
+}
+
+func TestLocalFileStorage_GetMedia_NotFound(t *testing.T) {
+	storage := NewLocalFileStorage(t.TempDir(), "https://files.example.com")
+
+	for _, mediaID := range []string{"", "missing.jpg", "../secret", ".hidden"} {
+		if _, err := storage.GetMedia(context.Background(), mediaID); !errors.Is(err, entities.ErrMediaNotFound) {
+			t.Errorf("GetMedia(%q) err = %v, want ErrMediaNotFound", mediaID, err)
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"api/app/domain/entities"
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -89,6 +91,106 @@ func (tfs *TemporaryFileStorage) UploadFiles(ctx context.Context, files []entiti
 	return results, nil
 }
 
+// SaveStream writes the content of r to a new temporary file without buffering it in memory.
+// The generated unique filename is used as the media ID.
+func (tfs *TemporaryFileStorage) SaveStream(ctx context.Context, filename, contentType string, r io.Reader) (*entities.MediaFile, error) {
+	uniqueFilename, err := tfs.generateUniqueFilename(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate unique filename: %w", err)
+	}
+
+	filePath := filepath.Join(tfs.uploadDir, uniqueFilename)
+	log.Printf("[FILE STORAGE] Streaming file %s to: %s", filename, filePath)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file on disk: %w", err)
+	}
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		os.Remove(filePath)
+		log.Printf("[FILE STORAGE] ERROR: Failed to stream file %s to %s after %d bytes: %v", filename, filePath, size, err)
+		return nil, fmt.Errorf("failed to write file to disk: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to close file on disk: %w", err)
+	}
+
+	publicURL := fmt.Sprintf("%s/%s", tfs.baseURL, uniqueFilename)
+	log.Printf("[FILE STORAGE] Successfully streamed temporary file: %s -> %s (%d bytes, TTL: %v)", filename, publicURL, size, tfs.fileTTL)
+
+	return &entities.MediaFile{
+		ID:          uniqueFilename,
+		Filename:    filename,
+		ContentType: contentType,
+		URL:         publicURL,
+		Size:        size,
+	}, nil
+}
+
+// GetMedia looks up a previously stored file by its media ID.
+// Files removed by the cleanup routine are reported as entities.ErrMediaNotFound.
+func (tfs *TemporaryFileStorage) GetMedia(ctx context.Context, mediaID string) (*entities.MediaFile, error) {
+	path, err := tfs.mediaPath(mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", entities.ErrMediaNotFound, mediaID)
+		}
+		return nil, fmt.Errorf("failed to stat media %s: %w", mediaID, err)
+	}
+
+	return &entities.MediaFile{
+		ID:   mediaID,
+		URL:  fmt.Sprintf("%s/%s", tfs.baseURL, mediaID),
+		Size: info.Size(),
+	}, nil
+}
+
+// ReadMedia returns the content of a previously stored file.
+// Files removed by the cleanup routine are reported as entities.ErrMediaNotFound.
+func (tfs *TemporaryFileStorage) ReadMedia(ctx context.Context, mediaID string) ([]byte, error) {
+	path, err := tfs.mediaPath(mediaID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", entities.ErrMediaNotFound, mediaID)
+		}
+		return nil, fmt.Errorf("failed to read media %s: %w", mediaID, err)
+	}
+	return content, nil
+}
+
+// DeleteMedia removes a previously stored file before the cleanup routine does. Missing files are ignored.
+func (tfs *TemporaryFileStorage) DeleteMedia(ctx context.Context, mediaID string) error {
+	path, err := tfs.mediaPath(mediaID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete media %s: %w", mediaID, err)
+	}
+	return nil
+}
+
+// mediaPath returns the path of the file with mediaID, rejecting IDs that point outside the upload directory.
+func (tfs *TemporaryFileStorage) mediaPath(mediaID string) (string, error) {
+	if mediaID == "" || mediaID != filepath.Base(mediaID) || strings.HasPrefix(mediaID, ".") {
+		return "", fmt.Errorf("%w: invalid media id %q", entities.ErrMediaNotFound, mediaID)
+	}
+	return filepath.Join(tfs.uploadDir, mediaID), nil
+}
+
 // generateUniqueFilename generates a unique filename using timestamp and random bytes
 func (tfs *TemporaryFileStorage) generateUniqueFilename(originalFilename string) (string, error) {
 	// Extract file extension
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// CardContentCacheStorage stores generated card content in PostgreSQL.
+type CardContentCacheStorage struct {
+	client postgresql.PostgreSQLClient
+}
+
+// NewCardContentCacheStorage creates a new CardContentCacheStorage instance.
+func NewCardContentCacheStorage(client postgresql.PostgreSQLClient) *CardContentCacheStorage {
+	return &CardContentCacheStorage{client: client}
+}
+
+// GetCardContent returns content cached under cacheKey no earlier than notBefore, or nil if there is none.
+func (s *CardContentCacheStorage) GetCardContent(ctx context.Context, cacheKey string, notBefore time.Time) (*entities.CachedCardContent, error) {
+	const query = `SELECT content, prompt_tokens, completion_tokens, created_at
+                    FROM card_content_cache
+                    WHERE cache_key = $1 AND created_at >= $2`
+	row := s.client.QueryRow(ctx, query, cacheKey, notBefore)
+
+	var contentJSON []byte
+	var cached entities.CachedCardContent
+	if err := row.Scan(&contentJSON, &cached.TokenUsage.TotalPromptTokens, &cached.TokenUsage.TotalCompletionTokens, &cached.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(contentJSON, &cached.Content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached content: %w", err)
+	}
+	cached.TokenUsage.TotalTokens = cached.TokenUsage.TotalPromptTokens + cached.TokenUsage.TotalCompletionTokens
+	return &cached, nil
+}
+
+// SetCardContent inserts or replaces content cached under cacheKey.
+func (s *CardContentCacheStorage) SetCardContent(ctx context.Context, cacheKey string, content entities.CardCraftAiGeneratedContent, usage entities.SessionData) error {
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to marshal content for cache: %w", err)
+	}
+	const query = `INSERT INTO card_content_cache (cache_key, content, prompt_tokens, completion_tokens, created_at)
+                    VALUES ($1, $2, $3, $4, NOW())
+                    ON CONFLICT (cache_key) DO UPDATE SET content = EXCLUDED.content,
+                        prompt_tokens = EXCLUDED.prompt_tokens,
+                        completion_tokens = EXCLUDED.completion_tokens,
+                        created_at = EXCLUDED.created_at`
+	_, err = s.client.Exec(ctx, query, cacheKey, contentJSON, usage.TotalPromptTokens, usage.TotalCompletionTokens)
+	return err
+}
+
+// DeleteExpiredCardContent removes content cached before notBefore.
+func (s *CardContentCacheStorage) DeleteExpiredCardContent(ctx context.Context, notBefore time.Time) error {
+	const query = "DELETE FROM card_content_cache WHERE created_at < $1"
+	_, err := s.client.Exec(ctx, query, notBefore)
+	return err
+}
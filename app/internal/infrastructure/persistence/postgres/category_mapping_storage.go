@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// CategoryMappingStorage stores the pairs of WB subjects and Ozon categories in PostgreSQL.
+type CategoryMappingStorage struct {
+	client postgresql.PostgreSQLClient
+}
+
+// NewCategoryMappingStorage creates a new CategoryMappingStorage instance.
+func NewCategoryMappingStorage(client postgresql.PostgreSQLClient) *CategoryMappingStorage {
+	return &CategoryMappingStorage{client: client}
+}
+
+const categoryMappingColumns = `wb_subject_id, wb_subject_name, ozon_description_category_id, ozon_description_category_name,
+                        ozon_type_id, ozon_type_name, resolutions, overridden, updated_at`
+
+// RecordCategoryMapping counts one more resolution of the pair and keeps the latest known names.
+func (s *CategoryMappingStorage) RecordCategoryMapping(ctx context.Context, mapping *entities.CategoryMapping) error {
+	const query = `INSERT INTO category_mappings (wb_subject_id, wb_subject_name, ozon_description_category_id,
+                        ozon_description_category_name, ozon_type_id, ozon_type_name, resolutions)
+                    VALUES ($1, $2, $3, $4, $5, $6, 1)
+                    ON CONFLICT (wb_subject_id, ozon_description_category_id, ozon_type_id) DO UPDATE SET
+                        wb_subject_name = COALESCE(NULLIF(EXCLUDED.wb_subject_name, ''), category_mappings.wb_subject_name),
+                        ozon_description_category_name = COALESCE(NULLIF(EXCLUDED.ozon_description_category_name, ''), category_mappings.ozon_description_category_name),
+                        ozon_type_name = COALESCE(NULLIF(EXCLUDED.ozon_type_name, ''), category_mappings.ozon_type_name),
+                        resolutions = category_mappings.resolutions + 1,
+                        updated_at = NOW()`
+	_, err := s.client.Exec(ctx, query, mapping.WbSubjectID, mapping.WbSubjectName, mapping.OzonDescriptionCategoryID,
+		mapping.OzonDescriptionCategoryName, mapping.OzonTypeID, mapping.OzonTypeName)
+	return err
+}
+
+// GetCategoryMappingByWbSubject returns the override of the WB subject or its most resolved pair, or nil if the
+// subject has none. The confidence is relative to the other pairs of the subject.
+func (s *CategoryMappingStorage) GetCategoryMappingByWbSubject(ctx context.Context, subjectID int32) (*entities.CategoryMapping, error) {
+	const query = `SELECT ` + categoryMappingColumns + `,
+                        COALESCE(resolutions::FLOAT8 / NULLIF(SUM(resolutions) OVER (), 0), 0)
+                    FROM category_mappings WHERE wb_subject_id = $1
+                    ORDER BY overridden DESC, resolutions DESC, updated_at DESC LIMIT 1`
+	return s.getCategoryMapping(ctx, query, subjectID)
+}
+
+// GetCategoryMappingByOzonType returns the pair of the Ozon category and type overridden or resolved most often,
+// or nil if it has none. The confidence is relative to the other pairs of the type.
+func (s *CategoryMappingStorage) GetCategoryMappingByOzonType(ctx context.Context, categoryID, typeID int32) (*entities.CategoryMapping, error) {
+	const query = `SELECT ` + categoryMappingColumns + `,
+                        COALESCE(resolutions::FLOAT8 / NULLIF(SUM(resolutions) OVER (), 0), 0)
+                    FROM category_mappings WHERE ozon_description_category_id = $1 AND ozon_type_id = $2
+                    ORDER BY overridden DESC, resolutions DESC, updated_at DESC LIMIT 1`
+	return s.getCategoryMapping(ctx, query, categoryID, typeID)
+}
+
+func (s *CategoryMappingStorage) getCategoryMapping(ctx context.Context, query string, args ...interface{}) (*entities.CategoryMapping, error) {
+	mapping, err := scanCategoryMapping(s.client.QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return mapping, err
+}
+
+// ListCategoryMappings returns the pairs matching the filter, ordered by WB subject with its most resolved pair
+// first. The confidence is relative to the other pairs of the WB subject.
+func (s *CategoryMappingStorage) ListCategoryMappings(ctx context.Context, filter entities.CategoryMappingFilter) ([]entities.CategoryMapping, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.WbSubjectID != 0 {
+		addCondition("wb_subject_id = $%d", filter.WbSubjectID)
+	}
+	if filter.OzonDescriptionCategoryID != 0 {
+		addCondition("ozon_description_category_id = $%d", filter.OzonDescriptionCategoryID)
+	}
+	if filter.OzonTypeID != 0 {
+		addCondition("ozon_type_id = $%d", filter.OzonTypeID)
+	}
+	if filter.OverriddenOnly {
+		conditions = append(conditions, "overridden")
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// The confidence is computed over all pairs of the subject, before the filter drops some of them
+	query := `SELECT * FROM (
+                SELECT ` + categoryMappingColumns + `,
+                    COALESCE(resolutions::FLOAT8 / NULLIF(SUM(resolutions) OVER (PARTITION BY wb_subject_id), 0), 0)
+                FROM category_mappings
+            ) AS mappings ` + where + `
+            ORDER BY wb_subject_id, overridden DESC, resolutions DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := s.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.CategoryMapping
+	for rows.Next() {
+		mapping, err := scanCategoryMapping(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *mapping)
+	}
+	return result, rows.Err()
+}
+
+func scanCategoryMapping(row pgx.Row) (*entities.CategoryMapping, error) {
+	var m entities.CategoryMapping
+	if err := row.Scan(&m.WbSubjectID, &m.WbSubjectName, &m.OzonDescriptionCategoryID, &m.OzonDescriptionCategoryName,
+		&m.OzonTypeID, &m.OzonTypeName, &m.Resolutions, &m.Overridden, &m.UpdatedAt, &m.Confidence); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// OverrideCategoryMapping makes the pair the override of its WB subject, replacing the previous override.
+// Names left empty keep the names already known for the pair.
+func (s *CategoryMappingStorage) OverrideCategoryMapping(ctx context.Context, mapping *entities.CategoryMapping) error {
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := resetCategoryMappingOverride(ctx, tx, mapping.WbSubjectID); err != nil {
+			return err
+		}
+		const query = `INSERT INTO category_mappings (wb_subject_id, wb_subject_name, ozon_description_category_id,
+                            ozon_description_category_name, ozon_type_id, ozon_type_name, overridden)
+                        VALUES ($1, $2, $3, $4, $5, $6, TRUE)
+                        ON CONFLICT (wb_subject_id, ozon_description_category_id, ozon_type_id) DO UPDATE SET
+                            wb_subject_name = COALESCE(NULLIF(EXCLUDED.wb_subject_name, ''), category_mappings.wb_subject_name),
+                            ozon_description_category_name = COALESCE(NULLIF(EXCLUDED.ozon_description_category_name, ''), category_mappings.ozon_description_category_name),
+                            ozon_type_name = COALESCE(NULLIF(EXCLUDED.ozon_type_name, ''), category_mappings.ozon_type_name),
+                            overridden = TRUE,
+                            updated_at = NOW()`
+		_, err := tx.Exec(ctx, query, mapping.WbSubjectID, mapping.WbSubjectName, mapping.OzonDescriptionCategoryID,
+			mapping.OzonDescriptionCategoryName, mapping.OzonTypeID, mapping.OzonTypeName)
+		return err
+	})
+}
+
+// ResetCategoryMappingOverride removes the override of the WB subject, so its resolved pairs are used again.
+// It returns ErrCategoryMappingNotFound if the subject has no override.
+func (s *CategoryMappingStorage) ResetCategoryMappingOverride(ctx context.Context, subjectID int32) error {
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var exists bool
+		const query = "SELECT EXISTS (SELECT 1 FROM category_mappings WHERE wb_subject_id = $1 AND overridden)"
+		if err := tx.QueryRow(ctx, query, subjectID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return entities.ErrCategoryMappingNotFound
+		}
+		return resetCategoryMappingOverride(ctx, tx, subjectID)
+	})
+}
+
+// resetCategoryMappingOverride drops the override of the WB subject; a pair that was never resolved is deleted.
+func resetCategoryMappingOverride(ctx context.Context, tx pgx.Tx, subjectID int32) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM category_mappings WHERE wb_subject_id = $1 AND overridden AND resolutions = 0", subjectID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, "UPDATE category_mappings SET overridden = FALSE, updated_at = NOW() WHERE wb_subject_id = $1 AND overridden", subjectID)
+	return err
+}
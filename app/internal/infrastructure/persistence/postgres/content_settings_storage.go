@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// ContentSettingsStorage provides content generation settings: prompt templates and per-account providers.
+type ContentSettingsStorage struct {
+	client postgresql.PostgreSQLClient
+}
+
+// NewContentSettingsStorage creates a new ContentSettingsStorage instance.
+func NewContentSettingsStorage(client postgresql.PostgreSQLClient) *ContentSettingsStorage {
+	return &ContentSettingsStorage{client: client}
+}
+
+// GetPromptTemplate returns the prompt template with the given name, or nil if there is none.
+func (s *ContentSettingsStorage) GetPromptTemplate(ctx context.Context, name string) (*entities.PromptTemplate, error) {
+	const query = "SELECT name, system_prompt, user_prompt FROM prompt_templates WHERE name = $1"
+	row := s.client.QueryRow(ctx, query, name)
+	var tmpl entities.PromptTemplate
+	if err := row.Scan(&tmpl.Name, &tmpl.SystemPrompt, &tmpl.UserPrompt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// GetContentProvider returns the content provider chosen for the apiKey, or an empty string if none is set.
+func (s *ContentSettingsStorage) GetContentProvider(ctx context.Context, apiKey string) (entities.ContentProvider, error) {
+	const query = "SELECT content_provider FROM account_content_settings WHERE api_key = $1"
+	row := s.client.QueryRow(ctx, query, apiKey)
+	var provider string
+	if err := row.Scan(&provider); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return entities.ContentProvider(provider), nil
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// ContentVariantStorage stores generated content variants in PostgreSQL.
+type ContentVariantStorage struct {
+	client postgresql.PostgreSQLClient
+}
+
+// NewContentVariantStorage creates a new ContentVariantStorage instance.
+func NewContentVariantStorage(client postgresql.PostgreSQLClient) *ContentVariantStorage {
+	return &ContentVariantStorage{client: client}
+}
+
+// SaveContentVariants stores the variants of one generation and assigns their IDs.
+func (s *ContentVariantStorage) SaveContentVariants(ctx context.Context, variants []*entities.ContentVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	generationID, err := newID()
+	if err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO content_variants (id, generation_id, variant_index, api_key, provider, content, product_card,
+                        prompt_tokens, completion_tokens, cost, from_cache, inline_media_files)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	for _, variant := range variants {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+		contentJSON, err := json.Marshal(variant.Content)
+		if err != nil {
+			return fmt.Errorf("failed to marshal variant content: %w", err)
+		}
+		productCardJSON, err := json.Marshal(variant.ProductCard)
+		if err != nil {
+			return fmt.Errorf("failed to marshal variant product card: %w", err)
+		}
+		var promptTokens, completionTokens int
+		if variant.Content.TokenUsage != nil {
+			promptTokens = variant.Content.TokenUsage.TotalPromptTokens
+			completionTokens = variant.Content.TokenUsage.TotalCompletionTokens
+		}
+		if _, err := s.client.Exec(ctx, query, id, generationID, variant.Index, variant.ApiKey, string(variant.Content.Provider),
+			contentJSON, productCardJSON, promptTokens, completionTokens, variant.Cost, variant.Content.FromCache, variant.InlineMediaFiles); err != nil {
+			return err
+		}
+		variant.ID = id
+		variant.GenerationID = generationID
+	}
+	return nil
+}
+
+// GetContentVariant returns the variant with the given ID owned by apiKey, or ErrContentVariantNotFound.
+func (s *ContentVariantStorage) GetContentVariant(ctx context.Context, apiKey, id string) (*entities.ContentVariant, error) {
+	const query = `SELECT id, generation_id, variant_index, api_key, provider, content, product_card,
+                        prompt_tokens, completion_tokens, cost, from_cache, created_at, published_at, published_to, inline_media_files
+                    FROM content_variants
+                    WHERE id = $1 AND api_key = $2`
+	row := s.client.QueryRow(ctx, query, id, apiKey)
+
+	var variant entities.ContentVariant
+	var provider string
+	var contentJSON, productCardJSON []byte
+	var usage entities.SessionData
+	var publishedTo []string
+	if err := row.Scan(&variant.ID, &variant.GenerationID, &variant.Index, &variant.ApiKey, &provider, &contentJSON, &productCardJSON,
+		&usage.TotalPromptTokens, &usage.TotalCompletionTokens, &variant.Cost, &variant.Content.FromCache,
+		&variant.CreatedAt, &variant.PublishedAt, &publishedTo, &variant.InlineMediaFiles); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrContentVariantNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contentJSON, &variant.Content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variant content: %w", err)
+	}
+	if err := json.Unmarshal(productCardJSON, &variant.ProductCard); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variant product card: %w", err)
+	}
+	usage.TotalTokens = usage.TotalPromptTokens + usage.TotalCompletionTokens
+	variant.Content.TokenUsage = &usage
+	variant.Content.Provider = entities.ContentProvider(provider)
+	for _, marketplace := range publishedTo {
+		variant.PublishedTo = append(variant.PublishedTo, entities.Marketplace(marketplace))
+	}
+	return &variant, nil
+}
+
+// MarkContentVariantPublished records that the variant was sent to the marketplaces as the cards with the
+// vendor codes of the publications.
+func (s *ContentVariantStorage) MarkContentVariantPublished(ctx context.Context, id string, publications []entities.ContentVariantPublication) error {
+	marketplaces := make([]string, len(publications))
+	vendorCodes := make([]string, len(publications))
+	for i, p := range publications {
+		marketplaces[i], vendorCodes[i] = string(p.Marketplace), p.VendorCode
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		const query = `UPDATE content_variants
+                        SET published_at = NOW(),
+                            published_to = ARRAY(SELECT DISTINCT unnest(published_to || $2::TEXT[]))
+                        WHERE id = $1`
+		if _, err := tx.Exec(ctx, query, id, marketplaces); err != nil {
+			return err
+		}
+		const insertQuery = `INSERT INTO content_variant_publications (variant_id, marketplace, vendor_code)
+                        SELECT $1::TEXT, * FROM unnest($2::TEXT[], $3::TEXT[])`
+		_, err := tx.Exec(ctx, insertQuery, id, marketplaces, vendorCodes)
+		return err
+	})
+}
+
+// newID generates a random hex identifier.
+func newID() (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(randomBytes), nil
+}
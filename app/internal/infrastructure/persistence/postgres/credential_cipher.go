@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedCredentialPrefix marks credentials encrypted by credentialCipher. Values without it were stored
+// before credentials were encrypted and are read as is.
+const encryptedCredentialPrefix = "enc:v1:"
+
+// errNoCredentialKey is returned when credentials are stored or read without an encryption key.
+var errNoCredentialKey = errors.New("STOCK_SYNC_ENCRYPTION_KEY is not set")
+
+// credentialCipher encrypts marketplace credentials at rest with AES-256-GCM.
+type credentialCipher struct {
+	aead cipher.AEAD // nil without a key
+}
+
+// newCredentialCipher creates a cipher from a base64-encoded 32-byte key. An empty key gives a cipher that
+// refuses to encrypt and decrypt.
+func newCredentialCipher(key string) (*credentialCipher, error) {
+	if key == "" {
+		return &credentialCipher{}, nil
+	}
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the credential encryption key: %w", err)
+	}
+	if len(rawKey) != 32 {
+		return nil, fmt.Errorf("the credential encryption key must be 32 bytes, got %d", len(rawKey))
+	}
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &credentialCipher{aead: aead}, nil
+}
+
+func (c *credentialCipher) encrypt(plaintext string) (string, error) {
+	if plaintext == "" || strings.HasPrefix(plaintext, encryptedCredentialPrefix) {
+		return plaintext, nil
+	}
+	if c.aead == nil {
+		return "", errNoCredentialKey
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedCredentialPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *credentialCipher) decrypt(stored string) (string, error) {
+	if !strings.HasPrefix(stored, encryptedCredentialPrefix) {
+		return stored, nil
+	}
+	if c.aead == nil {
+		return "", errNoCredentialKey
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedCredentialPrefix))
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted credential")
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+	return string(plaintext), nil
+}
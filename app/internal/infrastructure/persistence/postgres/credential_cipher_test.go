@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCredentialCipher(t *testing.T) {
+	c, err := newCredentialCipher(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	if err != nil {
+		t.Fatalf("newCredentialCipher: %v", err)
+	}
+
+	encrypted, err := c.encrypt("wb-secret")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, encryptedCredentialPrefix) || strings.Contains(encrypted, "wb-secret") {
+		t.Errorf("Expected an encrypted value, got %q", encrypted)
+	}
+	if again, _ := c.encrypt(encrypted); again != encrypted {
+		t.Error("Expected an encrypted value not to be encrypted twice")
+	}
+	if decrypted, err := c.decrypt(encrypted); err != nil || decrypted != "wb-secret" {
+		t.Errorf("Expected wb-secret, got %q, %v", decrypted, err)
+	}
+	if legacy, err := c.decrypt("plain-secret"); err != nil || legacy != "plain-secret" {
+		t.Errorf("Expected values stored before encryption to be read as is, got %q, %v", legacy, err)
+	}
+
+	other, _ := newCredentialCipher(base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+	if _, err := other.decrypt(encrypted); err == nil {
+		t.Error("Expected decryption with another key to fail")
+	}
+	noKey, _ := newCredentialCipher("")
+	if _, err := noKey.encrypt("wb-secret"); !errors.Is(err, errNoCredentialKey) {
+		t.Errorf("Expected errNoCredentialKey, got %v", err)
+	}
+	if _, err := newCredentialCipher(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Error("Expected a short key to be rejected")
+	}
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// StockSyncStorage stores stock sync settings, item mappings and reports in PostgreSQL.
+// Marketplace API keys are encrypted at rest.
+type StockSyncStorage struct {
+	client postgresql.PostgreSQLClient
+	cipher *credentialCipher
+}
+
+// NewStockSyncStorage creates a new StockSyncStorage instance. encryptionKey is the base64-encoded 32-byte key
+// the marketplace API keys are encrypted with; without it settings cannot be saved or read.
+func NewStockSyncStorage(client postgresql.PostgreSQLClient, encryptionKey string) (*StockSyncStorage, error) {
+	cipher, err := newCredentialCipher(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &StockSyncStorage{client: client, cipher: cipher}, nil
+}
+
+const stockSyncSettingsColumns = `api_key, source_marketplace, wb_api_key, wb_warehouse_id, ozon_client_id, ozon_api_key,
+                        ozon_warehouse_id, enabled, dry_run`
+
+// SaveStockSyncSettings creates or replaces the stock sync settings of the account.
+func (s *StockSyncStorage) SaveStockSyncSettings(ctx context.Context, settings *entities.StockSyncSettings) error {
+	const query = `INSERT INTO stock_sync_settings (` + stockSyncSettingsColumns + `)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+                    ON CONFLICT (api_key) DO UPDATE SET
+                        source_marketplace = EXCLUDED.source_marketplace,
+                        wb_api_key = EXCLUDED.wb_api_key,
+                        wb_warehouse_id = EXCLUDED.wb_warehouse_id,
+                        ozon_client_id = EXCLUDED.ozon_client_id,
+                        ozon_api_key = EXCLUDED.ozon_api_key,
+                        ozon_warehouse_id = EXCLUDED.ozon_warehouse_id,
+                        enabled = EXCLUDED.enabled,
+                        dry_run = EXCLUDED.dry_run,
+                        updated_at = NOW()`
+	wbApiKey, err := s.cipher.encrypt(settings.WbApiKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the WB API key: %w", err)
+	}
+	ozonApiKey, err := s.cipher.encrypt(settings.OzonApiKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the Ozon API key: %w", err)
+	}
+	_, err = s.client.Exec(ctx, query, settings.ApiKey, string(settings.Source), wbApiKey, settings.WbWarehouseID,
+		settings.OzonApiClientId, ozonApiKey, settings.OzonWarehouseID, settings.Enabled, settings.DryRun)
+	return err
+}
+
+// EncryptStockSyncCredentials encrypts the API keys stored before they were encrypted at rest and returns
+// the number of accounts updated. It does nothing without an encryption key.
+func (s *StockSyncStorage) EncryptStockSyncCredentials(ctx context.Context) (int, error) {
+	if s.cipher.aead == nil {
+		return 0, nil
+	}
+	updated := 0
+	err := s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		const query = `SELECT api_key, wb_api_key, ozon_api_key FROM stock_sync_settings
+                        WHERE (wb_api_key <> '' AND NOT starts_with(wb_api_key, $1))
+                           OR (ozon_api_key <> '' AND NOT starts_with(ozon_api_key, $1))
+                        FOR UPDATE`
+		rows, err := tx.Query(ctx, query, encryptedCredentialPrefix)
+		if err != nil {
+			return err
+		}
+		type credentials struct{ apiKey, wbApiKey, ozonApiKey string }
+		var plaintext []credentials
+		for rows.Next() {
+			var c credentials
+			if err := rows.Scan(&c.apiKey, &c.wbApiKey, &c.ozonApiKey); err != nil {
+				rows.Close()
+				return err
+			}
+			plaintext = append(plaintext, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, c := range plaintext {
+			wbApiKey, err := s.cipher.encrypt(c.wbApiKey)
+			if err != nil {
+				return err
+			}
+			ozonApiKey, err := s.cipher.encrypt(c.ozonApiKey)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, "UPDATE stock_sync_settings SET wb_api_key = $2, ozon_api_key = $3 WHERE api_key = $1", c.apiKey, wbApiKey, ozonApiKey); err != nil {
+				return err
+			}
+		}
+		updated = len(plaintext)
+		return nil
+	})
+	return updated, err
+}
+
+// GetStockSyncSettings returns the stock sync settings of the account, or ErrStockSyncNotConfigured.
+func (s *StockSyncStorage) GetStockSyncSettings(ctx context.Context, apiKey string) (*entities.StockSyncSettings, error) {
+	const query = `SELECT ` + stockSyncSettingsColumns + ` FROM stock_sync_settings WHERE api_key = $1`
+	settings, err := s.scanStockSyncSettings(s.client.QueryRow(ctx, query, apiKey))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entities.ErrStockSyncNotConfigured
+	}
+	return settings, err
+}
+
+// ListEnabledStockSyncSettings returns the settings of all accounts the periodic sync runs for.
+func (s *StockSyncStorage) ListEnabledStockSyncSettings(ctx context.Context) ([]*entities.StockSyncSettings, error) {
+	const query = `SELECT ` + stockSyncSettingsColumns + ` FROM stock_sync_settings WHERE enabled ORDER BY api_key`
+	rows, err := s.client.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entities.StockSyncSettings
+	for rows.Next() {
+		settings, err := s.scanStockSyncSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, settings)
+	}
+	return result, rows.Err()
+}
+
+func (s *StockSyncStorage) scanStockSyncSettings(row pgx.Row) (*entities.StockSyncSettings, error) {
+	var settings entities.StockSyncSettings
+	var source, wbApiKey, ozonApiKey string
+	if err := row.Scan(&settings.ApiKey, &source, &wbApiKey, &settings.WbWarehouseID, &settings.OzonApiClientId,
+		&ozonApiKey, &settings.OzonWarehouseID, &settings.Enabled, &settings.DryRun); err != nil {
+		return nil, err
+	}
+	settings.Source = entities.Marketplace(source)
+	var err error
+	if settings.WbApiKey, err = s.cipher.decrypt(wbApiKey); err != nil {
+		return nil, fmt.Errorf("failed to decrypt the WB API key: %w", err)
+	}
+	if settings.OzonApiKey, err = s.cipher.decrypt(ozonApiKey); err != nil {
+		return nil, fmt.Errorf("failed to decrypt the Ozon API key: %w", err)
+	}
+	return &settings, nil
+}
+
+// ReplaceStockSyncMappings replaces all item mappings of the account. The last synced amounts of
+// mappings that are kept are preserved. All mappings are deleted before the new ones are inserted, so
+// offer IDs can move between sizes without violating the unique index.
+func (s *StockSyncStorage) ReplaceStockSyncMappings(ctx context.Context, apiKey string, mappings []entities.StockSyncMapping) error {
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "DELETE FROM stock_sync_mappings WHERE api_key = $1 RETURNING wb_sku, ozon_offer_id, last_amount", apiKey)
+		if err != nil {
+			return err
+		}
+		type mappingKey struct{ wbSku, offerID string }
+		lastAmounts := make(map[mappingKey]*int32)
+		for rows.Next() {
+			var key mappingKey
+			var lastAmount *int32
+			if err := rows.Scan(&key.wbSku, &key.offerID, &lastAmount); err != nil {
+				rows.Close()
+				return err
+			}
+			lastAmounts[key] = lastAmount
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		wbSkus := make([]string, len(mappings))
+		vendorCodes := make([]string, len(mappings))
+		offerIDs := make([]string, len(mappings))
+		amounts := make([]*int32, len(mappings))
+		for i, m := range mappings {
+			wbSkus[i] = m.WbSku
+			vendorCodes[i] = m.VendorCode
+			offerIDs[i] = m.OzonOfferID
+			amounts[i] = lastAmounts[mappingKey{m.WbSku, m.OzonOfferID}]
+		}
+		const query = `INSERT INTO stock_sync_mappings (api_key, wb_sku, vendor_code, ozon_offer_id, last_amount)
+                        SELECT $1::TEXT, * FROM unnest($2::TEXT[], $3::TEXT[], $4::TEXT[], $5::INTEGER[])`
+		if _, err := tx.Exec(ctx, query, apiKey, wbSkus, vendorCodes, offerIDs, amounts); err != nil {
+			return fmt.Errorf("failed to save mappings: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetStockSyncMappings returns the item mappings of the account.
+func (s *StockSyncStorage) GetStockSyncMappings(ctx context.Context, apiKey string) ([]entities.StockSyncMapping, error) {
+	const query = `SELECT wb_sku, vendor_code, ozon_offer_id, last_amount FROM stock_sync_mappings
+                    WHERE api_key = $1 ORDER BY vendor_code, wb_sku`
+	rows, err := s.client.Query(ctx, query, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.StockSyncMapping
+	for rows.Next() {
+		var m entities.StockSyncMapping
+		if err := rows.Scan(&m.WbSku, &m.VendorCode, &m.OzonOfferID, &m.LastAmount); err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+// SetStockSyncLastAmounts records the amounts written by a sync, keyed by WB sku.
+func (s *StockSyncStorage) SetStockSyncLastAmounts(ctx context.Context, apiKey string, amounts map[string]int) error {
+	if len(amounts) == 0 {
+		return nil
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		const query = "UPDATE stock_sync_mappings SET last_amount = $3 WHERE api_key = $1 AND wb_sku = $2"
+		for wbSku, amount := range amounts {
+			if _, err := tx.Exec(ctx, query, apiKey, wbSku, amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveStockSyncReport stores the report of a sync.
+func (s *StockSyncStorage) SaveStockSyncReport(ctx context.Context, report *entities.StockSyncReport) error {
+	changesJSON, err := json.Marshal(report.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stock sync changes: %w", err)
+	}
+	conflictsJSON, err := json.Marshal(report.Conflicts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stock sync conflicts: %w", err)
+	}
+	const query = `INSERT INTO stock_sync_reports (api_key, source_marketplace, dry_run, started_at, finished_at, changes, conflicts, error)
+                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = s.client.Exec(ctx, query, report.ApiKey, string(report.Source), report.DryRun, report.StartedAt, report.FinishedAt,
+		changesJSON, conflictsJSON, report.Error)
+	return err
+}
+
+// DeleteStockSyncReportsBefore deletes the reports of syncs started before the given time, except the latest
+// report of every account, and returns the number deleted.
+func (s *StockSyncStorage) DeleteStockSyncReportsBefore(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM stock_sync_reports r WHERE started_at < $1
+                    AND id <> (SELECT id FROM stock_sync_reports l WHERE l.api_key = r.api_key ORDER BY started_at DESC LIMIT 1)`
+	tag, err := s.client.Exec(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetLatestStockSyncReport returns the report of the last sync of the account, or nil if it has never been synced.
+func (s *StockSyncStorage) GetLatestStockSyncReport(ctx context.Context, apiKey string) (*entities.StockSyncReport, error) {
+	const query = `SELECT api_key, source_marketplace, dry_run, started_at, finished_at, changes, conflicts, error
+                    FROM stock_sync_reports WHERE api_key = $1 ORDER BY started_at DESC LIMIT 1`
+	var report entities.StockSyncReport
+	var source string
+	var changesJSON, conflictsJSON []byte
+	if err := s.client.QueryRow(ctx, query, apiKey).Scan(&report.ApiKey, &source, &report.DryRun, &report.StartedAt, &report.FinishedAt,
+		&changesJSON, &conflictsJSON, &report.Error); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	report.Source = entities.Marketplace(source)
+	if err := json.Unmarshal(changesJSON, &report.Changes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stock sync changes: %w", err)
+	}
+	if err := json.Unmarshal(conflictsJSON, &report.Conflicts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stock sync conflicts: %w", err)
+	}
+	return &report, nil
+}
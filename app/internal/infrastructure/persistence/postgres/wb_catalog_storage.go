@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"api/app/domain/entities"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/marketconnect/db_client/postgresql"
+)
+
+// WBCatalogStorage stores the mirror of the WB category tree and directories in PostgreSQL.
+type WBCatalogStorage struct {
+	client postgresql.PostgreSQLClient
+}
+
+// NewWBCatalogStorage creates a new WBCatalogStorage instance.
+func NewWBCatalogStorage(client postgresql.PostgreSQLClient) *WBCatalogStorage {
+	return &WBCatalogStorage{client: client}
+}
+
+// ReplaceWBParentCategories replaces the mirrored parent categories.
+func (s *WBCatalogStorage) ReplaceWBParentCategories(ctx context.Context, categories []entities.WBParentCategory) error {
+	ids := make([]int32, len(categories))
+	names := make([]string, len(categories))
+	visible := make([]bool, len(categories))
+	for i, c := range categories {
+		ids[i], names[i], visible[i] = int32(c.ID), c.Name, c.IsVisible
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM wb_parent_categories WHERE NOT (id = ANY($1::INTEGER[]))", ids); err != nil {
+			return err
+		}
+		const query = `INSERT INTO wb_parent_categories (id, name, is_visible)
+                        SELECT * FROM unnest($1::INTEGER[], $2::TEXT[], $3::BOOLEAN[])
+                        ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, is_visible = EXCLUDED.is_visible`
+		_, err := tx.Exec(ctx, query, ids, names, visible)
+		return err
+	})
+}
+
+// ReplaceWBSubjects replaces the mirrored subjects. TNVED codes of subjects that are kept are preserved.
+func (s *WBCatalogStorage) ReplaceWBSubjects(ctx context.Context, subjects []entities.WBSubject) error {
+	ids := make([]int32, len(subjects))
+	names := make([]string, len(subjects))
+	parentIDs := make([]int32, len(subjects))
+	parentNames := make([]string, len(subjects))
+	for i, sub := range subjects {
+		ids[i], names[i], parentIDs[i], parentNames[i] = int32(sub.SubjectID), sub.SubjectName, int32(sub.ParentID), sub.ParentName
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM wb_tnved_codes WHERE NOT (subject_id = ANY($1::INTEGER[]))", ids); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM wb_subjects WHERE NOT (id = ANY($1::INTEGER[]))", ids); err != nil {
+			return err
+		}
+		const query = `INSERT INTO wb_subjects (id, name, parent_id, parent_name)
+                        SELECT * FROM unnest($1::INTEGER[], $2::TEXT[], $3::INTEGER[], $4::TEXT[])
+                        ON CONFLICT (id) DO UPDATE SET
+                            name = EXCLUDED.name,
+                            parent_id = EXCLUDED.parent_id,
+                            parent_name = EXCLUDED.parent_name`
+		_, err := tx.Exec(ctx, query, ids, names, parentIDs, parentNames)
+		return err
+	})
+}
+
+// ReplaceWBDirectory replaces the mirrored values of a directory other than TNVED.
+func (s *WBCatalogStorage) ReplaceWBDirectory(ctx context.Context, directory entities.WBDirectory, values []entities.WBDirectoryValue) error {
+	valueStrings := make([]string, len(values))
+	descriptions := make([]string, len(values))
+	for i, v := range values {
+		valueStrings[i], descriptions[i] = v.Value, v.Description
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM wb_directory_values WHERE directory = $1", string(directory)); err != nil {
+			return err
+		}
+		// WB lists some values twice, the first description is kept
+		const query = `INSERT INTO wb_directory_values (directory, value, description)
+                        SELECT $1::TEXT, * FROM unnest($2::TEXT[], $3::TEXT[])
+                        ON CONFLICT (directory, value) DO NOTHING`
+		_, err := tx.Exec(ctx, query, string(directory), valueStrings, descriptions)
+		return err
+	})
+}
+
+// ListWBSubjectsForTnvedSync returns up to limit subjects whose TNVED codes were never or least recently synced.
+func (s *WBCatalogStorage) ListWBSubjectsForTnvedSync(ctx context.Context, limit int) ([]int, error) {
+	const query = "SELECT id FROM wb_subjects ORDER BY tnved_synced_at NULLS FIRST, id LIMIT $1"
+	rows, err := s.client.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []int
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, int(id))
+	}
+	return result, rows.Err()
+}
+
+// ReplaceWBTnved replaces the mirrored TNVED codes of the subject and marks them synced.
+func (s *WBCatalogStorage) ReplaceWBTnved(ctx context.Context, subjectID int, codes []entities.WBTnved) error {
+	tnveds := make([]string, len(codes))
+	kiz := make([]bool, len(codes))
+	for i, c := range codes {
+		tnveds[i], kiz[i] = c.Tnved, c.IsKiz
+	}
+	return s.client.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM wb_tnved_codes WHERE subject_id = $1", subjectID); err != nil {
+			return err
+		}
+		const query = `INSERT INTO wb_tnved_codes (subject_id, tnved, is_kiz)
+                        SELECT $1::INTEGER, * FROM unnest($2::TEXT[], $3::BOOLEAN[])
+                        ON CONFLICT (subject_id, tnved) DO NOTHING`
+		if _, err := tx.Exec(ctx, query, subjectID, tnveds, kiz); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, "UPDATE wb_subjects SET tnved_synced_at = NOW() WHERE id = $1", subjectID)
+		return err
+	})
+}
+
+// ListWBParentCategories returns the mirrored parent categories ordered by name.
+func (s *WBCatalogStorage) ListWBParentCategories(ctx context.Context) ([]entities.WBParentCategory, error) {
+	rows, err := s.client.Query(ctx, "SELECT id, name, is_visible FROM wb_parent_categories ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.WBParentCategory
+	for rows.Next() {
+		var c entities.WBParentCategory
+		var id int32
+		if err := rows.Scan(&id, &c.Name, &c.IsVisible); err != nil {
+			return nil, err
+		}
+		c.ID = int(id)
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// SearchWBSubjects returns the mirrored subjects matching the filter, names starting with the query first.
+func (s *WBCatalogStorage) SearchWBSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error) {
+	args := []interface{}{"%" + escapeLike(filter.Query) + "%", strings.ToLower(escapeLike(filter.Query)) + "%", filter.Limit, filter.Offset}
+	query := `SELECT id, name, parent_id, parent_name FROM wb_subjects WHERE name ILIKE $1`
+	if filter.ParentID != 0 {
+		args = append(args, filter.ParentID)
+		query += fmt.Sprintf(" AND parent_id = $%d", len(args))
+	}
+	query += " ORDER BY LOWER(name) LIKE $2 DESC, name LIMIT $3 OFFSET $4"
+
+	rows, err := s.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.WBSubject
+	for rows.Next() {
+		var id, parentID int32
+		var sub entities.WBSubject
+		if err := rows.Scan(&id, &sub.SubjectName, &parentID, &sub.ParentName); err != nil {
+			return nil, err
+		}
+		sub.SubjectID, sub.ParentID = int(id), int(parentID)
+		result = append(result, sub)
+	}
+	return result, rows.Err()
+}
+
+// SearchWBDirectoryValues returns the mirrored values of the directory matching the filter.
+func (s *WBCatalogStorage) SearchWBDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error) {
+	var query string
+	args := []interface{}{"%" + escapeLike(filter.Query) + "%", filter.Limit, filter.Offset}
+	if filter.Directory == entities.WBDirectoryTnved {
+		args = append(args, filter.SubjectID)
+		query = `SELECT tnved, '', subject_id, is_kiz FROM wb_tnved_codes
+                    WHERE tnved LIKE $1 AND subject_id = $4 ORDER BY tnved LIMIT $2 OFFSET $3`
+	} else {
+		args = append(args, string(filter.Directory))
+		query = `SELECT value, description, 0, FALSE FROM wb_directory_values
+                    WHERE value ILIKE $1 AND directory = $4 ORDER BY value LIMIT $2 OFFSET $3`
+	}
+
+	rows, err := s.client.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []entities.WBDirectoryValue
+	for rows.Next() {
+		v := entities.WBDirectoryValue{Directory: filter.Directory}
+		var subjectID int32
+		if err := rows.Scan(&v.Value, &v.Description, &subjectID, &v.IsKiz); err != nil {
+			return nil, err
+		}
+		v.SubjectID = int(subjectID)
+		result = append(result, v)
+	}
+	return result, rows.Err()
+}
+
+// FindWBDirectoryValue returns the mirrored value of the directory equal to value ignoring case, or nil if there
+// is none. TNVED codes are looked up among the codes of subjectID.
+func (s *WBCatalogStorage) FindWBDirectoryValue(ctx context.Context, directory entities.WBDirectory, subjectID int, value string) (*entities.WBDirectoryValue, error) {
+	var row pgx.Row
+	if directory == entities.WBDirectoryTnved {
+		const query = `SELECT tnved, '', is_kiz FROM wb_tnved_codes
+                    WHERE subject_id = $1 AND LOWER(tnved) = LOWER($2) LIMIT 1`
+		row = s.client.QueryRow(ctx, query, subjectID, value)
+	} else {
+		const query = `SELECT value, description, FALSE FROM wb_directory_values
+                    WHERE directory = $1 AND LOWER(value) = LOWER($2) LIMIT 1`
+		row = s.client.QueryRow(ctx, query, string(directory), value)
+	}
+
+	v := entities.WBDirectoryValue{Directory: directory}
+	if directory == entities.WBDirectoryTnved {
+		v.SubjectID = subjectID
+	}
+	if err := row.Scan(&v.Value, &v.Description, &v.IsKiz); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+// escapeLike makes the LIKE wildcards in query match literally.
+func escapeLike(query string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+}
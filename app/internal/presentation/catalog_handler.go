@@ -0,0 +1,109 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+type CatalogUsecase interface {
+	ListParentCategories(ctx context.Context) ([]entities.WBParentCategory, error)
+	SearchSubjects(ctx context.Context, filter entities.WBSubjectFilter) ([]entities.WBSubject, error)
+	SearchDirectoryValues(ctx context.Context, filter entities.WBDirectoryFilter) ([]entities.WBDirectoryValue, error)
+}
+
+type CatalogHandler struct {
+	catalogUsecase CatalogUsecase
+}
+
+func NewCatalogHandler(catalogUsecase CatalogUsecase) *CatalogHandler {
+	return &CatalogHandler{catalogUsecase: catalogUsecase}
+}
+
+// ListWBParentCategories lists all WB parent categories
+func (h *CatalogHandler) ListWBParentCategories(ctx context.Context, req *connect.Request[apiv1.ListWBParentCategoriesRequest]) (*connect.Response[apiv1.ListWBParentCategoriesResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	categories, err := h.catalogUsecase.ListParentCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response := &apiv1.ListWBParentCategoriesResponse{Categories: make([]*apiv1.WBParentCategory, len(categories))}
+	for i, c := range categories {
+		response.Categories[i] = &apiv1.WBParentCategory{Id: int32(c.ID), Name: c.Name, IsVisible: c.IsVisible}
+	}
+	return connect.NewResponse(response), nil
+}
+
+// SearchWBSubjects searches the WB subjects to pick the category of a card
+func (h *CatalogHandler) SearchWBSubjects(ctx context.Context, req *connect.Request[apiv1.SearchWBSubjectsRequest]) (*connect.Response[apiv1.SearchWBSubjectsResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	subjects, err := h.catalogUsecase.SearchSubjects(ctx, entities.WBSubjectFilter{
+		Query:    req.Msg.Query,
+		ParentID: int(req.Msg.ParentId),
+		Limit:    int(req.Msg.Limit),
+		Offset:   int(req.Msg.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &apiv1.SearchWBSubjectsResponse{Subjects: make([]*apiv1.WBSubject, len(subjects))}
+	for i, s := range subjects {
+		response.Subjects[i] = &apiv1.WBSubject{
+			SubjectId:   int32(s.SubjectID),
+			SubjectName: s.SubjectName,
+			ParentId:    int32(s.ParentID),
+			ParentName:  s.ParentName,
+		}
+	}
+	return connect.NewResponse(response), nil
+}
+
+// SearchWBDirectoryValues searches the allowed values of a WB characteristic directory
+func (h *CatalogHandler) SearchWBDirectoryValues(ctx context.Context, req *connect.Request[apiv1.SearchWBDirectoryValuesRequest]) (*connect.Response[apiv1.SearchWBDirectoryValuesResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	values, err := h.catalogUsecase.SearchDirectoryValues(ctx, entities.WBDirectoryFilter{
+		Directory: wbDirectoryFromProto(req.Msg.Directory),
+		Query:     req.Msg.Query,
+		SubjectID: int(req.Msg.SubjectId),
+		Limit:     int(req.Msg.Limit),
+		Offset:    int(req.Msg.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &apiv1.SearchWBDirectoryValuesResponse{Values: make([]*apiv1.WBDirectoryValue, len(values))}
+	for i, v := range values {
+		response.Values[i] = &apiv1.WBDirectoryValue{Value: v.Value, Description: v.Description, IsKiz: v.IsKiz}
+	}
+	return connect.NewResponse(response), nil
+}
+
+// wbDirectoryFromProto returns an empty directory for unspecified or unknown values.
+func wbDirectoryFromProto(directory apiv1.WBDirectory) entities.WBDirectory {
+	switch directory {
+	case apiv1.WBDirectory_WB_DIRECTORY_COLORS:
+		return entities.WBDirectoryColors
+	case apiv1.WBDirectory_WB_DIRECTORY_KINDS:
+		return entities.WBDirectoryKinds
+	case apiv1.WBDirectory_WB_DIRECTORY_COUNTRIES:
+		return entities.WBDirectoryCountries
+	case apiv1.WBDirectory_WB_DIRECTORY_SEASONS:
+		return entities.WBDirectorySeasons
+	case apiv1.WBDirectory_WB_DIRECTORY_VAT:
+		return entities.WBDirectoryVat
+	case apiv1.WBDirectory_WB_DIRECTORY_TNVED:
+		return entities.WBDirectoryTnved
+	}
+	return ""
+}
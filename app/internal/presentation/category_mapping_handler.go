@@ -0,0 +1,76 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"log"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+type CategoryMappingUsecase interface {
+	ListCategoryMappings(ctx context.Context, filter entities.CategoryMappingFilter) ([]entities.CategoryMapping, error)
+	OverrideCategoryMapping(ctx context.Context, apiKey string, mapping *entities.CategoryMapping, reset bool) error
+}
+
+// ListCategoryMappings lists the WB subjects and Ozon categories known to describe the same products
+func (h *CreateProductCardHandler) ListCategoryMappings(ctx context.Context, req *connect.Request[apiv1.ListCategoryMappingsRequest]) (*connect.Response[apiv1.ListCategoryMappingsResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	mappings, err := h.categoryMappingUsecase.ListCategoryMappings(ctx, entities.CategoryMappingFilter{
+		WbSubjectID:               req.Msg.WbSubjectId,
+		OzonDescriptionCategoryID: req.Msg.OzonDescriptionCategoryId,
+		OzonTypeID:                req.Msg.OzonTypeId,
+		OverriddenOnly:            req.Msg.OverriddenOnly,
+		Limit:                     int(req.Msg.Limit),
+		Offset:                    int(req.Msg.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apiv1.ListCategoryMappingsResponse{Mappings: make([]*apiv1.CategoryMapping, len(mappings))}
+	for i, m := range mappings {
+		response.Mappings[i] = &apiv1.CategoryMapping{
+			WbSubjectId:                 m.WbSubjectID,
+			WbSubjectName:               m.WbSubjectName,
+			OzonDescriptionCategoryId:   m.OzonDescriptionCategoryID,
+			OzonDescriptionCategoryName: m.OzonDescriptionCategoryName,
+			OzonTypeId:                  m.OzonTypeID,
+			OzonTypeName:                m.OzonTypeName,
+			Resolutions:                 int32(m.Resolutions),
+			Confidence:                  m.Confidence,
+			Overridden:                  m.Overridden,
+			UpdatedAt:                   m.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return connect.NewResponse(response), nil
+}
+
+// OverrideCategoryMapping corrects the mapping of a WB subject for all future cards
+func (h *CreateProductCardHandler) OverrideCategoryMapping(ctx context.Context, req *connect.Request[apiv1.OverrideCategoryMappingRequest]) (*connect.Response[apiv1.OverrideCategoryMappingResponse], error) {
+	log.Printf("OverrideCategoryMapping request - WB subject: %d, Ozon category: %d, type: %d, reset: %t",
+		req.Msg.WbSubjectId, req.Msg.OzonDescriptionCategoryId, req.Msg.OzonTypeId, req.Msg.ResetOverride)
+
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	err = h.categoryMappingUsecase.OverrideCategoryMapping(ctx, apiKey, &entities.CategoryMapping{
+		WbSubjectID:                 req.Msg.WbSubjectId,
+		WbSubjectName:               req.Msg.WbSubjectName,
+		OzonDescriptionCategoryID:   req.Msg.OzonDescriptionCategoryId,
+		OzonDescriptionCategoryName: req.Msg.OzonDescriptionCategoryName,
+		OzonTypeID:                  req.Msg.OzonTypeId,
+		OzonTypeName:                req.Msg.OzonTypeName,
+	}, req.Msg.ResetOverride)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&apiv1.OverrideCategoryMappingResponse{}), nil
+}
@@ -4,6 +4,7 @@ import (
 	"api/app/domain/entities"
 	apiv1 "api/gen/api/v1"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -14,13 +15,30 @@ type CreateCardUsecase interface {
 	CreateProductCard(ctx context.Context, apiKey string, req entities.ProductCard) (*entities.CreateProductCardResult, error)
 }
 
+// maxContentVariants limits the number of content alternatives generated for one card
+const maxContentVariants = 5
+
+type PublishVariantUsecase interface {
+	PublishVariant(ctx context.Context, apiKey string, req entities.PublishVariantRequest) (*entities.CreateProductCardResult, error)
+}
+
 type CreateProductCardHandler struct {
-	createCardUsecase CreateCardUsecase
+	createCardUsecase      CreateCardUsecase
+	publishVariantUsecase  PublishVariantUsecase
+	listWBCardsUsecase     ListWBCardsUsecase
+	updatePricesUsecase    UpdatePricesUsecase
+	crossListUsecase       CrossListUsecase
+	categoryMappingUsecase CategoryMappingUsecase
 }
 
-func NewCreateProductCardHandler(createCardUsecase CreateCardUsecase) *CreateProductCardHandler {
+func NewCreateProductCardHandler(createCardUsecase CreateCardUsecase, publishVariantUsecase PublishVariantUsecase, listWBCardsUsecase ListWBCardsUsecase, updatePricesUsecase UpdatePricesUsecase, crossListUsecase CrossListUsecase, categoryMappingUsecase CategoryMappingUsecase) *CreateProductCardHandler {
 	return &CreateProductCardHandler{
-		createCardUsecase: createCardUsecase,
+		createCardUsecase:      createCardUsecase,
+		publishVariantUsecase:  publishVariantUsecase,
+		listWBCardsUsecase:     listWBCardsUsecase,
+		updatePricesUsecase:    updatePricesUsecase,
+		crossListUsecase:       crossListUsecase,
+		categoryMappingUsecase: categoryMappingUsecase,
 	}
 }
 
@@ -29,8 +47,8 @@ func (h *CreateProductCardHandler) Create(ctx context.Context, req *connect.Requ
 }
 
 func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *connect.Request[apiv1.CreateRequest]) (*connect.Response[apiv1.CreateResponse], error) {
-	log.Printf("CreateProductCard request - Title: %s, VendorCode: %s, WB: %t, Ozon: %t, MediaFiles: %d",
-		req.Msg.ProductTitle, req.Msg.VendorCode, req.Msg.GetWb(), req.Msg.GetOzon(), len(req.Msg.WbMediaToUploadFiles))
+	log.Printf("CreateProductCard request - Title: %s, VendorCode: %s, WB: %t, Ozon: %t, MediaFiles: %d, MediaRefs: %d",
+		req.Msg.ProductTitle, req.Msg.VendorCode, req.Msg.GetWb(), req.Msg.GetOzon(), len(req.Msg.WbMediaToUploadFiles), len(req.Msg.Media))
 
 	// Extract API key from Authorization header
 	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
@@ -52,8 +70,8 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_api_client_id is required when ozon is true"))
 		}
 
-		// Check for vendor_code
-		if req.Msg.GetVendorCode() == "" {
+		// Check for vendor_code, variants are checked below
+		if req.Msg.GetVendorCode() == "" && len(req.Msg.Variants) == 0 {
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("vendor_code is required when ozon is true"))
 		}
 
@@ -77,52 +95,72 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 		}
 	}
 
-	sizes := make([]*entities.WBSize, len(req.Msg.Sizes))
-	for i, s := range req.Msg.Sizes {
-		size := &entities.WBSize{
-			TechSize: s.TechSize,
-			WbSize:   s.WbSize,
-			Price:    int(s.Price), // Keep for backward compatibility
-			Skus:     s.Skus,
+	sizes := sizesFromProto(req.Msg.Sizes)
+	if err := validateSizePrices(sizes, req.Msg.GetWb(), req.Msg.GetOzon()); err != nil {
+		return nil, err
+	}
+
+	wbMediaToUploadFiles := wbMediaFilesFromProto(req.Msg.WbMediaToUploadFiles)
+	media, err := mediaReferencesFromProto(req.Msg.Media)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.GetWb() {
+		if err := validateWBVideoCount(wbMediaToUploadFiles, media); err != nil {
+			return nil, err
 		}
+	}
 
-		// Set marketplace-specific prices if provided
-		if s.WbPrice != nil {
-			size.WbPrice = s.WbPrice
+	// Every variant is a WB variant of one card and an Ozon offer with its own vendor code, sizes and media
+	if len(req.Msg.Variants) > 0 && len(req.Msg.Sizes) > 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("sizes must be set per variant when variants are set"))
+	}
+	variants := make([]*entities.ProductVariant, len(req.Msg.Variants))
+	for i, v := range req.Msg.Variants {
+		if v.GetVendorCode() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("vendor_code is required for variant %d", i))
 		}
-		if s.OzonPrice != nil {
-			size.OzonPrice = s.OzonPrice
+		variant := &entities.ProductVariant{
+			VendorCode:           v.VendorCode,
+			Color:                v.Color,
+			Sizes:                sizesFromProto(v.Sizes),
+			WbMediaToUploadFiles: wbMediaFilesFromProto(v.WbMediaToUploadFiles),
+			WbMediaToSaveLinks:   v.WbMediaToSaveLinks,
 		}
+		if err := validateSizePrices(variant.Sizes, req.Msg.GetWb(), req.Msg.GetOzon()); err != nil {
+			return nil, err
+		}
+		if variant.Media, err = mediaReferencesFromProto(v.Media); err != nil {
+			return nil, err
+		}
+		if req.Msg.GetWb() {
+			if err := validateWBVideoCount(variant.WbMediaToUploadFiles, variant.Media); err != nil {
+				return nil, err
+			}
+		}
+		variants[i] = variant
+	}
 
-		sizes[i] = size
+	if req.Msg.WbDiscount < 0 || req.Msg.WbDiscount > maxWBDiscount {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_discount must be between 0 and %d, got %d", maxWBDiscount, req.Msg.WbDiscount))
 	}
 
-	// Validate that prices are provided for enabled marketplaces
-	if req.Msg.GetWb() && len(sizes) > 0 {
-		for i, size := range sizes {
-			if size.WbPrice == nil && size.Price == 0 {
-				return nil, connect.NewError(connect.CodeInvalidArgument,
-					fmt.Errorf("price (wb_price or general price) is required for size %d when WildBerries integration is enabled", i))
-			}
-		}
+	initialStock, err := initialStockFromProto(req.Msg.InitialStock)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.Msg.GetOzon() && len(sizes) > 0 {
-		for i, size := range sizes {
-			if size.OzonPrice == nil && size.Price == 0 {
-				return nil, connect.NewError(connect.CodeInvalidArgument,
-					fmt.Errorf("price (ozon_price or general price) is required for size %d when Ozon integration is enabled", i))
-			}
-		}
+	if req.Msg.ContentVariants < 0 || req.Msg.ContentVariants > maxContentVariants {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("content_variants must be between 0 and %d, got %d", maxContentVariants, req.Msg.ContentVariants))
 	}
 
-	wbMediaToUploadFiles := make([]*entities.WBClientMediaFile, len(req.Msg.WbMediaToUploadFiles))
-	for i, f := range req.Msg.WbMediaToUploadFiles {
-		wbMediaToUploadFiles[i] = &entities.WBClientMediaFile{
-			Content:     f.Content,
-			Filename:    f.Filename,
-			PhotoNumber: f.PhotoNumber,
+	resolveCategories := make([]entities.Marketplace, 0, len(req.Msg.ResolveCategories))
+	for _, m := range req.Msg.ResolveCategories {
+		marketplace, ok := marketplaceFromProto(m)
+		if !ok {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported marketplace in resolve_categories: %s", m))
 		}
+		resolveCategories = append(resolveCategories, marketplace)
 	}
 
 	productCard := entities.ProductCard{
@@ -146,11 +184,23 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 		WbMediaToSaveLinks:   req.Msg.WbMediaToSaveLinks,
 		OzonApiClientId:      req.Msg.OzonApiClientId,
 		OzonApiKey:           req.Msg.OzonApiKey,
+		Media:                media,
+		ResolveCategories:    resolveCategories,
+		ForceRegenerate:      req.Msg.GetForceRegenerate(),
+		ContentProvider:      contentProviderFromProto(req.Msg.GetContentProvider()),
+		ContentVariants:      int(req.Msg.GetContentVariants()),
+		ContentValidation:    contentValidationModeFromProto(req.Msg.GetContentValidationMode()),
+		DryRun:               req.Msg.DryRun,
+		ImtID:                req.Msg.ImtId,
+		ModelName:            req.Msg.ModelName,
+		Variants:             variants,
+		WbDiscount:           int(req.Msg.WbDiscount),
+		InitialStock:         initialStock,
 	}
 
 	createProductCardResult, err := h.createCardUsecase.CreateProductCard(ctx, apiKey, productCard)
 	if err != nil {
-		return nil, err
+		return nil, withContentValidationDetails(err)
 	}
 
 	// Check if CardCraftAiGeneratedContent is nil (defensive programming)
@@ -158,19 +208,8 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("card craft AI generated content is nil"))
 	}
 
-	wbMediaUploadIndividualResponses := make([]*apiv1.WBMediaUploadIndividualResponse, len(createProductCardResult.WbMediaUploadResponses))
-	for i, response := range createProductCardResult.WbMediaUploadResponses {
-		wbMediaUploadIndividualResponses[i] = &apiv1.WBMediaUploadIndividualResponse{
-			PhotoNumber:  response.PhotoNumber,
-			ResponseJson: response.ResponseJson,
-			ErrorMessage: response.ErrorMessage,
-		}
-	}
-
-	wbMediaSaveByLinksResponse := &apiv1.WBMediaSaveByLinksResponse{
-		ResponseJson: createProductCardResult.WbMediaSaveResponse.ResponseJson,
-		ErrorMessage: createProductCardResult.WbMediaSaveResponse.ErrorMessage,
-	}
+	wbMediaUploadIndividualResponses := wbMediaUploadResponsesToProto(createProductCardResult.WbMediaUploadResponses)
+	wbMediaSaveByLinksResponse := wbMediaSaveResponseToProto(createProductCardResult.WbMediaSaveResponse)
 
 	createProductCardResponse := &apiv1.CreateResponse{
 		Title:                            createProductCardResult.CardCraftAiGeneratedContent.Title,
@@ -183,6 +222,17 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 		WbMediaSaveByLinksResponse:       wbMediaSaveByLinksResponse,
 		OzonApiResponseJson:              createProductCardResult.OzonApiResponseJson,
 		OzonRequestAttempted:             createProductCardResult.OzonRequestAttempted,
+		ContentFromCache:                 createProductCardResult.CardCraftAiGeneratedContent.FromCache,
+		ContentProvider:                  contentProviderToProto(createProductCardResult.CardCraftAiGeneratedContent.Provider),
+		ContentVariants:                  contentVariantsToProto(createProductCardResult.ContentVariants),
+		ContentViolations:                contentViolationsToProto(createProductCardResult.ContentViolations),
+		OzonPreparedRequestJson:          createProductCardResult.OzonPreparedRequestJson,
+		DryRun:                           createProductCardResult.DryRun,
+		OzonError:                        ozonErrorToProto(createProductCardResult.OzonError),
+		WbMediaPending:                   createProductCardResult.WbMediaPending,
+		WbMediaSaveByLinksResponses:      wbMediaSaveResponsesToProto(createProductCardResult.WbMediaSaveResponses),
+		GeneratedBarcodes:                generatedBarcodesToProto(createProductCardResult.GeneratedBarcodes),
+		OzonInitialStockError:            createProductCardResult.OzonInitialStockError,
 	}
 
 	// Safely handle pointer fields with nil checks
@@ -224,6 +274,252 @@ func (h *CreateProductCardHandler) CreateProductCard(ctx context.Context, req *c
 	}, nil
 }
 
+// PublishVariant pushes a previously generated content variant to WB/Ozon
+func (h *CreateProductCardHandler) PublishVariant(ctx context.Context, req *connect.Request[apiv1.PublishVariantRequest]) (*connect.Response[apiv1.PublishVariantResponse], error) {
+	log.Printf("PublishVariant request - VariantID: %s, WB: %t, Ozon: %t", req.Msg.VariantId, req.Msg.Wb, req.Msg.Ozon)
+
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if req.Msg.VariantId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("variant_id is required"))
+	}
+	if !req.Msg.Wb && !req.Msg.Ozon {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at least one of wb or ozon must be true"))
+	}
+
+	result, err := h.publishVariantUsecase.PublishVariant(ctx, apiKey, entities.PublishVariantRequest{
+		VariantID:         req.Msg.VariantId,
+		Wb:                req.Msg.Wb,
+		Ozon:              req.Msg.Ozon,
+		WbApiKey:          req.Msg.WbApiKey,
+		OzonApiClientId:   req.Msg.OzonApiClientId,
+		OzonApiKey:        req.Msg.OzonApiKey,
+		ContentValidation: contentValidationModeFromProto(req.Msg.GetContentValidationMode()),
+		DryRun:            req.Msg.DryRun,
+	})
+	if err != nil {
+		return nil, withContentValidationDetails(err)
+	}
+
+	return connect.NewResponse(&apiv1.PublishVariantResponse{
+		WbApiResponseJson:                result.WbApiResponseJson,
+		WbPreparedRequestJson:            result.WbPreparedRequestJson,
+		WbRequestAttempted:               result.WbRequestAttempted,
+		WbMediaUploadIndividualResponses: wbMediaUploadResponsesToProto(result.WbMediaUploadResponses),
+		WbMediaSaveByLinksResponse:       wbMediaSaveResponseToProto(result.WbMediaSaveResponse),
+		OzonApiResponseJson:              result.OzonApiResponseJson,
+		OzonRequestAttempted:             result.OzonRequestAttempted,
+		ContentViolations:                contentViolationsToProto(result.ContentViolations),
+		OzonPreparedRequestJson:          result.OzonPreparedRequestJson,
+		DryRun:                           result.DryRun,
+		OzonError:                        ozonErrorToProto(result.OzonError),
+		WbMediaPending:                   result.WbMediaPending,
+		WbMediaSaveByLinksResponses:      wbMediaSaveResponsesToProto(result.WbMediaSaveResponses),
+		GeneratedBarcodes:                generatedBarcodesToProto(result.GeneratedBarcodes),
+		OzonInitialStockError:            result.OzonInitialStockError,
+	}), nil
+}
+
+func sizesFromProto(protoSizes []*apiv1.Size) []*entities.WBSize {
+	sizes := make([]*entities.WBSize, len(protoSizes))
+	for i, s := range protoSizes {
+		size := &entities.WBSize{
+			TechSize: s.TechSize,
+			WbSize:   s.WbSize,
+			Price:    int(s.Price), // Keep for backward compatibility
+			Skus:     s.Skus,
+		}
+
+		// Set marketplace-specific prices if provided
+		if s.WbPrice != nil {
+			size.WbPrice = s.WbPrice
+		}
+		if s.OzonPrice != nil {
+			size.OzonPrice = s.OzonPrice
+		}
+
+		sizes[i] = size
+	}
+	return sizes
+}
+
+// validateSizePrices checks that prices are provided for enabled marketplaces.
+func validateSizePrices(sizes []*entities.WBSize, wb, ozon bool) error {
+	for i, size := range sizes {
+		if wb && size.WbPrice == nil && size.Price == 0 {
+			return connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("price (wb_price or general price) is required for size %d when WildBerries integration is enabled", i))
+		}
+		if ozon && size.OzonPrice == nil && size.Price == 0 {
+			return connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("price (ozon_price or general price) is required for size %d when Ozon integration is enabled", i))
+		}
+	}
+	return nil
+}
+
+func wbMediaFilesFromProto(files []*apiv1.WBMediaFileToUpload) []*entities.WBClientMediaFile {
+	wbMediaToUploadFiles := make([]*entities.WBClientMediaFile, len(files))
+	for i, f := range files {
+		wbMediaToUploadFiles[i] = &entities.WBClientMediaFile{
+			Content:     f.Content,
+			Filename:    f.Filename,
+			PhotoNumber: f.PhotoNumber,
+			Kind:        mediaKindFromProto(f.Kind),
+		}
+	}
+	return wbMediaToUploadFiles
+}
+
+func mediaReferencesFromProto(refs []*apiv1.MediaReference) ([]*entities.MediaReference, error) {
+	media := make([]*entities.MediaReference, len(refs))
+	for i, m := range refs {
+		if m.GetMediaId() == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("media_id is required for media %d", i))
+		}
+		media[i] = &entities.MediaReference{
+			MediaID:     m.MediaId,
+			PhotoNumber: m.PhotoNumber,
+			Kind:        mediaKindFromProto(m.Kind),
+			Primary:     m.Primary,
+		}
+	}
+	return media, nil
+}
+
+// validateWBVideoCount checks the media of one card, WB accepts a single video per card.
+// Ozon video covers are not sent to WB and are not counted.
+func validateWBVideoCount(files []*entities.WBClientMediaFile, media []*entities.MediaReference) error {
+	videoCount := 0
+	for _, f := range files {
+		if f.Kind == entities.MediaKindVideo {
+			videoCount++
+		}
+	}
+	for _, m := range media {
+		if m.Kind == entities.MediaKindVideo && !m.IsOzonVideoCover() {
+			videoCount++
+		}
+	}
+	if videoCount > 1 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only one video is allowed when wb is true, got %d", videoCount))
+	}
+	return nil
+}
+
+func wbMediaUploadResponsesToProto(responses []*entities.WbMediaUploadIndividualResponse) []*apiv1.WBMediaUploadIndividualResponse {
+	result := make([]*apiv1.WBMediaUploadIndividualResponse, len(responses))
+	for i, response := range responses {
+		result[i] = &apiv1.WBMediaUploadIndividualResponse{
+			VendorCode:   response.VendorCode,
+			PhotoNumber:  response.PhotoNumber,
+			ResponseJson: response.ResponseJson,
+			ErrorMessage: response.ErrorMessage,
+		}
+	}
+	return result
+}
+
+func wbMediaSaveResponsesToProto(responses []*entities.WbMediaSaveByLinksResponse) []*apiv1.WBMediaSaveByLinksResponse {
+	result := make([]*apiv1.WBMediaSaveByLinksResponse, len(responses))
+	for i, response := range responses {
+		result[i] = wbMediaSaveResponseToProto(response)
+	}
+	return result
+}
+
+func wbMediaSaveResponseToProto(response *entities.WbMediaSaveByLinksResponse) *apiv1.WBMediaSaveByLinksResponse {
+	if response == nil {
+		return &apiv1.WBMediaSaveByLinksResponse{}
+	}
+	return &apiv1.WBMediaSaveByLinksResponse{
+		VendorCode:   response.VendorCode,
+		ResponseJson: response.ResponseJson,
+		ErrorMessage: response.ErrorMessage,
+	}
+}
+
+func generatedBarcodesToProto(generated []*entities.GeneratedBarcodes) []*apiv1.GeneratedBarcodes {
+	result := make([]*apiv1.GeneratedBarcodes, len(generated))
+	for i, g := range generated {
+		result[i] = &apiv1.GeneratedBarcodes{
+			Marketplace: marketplaceToProto(g.Marketplace),
+			VendorCode:  g.VendorCode,
+			TechSize:    g.TechSize,
+			Barcodes:    g.Barcodes,
+		}
+	}
+	return result
+}
+
+func contentVariantsToProto(variants []*entities.ContentVariant) []*apiv1.ContentVariant {
+	result := make([]*apiv1.ContentVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = &apiv1.ContentVariant{
+			VariantId:   variant.ID,
+			Index:       int32(variant.Index),
+			Title:       variant.Content.Title,
+			Description: variant.Content.Description,
+			Attributes:  variant.Content.Attributes,
+			Cost:        int32(variant.Cost),
+			FromCache:   variant.Content.FromCache,
+		}
+		if variant.Content.TokenUsage != nil {
+			result[i].PromptTokens = int32(variant.Content.TokenUsage.TotalPromptTokens)
+			result[i].CompletionTokens = int32(variant.Content.TokenUsage.TotalCompletionTokens)
+		}
+	}
+	return result
+}
+
+// withContentValidationDetails attaches the content violations to the error as a ContentValidationError detail
+func withContentValidationDetails(err error) error {
+	var validationErr *entities.ContentValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, validationErr)
+	detail, detailErr := connect.NewErrorDetail(&apiv1.ContentValidationError{Violations: contentViolationsToProto(validationErr.Violations)})
+	if detailErr != nil {
+		log.Printf("failed to create content validation error detail: %v", detailErr)
+		return connectErr
+	}
+	connectErr.AddDetail(detail)
+	return connectErr
+}
+
+func ozonErrorToProto(ozonErr *entities.OzonError) *apiv1.OzonError {
+	if ozonErr == nil {
+		return nil
+	}
+	details := make([]*apiv1.OzonErrorDetail, len(ozonErr.Details))
+	for i, d := range ozonErr.Details {
+		details[i] = &apiv1.OzonErrorDetail{TypeUrl: d.TypeURL, Value: d.Value}
+	}
+	return &apiv1.OzonError{
+		HttpStatus: int32(ozonErr.HTTPStatus),
+		Code:       ozonErr.CodeString(),
+		Message:    ozonErr.Message,
+		Details:    details,
+	}
+}
+
+func contentViolationsToProto(violations []entities.ContentViolation) []*apiv1.ContentViolation {
+	result := make([]*apiv1.ContentViolation, len(violations))
+	for i, v := range violations {
+		result[i] = &apiv1.ContentViolation{
+			Marketplace: marketplaceToProto(v.Marketplace),
+			Field:       v.Field,
+			Rule:        v.Rule,
+			Message:     v.Message,
+			Fixed:       v.Fixed,
+		}
+	}
+	return result
+}
+
 // createDimensions safely creates WBDimensions handling nil input
 func createDimensions(dims *apiv1.Dimensions) *entities.WBDimensions {
 	if dims == nil {
@@ -256,3 +552,77 @@ func createDimensions(dims *apiv1.Dimensions) *entities.WBDimensions {
 
 	return result
 }
+
+// mediaKindFromProto maps the API media kind to the domain one, treating unspecified as photo
+func mediaKindFromProto(kind apiv1.MediaKind) entities.MediaKind {
+	switch kind {
+	case apiv1.MediaKind_MEDIA_KIND_VIDEO:
+		return entities.MediaKindVideo
+	case apiv1.MediaKind_MEDIA_KIND_IMAGE_360:
+		return entities.MediaKindImage360
+	case apiv1.MediaKind_MEDIA_KIND_COLOR_SWATCH:
+		return entities.MediaKindColorSwatch
+	default:
+		return entities.MediaKindPhoto
+	}
+}
+
+// marketplaceFromProto maps the API marketplace to the domain one
+func marketplaceFromProto(marketplace apiv1.Marketplace) (entities.Marketplace, bool) {
+	switch marketplace {
+	case apiv1.Marketplace_MARKETPLACE_WB:
+		return entities.MarketplaceWB, true
+	case apiv1.Marketplace_MARKETPLACE_OZON:
+		return entities.MarketplaceOzon, true
+	default:
+		return "", false
+	}
+}
+
+// contentProviderFromProto maps the API content provider to the domain one, empty when unspecified
+func contentProviderFromProto(provider apiv1.ContentProvider) entities.ContentProvider {
+	switch provider {
+	case apiv1.ContentProvider_CONTENT_PROVIDER_CARD_CRAFT_AI:
+		return entities.ContentProviderCardCraftAi
+	case apiv1.ContentProvider_CONTENT_PROVIDER_OPENAI:
+		return entities.ContentProviderOpenAi
+	default:
+		return ""
+	}
+}
+
+// contentProviderToProto maps the domain content provider to the API one
+func contentProviderToProto(provider entities.ContentProvider) apiv1.ContentProvider {
+	switch provider {
+	case entities.ContentProviderCardCraftAi:
+		return apiv1.ContentProvider_CONTENT_PROVIDER_CARD_CRAFT_AI
+	case entities.ContentProviderOpenAi:
+		return apiv1.ContentProvider_CONTENT_PROVIDER_OPENAI
+	default:
+		return apiv1.ContentProvider_CONTENT_PROVIDER_UNSPECIFIED
+	}
+}
+
+// marketplaceToProto maps the domain marketplace to the API one
+func marketplaceToProto(marketplace entities.Marketplace) apiv1.Marketplace {
+	switch marketplace {
+	case entities.MarketplaceWB:
+		return apiv1.Marketplace_MARKETPLACE_WB
+	case entities.MarketplaceOzon:
+		return apiv1.Marketplace_MARKETPLACE_OZON
+	default:
+		return apiv1.Marketplace_MARKETPLACE_UNSPECIFIED
+	}
+}
+
+// contentValidationModeFromProto maps the API content validation mode to the domain one, truncate when unspecified
+func contentValidationModeFromProto(mode apiv1.ContentValidationMode) entities.ContentValidationMode {
+	switch mode {
+	case apiv1.ContentValidationMode_CONTENT_VALIDATION_MODE_REGENERATE:
+		return entities.ContentValidationRegenerate
+	case apiv1.ContentValidationMode_CONTENT_VALIDATION_MODE_REJECT:
+		return entities.ContentValidationReject
+	default:
+		return entities.ContentValidationTruncate
+	}
+}
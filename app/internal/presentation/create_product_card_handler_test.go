@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+)
+
+func TestValidateWBVideoCount(t *testing.T) {
+	video := &entities.MediaReference{MediaID: "video", Kind: entities.MediaKindVideo}
+	cover := &entities.MediaReference{MediaID: "cover", Kind: entities.MediaKindVideo, Primary: true}
+	videoFile := &entities.WBClientMediaFile{Filename: "video.mp4", Kind: entities.MediaKindVideo}
+
+	tests := []struct {
+		name    string
+		files   []*entities.WBClientMediaFile
+		media   []*entities.MediaReference
+		wantErr bool
+	}{
+		{"single video", nil, []*entities.MediaReference{video}, false},
+		{"video with Ozon cover", nil, []*entities.MediaReference{video, cover}, false},
+		{"uploaded video with Ozon cover", []*entities.WBClientMediaFile{videoFile}, []*entities.MediaReference{cover}, false},
+		{"two videos", []*entities.WBClientMediaFile{videoFile}, []*entities.MediaReference{video}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateWBVideoCount(tt.files, tt.media); (err != nil) != tt.wantErr {
+				t.Errorf("validateWBVideoCount() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateProductCard_RejectsSizesWithVariants(t *testing.T) {
+	h := NewCreateProductCardHandler(nil, nil, nil, nil, nil, nil)
+	req := connect.NewRequest(&apiv1.CreateRequest{
+		ProductTitle: "Футболка",
+		Sizes:        []*apiv1.Size{{TechSize: "S"}},
+		Variants:     []*apiv1.ProductVariant{{VendorCode: "VC001-RED", Color: "красный"}},
+	})
+	req.Header().Set("Authorization", "Bearer key")
+
+	_, err := h.CreateProductCard(context.Background(), req)
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Expected InvalidArgument for sizes set next to variants, got %v", err)
+	}
+}
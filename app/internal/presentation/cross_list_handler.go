@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type CrossListUsecase interface {
+	CrossList(ctx context.Context, apiKey string, req entities.CrossListRequest) (*entities.CrossListResult, error)
+}
+
+// CrossList copies an existing WB card to Ozon or an existing Ozon product to WB
+func (h *CreateProductCardHandler) CrossList(ctx context.Context, req *connect.Request[apiv1.CrossListRequest]) (*connect.Response[apiv1.CrossListResponse], error) {
+	log.Printf("CrossList request - nmID: %d, offer ID: %s", req.Msg.NmId, req.Msg.OfferId)
+
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if (req.Msg.NmId > 0) == (req.Msg.OfferId != "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("exactly one of nm_id and offer_id is required"))
+	}
+	// Both marketplaces are read: the source card and the attributes of the target category, even in dry-run mode
+	if req.Msg.WbApiKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key is required"))
+	}
+	if req.Msg.OzonApiClientId == "" || req.Msg.OzonApiKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_api_client_id and ozon_api_key are required"))
+	}
+	if (req.Msg.DescriptionCategoryId == 0) != (req.Msg.TypeId == 0) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("description_category_id and type_id must be set together"))
+	}
+	if req.Msg.Price < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("price must not be negative"))
+	}
+
+	result, err := h.crossListUsecase.CrossList(ctx, apiKey, entities.CrossListRequest{
+		WbApiKey:              req.Msg.WbApiKey,
+		OzonApiClientId:       req.Msg.OzonApiClientId,
+		OzonApiKey:            req.Msg.OzonApiKey,
+		NmID:                  int(req.Msg.NmId),
+		OfferID:               req.Msg.OfferId,
+		DescriptionCategoryID: req.Msg.DescriptionCategoryId,
+		TypeID:                req.Msg.TypeId,
+		SubjectID:             req.Msg.SubjectId,
+		Price:                 int(req.Msg.Price),
+		DryRun:                req.Msg.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &apiv1.CrossListResponse{
+		VendorCode:                  result.VendorCode,
+		UnmappedCharacteristics:     result.UnmappedCharacteristics,
+		OzonApiResponseJson:         result.OzonApiResponseJson,
+		OzonPreparedRequestJson:     result.OzonPreparedRequestJson,
+		OzonRequestAttempted:        result.OzonRequestAttempted,
+		OzonError:                   ozonErrorToProto(result.OzonError),
+		DryRun:                      result.DryRun,
+		GeneratedBarcodes:           generatedBarcodesToProto(result.GeneratedBarcodes),
+		WbApiResponseJson:           result.WbApiResponseJson,
+		WbPreparedRequestJson:       result.WbPreparedRequestJson,
+		WbRequestAttempted:          result.WbRequestAttempted,
+		WbMediaPending:              result.WbMediaPending,
+		WbMediaSaveByLinksResponses: wbMediaSaveResponsesToProto(result.WbMediaSaveResponses),
+		ContentViolations:           contentViolationsToProto(result.ContentViolations),
+	}
+	if content := result.CardCraftAiGeneratedContent; content != nil {
+		if req.Msg.OfferId != "" {
+			if content.SubjectID != nil {
+				response.SubjectId = *content.SubjectID
+			}
+			if content.SubjectName != nil {
+				response.SubjectName = *content.SubjectName
+			}
+		} else {
+			if content.SubID != nil {
+				response.DescriptionCategoryId = *content.SubID
+			}
+			if content.TypeID != nil {
+				response.TypeId = *content.TypeID
+			}
+			if content.TypeName != nil {
+				response.TypeName = *content.TypeName
+			}
+		}
+	}
+	return connect.NewResponse(response), nil
+}
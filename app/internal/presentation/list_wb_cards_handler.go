@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type ListWBCardsUsecase interface {
+	ListWBCards(ctx context.Context, wbApiKey string, filter *entities.WBGetCardListRequestFilter, pageSize int, send func(cards []entities.WBCardDefinition) error) error
+}
+
+// ListWBCards streams all WB cards of the seller matching the filters, one message per page
+func (h *CreateProductCardHandler) ListWBCards(ctx context.Context, req *connect.Request[apiv1.ListWBCardsRequest], stream *connect.ServerStream[apiv1.ListWBCardsResponse]) error {
+	log.Printf("ListWBCards request - TextSearch: %s, Brands: %v, Subjects: %v, Tags: %v", req.Msg.TextSearch, req.Msg.Brands, req.Msg.SubjectIds, req.Msg.TagIds)
+
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if req.Msg.PageSize < 0 || req.Msg.PageSize > 100 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("page_size must be between 1 and 100"))
+	}
+
+	withPhoto := -1
+	if req.Msg.WithPhoto != nil {
+		withPhoto = 0
+		if *req.Msg.WithPhoto {
+			withPhoto = 1
+		}
+	}
+	filter := &entities.WBGetCardListRequestFilter{
+		TextSearch: req.Msg.TextSearch,
+		WithPhoto:  &withPhoto,
+		Brands:     req.Msg.Brands,
+		ObjectIDs:  int64sToInts(req.Msg.SubjectIds),
+		TagIDs:     int64sToInts(req.Msg.TagIds),
+	}
+
+	return h.listWBCardsUsecase.ListWBCards(ctx, req.Msg.WbApiKey, filter, int(req.Msg.PageSize), func(cards []entities.WBCardDefinition) error {
+		return stream.Send(&apiv1.ListWBCardsResponse{Cards: wbCardsToProto(cards)})
+	})
+}
+
+func wbCardsToProto(cards []entities.WBCardDefinition) []*apiv1.WBCard {
+	result := make([]*apiv1.WBCard, len(cards))
+	for i, card := range cards {
+		photoURLs := make([]string, len(card.Photos))
+		for j, photo := range card.Photos {
+			photoURLs[j] = photo.Big
+		}
+		characteristics := make([]*apiv1.WBCardCharacteristic, len(card.Characteristics))
+		for j, c := range card.Characteristics {
+			valueJSON, _ := json.Marshal(c.Value) // Values come from WB JSON and always marshal
+			characteristics[j] = &apiv1.WBCardCharacteristic{Id: int64(c.ID), Name: c.Name, ValueJson: string(valueJSON)}
+		}
+		sizes := make([]*apiv1.WBCardSize, len(card.Sizes))
+		for j, s := range card.Sizes {
+			sizes[j] = &apiv1.WBCardSize{ChrtId: int64(s.ChrtID), TechSize: s.TechSize, WbSize: s.WbSize, Skus: s.Skus}
+		}
+		tags := make([]*apiv1.WBCardTag, len(card.Tags))
+		for j, t := range card.Tags {
+			tags[j] = &apiv1.WBCardTag{Id: int64(t.ID), Name: t.Name, Color: t.Color}
+		}
+
+		result[i] = &apiv1.WBCard{
+			NmId:            int64(card.NmID),
+			ImtId:           int64(card.ImtID),
+			VendorCode:      card.VendorCode,
+			SubjectId:       int64(card.SubjectID),
+			SubjectName:     card.SubjectName,
+			Brand:           card.Brand,
+			Title:           card.Title,
+			Description:     card.Description,
+			PhotoUrls:       photoURLs,
+			VideoUrl:        card.Video,
+			Dimensions:      wbDimensionsToProto(card.Dimensions),
+			Characteristics: characteristics,
+			Sizes:           sizes,
+			Tags:            tags,
+			CreatedAt:       card.CreatedAt,
+			UpdatedAt:       card.UpdatedAt,
+		}
+	}
+	return result
+}
+
+func wbDimensionsToProto(dims *entities.WBDimensions) *apiv1.Dimensions {
+	if dims == nil {
+		return nil
+	}
+	result := &apiv1.Dimensions{}
+	if dims.Length != nil {
+		result.Length = *dims.Length
+	}
+	if dims.Width != nil {
+		result.Width = *dims.Width
+	}
+	if dims.Height != nil {
+		result.Height = *dims.Height
+	}
+	if dims.WeightBrutto != nil {
+		result.WeightBrutto = *dims.WeightBrutto
+	}
+	return result
+}
+
+func int64sToInts(values []int64) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make([]int, len(values))
+	for i, v := range values {
+		result[i] = int(v)
+	}
+	return result
+}
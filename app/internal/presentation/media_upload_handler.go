@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type UploadMediaUsecase interface {
+	UploadMedia(ctx context.Context, filename, contentType string, content io.Reader) (*entities.MediaFile, error)
+}
+
+type MediaUploadHandler struct {
+	uploadMediaUsecase UploadMediaUsecase
+}
+
+func NewMediaUploadHandler(uploadMediaUsecase UploadMediaUsecase) *MediaUploadHandler {
+	return &MediaUploadHandler{
+		uploadMediaUsecase: uploadMediaUsecase,
+	}
+}
+
+// Upload implements the MediaService.Upload client-streaming RPC.
+// The first message must carry metadata, the following ones carry file chunks.
+func (h *MediaUploadHandler) Upload(ctx context.Context, stream *connect.ClientStream[apiv1.UploadMediaRequest]) (*connect.Response[apiv1.UploadMediaResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(stream.RequestHeader()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("upload stream is empty"))
+	}
+
+	metadata := stream.Msg().GetMetadata()
+	if metadata == nil || metadata.GetFilename() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("first message must carry metadata with filename"))
+	}
+
+	log.Printf("UploadMedia request - Filename: %s, ContentType: %s", metadata.GetFilename(), metadata.GetContentType())
+
+	mediaFile, err := h.uploadMediaUsecase.UploadMedia(ctx, metadata.GetFilename(), metadata.GetContentType(), &mediaStreamReader{stream: stream})
+	if err != nil {
+		var connectErr *connect.Error
+		switch {
+		case errors.Is(err, entities.ErrMediaTooLarge):
+			return nil, connect.NewError(connect.CodeResourceExhausted, err)
+		case errors.As(err, &connectErr):
+			return nil, connectErr
+		default:
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	return &connect.Response[apiv1.UploadMediaResponse]{
+		Msg: &apiv1.UploadMediaResponse{
+			MediaId: mediaFile.ID,
+			Url:     mediaFile.URL,
+			Size:    mediaFile.Size,
+		},
+	}, nil
+}
+
+// mediaStreamReader exposes the chunks of an upload stream as an io.Reader,
+// so the content goes to the storage without being collected in memory.
+type mediaStreamReader struct {
+	stream *connect.ClientStream[apiv1.UploadMediaRequest]
+	buf    []byte
+}
+
+func (r *mediaStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		if r.stream.Msg().GetMetadata() != nil {
+			return 0, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("metadata is only allowed in the first message"))
+		}
+		r.buf = r.stream.Msg().GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,99 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"connectrpc.com/connect"
+)
+
+// maxWBDiscount is the highest discount in percent WB accepts
+const maxWBDiscount = 99
+
+type UpdatePricesUsecase interface {
+	UpdatePrices(ctx context.Context, req *entities.PriceUpdateRequest) *entities.PriceUpdateResult
+}
+
+// UpdatePrices sets prices and discounts of existing WB cards and Ozon offers
+func (h *CreateProductCardHandler) UpdatePrices(ctx context.Context, req *connect.Request[apiv1.UpdatePricesRequest]) (*connect.Response[apiv1.UpdatePricesResponse], error) {
+	log.Printf("UpdatePrices request - WB prices: %d, Ozon prices: %d", len(req.Msg.WbPrices), len(req.Msg.OzonPrices))
+
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if len(req.Msg.WbPrices) == 0 && len(req.Msg.OzonPrices) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_prices or ozon_prices is required"))
+	}
+
+	wbPrices := make([]entities.WBPriceGood, len(req.Msg.WbPrices))
+	if len(wbPrices) > 0 && req.Msg.WbApiKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key is required for wb_prices"))
+	}
+	for i, p := range req.Msg.WbPrices {
+		if p.NmId <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("nm_id is required for wb price %d", i))
+		}
+		if p.Price <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("price must be greater than zero for nm_id %d", p.NmId))
+		}
+		if p.Discount < 0 || p.Discount > maxWBDiscount {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("discount must be between 0 and %d for nm_id %d", maxWBDiscount, p.NmId))
+		}
+		wbPrices[i] = entities.WBPriceGood{NmID: int(p.NmId), Price: int(p.Price), Discount: int(p.Discount)}
+	}
+
+	ozonPrices := make([]entities.OzonPrice, len(req.Msg.OzonPrices))
+	if len(ozonPrices) > 0 && (req.Msg.OzonApiClientId == "" || req.Msg.OzonApiKey == "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_api_client_id and ozon_api_key are required for ozon_prices"))
+	}
+	for i, p := range req.Msg.OzonPrices {
+		if p.OfferId == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("offer_id is required for ozon price %d", i))
+		}
+		if p.Price <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("price must be greater than zero for offer_id %s", p.OfferId))
+		}
+		ozonPrices[i] = entities.OzonPrice{
+			OfferID:  p.OfferId,
+			Price:    strconv.Itoa(int(p.Price)),
+			OldPrice: strconv.Itoa(int(p.OldPrice)),
+		}
+		if p.MinPrice > 0 {
+			ozonPrices[i].MinPrice = strconv.Itoa(int(p.MinPrice))
+		}
+	}
+
+	result := h.updatePricesUsecase.UpdatePrices(ctx, &entities.PriceUpdateRequest{
+		WbApiKey:        req.Msg.WbApiKey,
+		OzonApiClientId: req.Msg.OzonApiClientId,
+		OzonApiKey:      req.Msg.OzonApiKey,
+		WbPrices:        wbPrices,
+		OzonPrices:      ozonPrices,
+	})
+
+	response := &apiv1.UpdatePricesResponse{
+		WbUploadId: result.WbUploadID,
+		WbError:    result.WbError,
+		OzonError:  result.OzonError,
+	}
+	if task := result.WbTask; task != nil {
+		response.WbTaskStatus = int32(task.Status)
+		response.WbGoodsTotal = int32(task.OverAllGoodsNumber)
+		response.WbGoodsSucceeded = int32(task.SuccessGoodsNumber)
+		if response.WbError == "" {
+			response.WbError = task.ErrorText
+		}
+	}
+	for _, r := range result.OzonResults {
+		errs := make([]string, len(r.Errors))
+		for i, e := range r.Errors {
+			errs[i] = e.Code + ": " + e.Message
+		}
+		response.OzonResults = append(response.OzonResults, &apiv1.OzonPriceResult{OfferId: r.OfferID, ProductId: r.ProductID, Updated: r.Updated, Errors: errs})
+	}
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,130 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+)
+
+type StockUsecase interface {
+	ListWarehouses(ctx context.Context, req *entities.StockRequest) *entities.WarehouseListResult
+	SetStocks(ctx context.Context, req *entities.StockRequest) *entities.StockUpdateResult
+}
+
+type StockHandler struct {
+	stockUsecase     StockUsecase
+	stockSyncUsecase StockSyncUsecase
+}
+
+func NewStockHandler(stockUsecase StockUsecase, stockSyncUsecase StockSyncUsecase) *StockHandler {
+	return &StockHandler{stockUsecase: stockUsecase, stockSyncUsecase: stockSyncUsecase}
+}
+
+// ListWarehouses lists the seller warehouses of every marketplace with credentials in the request
+func (h *StockHandler) ListWarehouses(ctx context.Context, req *connect.Request[apiv1.ListWarehousesRequest]) (*connect.Response[apiv1.ListWarehousesResponse], error) {
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if req.Msg.WbApiKey == "" && (req.Msg.OzonApiClientId == "" || req.Msg.OzonApiKey == "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key or ozon_api_client_id and ozon_api_key are required"))
+	}
+
+	result := h.stockUsecase.ListWarehouses(ctx, &entities.StockRequest{
+		WbApiKey:        req.Msg.WbApiKey,
+		OzonApiClientId: req.Msg.OzonApiClientId,
+		OzonApiKey:      req.Msg.OzonApiKey,
+	})
+
+	warehouses := make([]*apiv1.Warehouse, len(result.Warehouses))
+	for i, w := range result.Warehouses {
+		warehouses[i] = &apiv1.Warehouse{Marketplace: marketplaceToProto(w.Marketplace), Id: w.ID, Name: w.Name}
+	}
+	return connect.NewResponse(&apiv1.ListWarehousesResponse{
+		Warehouses: warehouses,
+		WbError:    result.WbError,
+		OzonError:  result.OzonError,
+	}), nil
+}
+
+// SetStocks sets stocks of WB sizes and Ozon offers in seller warehouses
+func (h *StockHandler) SetStocks(ctx context.Context, req *connect.Request[apiv1.SetStocksRequest]) (*connect.Response[apiv1.SetStocksResponse], error) {
+	log.Printf("SetStocks request - WB stocks: %d, Ozon stocks: %d", len(req.Msg.WbStocks), len(req.Msg.OzonStocks))
+
+	if _, err := ExtractAPIKeyFromHeader(req.Header()); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if len(req.Msg.WbStocks) == 0 && len(req.Msg.OzonStocks) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_stocks or ozon_stocks is required"))
+	}
+
+	wbStocks := make([]entities.WBWarehouseStock, len(req.Msg.WbStocks))
+	if len(wbStocks) > 0 && req.Msg.WbApiKey == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("wb_api_key is required for wb_stocks"))
+	}
+	for i, s := range req.Msg.WbStocks {
+		if s.WarehouseId <= 0 || s.Sku == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("warehouse_id and sku are required for wb stock %d", i))
+		}
+		if s.Amount < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("amount must not be negative for sku %s", s.Sku))
+		}
+		wbStocks[i] = entities.WBWarehouseStock{WarehouseID: s.WarehouseId, WBStock: entities.WBStock{Sku: s.Sku, Amount: int(s.Amount)}}
+	}
+
+	ozonStocks := make([]entities.OzonStock, len(req.Msg.OzonStocks))
+	if len(ozonStocks) > 0 && (req.Msg.OzonApiClientId == "" || req.Msg.OzonApiKey == "") {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ozon_api_client_id and ozon_api_key are required for ozon_stocks"))
+	}
+	for i, s := range req.Msg.OzonStocks {
+		if s.WarehouseId <= 0 || s.OfferId == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("warehouse_id and offer_id are required for ozon stock %d", i))
+		}
+		if s.Amount < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("amount must not be negative for offer_id %s", s.OfferId))
+		}
+		ozonStocks[i] = entities.OzonStock{OfferID: s.OfferId, Stock: int(s.Amount), WarehouseID: s.WarehouseId}
+	}
+
+	result := h.stockUsecase.SetStocks(ctx, &entities.StockRequest{
+		WbApiKey:        req.Msg.WbApiKey,
+		OzonApiClientId: req.Msg.OzonApiClientId,
+		OzonApiKey:      req.Msg.OzonApiKey,
+		WbStocks:        wbStocks,
+		OzonStocks:      ozonStocks,
+	})
+
+	response := &apiv1.SetStocksResponse{WbError: result.WbError, OzonError: result.OzonError}
+	for _, r := range result.OzonResults {
+		errs := make([]string, len(r.Errors))
+		for i, e := range r.Errors {
+			errs[i] = e.Code + ": " + e.Message
+		}
+		response.OzonResults = append(response.OzonResults, &apiv1.OzonStockResult{
+			WarehouseId: r.WarehouseID,
+			OfferId:     r.OfferID,
+			ProductId:   r.ProductID,
+			Updated:     r.Updated,
+			Errors:      errs,
+		})
+	}
+	return connect.NewResponse(response), nil
+}
+
+// initialStockFromProto converts the initial stock of a CreateRequest, nil if it sets no warehouse
+func initialStockFromProto(stock *apiv1.InitialStock) (*entities.InitialStock, error) {
+	if stock == nil || (stock.WbWarehouseId == 0 && stock.OzonWarehouseId == 0) {
+		return nil, nil
+	}
+	if stock.Amount < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("initial_stock.amount must not be negative"))
+	}
+	return &entities.InitialStock{
+		WbWarehouseID:   stock.WbWarehouseId,
+		OzonWarehouseID: stock.OzonWarehouseId,
+		Amount:          int(stock.Amount),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+)
+
+type fakeStockUsecase struct {
+	req    *entities.StockRequest
+	result *entities.StockUpdateResult
+}
+
+func (f *fakeStockUsecase) ListWarehouses(ctx context.Context, req *entities.StockRequest) *entities.WarehouseListResult {
+	f.req = req
+	return &entities.WarehouseListResult{
+		Warehouses: []entities.Warehouse{{Marketplace: entities.MarketplaceOzon, ID: 22, Name: "Склад"}},
+		WbError:    "WB is unavailable",
+	}
+}
+
+func (f *fakeStockUsecase) SetStocks(ctx context.Context, req *entities.StockRequest) *entities.StockUpdateResult {
+	f.req = req
+	return f.result
+}
+
+func TestStockHandler_ListWarehouses(t *testing.T) {
+	uc := &fakeStockUsecase{}
+	h := NewStockHandler(uc, nil)
+
+	req := connect.NewRequest(&apiv1.ListWarehousesRequest{WbApiKey: "wb", OzonApiClientId: "client"})
+	if _, err := h.ListWarehouses(context.Background(), req); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("Expected Unauthenticated without an API key, got %v", err)
+	}
+
+	req.Header().Set("Authorization", "Bearer key")
+	resp, err := h.ListWarehouses(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ListWarehouses: %v", err)
+	}
+	if len(resp.Msg.Warehouses) != 1 || resp.Msg.Warehouses[0].Id != 22 || resp.Msg.Warehouses[0].Marketplace != apiv1.Marketplace_MARKETPLACE_OZON {
+		t.Errorf("Unexpected warehouses: %+v", resp.Msg.Warehouses)
+	}
+	if resp.Msg.WbError != "WB is unavailable" {
+		t.Errorf("Expected the WB error, got %q", resp.Msg.WbError)
+	}
+
+	req = connect.NewRequest(&apiv1.ListWarehousesRequest{OzonApiClientId: "client"})
+	req.Header().Set("Authorization", "Bearer key")
+	if _, err := h.ListWarehouses(context.Background(), req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("Expected InvalidArgument without marketplace credentials, got %v", err)
+	}
+}
+
+func TestStockHandler_SetStocks(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *apiv1.SetStocksRequest
+	}{
+		{"no stocks", &apiv1.SetStocksRequest{WbApiKey: "wb"}},
+		{"WB stocks without API key", &apiv1.SetStocksRequest{WbStocks: []*apiv1.WBStock{{WarehouseId: 1, Sku: "2000000000011", Amount: 1}}}},
+		{"WB stock without sku", &apiv1.SetStocksRequest{WbApiKey: "wb", WbStocks: []*apiv1.WBStock{{WarehouseId: 1, Amount: 1}}}},
+		{"negative WB amount", &apiv1.SetStocksRequest{WbApiKey: "wb", WbStocks: []*apiv1.WBStock{{WarehouseId: 1, Sku: "2000000000011", Amount: -1}}}},
+		{"Ozon stocks without credentials", &apiv1.SetStocksRequest{OzonApiKey: "ozon", OzonStocks: []*apiv1.OzonStock{{WarehouseId: 22, OfferId: "VC001", Amount: 1}}}},
+		{"Ozon stock without warehouse", &apiv1.SetStocksRequest{OzonApiClientId: "client", OzonApiKey: "ozon", OzonStocks: []*apiv1.OzonStock{{OfferId: "VC001", Amount: 1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &fakeStockUsecase{}
+			req := connect.NewRequest(tt.req)
+			req.Header().Set("Authorization", "Bearer key")
+
+			if _, err := NewStockHandler(uc, nil).SetStocks(context.Background(), req); connect.CodeOf(err) != connect.CodeInvalidArgument {
+				t.Errorf("Expected InvalidArgument, got %v", err)
+			}
+			if uc.req != nil {
+				t.Error("Expected the usecase not to be called")
+			}
+		})
+	}
+
+	t.Run("converts stocks and results", func(t *testing.T) {
+		uc := &fakeStockUsecase{result: &entities.StockUpdateResult{
+			OzonResults: []entities.OzonStockUpdateResult{{WarehouseID: 22, OfferID: "VC001", Errors: []entities.OzonPriceUpdateError{{Code: "NOT_FOUND", Message: "offer not found"}}}},
+		}}
+		req := connect.NewRequest(&apiv1.SetStocksRequest{
+			WbApiKey:        "wb",
+			OzonApiClientId: "client",
+			OzonApiKey:      "ozon",
+			WbStocks:        []*apiv1.WBStock{{WarehouseId: 1, Sku: "2000000000011", Amount: 3}},
+			OzonStocks:      []*apiv1.OzonStock{{WarehouseId: 22, OfferId: "VC001", Amount: 4}},
+		})
+		req.Header().Set("Authorization", "Bearer key")
+
+		resp, err := NewStockHandler(uc, nil).SetStocks(context.Background(), req)
+		if err != nil {
+			t.Fatalf("SetStocks: %v", err)
+		}
+		if len(uc.req.WbStocks) != 1 || uc.req.WbStocks[0] != (entities.WBWarehouseStock{WarehouseID: 1, WBStock: entities.WBStock{Sku: "2000000000011", Amount: 3}}) {
+			t.Errorf("Unexpected WB stocks: %+v", uc.req.WbStocks)
+		}
+		if len(uc.req.OzonStocks) != 1 || uc.req.OzonStocks[0] != (entities.OzonStock{OfferID: "VC001", Stock: 4, WarehouseID: 22}) {
+			t.Errorf("Unexpected Ozon stocks: %+v", uc.req.OzonStocks)
+		}
+		if len(resp.Msg.OzonResults) != 1 || len(resp.Msg.OzonResults[0].Errors) != 1 || resp.Msg.OzonResults[0].Errors[0] != "NOT_FOUND: offer not found" {
+			t.Errorf("Unexpected Ozon results: %+v", resp.Msg.OzonResults)
+		}
+	})
+}
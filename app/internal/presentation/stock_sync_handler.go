@@ -0,0 +1,122 @@
+package presentation
+
+import (
+	"api/app/domain/entities"
+	apiv1 "api/gen/api/v1"
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+type StockSyncUsecase interface {
+	SaveSettings(ctx context.Context, settings *entities.StockSyncSettings) error
+	SetMappings(ctx context.Context, apiKey string, mappings []entities.StockSyncMapping) error
+	Run(ctx context.Context, apiKey string, dryRun *bool) (*entities.StockSyncReport, error)
+	LatestReport(ctx context.Context, apiKey string) (*entities.StockSyncReport, error)
+}
+
+// SetStockSyncSettings configures the periodic stock sync of the account
+func (h *StockHandler) SetStockSyncSettings(ctx context.Context, req *connect.Request[apiv1.SetStockSyncSettingsRequest]) (*connect.Response[apiv1.SetStockSyncSettingsResponse], error) {
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	s := req.Msg.Settings
+	if s == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("settings are required"))
+	}
+	source, ok := marketplaceFromProto(s.Source)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported source marketplace: %s", s.Source))
+	}
+
+	err = h.stockSyncUsecase.SaveSettings(ctx, &entities.StockSyncSettings{
+		ApiKey:          apiKey,
+		Source:          source,
+		WbApiKey:        s.WbApiKey,
+		WbWarehouseID:   s.WbWarehouseId,
+		OzonApiClientId: s.OzonApiClientId,
+		OzonApiKey:      s.OzonApiKey,
+		OzonWarehouseID: s.OzonWarehouseId,
+		Enabled:         s.Enabled,
+		DryRun:          s.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&apiv1.SetStockSyncSettingsResponse{}), nil
+}
+
+// SetStockSyncMappings replaces the items synced for the account
+func (h *StockHandler) SetStockSyncMappings(ctx context.Context, req *connect.Request[apiv1.SetStockSyncMappingsRequest]) (*connect.Response[apiv1.SetStockSyncMappingsResponse], error) {
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	mappings := make([]entities.StockSyncMapping, len(req.Msg.Mappings))
+	for i, m := range req.Msg.Mappings {
+		mappings[i] = entities.StockSyncMapping{WbSku: m.WbSku, VendorCode: m.VendorCode, OzonOfferID: m.OzonOfferId}
+	}
+	if err := h.stockSyncUsecase.SetMappings(ctx, apiKey, mappings); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&apiv1.SetStockSyncMappingsResponse{}), nil
+}
+
+// RunStockSync syncs the stocks of the account now
+func (h *StockHandler) RunStockSync(ctx context.Context, req *connect.Request[apiv1.RunStockSyncRequest]) (*connect.Response[apiv1.StockSyncReportResponse], error) {
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	report, err := h.stockSyncUsecase.Run(ctx, apiKey, req.Msg.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&apiv1.StockSyncReportResponse{Report: stockSyncReportToProto(report)}), nil
+}
+
+// GetStockSyncReport returns the report of the last sync of the account
+func (h *StockHandler) GetStockSyncReport(ctx context.Context, req *connect.Request[apiv1.GetStockSyncReportRequest]) (*connect.Response[apiv1.StockSyncReportResponse], error) {
+	apiKey, err := ExtractAPIKeyFromHeader(req.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	report, err := h.stockSyncUsecase.LatestReport(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&apiv1.StockSyncReportResponse{Report: stockSyncReportToProto(report)}), nil
+}
+
+func stockSyncReportToProto(report *entities.StockSyncReport) *apiv1.StockSyncReport {
+	if report == nil {
+		return nil
+	}
+	changes := make([]*apiv1.StockSyncChange, len(report.Changes))
+	for i, c := range report.Changes {
+		changes[i] = &apiv1.StockSyncChange{WbSku: c.WbSku, OzonOfferId: c.OzonOfferID, From: int32(c.From), To: int32(c.To)}
+	}
+	conflicts := make([]*apiv1.StockSyncConflict, len(report.Conflicts))
+	for i, c := range report.Conflicts {
+		conflicts[i] = &apiv1.StockSyncConflict{
+			WbSku:        c.WbSku,
+			OzonOfferId:  c.OzonOfferID,
+			Kind:         string(c.Kind),
+			SourceAmount: int32(c.SourceAmount),
+			TargetAmount: int32(c.TargetAmount),
+			Message:      c.Message,
+		}
+	}
+	return &apiv1.StockSyncReport{
+		Source:     marketplaceToProto(report.Source),
+		DryRun:     report.DryRun,
+		StartedAt:  report.StartedAt.Format(time.RFC3339),
+		FinishedAt: report.FinishedAt.Format(time.RFC3339),
+		Changes:    changes,
+		Conflicts:  conflicts,
+		Error:      report.Error,
+	}
+}
@@ -28,15 +28,32 @@ type TinkoffNotificationHandler struct {
 	secretKey        string
 	terminalKey      string
 	telegramBotToken string
+
+	tinkoffAPIURL string
+	httpClient    *http.Client
 }
 
-func NewTinkoffNotificationHandler(balanceUsecase BalanceUsecase, secretKey string, terminalKey string, telegramBotToken string) *TinkoffNotificationHandler {
+// tinkoffAPIHost is the default base URL for Tinkoff acquiring API.
+const tinkoffAPIHost = "https://securepay.tinkoff.ru"
+
+// NewTinkoffNotificationHandler creates the payment handler. An empty tinkoffAPIURL selects the production host
+// and a nil httpClient a client without timeout.
+func NewTinkoffNotificationHandler(balanceUsecase BalanceUsecase, secretKey string, terminalKey string, telegramBotToken string, tinkoffAPIURL string, httpClient *http.Client) *TinkoffNotificationHandler {
+	if tinkoffAPIURL == "" {
+		tinkoffAPIURL = tinkoffAPIHost
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 	return &TinkoffNotificationHandler{
 		balanceUsecase: balanceUsecase,
 
 		secretKey:        secretKey,
 		terminalKey:      terminalKey,
 		telegramBotToken: telegramBotToken,
+
+		tinkoffAPIURL: strings.TrimSuffix(tinkoffAPIURL, "/"),
+		httpClient:    httpClient,
 	}
 }
 
@@ -128,7 +145,7 @@ func (h *TinkoffNotificationHandler) GeneratePaymentLink(order entities.Order) (
 
 	log.Printf("data: %s", data)
 	// Отправляем запрос в Тинькофф
-	url := "https://securepay.tinkoff.ru/v2/Init"
+	url := h.tinkoffAPIURL + "/v2/Init"
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return "", fmt.Errorf("ошибка при создании запроса: %v", err)
@@ -136,8 +153,7 @@ func (h *TinkoffNotificationHandler) GeneratePaymentLink(order entities.Order) (
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("ошибка при отправке запроса: %v", err)
 	}
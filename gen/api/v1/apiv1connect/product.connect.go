@@ -27,6 +27,12 @@ const (
 	BalanceServiceName = "api.v1.BalanceService"
 	// PaymentServiceName is the fully-qualified name of the PaymentService service.
 	PaymentServiceName = "api.v1.PaymentService"
+	// MediaServiceName is the fully-qualified name of the MediaService service.
+	MediaServiceName = "api.v1.MediaService"
+	// StockServiceName is the fully-qualified name of the StockService service.
+	StockServiceName = "api.v1.StockService"
+	// CatalogServiceName is the fully-qualified name of the CatalogService service.
+	CatalogServiceName = "api.v1.CatalogService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -39,6 +45,24 @@ const (
 const (
 	// ProductServiceCreateProcedure is the fully-qualified name of the ProductService's Create RPC.
 	ProductServiceCreateProcedure = "/api.v1.ProductService/Create"
+	// ProductServicePublishVariantProcedure is the fully-qualified name of the ProductService's
+	// PublishVariant RPC.
+	ProductServicePublishVariantProcedure = "/api.v1.ProductService/PublishVariant"
+	// ProductServiceListWBCardsProcedure is the fully-qualified name of the ProductService's
+	// ListWBCards RPC.
+	ProductServiceListWBCardsProcedure = "/api.v1.ProductService/ListWBCards"
+	// ProductServiceUpdatePricesProcedure is the fully-qualified name of the ProductService's
+	// UpdatePrices RPC.
+	ProductServiceUpdatePricesProcedure = "/api.v1.ProductService/UpdatePrices"
+	// ProductServiceCrossListProcedure is the fully-qualified name of the ProductService's CrossList
+	// RPC.
+	ProductServiceCrossListProcedure = "/api.v1.ProductService/CrossList"
+	// ProductServiceListCategoryMappingsProcedure is the fully-qualified name of the ProductService's
+	// ListCategoryMappings RPC.
+	ProductServiceListCategoryMappingsProcedure = "/api.v1.ProductService/ListCategoryMappings"
+	// ProductServiceOverrideCategoryMappingProcedure is the fully-qualified name of the
+	// ProductService's OverrideCategoryMapping RPC.
+	ProductServiceOverrideCategoryMappingProcedure = "/api.v1.ProductService/OverrideCategoryMapping"
 	// BalanceServiceGetBalanceProcedure is the fully-qualified name of the BalanceService's GetBalance
 	// RPC.
 	BalanceServiceGetBalanceProcedure = "/api.v1.BalanceService/GetBalance"
@@ -47,11 +71,51 @@ const (
 	// PaymentServiceTinkoffNotificationProcedure is the fully-qualified name of the PaymentService's
 	// TinkoffNotification RPC.
 	PaymentServiceTinkoffNotificationProcedure = "/api.v1.PaymentService/TinkoffNotification"
+	// MediaServiceUploadProcedure is the fully-qualified name of the MediaService's Upload RPC.
+	MediaServiceUploadProcedure = "/api.v1.MediaService/Upload"
+	// StockServiceListWarehousesProcedure is the fully-qualified name of the StockService's
+	// ListWarehouses RPC.
+	StockServiceListWarehousesProcedure = "/api.v1.StockService/ListWarehouses"
+	// StockServiceSetStocksProcedure is the fully-qualified name of the StockService's SetStocks RPC.
+	StockServiceSetStocksProcedure = "/api.v1.StockService/SetStocks"
+	// StockServiceSetStockSyncSettingsProcedure is the fully-qualified name of the StockService's
+	// SetStockSyncSettings RPC.
+	StockServiceSetStockSyncSettingsProcedure = "/api.v1.StockService/SetStockSyncSettings"
+	// StockServiceSetStockSyncMappingsProcedure is the fully-qualified name of the StockService's
+	// SetStockSyncMappings RPC.
+	StockServiceSetStockSyncMappingsProcedure = "/api.v1.StockService/SetStockSyncMappings"
+	// StockServiceRunStockSyncProcedure is the fully-qualified name of the StockService's RunStockSync
+	// RPC.
+	StockServiceRunStockSyncProcedure = "/api.v1.StockService/RunStockSync"
+	// StockServiceGetStockSyncReportProcedure is the fully-qualified name of the StockService's
+	// GetStockSyncReport RPC.
+	StockServiceGetStockSyncReportProcedure = "/api.v1.StockService/GetStockSyncReport"
+	// CatalogServiceListWBParentCategoriesProcedure is the fully-qualified name of the CatalogService's
+	// ListWBParentCategories RPC.
+	CatalogServiceListWBParentCategoriesProcedure = "/api.v1.CatalogService/ListWBParentCategories"
+	// CatalogServiceSearchWBSubjectsProcedure is the fully-qualified name of the CatalogService's
+	// SearchWBSubjects RPC.
+	CatalogServiceSearchWBSubjectsProcedure = "/api.v1.CatalogService/SearchWBSubjects"
+	// CatalogServiceSearchWBDirectoryValuesProcedure is the fully-qualified name of the
+	// CatalogService's SearchWBDirectoryValues RPC.
+	CatalogServiceSearchWBDirectoryValuesProcedure = "/api.v1.CatalogService/SearchWBDirectoryValues"
 )
 
 // ProductServiceClient is a client for the api.v1.ProductService service.
 type ProductServiceClient interface {
 	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	// PublishVariant pushes a previously generated content variant to WB/Ozon without regenerating it
+	PublishVariant(context.Context, *connect.Request[v1.PublishVariantRequest]) (*connect.Response[v1.PublishVariantResponse], error)
+	// ListWBCards streams all WB cards of the seller matching the filters, one message per page
+	ListWBCards(context.Context, *connect.Request[v1.ListWBCardsRequest]) (*connect.ServerStreamForClient[v1.ListWBCardsResponse], error)
+	// UpdatePrices sets prices and discounts of existing WB cards and Ozon offers
+	UpdatePrices(context.Context, *connect.Request[v1.UpdatePricesRequest]) (*connect.Response[v1.UpdatePricesResponse], error)
+	// CrossList copies an existing WB card to Ozon or an existing Ozon product to WB
+	CrossList(context.Context, *connect.Request[v1.CrossListRequest]) (*connect.Response[v1.CrossListResponse], error)
+	// ListCategoryMappings lists the WB subjects and Ozon categories known to describe the same products
+	ListCategoryMappings(context.Context, *connect.Request[v1.ListCategoryMappingsRequest]) (*connect.Response[v1.ListCategoryMappingsResponse], error)
+	// OverrideCategoryMapping corrects the mapping of a WB subject for all future cards
+	OverrideCategoryMapping(context.Context, *connect.Request[v1.OverrideCategoryMappingRequest]) (*connect.Response[v1.OverrideCategoryMappingResponse], error)
 }
 
 // NewProductServiceClient constructs a client for the api.v1.ProductService service. By default, it
@@ -71,12 +135,54 @@ func NewProductServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(productServiceMethods.ByName("Create")),
 			connect.WithClientOptions(opts...),
 		),
+		publishVariant: connect.NewClient[v1.PublishVariantRequest, v1.PublishVariantResponse](
+			httpClient,
+			baseURL+ProductServicePublishVariantProcedure,
+			connect.WithSchema(productServiceMethods.ByName("PublishVariant")),
+			connect.WithClientOptions(opts...),
+		),
+		listWBCards: connect.NewClient[v1.ListWBCardsRequest, v1.ListWBCardsResponse](
+			httpClient,
+			baseURL+ProductServiceListWBCardsProcedure,
+			connect.WithSchema(productServiceMethods.ByName("ListWBCards")),
+			connect.WithClientOptions(opts...),
+		),
+		updatePrices: connect.NewClient[v1.UpdatePricesRequest, v1.UpdatePricesResponse](
+			httpClient,
+			baseURL+ProductServiceUpdatePricesProcedure,
+			connect.WithSchema(productServiceMethods.ByName("UpdatePrices")),
+			connect.WithClientOptions(opts...),
+		),
+		crossList: connect.NewClient[v1.CrossListRequest, v1.CrossListResponse](
+			httpClient,
+			baseURL+ProductServiceCrossListProcedure,
+			connect.WithSchema(productServiceMethods.ByName("CrossList")),
+			connect.WithClientOptions(opts...),
+		),
+		listCategoryMappings: connect.NewClient[v1.ListCategoryMappingsRequest, v1.ListCategoryMappingsResponse](
+			httpClient,
+			baseURL+ProductServiceListCategoryMappingsProcedure,
+			connect.WithSchema(productServiceMethods.ByName("ListCategoryMappings")),
+			connect.WithClientOptions(opts...),
+		),
+		overrideCategoryMapping: connect.NewClient[v1.OverrideCategoryMappingRequest, v1.OverrideCategoryMappingResponse](
+			httpClient,
+			baseURL+ProductServiceOverrideCategoryMappingProcedure,
+			connect.WithSchema(productServiceMethods.ByName("OverrideCategoryMapping")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // productServiceClient implements ProductServiceClient.
 type productServiceClient struct {
-	create *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	create                  *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	publishVariant          *connect.Client[v1.PublishVariantRequest, v1.PublishVariantResponse]
+	listWBCards             *connect.Client[v1.ListWBCardsRequest, v1.ListWBCardsResponse]
+	updatePrices            *connect.Client[v1.UpdatePricesRequest, v1.UpdatePricesResponse]
+	crossList               *connect.Client[v1.CrossListRequest, v1.CrossListResponse]
+	listCategoryMappings    *connect.Client[v1.ListCategoryMappingsRequest, v1.ListCategoryMappingsResponse]
+	overrideCategoryMapping *connect.Client[v1.OverrideCategoryMappingRequest, v1.OverrideCategoryMappingResponse]
 }
 
 // Create calls api.v1.ProductService.Create.
@@ -84,9 +190,51 @@ func (c *productServiceClient) Create(ctx context.Context, req *connect.Request[
 	return c.create.CallUnary(ctx, req)
 }
 
+// PublishVariant calls api.v1.ProductService.PublishVariant.
+func (c *productServiceClient) PublishVariant(ctx context.Context, req *connect.Request[v1.PublishVariantRequest]) (*connect.Response[v1.PublishVariantResponse], error) {
+	return c.publishVariant.CallUnary(ctx, req)
+}
+
+// ListWBCards calls api.v1.ProductService.ListWBCards.
+func (c *productServiceClient) ListWBCards(ctx context.Context, req *connect.Request[v1.ListWBCardsRequest]) (*connect.ServerStreamForClient[v1.ListWBCardsResponse], error) {
+	return c.listWBCards.CallServerStream(ctx, req)
+}
+
+// UpdatePrices calls api.v1.ProductService.UpdatePrices.
+func (c *productServiceClient) UpdatePrices(ctx context.Context, req *connect.Request[v1.UpdatePricesRequest]) (*connect.Response[v1.UpdatePricesResponse], error) {
+	return c.updatePrices.CallUnary(ctx, req)
+}
+
+// CrossList calls api.v1.ProductService.CrossList.
+func (c *productServiceClient) CrossList(ctx context.Context, req *connect.Request[v1.CrossListRequest]) (*connect.Response[v1.CrossListResponse], error) {
+	return c.crossList.CallUnary(ctx, req)
+}
+
+// ListCategoryMappings calls api.v1.ProductService.ListCategoryMappings.
+func (c *productServiceClient) ListCategoryMappings(ctx context.Context, req *connect.Request[v1.ListCategoryMappingsRequest]) (*connect.Response[v1.ListCategoryMappingsResponse], error) {
+	return c.listCategoryMappings.CallUnary(ctx, req)
+}
+
+// OverrideCategoryMapping calls api.v1.ProductService.OverrideCategoryMapping.
+func (c *productServiceClient) OverrideCategoryMapping(ctx context.Context, req *connect.Request[v1.OverrideCategoryMappingRequest]) (*connect.Response[v1.OverrideCategoryMappingResponse], error) {
+	return c.overrideCategoryMapping.CallUnary(ctx, req)
+}
+
 // ProductServiceHandler is an implementation of the api.v1.ProductService service.
 type ProductServiceHandler interface {
 	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	// PublishVariant pushes a previously generated content variant to WB/Ozon without regenerating it
+	PublishVariant(context.Context, *connect.Request[v1.PublishVariantRequest]) (*connect.Response[v1.PublishVariantResponse], error)
+	// ListWBCards streams all WB cards of the seller matching the filters, one message per page
+	ListWBCards(context.Context, *connect.Request[v1.ListWBCardsRequest], *connect.ServerStream[v1.ListWBCardsResponse]) error
+	// UpdatePrices sets prices and discounts of existing WB cards and Ozon offers
+	UpdatePrices(context.Context, *connect.Request[v1.UpdatePricesRequest]) (*connect.Response[v1.UpdatePricesResponse], error)
+	// CrossList copies an existing WB card to Ozon or an existing Ozon product to WB
+	CrossList(context.Context, *connect.Request[v1.CrossListRequest]) (*connect.Response[v1.CrossListResponse], error)
+	// ListCategoryMappings lists the WB subjects and Ozon categories known to describe the same products
+	ListCategoryMappings(context.Context, *connect.Request[v1.ListCategoryMappingsRequest]) (*connect.Response[v1.ListCategoryMappingsResponse], error)
+	// OverrideCategoryMapping corrects the mapping of a WB subject for all future cards
+	OverrideCategoryMapping(context.Context, *connect.Request[v1.OverrideCategoryMappingRequest]) (*connect.Response[v1.OverrideCategoryMappingResponse], error)
 }
 
 // NewProductServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -102,10 +250,58 @@ func NewProductServiceHandler(svc ProductServiceHandler, opts ...connect.Handler
 		connect.WithSchema(productServiceMethods.ByName("Create")),
 		connect.WithHandlerOptions(opts...),
 	)
+	productServicePublishVariantHandler := connect.NewUnaryHandler(
+		ProductServicePublishVariantProcedure,
+		svc.PublishVariant,
+		connect.WithSchema(productServiceMethods.ByName("PublishVariant")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceListWBCardsHandler := connect.NewServerStreamHandler(
+		ProductServiceListWBCardsProcedure,
+		svc.ListWBCards,
+		connect.WithSchema(productServiceMethods.ByName("ListWBCards")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceUpdatePricesHandler := connect.NewUnaryHandler(
+		ProductServiceUpdatePricesProcedure,
+		svc.UpdatePrices,
+		connect.WithSchema(productServiceMethods.ByName("UpdatePrices")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceCrossListHandler := connect.NewUnaryHandler(
+		ProductServiceCrossListProcedure,
+		svc.CrossList,
+		connect.WithSchema(productServiceMethods.ByName("CrossList")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceListCategoryMappingsHandler := connect.NewUnaryHandler(
+		ProductServiceListCategoryMappingsProcedure,
+		svc.ListCategoryMappings,
+		connect.WithSchema(productServiceMethods.ByName("ListCategoryMappings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceOverrideCategoryMappingHandler := connect.NewUnaryHandler(
+		ProductServiceOverrideCategoryMappingProcedure,
+		svc.OverrideCategoryMapping,
+		connect.WithSchema(productServiceMethods.ByName("OverrideCategoryMapping")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.ProductService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ProductServiceCreateProcedure:
 			productServiceCreateHandler.ServeHTTP(w, r)
+		case ProductServicePublishVariantProcedure:
+			productServicePublishVariantHandler.ServeHTTP(w, r)
+		case ProductServiceListWBCardsProcedure:
+			productServiceListWBCardsHandler.ServeHTTP(w, r)
+		case ProductServiceUpdatePricesProcedure:
+			productServiceUpdatePricesHandler.ServeHTTP(w, r)
+		case ProductServiceCrossListProcedure:
+			productServiceCrossListHandler.ServeHTTP(w, r)
+		case ProductServiceListCategoryMappingsProcedure:
+			productServiceListCategoryMappingsHandler.ServeHTTP(w, r)
+		case ProductServiceOverrideCategoryMappingProcedure:
+			productServiceOverrideCategoryMappingHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -119,6 +315,30 @@ func (UnimplementedProductServiceHandler) Create(context.Context, *connect.Reque
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.Create is not implemented"))
 }
 
+func (UnimplementedProductServiceHandler) PublishVariant(context.Context, *connect.Request[v1.PublishVariantRequest]) (*connect.Response[v1.PublishVariantResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.PublishVariant is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) ListWBCards(context.Context, *connect.Request[v1.ListWBCardsRequest], *connect.ServerStream[v1.ListWBCardsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.ListWBCards is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) UpdatePrices(context.Context, *connect.Request[v1.UpdatePricesRequest]) (*connect.Response[v1.UpdatePricesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.UpdatePrices is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) CrossList(context.Context, *connect.Request[v1.CrossListRequest]) (*connect.Response[v1.CrossListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.CrossList is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) ListCategoryMappings(context.Context, *connect.Request[v1.ListCategoryMappingsRequest]) (*connect.Response[v1.ListCategoryMappingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.ListCategoryMappings is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) OverrideCategoryMapping(context.Context, *connect.Request[v1.OverrideCategoryMappingRequest]) (*connect.Response[v1.OverrideCategoryMappingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.ProductService.OverrideCategoryMapping is not implemented"))
+}
+
 // BalanceServiceClient is a client for the api.v1.BalanceService service.
 type BalanceServiceClient interface {
 	GetBalance(context.Context, *connect.Request[v1.GetBalanceRequest]) (*connect.Response[v1.GetBalanceResponse], error)
@@ -284,3 +504,405 @@ func (UnimplementedPaymentServiceHandler) Payment(context.Context, *connect.Requ
 func (UnimplementedPaymentServiceHandler) TinkoffNotification(context.Context, *connect.Request[v1.TinkoffNotificationRequest]) (*connect.Response[v1.TinkoffNotificationResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.PaymentService.TinkoffNotification is not implemented"))
 }
+
+// MediaServiceClient is a client for the api.v1.MediaService service.
+type MediaServiceClient interface {
+	Upload(context.Context) *connect.ClientStreamForClient[v1.UploadMediaRequest, v1.UploadMediaResponse]
+}
+
+// NewMediaServiceClient constructs a client for the api.v1.MediaService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewMediaServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) MediaServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	mediaServiceMethods := v1.File_api_v1_product_proto.Services().ByName("MediaService").Methods()
+	return &mediaServiceClient{
+		upload: connect.NewClient[v1.UploadMediaRequest, v1.UploadMediaResponse](
+			httpClient,
+			baseURL+MediaServiceUploadProcedure,
+			connect.WithSchema(mediaServiceMethods.ByName("Upload")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// mediaServiceClient implements MediaServiceClient.
+type mediaServiceClient struct {
+	upload *connect.Client[v1.UploadMediaRequest, v1.UploadMediaResponse]
+}
+
+// Upload calls api.v1.MediaService.Upload.
+func (c *mediaServiceClient) Upload(ctx context.Context) *connect.ClientStreamForClient[v1.UploadMediaRequest, v1.UploadMediaResponse] {
+	return c.upload.CallClientStream(ctx)
+}
+
+// MediaServiceHandler is an implementation of the api.v1.MediaService service.
+type MediaServiceHandler interface {
+	Upload(context.Context, *connect.ClientStream[v1.UploadMediaRequest]) (*connect.Response[v1.UploadMediaResponse], error)
+}
+
+// NewMediaServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewMediaServiceHandler(svc MediaServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	mediaServiceMethods := v1.File_api_v1_product_proto.Services().ByName("MediaService").Methods()
+	mediaServiceUploadHandler := connect.NewClientStreamHandler(
+		MediaServiceUploadProcedure,
+		svc.Upload,
+		connect.WithSchema(mediaServiceMethods.ByName("Upload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.MediaService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case MediaServiceUploadProcedure:
+			mediaServiceUploadHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedMediaServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedMediaServiceHandler struct{}
+
+func (UnimplementedMediaServiceHandler) Upload(context.Context, *connect.ClientStream[v1.UploadMediaRequest]) (*connect.Response[v1.UploadMediaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.MediaService.Upload is not implemented"))
+}
+
+// StockServiceClient is a client for the api.v1.StockService service.
+type StockServiceClient interface {
+	ListWarehouses(context.Context, *connect.Request[v1.ListWarehousesRequest]) (*connect.Response[v1.ListWarehousesResponse], error)
+	SetStocks(context.Context, *connect.Request[v1.SetStocksRequest]) (*connect.Response[v1.SetStocksResponse], error)
+	// SetStockSyncSettings configures the periodic stock sync of the account
+	SetStockSyncSettings(context.Context, *connect.Request[v1.SetStockSyncSettingsRequest]) (*connect.Response[v1.SetStockSyncSettingsResponse], error)
+	SetStockSyncMappings(context.Context, *connect.Request[v1.SetStockSyncMappingsRequest]) (*connect.Response[v1.SetStockSyncMappingsResponse], error)
+	// RunStockSync syncs the stocks of the account now
+	RunStockSync(context.Context, *connect.Request[v1.RunStockSyncRequest]) (*connect.Response[v1.StockSyncReportResponse], error)
+	// GetStockSyncReport returns the report of the last sync of the account
+	GetStockSyncReport(context.Context, *connect.Request[v1.GetStockSyncReportRequest]) (*connect.Response[v1.StockSyncReportResponse], error)
+}
+
+// NewStockServiceClient constructs a client for the api.v1.StockService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewStockServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) StockServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	stockServiceMethods := v1.File_api_v1_product_proto.Services().ByName("StockService").Methods()
+	return &stockServiceClient{
+		listWarehouses: connect.NewClient[v1.ListWarehousesRequest, v1.ListWarehousesResponse](
+			httpClient,
+			baseURL+StockServiceListWarehousesProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("ListWarehouses")),
+			connect.WithClientOptions(opts...),
+		),
+		setStocks: connect.NewClient[v1.SetStocksRequest, v1.SetStocksResponse](
+			httpClient,
+			baseURL+StockServiceSetStocksProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("SetStocks")),
+			connect.WithClientOptions(opts...),
+		),
+		setStockSyncSettings: connect.NewClient[v1.SetStockSyncSettingsRequest, v1.SetStockSyncSettingsResponse](
+			httpClient,
+			baseURL+StockServiceSetStockSyncSettingsProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("SetStockSyncSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		setStockSyncMappings: connect.NewClient[v1.SetStockSyncMappingsRequest, v1.SetStockSyncMappingsResponse](
+			httpClient,
+			baseURL+StockServiceSetStockSyncMappingsProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("SetStockSyncMappings")),
+			connect.WithClientOptions(opts...),
+		),
+		runStockSync: connect.NewClient[v1.RunStockSyncRequest, v1.StockSyncReportResponse](
+			httpClient,
+			baseURL+StockServiceRunStockSyncProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("RunStockSync")),
+			connect.WithClientOptions(opts...),
+		),
+		getStockSyncReport: connect.NewClient[v1.GetStockSyncReportRequest, v1.StockSyncReportResponse](
+			httpClient,
+			baseURL+StockServiceGetStockSyncReportProcedure,
+			connect.WithSchema(stockServiceMethods.ByName("GetStockSyncReport")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// stockServiceClient implements StockServiceClient.
+type stockServiceClient struct {
+	listWarehouses       *connect.Client[v1.ListWarehousesRequest, v1.ListWarehousesResponse]
+	setStocks            *connect.Client[v1.SetStocksRequest, v1.SetStocksResponse]
+	setStockSyncSettings *connect.Client[v1.SetStockSyncSettingsRequest, v1.SetStockSyncSettingsResponse]
+	setStockSyncMappings *connect.Client[v1.SetStockSyncMappingsRequest, v1.SetStockSyncMappingsResponse]
+	runStockSync         *connect.Client[v1.RunStockSyncRequest, v1.StockSyncReportResponse]
+	getStockSyncReport   *connect.Client[v1.GetStockSyncReportRequest, v1.StockSyncReportResponse]
+}
+
+// ListWarehouses calls api.v1.StockService.ListWarehouses.
+func (c *stockServiceClient) ListWarehouses(ctx context.Context, req *connect.Request[v1.ListWarehousesRequest]) (*connect.Response[v1.ListWarehousesResponse], error) {
+	return c.listWarehouses.CallUnary(ctx, req)
+}
+
+// SetStocks calls api.v1.StockService.SetStocks.
+func (c *stockServiceClient) SetStocks(ctx context.Context, req *connect.Request[v1.SetStocksRequest]) (*connect.Response[v1.SetStocksResponse], error) {
+	return c.setStocks.CallUnary(ctx, req)
+}
+
+// SetStockSyncSettings calls api.v1.StockService.SetStockSyncSettings.
+func (c *stockServiceClient) SetStockSyncSettings(ctx context.Context, req *connect.Request[v1.SetStockSyncSettingsRequest]) (*connect.Response[v1.SetStockSyncSettingsResponse], error) {
+	return c.setStockSyncSettings.CallUnary(ctx, req)
+}
+
+// SetStockSyncMappings calls api.v1.StockService.SetStockSyncMappings.
+func (c *stockServiceClient) SetStockSyncMappings(ctx context.Context, req *connect.Request[v1.SetStockSyncMappingsRequest]) (*connect.Response[v1.SetStockSyncMappingsResponse], error) {
+	return c.setStockSyncMappings.CallUnary(ctx, req)
+}
+
+// RunStockSync calls api.v1.StockService.RunStockSync.
+func (c *stockServiceClient) RunStockSync(ctx context.Context, req *connect.Request[v1.RunStockSyncRequest]) (*connect.Response[v1.StockSyncReportResponse], error) {
+	return c.runStockSync.CallUnary(ctx, req)
+}
+
+// GetStockSyncReport calls api.v1.StockService.GetStockSyncReport.
+func (c *stockServiceClient) GetStockSyncReport(ctx context.Context, req *connect.Request[v1.GetStockSyncReportRequest]) (*connect.Response[v1.StockSyncReportResponse], error) {
+	return c.getStockSyncReport.CallUnary(ctx, req)
+}
+
+// StockServiceHandler is an implementation of the api.v1.StockService service.
+type StockServiceHandler interface {
+	ListWarehouses(context.Context, *connect.Request[v1.ListWarehousesRequest]) (*connect.Response[v1.ListWarehousesResponse], error)
+	SetStocks(context.Context, *connect.Request[v1.SetStocksRequest]) (*connect.Response[v1.SetStocksResponse], error)
+	// SetStockSyncSettings configures the periodic stock sync of the account
+	SetStockSyncSettings(context.Context, *connect.Request[v1.SetStockSyncSettingsRequest]) (*connect.Response[v1.SetStockSyncSettingsResponse], error)
+	SetStockSyncMappings(context.Context, *connect.Request[v1.SetStockSyncMappingsRequest]) (*connect.Response[v1.SetStockSyncMappingsResponse], error)
+	// RunStockSync syncs the stocks of the account now
+	RunStockSync(context.Context, *connect.Request[v1.RunStockSyncRequest]) (*connect.Response[v1.StockSyncReportResponse], error)
+	// GetStockSyncReport returns the report of the last sync of the account
+	GetStockSyncReport(context.Context, *connect.Request[v1.GetStockSyncReportRequest]) (*connect.Response[v1.StockSyncReportResponse], error)
+}
+
+// NewStockServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewStockServiceHandler(svc StockServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	stockServiceMethods := v1.File_api_v1_product_proto.Services().ByName("StockService").Methods()
+	stockServiceListWarehousesHandler := connect.NewUnaryHandler(
+		StockServiceListWarehousesProcedure,
+		svc.ListWarehouses,
+		connect.WithSchema(stockServiceMethods.ByName("ListWarehouses")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stockServiceSetStocksHandler := connect.NewUnaryHandler(
+		StockServiceSetStocksProcedure,
+		svc.SetStocks,
+		connect.WithSchema(stockServiceMethods.ByName("SetStocks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stockServiceSetStockSyncSettingsHandler := connect.NewUnaryHandler(
+		StockServiceSetStockSyncSettingsProcedure,
+		svc.SetStockSyncSettings,
+		connect.WithSchema(stockServiceMethods.ByName("SetStockSyncSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stockServiceSetStockSyncMappingsHandler := connect.NewUnaryHandler(
+		StockServiceSetStockSyncMappingsProcedure,
+		svc.SetStockSyncMappings,
+		connect.WithSchema(stockServiceMethods.ByName("SetStockSyncMappings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stockServiceRunStockSyncHandler := connect.NewUnaryHandler(
+		StockServiceRunStockSyncProcedure,
+		svc.RunStockSync,
+		connect.WithSchema(stockServiceMethods.ByName("RunStockSync")),
+		connect.WithHandlerOptions(opts...),
+	)
+	stockServiceGetStockSyncReportHandler := connect.NewUnaryHandler(
+		StockServiceGetStockSyncReportProcedure,
+		svc.GetStockSyncReport,
+		connect.WithSchema(stockServiceMethods.ByName("GetStockSyncReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.StockService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StockServiceListWarehousesProcedure:
+			stockServiceListWarehousesHandler.ServeHTTP(w, r)
+		case StockServiceSetStocksProcedure:
+			stockServiceSetStocksHandler.ServeHTTP(w, r)
+		case StockServiceSetStockSyncSettingsProcedure:
+			stockServiceSetStockSyncSettingsHandler.ServeHTTP(w, r)
+		case StockServiceSetStockSyncMappingsProcedure:
+			stockServiceSetStockSyncMappingsHandler.ServeHTTP(w, r)
+		case StockServiceRunStockSyncProcedure:
+			stockServiceRunStockSyncHandler.ServeHTTP(w, r)
+		case StockServiceGetStockSyncReportProcedure:
+			stockServiceGetStockSyncReportHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedStockServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedStockServiceHandler struct{}
+
+func (UnimplementedStockServiceHandler) ListWarehouses(context.Context, *connect.Request[v1.ListWarehousesRequest]) (*connect.Response[v1.ListWarehousesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.ListWarehouses is not implemented"))
+}
+
+func (UnimplementedStockServiceHandler) SetStocks(context.Context, *connect.Request[v1.SetStocksRequest]) (*connect.Response[v1.SetStocksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.SetStocks is not implemented"))
+}
+
+func (UnimplementedStockServiceHandler) SetStockSyncSettings(context.Context, *connect.Request[v1.SetStockSyncSettingsRequest]) (*connect.Response[v1.SetStockSyncSettingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.SetStockSyncSettings is not implemented"))
+}
+
+func (UnimplementedStockServiceHandler) SetStockSyncMappings(context.Context, *connect.Request[v1.SetStockSyncMappingsRequest]) (*connect.Response[v1.SetStockSyncMappingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.SetStockSyncMappings is not implemented"))
+}
+
+func (UnimplementedStockServiceHandler) RunStockSync(context.Context, *connect.Request[v1.RunStockSyncRequest]) (*connect.Response[v1.StockSyncReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.RunStockSync is not implemented"))
+}
+
+func (UnimplementedStockServiceHandler) GetStockSyncReport(context.Context, *connect.Request[v1.GetStockSyncReportRequest]) (*connect.Response[v1.StockSyncReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.StockService.GetStockSyncReport is not implemented"))
+}
+
+// CatalogServiceClient is a client for the api.v1.CatalogService service.
+type CatalogServiceClient interface {
+	ListWBParentCategories(context.Context, *connect.Request[v1.ListWBParentCategoriesRequest]) (*connect.Response[v1.ListWBParentCategoriesResponse], error)
+	// SearchWBSubjects searches the WB subjects to pick the category of a card
+	SearchWBSubjects(context.Context, *connect.Request[v1.SearchWBSubjectsRequest]) (*connect.Response[v1.SearchWBSubjectsResponse], error)
+	// SearchWBDirectoryValues searches the allowed values of a WB characteristic directory
+	SearchWBDirectoryValues(context.Context, *connect.Request[v1.SearchWBDirectoryValuesRequest]) (*connect.Response[v1.SearchWBDirectoryValuesResponse], error)
+}
+
+// NewCatalogServiceClient constructs a client for the api.v1.CatalogService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCatalogServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CatalogServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	catalogServiceMethods := v1.File_api_v1_product_proto.Services().ByName("CatalogService").Methods()
+	return &catalogServiceClient{
+		listWBParentCategories: connect.NewClient[v1.ListWBParentCategoriesRequest, v1.ListWBParentCategoriesResponse](
+			httpClient,
+			baseURL+CatalogServiceListWBParentCategoriesProcedure,
+			connect.WithSchema(catalogServiceMethods.ByName("ListWBParentCategories")),
+			connect.WithClientOptions(opts...),
+		),
+		searchWBSubjects: connect.NewClient[v1.SearchWBSubjectsRequest, v1.SearchWBSubjectsResponse](
+			httpClient,
+			baseURL+CatalogServiceSearchWBSubjectsProcedure,
+			connect.WithSchema(catalogServiceMethods.ByName("SearchWBSubjects")),
+			connect.WithClientOptions(opts...),
+		),
+		searchWBDirectoryValues: connect.NewClient[v1.SearchWBDirectoryValuesRequest, v1.SearchWBDirectoryValuesResponse](
+			httpClient,
+			baseURL+CatalogServiceSearchWBDirectoryValuesProcedure,
+			connect.WithSchema(catalogServiceMethods.ByName("SearchWBDirectoryValues")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// catalogServiceClient implements CatalogServiceClient.
+type catalogServiceClient struct {
+	listWBParentCategories  *connect.Client[v1.ListWBParentCategoriesRequest, v1.ListWBParentCategoriesResponse]
+	searchWBSubjects        *connect.Client[v1.SearchWBSubjectsRequest, v1.SearchWBSubjectsResponse]
+	searchWBDirectoryValues *connect.Client[v1.SearchWBDirectoryValuesRequest, v1.SearchWBDirectoryValuesResponse]
+}
+
+// ListWBParentCategories calls api.v1.CatalogService.ListWBParentCategories.
+func (c *catalogServiceClient) ListWBParentCategories(ctx context.Context, req *connect.Request[v1.ListWBParentCategoriesRequest]) (*connect.Response[v1.ListWBParentCategoriesResponse], error) {
+	return c.listWBParentCategories.CallUnary(ctx, req)
+}
+
+// SearchWBSubjects calls api.v1.CatalogService.SearchWBSubjects.
+func (c *catalogServiceClient) SearchWBSubjects(ctx context.Context, req *connect.Request[v1.SearchWBSubjectsRequest]) (*connect.Response[v1.SearchWBSubjectsResponse], error) {
+	return c.searchWBSubjects.CallUnary(ctx, req)
+}
+
+// SearchWBDirectoryValues calls api.v1.CatalogService.SearchWBDirectoryValues.
+func (c *catalogServiceClient) SearchWBDirectoryValues(ctx context.Context, req *connect.Request[v1.SearchWBDirectoryValuesRequest]) (*connect.Response[v1.SearchWBDirectoryValuesResponse], error) {
+	return c.searchWBDirectoryValues.CallUnary(ctx, req)
+}
+
+// CatalogServiceHandler is an implementation of the api.v1.CatalogService service.
+type CatalogServiceHandler interface {
+	ListWBParentCategories(context.Context, *connect.Request[v1.ListWBParentCategoriesRequest]) (*connect.Response[v1.ListWBParentCategoriesResponse], error)
+	// SearchWBSubjects searches the WB subjects to pick the category of a card
+	SearchWBSubjects(context.Context, *connect.Request[v1.SearchWBSubjectsRequest]) (*connect.Response[v1.SearchWBSubjectsResponse], error)
+	// SearchWBDirectoryValues searches the allowed values of a WB characteristic directory
+	SearchWBDirectoryValues(context.Context, *connect.Request[v1.SearchWBDirectoryValuesRequest]) (*connect.Response[v1.SearchWBDirectoryValuesResponse], error)
+}
+
+// NewCatalogServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCatalogServiceHandler(svc CatalogServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	catalogServiceMethods := v1.File_api_v1_product_proto.Services().ByName("CatalogService").Methods()
+	catalogServiceListWBParentCategoriesHandler := connect.NewUnaryHandler(
+		CatalogServiceListWBParentCategoriesProcedure,
+		svc.ListWBParentCategories,
+		connect.WithSchema(catalogServiceMethods.ByName("ListWBParentCategories")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catalogServiceSearchWBSubjectsHandler := connect.NewUnaryHandler(
+		CatalogServiceSearchWBSubjectsProcedure,
+		svc.SearchWBSubjects,
+		connect.WithSchema(catalogServiceMethods.ByName("SearchWBSubjects")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catalogServiceSearchWBDirectoryValuesHandler := connect.NewUnaryHandler(
+		CatalogServiceSearchWBDirectoryValuesProcedure,
+		svc.SearchWBDirectoryValues,
+		connect.WithSchema(catalogServiceMethods.ByName("SearchWBDirectoryValues")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.CatalogService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CatalogServiceListWBParentCategoriesProcedure:
+			catalogServiceListWBParentCategoriesHandler.ServeHTTP(w, r)
+		case CatalogServiceSearchWBSubjectsProcedure:
+			catalogServiceSearchWBSubjectsHandler.ServeHTTP(w, r)
+		case CatalogServiceSearchWBDirectoryValuesProcedure:
+			catalogServiceSearchWBDirectoryValuesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCatalogServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCatalogServiceHandler struct{}
+
+func (UnimplementedCatalogServiceHandler) ListWBParentCategories(context.Context, *connect.Request[v1.ListWBParentCategoriesRequest]) (*connect.Response[v1.ListWBParentCategoriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CatalogService.ListWBParentCategories is not implemented"))
+}
+
+func (UnimplementedCatalogServiceHandler) SearchWBSubjects(context.Context, *connect.Request[v1.SearchWBSubjectsRequest]) (*connect.Response[v1.SearchWBSubjectsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CatalogService.SearchWBSubjects is not implemented"))
+}
+
+func (UnimplementedCatalogServiceHandler) SearchWBDirectoryValues(context.Context, *connect.Request[v1.SearchWBDirectoryValuesRequest]) (*connect.Response[v1.SearchWBDirectoryValuesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.CatalogService.SearchWBDirectoryValues is not implemented"))
+}
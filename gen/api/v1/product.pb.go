@@ -21,31 +21,310 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ContentValidationMode int32
+
+const (
+	ContentValidationMode_CONTENT_VALIDATION_MODE_UNSPECIFIED ContentValidationMode = 0 // Same as truncate
+	ContentValidationMode_CONTENT_VALIDATION_MODE_TRUNCATE    ContentValidationMode = 1 // Fix the content: cut at word/sentence boundary, strip HTML, forbidden characters and stop-words
+	ContentValidationMode_CONTENT_VALIDATION_MODE_REGENERATE  ContentValidationMode = 2 // Regenerate once with the violations as feedback, then fix what is left
+	ContentValidationMode_CONTENT_VALIDATION_MODE_REJECT      ContentValidationMode = 3 // Fail with ContentValidationError details before any marketplace call
+)
+
+// Enum value maps for ContentValidationMode.
+var (
+	ContentValidationMode_name = map[int32]string{
+		0: "CONTENT_VALIDATION_MODE_UNSPECIFIED",
+		1: "CONTENT_VALIDATION_MODE_TRUNCATE",
+		2: "CONTENT_VALIDATION_MODE_REGENERATE",
+		3: "CONTENT_VALIDATION_MODE_REJECT",
+	}
+	ContentValidationMode_value = map[string]int32{
+		"CONTENT_VALIDATION_MODE_UNSPECIFIED": 0,
+		"CONTENT_VALIDATION_MODE_TRUNCATE":    1,
+		"CONTENT_VALIDATION_MODE_REGENERATE":  2,
+		"CONTENT_VALIDATION_MODE_REJECT":      3,
+	}
+)
+
+func (x ContentValidationMode) Enum() *ContentValidationMode {
+	p := new(ContentValidationMode)
+	*p = x
+	return p
+}
+
+func (x ContentValidationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentValidationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_product_proto_enumTypes[0].Descriptor()
+}
+
+func (ContentValidationMode) Type() protoreflect.EnumType {
+	return &file_api_v1_product_proto_enumTypes[0]
+}
+
+func (x ContentValidationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentValidationMode.Descriptor instead.
+func (ContentValidationMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+type ContentProvider int32
+
+const (
+	ContentProvider_CONTENT_PROVIDER_UNSPECIFIED   ContentProvider = 0
+	ContentProvider_CONTENT_PROVIDER_CARD_CRAFT_AI ContentProvider = 1
+	ContentProvider_CONTENT_PROVIDER_OPENAI        ContentProvider = 2 // Any OpenAI-compatible chat completions endpoint
+)
+
+// Enum value maps for ContentProvider.
+var (
+	ContentProvider_name = map[int32]string{
+		0: "CONTENT_PROVIDER_UNSPECIFIED",
+		1: "CONTENT_PROVIDER_CARD_CRAFT_AI",
+		2: "CONTENT_PROVIDER_OPENAI",
+	}
+	ContentProvider_value = map[string]int32{
+		"CONTENT_PROVIDER_UNSPECIFIED":   0,
+		"CONTENT_PROVIDER_CARD_CRAFT_AI": 1,
+		"CONTENT_PROVIDER_OPENAI":        2,
+	}
+)
+
+func (x ContentProvider) Enum() *ContentProvider {
+	p := new(ContentProvider)
+	*p = x
+	return p
+}
+
+func (x ContentProvider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentProvider) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_product_proto_enumTypes[1].Descriptor()
+}
+
+func (ContentProvider) Type() protoreflect.EnumType {
+	return &file_api_v1_product_proto_enumTypes[1]
+}
+
+func (x ContentProvider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentProvider.Descriptor instead.
+func (ContentProvider) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+type Marketplace int32
+
+const (
+	Marketplace_MARKETPLACE_UNSPECIFIED Marketplace = 0
+	Marketplace_MARKETPLACE_WB          Marketplace = 1
+	Marketplace_MARKETPLACE_OZON        Marketplace = 2
+)
+
+// Enum value maps for Marketplace.
+var (
+	Marketplace_name = map[int32]string{
+		0: "MARKETPLACE_UNSPECIFIED",
+		1: "MARKETPLACE_WB",
+		2: "MARKETPLACE_OZON",
+	}
+	Marketplace_value = map[string]int32{
+		"MARKETPLACE_UNSPECIFIED": 0,
+		"MARKETPLACE_WB":          1,
+		"MARKETPLACE_OZON":        2,
+	}
+)
+
+func (x Marketplace) Enum() *Marketplace {
+	p := new(Marketplace)
+	*p = x
+	return p
+}
+
+func (x Marketplace) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Marketplace) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_product_proto_enumTypes[2].Descriptor()
+}
+
+func (Marketplace) Type() protoreflect.EnumType {
+	return &file_api_v1_product_proto_enumTypes[2]
+}
+
+func (x Marketplace) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Marketplace.Descriptor instead.
+func (Marketplace) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{2}
+}
+
+type MediaKind int32
+
+const (
+	MediaKind_MEDIA_KIND_UNSPECIFIED  MediaKind = 0 // Treated as photo
+	MediaKind_MEDIA_KIND_PHOTO        MediaKind = 1
+	MediaKind_MEDIA_KIND_VIDEO        MediaKind = 2
+	MediaKind_MEDIA_KIND_IMAGE_360    MediaKind = 3 // One frame of a 360-degree set (Ozon only)
+	MediaKind_MEDIA_KIND_COLOR_SWATCH MediaKind = 4 // Color sample image (Ozon only)
+)
+
+// Enum value maps for MediaKind.
+var (
+	MediaKind_name = map[int32]string{
+		0: "MEDIA_KIND_UNSPECIFIED",
+		1: "MEDIA_KIND_PHOTO",
+		2: "MEDIA_KIND_VIDEO",
+		3: "MEDIA_KIND_IMAGE_360",
+		4: "MEDIA_KIND_COLOR_SWATCH",
+	}
+	MediaKind_value = map[string]int32{
+		"MEDIA_KIND_UNSPECIFIED":  0,
+		"MEDIA_KIND_PHOTO":        1,
+		"MEDIA_KIND_VIDEO":        2,
+		"MEDIA_KIND_IMAGE_360":    3,
+		"MEDIA_KIND_COLOR_SWATCH": 4,
+	}
+)
+
+func (x MediaKind) Enum() *MediaKind {
+	p := new(MediaKind)
+	*p = x
+	return p
+}
+
+func (x MediaKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_product_proto_enumTypes[3].Descriptor()
+}
+
+func (MediaKind) Type() protoreflect.EnumType {
+	return &file_api_v1_product_proto_enumTypes[3]
+}
+
+func (x MediaKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MediaKind.Descriptor instead.
+func (MediaKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+// Catalog messages: the local mirror of the WB category tree and directories
+type WBDirectory int32
+
+const (
+	WBDirectory_WB_DIRECTORY_UNSPECIFIED WBDirectory = 0
+	WBDirectory_WB_DIRECTORY_COLORS      WBDirectory = 1
+	WBDirectory_WB_DIRECTORY_KINDS       WBDirectory = 2 // Пол
+	WBDirectory_WB_DIRECTORY_COUNTRIES   WBDirectory = 3
+	WBDirectory_WB_DIRECTORY_SEASONS     WBDirectory = 4
+	WBDirectory_WB_DIRECTORY_VAT         WBDirectory = 5
+	WBDirectory_WB_DIRECTORY_TNVED       WBDirectory = 6 // Codes of a subject, subject_id is required
+)
+
+// Enum value maps for WBDirectory.
+var (
+	WBDirectory_name = map[int32]string{
+		0: "WB_DIRECTORY_UNSPECIFIED",
+		1: "WB_DIRECTORY_COLORS",
+		2: "WB_DIRECTORY_KINDS",
+		3: "WB_DIRECTORY_COUNTRIES",
+		4: "WB_DIRECTORY_SEASONS",
+		5: "WB_DIRECTORY_VAT",
+		6: "WB_DIRECTORY_TNVED",
+	}
+	WBDirectory_value = map[string]int32{
+		"WB_DIRECTORY_UNSPECIFIED": 0,
+		"WB_DIRECTORY_COLORS":      1,
+		"WB_DIRECTORY_KINDS":       2,
+		"WB_DIRECTORY_COUNTRIES":   3,
+		"WB_DIRECTORY_SEASONS":     4,
+		"WB_DIRECTORY_VAT":         5,
+		"WB_DIRECTORY_TNVED":       6,
+	}
+)
+
+func (x WBDirectory) Enum() *WBDirectory {
+	p := new(WBDirectory)
+	*p = x
+	return p
+}
+
+func (x WBDirectory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WBDirectory) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_product_proto_enumTypes[4].Descriptor()
+}
+
+func (WBDirectory) Type() protoreflect.EnumType {
+	return &file_api_v1_product_proto_enumTypes[4]
+}
+
+func (x WBDirectory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WBDirectory.Descriptor instead.
+func (WBDirectory) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{4}
+}
+
 // ProductRequest represents the input with the 5 required fields
 type CreateRequest struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	ProductTitle         string                 `protobuf:"bytes,1,opt,name=product_title,json=productTitle,proto3" json:"product_title,omitempty"`
-	ProductDescription   string                 `protobuf:"bytes,2,opt,name=product_description,json=productDescription,proto3" json:"product_description,omitempty"`
-	ParentId             int32                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	SubjectId            int32                  `protobuf:"varint,4,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
-	RootId               int32                  `protobuf:"varint,5,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
-	SubId                int32                  `protobuf:"varint,6,opt,name=sub_id,json=subId,proto3" json:"sub_id,omitempty"`
-	TypeId               int32                  `protobuf:"varint,7,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
-	GenerateContent      bool                   `protobuf:"varint,8,opt,name=generate_content,json=generateContent,proto3" json:"generate_content,omitempty"`
-	Ozon                 bool                   `protobuf:"varint,9,opt,name=ozon,proto3" json:"ozon,omitempty"`
-	Wb                   bool                   `protobuf:"varint,10,opt,name=wb,proto3" json:"wb,omitempty"`
-	Translate            bool                   `protobuf:"varint,11,opt,name=translate,proto3" json:"translate,omitempty"`
-	VendorCode           string                 `protobuf:"bytes,12,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`
-	Dimensions           *Dimensions            `protobuf:"bytes,13,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
-	Brand                string                 `protobuf:"bytes,14,opt,name=brand,proto3" json:"brand,omitempty"`
-	Sizes                []*Size                `protobuf:"bytes,15,rep,name=sizes,proto3" json:"sizes,omitempty"`
-	WbApiKey             string                 `protobuf:"bytes,16,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`                                         // API key for Wildberries, provided by user
-	WbMediaToUploadFiles []*WBMediaFileToUpload `protobuf:"bytes,17,rep,name=wb_media_to_upload_files,json=wbMediaToUploadFiles,proto3" json:"wb_media_to_upload_files,omitempty"` // List of files to upload
-	WbMediaToSaveLinks   []string               `protobuf:"bytes,18,rep,name=wb_media_to_save_links,json=wbMediaToSaveLinks,proto3" json:"wb_media_to_save_links,omitempty"`       // List of URLs for media_save
-	OzonApiClientId      string                 `protobuf:"bytes,19,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`                  // Client ID for Ozon API
-	OzonApiKey           string                 `protobuf:"bytes,20,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`                                   // API Key for Ozon API
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ProductTitle          string                 `protobuf:"bytes,1,opt,name=product_title,json=productTitle,proto3" json:"product_title,omitempty"`
+	ProductDescription    string                 `protobuf:"bytes,2,opt,name=product_description,json=productDescription,proto3" json:"product_description,omitempty"`
+	ParentId              int32                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	SubjectId             int32                  `protobuf:"varint,4,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	RootId                int32                  `protobuf:"varint,5,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	SubId                 int32                  `protobuf:"varint,6,opt,name=sub_id,json=subId,proto3" json:"sub_id,omitempty"`
+	TypeId                int32                  `protobuf:"varint,7,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	GenerateContent       bool                   `protobuf:"varint,8,opt,name=generate_content,json=generateContent,proto3" json:"generate_content,omitempty"`
+	Ozon                  bool                   `protobuf:"varint,9,opt,name=ozon,proto3" json:"ozon,omitempty"`
+	Wb                    bool                   `protobuf:"varint,10,opt,name=wb,proto3" json:"wb,omitempty"`
+	Translate             bool                   `protobuf:"varint,11,opt,name=translate,proto3" json:"translate,omitempty"`
+	VendorCode            string                 `protobuf:"bytes,12,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`
+	Dimensions            *Dimensions            `protobuf:"bytes,13,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Brand                 string                 `protobuf:"bytes,14,opt,name=brand,proto3" json:"brand,omitempty"`
+	Sizes                 []*Size                `protobuf:"bytes,15,rep,name=sizes,proto3" json:"sizes,omitempty"`
+	WbApiKey              string                 `protobuf:"bytes,16,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`                                                                           // API key for Wildberries, provided by user
+	WbMediaToUploadFiles  []*WBMediaFileToUpload `protobuf:"bytes,17,rep,name=wb_media_to_upload_files,json=wbMediaToUploadFiles,proto3" json:"wb_media_to_upload_files,omitempty"`                                   // List of files to upload
+	WbMediaToSaveLinks    []string               `protobuf:"bytes,18,rep,name=wb_media_to_save_links,json=wbMediaToSaveLinks,proto3" json:"wb_media_to_save_links,omitempty"`                                         // List of URLs for media_save
+	OzonApiClientId       string                 `protobuf:"bytes,19,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`                                                    // Client ID for Ozon API
+	OzonApiKey            string                 `protobuf:"bytes,20,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`                                                                     // API Key for Ozon API
+	Media                 []*MediaReference      `protobuf:"bytes,21,rep,name=media,proto3" json:"media,omitempty"`                                                                                                   // Media previously uploaded via MediaService.Upload
+	ResolveCategories     []Marketplace          `protobuf:"varint,22,rep,packed,name=resolve_categories,json=resolveCategories,proto3,enum=api.v1.Marketplace" json:"resolve_categories,omitempty"`                  // Marketplaces whose categories CardCraftAI should resolve; defaults to WB and, if ozon is true, Ozon
+	ForceRegenerate       bool                   `protobuf:"varint,23,opt,name=force_regenerate,json=forceRegenerate,proto3" json:"force_regenerate,omitempty"`                                                       // Bypass the cache of previously generated content
+	ContentProvider       ContentProvider        `protobuf:"varint,24,opt,name=content_provider,json=contentProvider,proto3,enum=api.v1.ContentProvider" json:"content_provider,omitempty"`                           // Content generator; defaults to the account setting, then the server default
+	ContentVariants       int32                  `protobuf:"varint,25,opt,name=content_variants,json=contentVariants,proto3" json:"content_variants,omitempty"`                                                       // Number of title/description alternatives to generate (1 if unset); the first one is published
+	ContentValidationMode ContentValidationMode  `protobuf:"varint,26,opt,name=content_validation_mode,json=contentValidationMode,proto3,enum=api.v1.ContentValidationMode" json:"content_validation_mode,omitempty"` // What to do when the content breaks marketplace constraints
+	DryRun                *bool                  `protobuf:"varint,27,opt,name=dry_run,json=dryRun,proto3,oneof" json:"dry_run,omitempty"`                                                                            // Prepare marketplace requests without sending them; defaults to true in development environments
+	ImtId                 int64                  `protobuf:"varint,28,opt,name=imt_id,json=imtId,proto3" json:"imt_id,omitempty"`                                                                                     // Existing WB card group (imtID) to add the product to as a new variant instead of creating a new card
+	ModelName             string                 `protobuf:"bytes,29,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`                                                                          // Ozon model name (attribute 9048) merging offers into one product card; defaults to vendor_code (of the first variant)
+	Variants              []*ProductVariant      `protobuf:"bytes,30,rep,name=variants,proto3" json:"variants,omitempty"`                                                                                             // Colors of the product: WB variants of one card and Ozon offers merged by model name; replace vendor_code, sizes must not be set
+	WbDiscount            int32                  `protobuf:"varint,31,opt,name=wb_discount,json=wbDiscount,proto3" json:"wb_discount,omitempty"`                                                                      // WB discount in percent, set together with the size price once the card is created
+	InitialStock          *InitialStock          `protobuf:"bytes,32,opt,name=initial_stock,json=initialStock,proto3" json:"initial_stock,omitempty"`                                                                 // Stock set for every size once the marketplaces have created the card
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateRequest) Reset() {
@@ -218,135 +497,115 @@ func (x *CreateRequest) GetOzonApiKey() string {
 	return ""
 }
 
-type Dimensions struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	Length       int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"` // For WB compatibility
-	Width        int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
-	Height       int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
-	WeightBrutto float64                `protobuf:"fixed64,4,opt,name=weight_brutto,json=weightBrutto,proto3" json:"weight_brutto,omitempty"` // For WB compatibility
-	// Ozon specific fields
-	Depth         int32  `protobuf:"varint,5,opt,name=depth,proto3" json:"depth,omitempty"`                                     // Ozon depth (can be same as length)
-	DimensionUnit string `protobuf:"bytes,6,opt,name=dimension_unit,json=dimensionUnit,proto3" json:"dimension_unit,omitempty"` // Ozon dimension unit (e.g., "mm")
-	Weight        int32  `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`                                   // Ozon weight (can be same as weight_brutto)
-	WeightUnit    string `protobuf:"bytes,8,opt,name=weight_unit,json=weightUnit,proto3" json:"weight_unit,omitempty"`          // Ozon weight unit (e.g., "g")
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Dimensions) Reset() {
-	*x = Dimensions{}
-	mi := &file_api_v1_product_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateRequest) GetMedia() []*MediaReference {
+	if x != nil {
+		return x.Media
+	}
+	return nil
 }
 
-func (x *Dimensions) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateRequest) GetResolveCategories() []Marketplace {
+	if x != nil {
+		return x.ResolveCategories
+	}
+	return nil
 }
 
-func (*Dimensions) ProtoMessage() {}
-
-func (x *Dimensions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[1]
+func (x *CreateRequest) GetForceRegenerate() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ForceRegenerate
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
-func (*Dimensions) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{1}
+func (x *CreateRequest) GetContentProvider() ContentProvider {
+	if x != nil {
+		return x.ContentProvider
+	}
+	return ContentProvider_CONTENT_PROVIDER_UNSPECIFIED
 }
 
-func (x *Dimensions) GetLength() int32 {
+func (x *CreateRequest) GetContentVariants() int32 {
 	if x != nil {
-		return x.Length
+		return x.ContentVariants
 	}
 	return 0
 }
 
-func (x *Dimensions) GetWidth() int32 {
+func (x *CreateRequest) GetContentValidationMode() ContentValidationMode {
 	if x != nil {
-		return x.Width
+		return x.ContentValidationMode
 	}
-	return 0
+	return ContentValidationMode_CONTENT_VALIDATION_MODE_UNSPECIFIED
 }
 
-func (x *Dimensions) GetHeight() int32 {
-	if x != nil {
-		return x.Height
+func (x *CreateRequest) GetDryRun() bool {
+	if x != nil && x.DryRun != nil {
+		return *x.DryRun
 	}
-	return 0
+	return false
 }
 
-func (x *Dimensions) GetWeightBrutto() float64 {
+func (x *CreateRequest) GetImtId() int64 {
 	if x != nil {
-		return x.WeightBrutto
+		return x.ImtId
 	}
 	return 0
 }
 
-func (x *Dimensions) GetDepth() int32 {
+func (x *CreateRequest) GetModelName() string {
 	if x != nil {
-		return x.Depth
+		return x.ModelName
 	}
-	return 0
+	return ""
 }
 
-func (x *Dimensions) GetDimensionUnit() string {
+func (x *CreateRequest) GetVariants() []*ProductVariant {
 	if x != nil {
-		return x.DimensionUnit
+		return x.Variants
 	}
-	return ""
+	return nil
 }
 
-func (x *Dimensions) GetWeight() int32 {
+func (x *CreateRequest) GetWbDiscount() int32 {
 	if x != nil {
-		return x.Weight
+		return x.WbDiscount
 	}
 	return 0
 }
 
-func (x *Dimensions) GetWeightUnit() string {
+func (x *CreateRequest) GetInitialStock() *InitialStock {
 	if x != nil {
-		return x.WeightUnit
+		return x.InitialStock
 	}
-	return ""
+	return nil
 }
 
-type Size struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	TechSize string                 `protobuf:"bytes,1,opt,name=tech_size,json=techSize,proto3" json:"tech_size,omitempty"`
-	WbSize   string                 `protobuf:"bytes,2,opt,name=wb_size,json=wbSize,proto3" json:"wb_size,omitempty"`
-	Price    int32                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"` // Primary price in kopecks (used as fallback if marketplace-specific prices not provided)
-	Skus     []string               `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"`
-	// Marketplace-specific prices (in kopecks for consistency)
-	WbPrice       *int32 `protobuf:"varint,5,opt,name=wb_price,json=wbPrice,proto3,oneof" json:"wb_price,omitempty"`       // Price for WildBerries in kopecks
-	OzonPrice     *int32 `protobuf:"varint,6,opt,name=ozon_price,json=ozonPrice,proto3,oneof" json:"ozon_price,omitempty"` // Price for Ozon in kopecks
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// InitialStock sets the same amount for every size of a new card; a zero warehouse ID skips the marketplace
+type InitialStock struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WbWarehouseId   int64                  `protobuf:"varint,1,opt,name=wb_warehouse_id,json=wbWarehouseId,proto3" json:"wb_warehouse_id,omitempty"` // See StockService.ListWarehouses
+	OzonWarehouseId int64                  `protobuf:"varint,2,opt,name=ozon_warehouse_id,json=ozonWarehouseId,proto3" json:"ozon_warehouse_id,omitempty"`
+	Amount          int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *Size) Reset() {
-	*x = Size{}
-	mi := &file_api_v1_product_proto_msgTypes[2]
+func (x *InitialStock) Reset() {
+	*x = InitialStock{}
+	mi := &file_api_v1_product_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Size) String() string {
+func (x *InitialStock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Size) ProtoMessage() {}
+func (*InitialStock) ProtoMessage() {}
 
-func (x *Size) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[2]
+func (x *InitialStock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,77 +616,60 @@ func (x *Size) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Size.ProtoReflect.Descriptor instead.
-func (*Size) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *Size) GetTechSize() string {
-	if x != nil {
-		return x.TechSize
-	}
-	return ""
-}
-
-func (x *Size) GetWbSize() string {
-	if x != nil {
-		return x.WbSize
-	}
-	return ""
+// Deprecated: Use InitialStock.ProtoReflect.Descriptor instead.
+func (*InitialStock) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Size) GetPrice() int32 {
+func (x *InitialStock) GetWbWarehouseId() int64 {
 	if x != nil {
-		return x.Price
+		return x.WbWarehouseId
 	}
 	return 0
 }
 
-func (x *Size) GetSkus() []string {
+func (x *InitialStock) GetOzonWarehouseId() int64 {
 	if x != nil {
-		return x.Skus
-	}
-	return nil
-}
-
-func (x *Size) GetWbPrice() int32 {
-	if x != nil && x.WbPrice != nil {
-		return *x.WbPrice
+		return x.OzonWarehouseId
 	}
 	return 0
 }
 
-func (x *Size) GetOzonPrice() int32 {
-	if x != nil && x.OzonPrice != nil {
-		return *x.OzonPrice
+func (x *InitialStock) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
 	}
 	return 0
 }
 
-type WBMediaFileToUpload struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                             // File content
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                           // Original filename, e.g. "photo.jpg"
-	PhotoNumber   int32                  `protobuf:"varint,3,opt,name=photo_number,json=photoNumber,proto3" json:"photo_number,omitempty"` // Value for X-Photo-Number header
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ProductVariant is one color of a multi-variant product; content is generated once for all variants
+type ProductVariant struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	VendorCode           string                 `protobuf:"bytes,1,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`
+	Color                string                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"` // WB "Цвет" characteristic and Ozon color name
+	Sizes                []*Size                `protobuf:"bytes,3,rep,name=sizes,proto3" json:"sizes,omitempty"`
+	WbMediaToUploadFiles []*WBMediaFileToUpload `protobuf:"bytes,4,rep,name=wb_media_to_upload_files,json=wbMediaToUploadFiles,proto3" json:"wb_media_to_upload_files,omitempty"` // Media of the variant; the media of the request is used if all media fields are empty
+	WbMediaToSaveLinks   []string               `protobuf:"bytes,5,rep,name=wb_media_to_save_links,json=wbMediaToSaveLinks,proto3" json:"wb_media_to_save_links,omitempty"`
+	Media                []*MediaReference      `protobuf:"bytes,6,rep,name=media,proto3" json:"media,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *WBMediaFileToUpload) Reset() {
-	*x = WBMediaFileToUpload{}
-	mi := &file_api_v1_product_proto_msgTypes[3]
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_api_v1_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WBMediaFileToUpload) String() string {
+func (x *ProductVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WBMediaFileToUpload) ProtoMessage() {}
+func (*ProductVariant) ProtoMessage() {}
 
-func (x *WBMediaFileToUpload) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[3]
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,73 +680,151 @@ func (x *WBMediaFileToUpload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WBMediaFileToUpload.ProtoReflect.Descriptor instead.
-func (*WBMediaFileToUpload) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *WBMediaFileToUpload) GetContent() []byte {
+func (x *ProductVariant) GetVendorCode() string {
 	if x != nil {
-		return x.Content
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetSizes() []*Size {
+	if x != nil {
+		return x.Sizes
 	}
 	return nil
 }
 
-func (x *WBMediaFileToUpload) GetFilename() string {
+func (x *ProductVariant) GetWbMediaToUploadFiles() []*WBMediaFileToUpload {
 	if x != nil {
-		return x.Filename
+		return x.WbMediaToUploadFiles
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetWbMediaToSaveLinks() []string {
+	if x != nil {
+		return x.WbMediaToSaveLinks
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetMedia() []*MediaReference {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ContentViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marketplace   Marketplace            `protobuf:"varint,1,opt,name=marketplace,proto3,enum=api.v1.Marketplace" json:"marketplace,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"` // "title", "description" or "attributes.<name>"
+	Rule          string                 `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`   // max_length, empty, html, forbidden_characters, stop_word, brand_in_title, unknown_value
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Fixed         bool                   `protobuf:"varint,5,opt,name=fixed,proto3" json:"fixed,omitempty"` // The content was corrected automatically
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentViolation) Reset() {
+	*x = ContentViolation{}
+	mi := &file_api_v1_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentViolation) ProtoMessage() {}
+
+func (x *ContentViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentViolation.ProtoReflect.Descriptor instead.
+func (*ContentViolation) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ContentViolation) GetMarketplace() Marketplace {
+	if x != nil {
+		return x.Marketplace
+	}
+	return Marketplace_MARKETPLACE_UNSPECIFIED
+}
+
+func (x *ContentViolation) GetField() string {
+	if x != nil {
+		return x.Field
 	}
 	return ""
 }
 
-func (x *WBMediaFileToUpload) GetPhotoNumber() int32 {
+func (x *ContentViolation) GetRule() string {
 	if x != nil {
-		return x.PhotoNumber
+		return x.Rule
 	}
-	return 0
+	return ""
 }
 
-// ProductResponse represents the output from the Python API
-type CreateResponse struct {
-	state                            protoimpl.MessageState             `protogen:"open.v1"`
-	Title                            string                             `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Attributes                       map[string]string                  `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Description                      string                             `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	ParentName                       string                             `protobuf:"bytes,4,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`
-	ParentId                         int32                              `protobuf:"varint,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	SubjectName                      string                             `protobuf:"bytes,6,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"`
-	SubjectId                        int32                              `protobuf:"varint,7,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
-	RootId                           int32                              `protobuf:"varint,8,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
-	RootName                         string                             `protobuf:"bytes,9,opt,name=root_name,json=rootName,proto3" json:"root_name,omitempty"`
-	TypeId                           int32                              `protobuf:"varint,10,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
-	TypeName                         string                             `protobuf:"bytes,11,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
-	SubId                            int32                              `protobuf:"varint,12,opt,name=sub_id,json=subId,proto3" json:"sub_id,omitempty"`
-	SubName                          string                             `protobuf:"bytes,13,opt,name=sub_name,json=subName,proto3" json:"sub_name,omitempty"`
-	WbApiResponseJson                *string                            `protobuf:"bytes,14,opt,name=wb_api_response_json,json=wbApiResponseJson,proto3,oneof" json:"wb_api_response_json,omitempty"`             // JSON string of the WB API response if attempted
-	WbPreparedRequestJson            *string                            `protobuf:"bytes,15,opt,name=wb_prepared_request_json,json=wbPreparedRequestJson,proto3,oneof" json:"wb_prepared_request_json,omitempty"` // JSON string of the prepared WB request if API key not provided
-	WbRequestAttempted               *bool                              `protobuf:"varint,16,opt,name=wb_request_attempted,json=wbRequestAttempted,proto3,oneof" json:"wb_request_attempted,omitempty"`           // True if WB API call was made, False if JSON prepared, Null if wb=false
-	WbMediaUploadIndividualResponses []*WBMediaUploadIndividualResponse `protobuf:"bytes,17,rep,name=wb_media_upload_individual_responses,json=wbMediaUploadIndividualResponses,proto3" json:"wb_media_upload_individual_responses,omitempty"`
-	WbMediaSaveByLinksResponse       *WBMediaSaveByLinksResponse        `protobuf:"bytes,18,opt,name=wb_media_save_by_links_response,json=wbMediaSaveByLinksResponse,proto3,oneof" json:"wb_media_save_by_links_response,omitempty"`
-	OzonApiResponseJson              *string                            `protobuf:"bytes,19,opt,name=ozon_api_response_json,json=ozonApiResponseJson,proto3,oneof" json:"ozon_api_response_json,omitempty"`   // JSON string of the Ozon API response if attempted
-	OzonRequestAttempted             *bool                              `protobuf:"varint,20,opt,name=ozon_request_attempted,json=ozonRequestAttempted,proto3,oneof" json:"ozon_request_attempted,omitempty"` // True if Ozon API call was made
-	unknownFields                    protoimpl.UnknownFields
-	sizeCache                        protoimpl.SizeCache
+func (x *ContentViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *CreateResponse) Reset() {
-	*x = CreateResponse{}
+func (x *ContentViolation) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+// ContentValidationError is attached as an error detail when the content is rejected
+type ContentValidationError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*ContentViolation    `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentValidationError) Reset() {
+	*x = ContentValidationError{}
 	mi := &file_api_v1_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateResponse) String() string {
+func (x *ContentValidationError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateResponse) ProtoMessage() {}
+func (*ContentValidationError) ProtoMessage() {}
 
-func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+func (x *ContentValidationError) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -516,175 +836,4679 @@ func (x *CreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
-func (*CreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ContentValidationError.ProtoReflect.Descriptor instead.
+func (*ContentValidationError) Descriptor() ([]byte, []int) {
 	return file_api_v1_product_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateResponse) GetTitle() string {
+func (x *ContentValidationError) GetViolations() []*ContentViolation {
 	if x != nil {
-		return x.Title
+		return x.Violations
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateResponse) GetAttributes() map[string]string {
+type Dimensions struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Length       int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"` // For WB compatibility
+	Width        int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height       int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	WeightBrutto float64                `protobuf:"fixed64,4,opt,name=weight_brutto,json=weightBrutto,proto3" json:"weight_brutto,omitempty"` // For WB compatibility
+	// Ozon specific fields
+	Depth         int32  `protobuf:"varint,5,opt,name=depth,proto3" json:"depth,omitempty"`                                     // Ozon depth (can be same as length)
+	DimensionUnit string `protobuf:"bytes,6,opt,name=dimension_unit,json=dimensionUnit,proto3" json:"dimension_unit,omitempty"` // Ozon dimension unit (e.g., "mm")
+	Weight        int32  `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`                                   // Ozon weight (can be same as weight_brutto)
+	WeightUnit    string `protobuf:"bytes,8,opt,name=weight_unit,json=weightUnit,proto3" json:"weight_unit,omitempty"`          // Ozon weight unit (e.g., "g")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_api_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[5]
 	if x != nil {
-		return x.Attributes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreateResponse) GetDescription() string {
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Dimensions) GetLength() int32 {
 	if x != nil {
-		return x.Description
+		return x.Length
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateResponse) GetParentName() string {
+func (x *Dimensions) GetWidth() int32 {
 	if x != nil {
-		return x.ParentName
+		return x.Width
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateResponse) GetParentId() int32 {
+func (x *Dimensions) GetHeight() int32 {
 	if x != nil {
-		return x.ParentId
+		return x.Height
 	}
 	return 0
 }
 
-func (x *CreateResponse) GetSubjectName() string {
+func (x *Dimensions) GetWeightBrutto() float64 {
 	if x != nil {
-		return x.SubjectName
+		return x.WeightBrutto
+	}
+	return 0
+}
+
+func (x *Dimensions) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Dimensions) GetDimensionUnit() string {
+	if x != nil {
+		return x.DimensionUnit
 	}
 	return ""
 }
 
-func (x *CreateResponse) GetSubjectId() int32 {
+func (x *Dimensions) GetWeight() int32 {
 	if x != nil {
-		return x.SubjectId
+		return x.Weight
 	}
 	return 0
 }
 
-func (x *CreateResponse) GetRootId() int32 {
+func (x *Dimensions) GetWeightUnit() string {
+	if x != nil {
+		return x.WeightUnit
+	}
+	return ""
+}
+
+type Size struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TechSize string                 `protobuf:"bytes,1,opt,name=tech_size,json=techSize,proto3" json:"tech_size,omitempty"`
+	WbSize   string                 `protobuf:"bytes,2,opt,name=wb_size,json=wbSize,proto3" json:"wb_size,omitempty"`
+	Price    int32                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"` // Primary price in rubles (used as fallback if marketplace-specific prices not provided)
+	Skus     []string               `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"`
+	// Marketplace-specific prices, in rubles like the WB and Ozon price APIs
+	WbPrice       *int32 `protobuf:"varint,5,opt,name=wb_price,json=wbPrice,proto3,oneof" json:"wb_price,omitempty"`       // Price for WildBerries in rubles, before wb_discount
+	OzonPrice     *int32 `protobuf:"varint,6,opt,name=ozon_price,json=ozonPrice,proto3,oneof" json:"ozon_price,omitempty"` // Price for Ozon in rubles
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Size) Reset() {
+	*x = Size{}
+	mi := &file_api_v1_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Size) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Size) ProtoMessage() {}
+
+func (x *Size) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Size.ProtoReflect.Descriptor instead.
+func (*Size) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Size) GetTechSize() string {
+	if x != nil {
+		return x.TechSize
+	}
+	return ""
+}
+
+func (x *Size) GetWbSize() string {
+	if x != nil {
+		return x.WbSize
+	}
+	return ""
+}
+
+func (x *Size) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Size) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+func (x *Size) GetWbPrice() int32 {
+	if x != nil && x.WbPrice != nil {
+		return *x.WbPrice
+	}
+	return 0
+}
+
+func (x *Size) GetOzonPrice() int32 {
+	if x != nil && x.OzonPrice != nil {
+		return *x.OzonPrice
+	}
+	return 0
+}
+
+type WBMediaFileToUpload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                             // File content
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                           // Original filename, e.g. "photo.jpg"
+	PhotoNumber   int32                  `protobuf:"varint,3,opt,name=photo_number,json=photoNumber,proto3" json:"photo_number,omitempty"` // Value for X-Photo-Number header
+	Kind          MediaKind              `protobuf:"varint,4,opt,name=kind,proto3,enum=api.v1.MediaKind" json:"kind,omitempty"`            // Photo if unspecified; WB videos are always uploaded with photo number 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBMediaFileToUpload) Reset() {
+	*x = WBMediaFileToUpload{}
+	mi := &file_api_v1_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBMediaFileToUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBMediaFileToUpload) ProtoMessage() {}
+
+func (x *WBMediaFileToUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBMediaFileToUpload.ProtoReflect.Descriptor instead.
+func (*WBMediaFileToUpload) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WBMediaFileToUpload) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *WBMediaFileToUpload) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *WBMediaFileToUpload) GetPhotoNumber() int32 {
+	if x != nil {
+		return x.PhotoNumber
+	}
+	return 0
+}
+
+func (x *WBMediaFileToUpload) GetKind() MediaKind {
+	if x != nil {
+		return x.Kind
+	}
+	return MediaKind_MEDIA_KIND_UNSPECIFIED
+}
+
+type MediaReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaId       string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`              // ID returned by MediaService.Upload
+	PhotoNumber   int32                  `protobuf:"varint,2,opt,name=photo_number,json=photoNumber,proto3" json:"photo_number,omitempty"` // Position of the media in the card (1-based)
+	Kind          MediaKind              `protobuf:"varint,3,opt,name=kind,proto3,enum=api.v1.MediaKind" json:"kind,omitempty"`
+	Primary       bool                   `protobuf:"varint,4,opt,name=primary,proto3" json:"primary,omitempty"` // Main photo, or video cover for Ozon when kind is video (not sent to WB)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaReference) Reset() {
+	*x = MediaReference{}
+	mi := &file_api_v1_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaReference) ProtoMessage() {}
+
+func (x *MediaReference) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaReference.ProtoReflect.Descriptor instead.
+func (*MediaReference) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MediaReference) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *MediaReference) GetPhotoNumber() int32 {
+	if x != nil {
+		return x.PhotoNumber
+	}
+	return 0
+}
+
+func (x *MediaReference) GetKind() MediaKind {
+	if x != nil {
+		return x.Kind
+	}
+	return MediaKind_MEDIA_KIND_UNSPECIFIED
+}
+
+func (x *MediaReference) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+// ProductResponse represents the output from the Python API
+type CreateResponse struct {
+	state                            protoimpl.MessageState             `protogen:"open.v1"`
+	Title                            string                             `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Attributes                       map[string]string                  `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Description                      string                             `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ParentName                       string                             `protobuf:"bytes,4,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`
+	ParentId                         int32                              `protobuf:"varint,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	SubjectName                      string                             `protobuf:"bytes,6,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"`
+	SubjectId                        int32                              `protobuf:"varint,7,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	RootId                           int32                              `protobuf:"varint,8,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	RootName                         string                             `protobuf:"bytes,9,opt,name=root_name,json=rootName,proto3" json:"root_name,omitempty"`
+	TypeId                           int32                              `protobuf:"varint,10,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName                         string                             `protobuf:"bytes,11,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	SubId                            int32                              `protobuf:"varint,12,opt,name=sub_id,json=subId,proto3" json:"sub_id,omitempty"`
+	SubName                          string                             `protobuf:"bytes,13,opt,name=sub_name,json=subName,proto3" json:"sub_name,omitempty"`
+	WbApiResponseJson                *string                            `protobuf:"bytes,14,opt,name=wb_api_response_json,json=wbApiResponseJson,proto3,oneof" json:"wb_api_response_json,omitempty"`             // JSON string of the WB API response if attempted
+	WbPreparedRequestJson            *string                            `protobuf:"bytes,15,opt,name=wb_prepared_request_json,json=wbPreparedRequestJson,proto3,oneof" json:"wb_prepared_request_json,omitempty"` // JSON string of the prepared WB request if API key not provided
+	WbRequestAttempted               *bool                              `protobuf:"varint,16,opt,name=wb_request_attempted,json=wbRequestAttempted,proto3,oneof" json:"wb_request_attempted,omitempty"`           // True if WB API call was made, False if JSON prepared, Null if wb=false
+	WbMediaUploadIndividualResponses []*WBMediaUploadIndividualResponse `protobuf:"bytes,17,rep,name=wb_media_upload_individual_responses,json=wbMediaUploadIndividualResponses,proto3" json:"wb_media_upload_individual_responses,omitempty"`
+	WbMediaSaveByLinksResponse       *WBMediaSaveByLinksResponse        `protobuf:"bytes,18,opt,name=wb_media_save_by_links_response,json=wbMediaSaveByLinksResponse,proto3,oneof" json:"wb_media_save_by_links_response,omitempty"`
+	OzonApiResponseJson              *string                            `protobuf:"bytes,19,opt,name=ozon_api_response_json,json=ozonApiResponseJson,proto3,oneof" json:"ozon_api_response_json,omitempty"`                       // JSON string of the Ozon API response if attempted
+	OzonRequestAttempted             *bool                              `protobuf:"varint,20,opt,name=ozon_request_attempted,json=ozonRequestAttempted,proto3,oneof" json:"ozon_request_attempted,omitempty"`                     // True if Ozon API call was made
+	ContentFromCache                 bool                               `protobuf:"varint,21,opt,name=content_from_cache,json=contentFromCache,proto3" json:"content_from_cache,omitempty"`                                       // True if the generated content was served from the cache
+	ContentProvider                  ContentProvider                    `protobuf:"varint,22,opt,name=content_provider,json=contentProvider,proto3,enum=api.v1.ContentProvider" json:"content_provider,omitempty"`                // Provider that generated the content
+	ContentVariants                  []*ContentVariant                  `protobuf:"bytes,23,rep,name=content_variants,json=contentVariants,proto3" json:"content_variants,omitempty"`                                             // All generated alternatives, the first one equals title/description above
+	ContentViolations                []*ContentViolation                `protobuf:"bytes,24,rep,name=content_violations,json=contentViolations,proto3" json:"content_violations,omitempty"`                                       // Marketplace constraints the content broke and how they were handled
+	OzonPreparedRequestJson          *string                            `protobuf:"bytes,25,opt,name=ozon_prepared_request_json,json=ozonPreparedRequestJson,proto3,oneof" json:"ozon_prepared_request_json,omitempty"`           // JSON string of the prepared Ozon request if it was not sent
+	DryRun                           bool                               `protobuf:"varint,26,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                                                       // True if marketplace requests were prepared but not sent
+	OzonError                        *OzonError                         `protobuf:"bytes,27,opt,name=ozon_error,json=ozonError,proto3,oneof" json:"ozon_error,omitempty"`                                                         // Parsed Ozon error if the Ozon call failed
+	WbMediaPending                   bool                               `protobuf:"varint,28,opt,name=wb_media_pending,json=wbMediaPending,proto3" json:"wb_media_pending,omitempty"`                                             // WB media is uploaded in the background once WB has created the card
+	WbMediaSaveByLinksResponses      []*WBMediaSaveByLinksResponse      `protobuf:"bytes,29,rep,name=wb_media_save_by_links_responses,json=wbMediaSaveByLinksResponses,proto3" json:"wb_media_save_by_links_responses,omitempty"` // Save by links responses of every card of a multi-variant product
+	GeneratedBarcodes                []*GeneratedBarcodes               `protobuf:"bytes,30,rep,name=generated_barcodes,json=generatedBarcodes,proto3" json:"generated_barcodes,omitempty"`                                       // Barcodes generated for sizes sent without skus
+	OzonInitialStockError            string                             `protobuf:"bytes,31,opt,name=ozon_initial_stock_error,json=ozonInitialStockError,proto3" json:"ozon_initial_stock_error,omitempty"`                       // Why initial_stock was not fully set on Ozon, empty if it was or no Ozon warehouse was given
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetParentName() string {
+	if x != nil {
+		return x.ParentName
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetSubjectName() string {
+	if x != nil {
+		return x.SubjectName
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetSubjectId() int32 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetRootId() int32 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetRootName() string {
+	if x != nil {
+		return x.RootName
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetSubId() int32 {
+	if x != nil {
+		return x.SubId
+	}
+	return 0
+}
+
+func (x *CreateResponse) GetSubName() string {
+	if x != nil {
+		return x.SubName
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetWbApiResponseJson() string {
+	if x != nil && x.WbApiResponseJson != nil {
+		return *x.WbApiResponseJson
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetWbPreparedRequestJson() string {
+	if x != nil && x.WbPreparedRequestJson != nil {
+		return *x.WbPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetWbRequestAttempted() bool {
+	if x != nil && x.WbRequestAttempted != nil {
+		return *x.WbRequestAttempted
+	}
+	return false
+}
+
+func (x *CreateResponse) GetWbMediaUploadIndividualResponses() []*WBMediaUploadIndividualResponse {
+	if x != nil {
+		return x.WbMediaUploadIndividualResponses
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetWbMediaSaveByLinksResponse() *WBMediaSaveByLinksResponse {
+	if x != nil {
+		return x.WbMediaSaveByLinksResponse
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetOzonApiResponseJson() string {
+	if x != nil && x.OzonApiResponseJson != nil {
+		return *x.OzonApiResponseJson
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetOzonRequestAttempted() bool {
+	if x != nil && x.OzonRequestAttempted != nil {
+		return *x.OzonRequestAttempted
+	}
+	return false
+}
+
+func (x *CreateResponse) GetContentFromCache() bool {
+	if x != nil {
+		return x.ContentFromCache
+	}
+	return false
+}
+
+func (x *CreateResponse) GetContentProvider() ContentProvider {
+	if x != nil {
+		return x.ContentProvider
+	}
+	return ContentProvider_CONTENT_PROVIDER_UNSPECIFIED
+}
+
+func (x *CreateResponse) GetContentVariants() []*ContentVariant {
+	if x != nil {
+		return x.ContentVariants
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetContentViolations() []*ContentViolation {
+	if x != nil {
+		return x.ContentViolations
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetOzonPreparedRequestJson() string {
+	if x != nil && x.OzonPreparedRequestJson != nil {
+		return *x.OzonPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *CreateResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CreateResponse) GetOzonError() *OzonError {
+	if x != nil {
+		return x.OzonError
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetWbMediaPending() bool {
+	if x != nil {
+		return x.WbMediaPending
+	}
+	return false
+}
+
+func (x *CreateResponse) GetWbMediaSaveByLinksResponses() []*WBMediaSaveByLinksResponse {
+	if x != nil {
+		return x.WbMediaSaveByLinksResponses
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetGeneratedBarcodes() []*GeneratedBarcodes {
+	if x != nil {
+		return x.GeneratedBarcodes
+	}
+	return nil
+}
+
+func (x *CreateResponse) GetOzonInitialStockError() string {
+	if x != nil {
+		return x.OzonInitialStockError
+	}
+	return ""
+}
+
+// GeneratedBarcodes are the barcodes a marketplace generated for a size sent without skus
+type GeneratedBarcodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marketplace   Marketplace            `protobuf:"varint,1,opt,name=marketplace,proto3,enum=api.v1.Marketplace" json:"marketplace,omitempty"`
+	VendorCode    string                 `protobuf:"bytes,2,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`
+	TechSize      string                 `protobuf:"bytes,3,opt,name=tech_size,json=techSize,proto3" json:"tech_size,omitempty"` // Empty for Ozon offers of products without sizes
+	Barcodes      []string               `protobuf:"bytes,4,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratedBarcodes) Reset() {
+	*x = GeneratedBarcodes{}
+	mi := &file_api_v1_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratedBarcodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratedBarcodes) ProtoMessage() {}
+
+func (x *GeneratedBarcodes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratedBarcodes.ProtoReflect.Descriptor instead.
+func (*GeneratedBarcodes) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GeneratedBarcodes) GetMarketplace() Marketplace {
+	if x != nil {
+		return x.Marketplace
+	}
+	return Marketplace_MARKETPLACE_UNSPECIFIED
+}
+
+func (x *GeneratedBarcodes) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *GeneratedBarcodes) GetTechSize() string {
+	if x != nil {
+		return x.TechSize
+	}
+	return ""
+}
+
+func (x *GeneratedBarcodes) GetBarcodes() []string {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+type ContentVariant struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VariantId        string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // ID to pass to ProductService.PublishVariant
+	Index            int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                         // 0-based position, 0 is the variant published by Create
+	Title            string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Attributes       map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PromptTokens     int32                  `protobuf:"varint,6,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int32                  `protobuf:"varint,7,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	Cost             int32                  `protobuf:"varint,8,opt,name=cost,proto3" json:"cost,omitempty"` // Amount charged from the balance for this variant
+	FromCache        bool                   `protobuf:"varint,9,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ContentVariant) Reset() {
+	*x = ContentVariant{}
+	mi := &file_api_v1_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentVariant) ProtoMessage() {}
+
+func (x *ContentVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentVariant.ProtoReflect.Descriptor instead.
+func (*ContentVariant) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ContentVariant) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *ContentVariant) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ContentVariant) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ContentVariant) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ContentVariant) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ContentVariant) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *ContentVariant) GetCompletionTokens() int32 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *ContentVariant) GetCost() int32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *ContentVariant) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+type PublishVariantRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	VariantId             string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Wb                    bool                   `protobuf:"varint,2,opt,name=wb,proto3" json:"wb,omitempty"`
+	Ozon                  bool                   `protobuf:"varint,3,opt,name=ozon,proto3" json:"ozon,omitempty"`
+	WbApiKey              string                 `protobuf:"bytes,4,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	OzonApiClientId       string                 `protobuf:"bytes,5,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`
+	OzonApiKey            string                 `protobuf:"bytes,6,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	ContentValidationMode ContentValidationMode  `protobuf:"varint,7,opt,name=content_validation_mode,json=contentValidationMode,proto3,enum=api.v1.ContentValidationMode" json:"content_validation_mode,omitempty"` // Regenerate is not supported and behaves like truncate
+	DryRun                *bool                  `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3,oneof" json:"dry_run,omitempty"`                                                                            // Prepare marketplace requests without sending them; defaults to true in development environments
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PublishVariantRequest) Reset() {
+	*x = PublishVariantRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishVariantRequest) ProtoMessage() {}
+
+func (x *PublishVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishVariantRequest.ProtoReflect.Descriptor instead.
+func (*PublishVariantRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PublishVariantRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *PublishVariantRequest) GetWb() bool {
+	if x != nil {
+		return x.Wb
+	}
+	return false
+}
+
+func (x *PublishVariantRequest) GetOzon() bool {
+	if x != nil {
+		return x.Ozon
+	}
+	return false
+}
+
+func (x *PublishVariantRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *PublishVariantRequest) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *PublishVariantRequest) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+func (x *PublishVariantRequest) GetContentValidationMode() ContentValidationMode {
+	if x != nil {
+		return x.ContentValidationMode
+	}
+	return ContentValidationMode_CONTENT_VALIDATION_MODE_UNSPECIFIED
+}
+
+func (x *PublishVariantRequest) GetDryRun() bool {
+	if x != nil && x.DryRun != nil {
+		return *x.DryRun
+	}
+	return false
+}
+
+type PublishVariantResponse struct {
+	state                            protoimpl.MessageState             `protogen:"open.v1"`
+	WbApiResponseJson                *string                            `protobuf:"bytes,1,opt,name=wb_api_response_json,json=wbApiResponseJson,proto3,oneof" json:"wb_api_response_json,omitempty"`
+	WbPreparedRequestJson            *string                            `protobuf:"bytes,2,opt,name=wb_prepared_request_json,json=wbPreparedRequestJson,proto3,oneof" json:"wb_prepared_request_json,omitempty"`
+	WbRequestAttempted               *bool                              `protobuf:"varint,3,opt,name=wb_request_attempted,json=wbRequestAttempted,proto3,oneof" json:"wb_request_attempted,omitempty"`
+	WbMediaUploadIndividualResponses []*WBMediaUploadIndividualResponse `protobuf:"bytes,4,rep,name=wb_media_upload_individual_responses,json=wbMediaUploadIndividualResponses,proto3" json:"wb_media_upload_individual_responses,omitempty"`
+	WbMediaSaveByLinksResponse       *WBMediaSaveByLinksResponse        `protobuf:"bytes,5,opt,name=wb_media_save_by_links_response,json=wbMediaSaveByLinksResponse,proto3,oneof" json:"wb_media_save_by_links_response,omitempty"`
+	OzonApiResponseJson              *string                            `protobuf:"bytes,6,opt,name=ozon_api_response_json,json=ozonApiResponseJson,proto3,oneof" json:"ozon_api_response_json,omitempty"`
+	OzonRequestAttempted             *bool                              `protobuf:"varint,7,opt,name=ozon_request_attempted,json=ozonRequestAttempted,proto3,oneof" json:"ozon_request_attempted,omitempty"`
+	ContentViolations                []*ContentViolation                `protobuf:"bytes,8,rep,name=content_violations,json=contentViolations,proto3" json:"content_violations,omitempty"`
+	OzonPreparedRequestJson          *string                            `protobuf:"bytes,9,opt,name=ozon_prepared_request_json,json=ozonPreparedRequestJson,proto3,oneof" json:"ozon_prepared_request_json,omitempty"`
+	DryRun                           bool                               `protobuf:"varint,10,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	OzonError                        *OzonError                         `protobuf:"bytes,11,opt,name=ozon_error,json=ozonError,proto3,oneof" json:"ozon_error,omitempty"`
+	WbMediaPending                   bool                               `protobuf:"varint,12,opt,name=wb_media_pending,json=wbMediaPending,proto3" json:"wb_media_pending,omitempty"`
+	WbMediaSaveByLinksResponses      []*WBMediaSaveByLinksResponse      `protobuf:"bytes,13,rep,name=wb_media_save_by_links_responses,json=wbMediaSaveByLinksResponses,proto3" json:"wb_media_save_by_links_responses,omitempty"`
+	GeneratedBarcodes                []*GeneratedBarcodes               `protobuf:"bytes,14,rep,name=generated_barcodes,json=generatedBarcodes,proto3" json:"generated_barcodes,omitempty"`
+	OzonInitialStockError            string                             `protobuf:"bytes,15,opt,name=ozon_initial_stock_error,json=ozonInitialStockError,proto3" json:"ozon_initial_stock_error,omitempty"` // Why the initial stock of the variant was not fully set on Ozon
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *PublishVariantResponse) Reset() {
+	*x = PublishVariantResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishVariantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishVariantResponse) ProtoMessage() {}
+
+func (x *PublishVariantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishVariantResponse.ProtoReflect.Descriptor instead.
+func (*PublishVariantResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PublishVariantResponse) GetWbApiResponseJson() string {
+	if x != nil && x.WbApiResponseJson != nil {
+		return *x.WbApiResponseJson
+	}
+	return ""
+}
+
+func (x *PublishVariantResponse) GetWbPreparedRequestJson() string {
+	if x != nil && x.WbPreparedRequestJson != nil {
+		return *x.WbPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *PublishVariantResponse) GetWbRequestAttempted() bool {
+	if x != nil && x.WbRequestAttempted != nil {
+		return *x.WbRequestAttempted
+	}
+	return false
+}
+
+func (x *PublishVariantResponse) GetWbMediaUploadIndividualResponses() []*WBMediaUploadIndividualResponse {
+	if x != nil {
+		return x.WbMediaUploadIndividualResponses
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetWbMediaSaveByLinksResponse() *WBMediaSaveByLinksResponse {
+	if x != nil {
+		return x.WbMediaSaveByLinksResponse
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetOzonApiResponseJson() string {
+	if x != nil && x.OzonApiResponseJson != nil {
+		return *x.OzonApiResponseJson
+	}
+	return ""
+}
+
+func (x *PublishVariantResponse) GetOzonRequestAttempted() bool {
+	if x != nil && x.OzonRequestAttempted != nil {
+		return *x.OzonRequestAttempted
+	}
+	return false
+}
+
+func (x *PublishVariantResponse) GetContentViolations() []*ContentViolation {
+	if x != nil {
+		return x.ContentViolations
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetOzonPreparedRequestJson() string {
+	if x != nil && x.OzonPreparedRequestJson != nil {
+		return *x.OzonPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *PublishVariantResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *PublishVariantResponse) GetOzonError() *OzonError {
+	if x != nil {
+		return x.OzonError
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetWbMediaPending() bool {
+	if x != nil {
+		return x.WbMediaPending
+	}
+	return false
+}
+
+func (x *PublishVariantResponse) GetWbMediaSaveByLinksResponses() []*WBMediaSaveByLinksResponse {
+	if x != nil {
+		return x.WbMediaSaveByLinksResponses
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetGeneratedBarcodes() []*GeneratedBarcodes {
+	if x != nil {
+		return x.GeneratedBarcodes
+	}
+	return nil
+}
+
+func (x *PublishVariantResponse) GetOzonInitialStockError() string {
+	if x != nil {
+		return x.OzonInitialStockError
+	}
+	return ""
+}
+
+// OzonError is the parsed error response of the Ozon Seller API
+type OzonError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HttpStatus    int32                  `protobuf:"varint,1,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Ozon error code, numeric gRPC codes are sent as text
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Details       []*OzonErrorDetail     `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonError) Reset() {
+	*x = OzonError{}
+	mi := &file_api_v1_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonError) ProtoMessage() {}
+
+func (x *OzonError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonError.ProtoReflect.Descriptor instead.
+func (*OzonError) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OzonError) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+func (x *OzonError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OzonError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OzonError) GetDetails() []*OzonErrorDetail {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type OzonErrorDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TypeUrl       string                 `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonErrorDetail) Reset() {
+	*x = OzonErrorDetail{}
+	mi := &file_api_v1_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonErrorDetail) ProtoMessage() {}
+
+func (x *OzonErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonErrorDetail.ProtoReflect.Descriptor instead.
+func (*OzonErrorDetail) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OzonErrorDetail) GetTypeUrl() string {
+	if x != nil {
+		return x.TypeUrl
+	}
+	return ""
+}
+
+func (x *OzonErrorDetail) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type WBMediaUploadIndividualResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhotoNumber   int32                  `protobuf:"varint,1,opt,name=photo_number,json=photoNumber,proto3" json:"photo_number,omitempty"`         // Corresponds to the photo_number from WBMediaFileToUpload
+	ResponseJson  *string                `protobuf:"bytes,2,opt,name=response_json,json=responseJson,proto3,oneof" json:"response_json,omitempty"` // JSON string of WBMediaGenericResponse
+	ErrorMessage  *string                `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"` // Error message if this specific upload failed
+	VendorCode    string                 `protobuf:"bytes,4,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`             // Card the media was uploaded to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBMediaUploadIndividualResponse) Reset() {
+	*x = WBMediaUploadIndividualResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBMediaUploadIndividualResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBMediaUploadIndividualResponse) ProtoMessage() {}
+
+func (x *WBMediaUploadIndividualResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBMediaUploadIndividualResponse.ProtoReflect.Descriptor instead.
+func (*WBMediaUploadIndividualResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WBMediaUploadIndividualResponse) GetPhotoNumber() int32 {
+	if x != nil {
+		return x.PhotoNumber
+	}
+	return 0
+}
+
+func (x *WBMediaUploadIndividualResponse) GetResponseJson() string {
+	if x != nil && x.ResponseJson != nil {
+		return *x.ResponseJson
+	}
+	return ""
+}
+
+func (x *WBMediaUploadIndividualResponse) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *WBMediaUploadIndividualResponse) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type WBMediaSaveByLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResponseJson  *string                `protobuf:"bytes,1,opt,name=response_json,json=responseJson,proto3,oneof" json:"response_json,omitempty"` // JSON string of WBMediaGenericResponse
+	ErrorMessage  *string                `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"` // Error message if save by links operation failed
+	VendorCode    string                 `protobuf:"bytes,3,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`             // Card the media was saved to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBMediaSaveByLinksResponse) Reset() {
+	*x = WBMediaSaveByLinksResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBMediaSaveByLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBMediaSaveByLinksResponse) ProtoMessage() {}
+
+func (x *WBMediaSaveByLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBMediaSaveByLinksResponse.ProtoReflect.Descriptor instead.
+func (*WBMediaSaveByLinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WBMediaSaveByLinksResponse) GetResponseJson() string {
+	if x != nil && x.ResponseJson != nil {
+		return *x.ResponseJson
+	}
+	return ""
+}
+
+func (x *WBMediaSaveByLinksResponse) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *WBMediaSaveByLinksResponse) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+type ListWBCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WbApiKey      string                 `protobuf:"bytes,1,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	TextSearch    string                 `protobuf:"bytes,2,opt,name=text_search,json=textSearch,proto3" json:"text_search,omitempty"` // Vendor code, nmID or part of the title
+	Brands        []string               `protobuf:"bytes,3,rep,name=brands,proto3" json:"brands,omitempty"`
+	SubjectIds    []int64                `protobuf:"varint,4,rep,packed,name=subject_ids,json=subjectIds,proto3" json:"subject_ids,omitempty"`
+	TagIds        []int64                `protobuf:"varint,5,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	WithPhoto     *bool                  `protobuf:"varint,6,opt,name=with_photo,json=withPhoto,proto3,oneof" json:"with_photo,omitempty"` // Only cards with (true) or without (false) photos, all cards if unset
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`          // Cards per message, 1-100, defaults to 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWBCardsRequest) Reset() {
+	*x = ListWBCardsRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWBCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWBCardsRequest) ProtoMessage() {}
+
+func (x *ListWBCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWBCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListWBCardsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListWBCardsRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *ListWBCardsRequest) GetTextSearch() string {
+	if x != nil {
+		return x.TextSearch
+	}
+	return ""
+}
+
+func (x *ListWBCardsRequest) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *ListWBCardsRequest) GetSubjectIds() []int64 {
+	if x != nil {
+		return x.SubjectIds
+	}
+	return nil
+}
+
+func (x *ListWBCardsRequest) GetTagIds() []int64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *ListWBCardsRequest) GetWithPhoto() bool {
+	if x != nil && x.WithPhoto != nil {
+		return *x.WithPhoto
+	}
+	return false
+}
+
+func (x *ListWBCardsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListWBCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*WBCard              `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWBCardsResponse) Reset() {
+	*x = ListWBCardsResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWBCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWBCardsResponse) ProtoMessage() {}
+
+func (x *ListWBCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWBCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListWBCardsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListWBCardsResponse) GetCards() []*WBCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type WBCard struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	NmId            int64                   `protobuf:"varint,1,opt,name=nm_id,json=nmId,proto3" json:"nm_id,omitempty"`
+	ImtId           int64                   `protobuf:"varint,2,opt,name=imt_id,json=imtId,proto3" json:"imt_id,omitempty"`
+	VendorCode      string                  `protobuf:"bytes,3,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"`
+	SubjectId       int64                   `protobuf:"varint,4,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	SubjectName     string                  `protobuf:"bytes,5,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"`
+	Brand           string                  `protobuf:"bytes,6,opt,name=brand,proto3" json:"brand,omitempty"`
+	Title           string                  `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                  `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	PhotoUrls       []string                `protobuf:"bytes,9,rep,name=photo_urls,json=photoUrls,proto3" json:"photo_urls,omitempty"` // 900x1200 photos in card order
+	VideoUrl        string                  `protobuf:"bytes,10,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	Dimensions      *Dimensions             `protobuf:"bytes,11,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Characteristics []*WBCardCharacteristic `protobuf:"bytes,12,rep,name=characteristics,proto3" json:"characteristics,omitempty"`
+	Sizes           []*WBCardSize           `protobuf:"bytes,13,rep,name=sizes,proto3" json:"sizes,omitempty"`
+	Tags            []*WBCardTag            `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt       string                  `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                  `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WBCard) Reset() {
+	*x = WBCard{}
+	mi := &file_api_v1_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBCard) ProtoMessage() {}
+
+func (x *WBCard) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBCard.ProtoReflect.Descriptor instead.
+func (*WBCard) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WBCard) GetNmId() int64 {
+	if x != nil {
+		return x.NmId
+	}
+	return 0
+}
+
+func (x *WBCard) GetImtId() int64 {
+	if x != nil {
+		return x.ImtId
+	}
+	return 0
+}
+
+func (x *WBCard) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *WBCard) GetSubjectId() int64 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+func (x *WBCard) GetSubjectName() string {
+	if x != nil {
+		return x.SubjectName
+	}
+	return ""
+}
+
+func (x *WBCard) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *WBCard) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WBCard) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WBCard) GetPhotoUrls() []string {
+	if x != nil {
+		return x.PhotoUrls
+	}
+	return nil
+}
+
+func (x *WBCard) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *WBCard) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *WBCard) GetCharacteristics() []*WBCardCharacteristic {
+	if x != nil {
+		return x.Characteristics
+	}
+	return nil
+}
+
+func (x *WBCard) GetSizes() []*WBCardSize {
+	if x != nil {
+		return x.Sizes
+	}
+	return nil
+}
+
+func (x *WBCard) GetTags() []*WBCardTag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WBCard) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WBCard) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type WBCardCharacteristic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ValueJson     string                 `protobuf:"bytes,3,opt,name=value_json,json=valueJson,proto3" json:"value_json,omitempty"` // JSON encoded value, its type depends on the characteristic
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBCardCharacteristic) Reset() {
+	*x = WBCardCharacteristic{}
+	mi := &file_api_v1_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBCardCharacteristic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBCardCharacteristic) ProtoMessage() {}
+
+func (x *WBCardCharacteristic) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBCardCharacteristic.ProtoReflect.Descriptor instead.
+func (*WBCardCharacteristic) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WBCardCharacteristic) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WBCardCharacteristic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WBCardCharacteristic) GetValueJson() string {
+	if x != nil {
+		return x.ValueJson
+	}
+	return ""
+}
+
+type WBCardSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChrtId        int64                  `protobuf:"varint,1,opt,name=chrt_id,json=chrtId,proto3" json:"chrt_id,omitempty"`
+	TechSize      string                 `protobuf:"bytes,2,opt,name=tech_size,json=techSize,proto3" json:"tech_size,omitempty"`
+	WbSize        string                 `protobuf:"bytes,3,opt,name=wb_size,json=wbSize,proto3" json:"wb_size,omitempty"`
+	Skus          []string               `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"` // Barcodes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBCardSize) Reset() {
+	*x = WBCardSize{}
+	mi := &file_api_v1_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBCardSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBCardSize) ProtoMessage() {}
+
+func (x *WBCardSize) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBCardSize.ProtoReflect.Descriptor instead.
+func (*WBCardSize) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WBCardSize) GetChrtId() int64 {
+	if x != nil {
+		return x.ChrtId
+	}
+	return 0
+}
+
+func (x *WBCardSize) GetTechSize() string {
+	if x != nil {
+		return x.TechSize
+	}
+	return ""
+}
+
+func (x *WBCardSize) GetWbSize() string {
+	if x != nil {
+		return x.WbSize
+	}
+	return ""
+}
+
+func (x *WBCardSize) GetSkus() []string {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+type WBCardTag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBCardTag) Reset() {
+	*x = WBCardTag{}
+	mi := &file_api_v1_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBCardTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBCardTag) ProtoMessage() {}
+
+func (x *WBCardTag) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBCardTag.ProtoReflect.Descriptor instead.
+func (*WBCardTag) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WBCardTag) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WBCardTag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WBCardTag) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type UpdatePricesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WbApiKey        string                 `protobuf:"bytes,1,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	OzonApiClientId string                 `protobuf:"bytes,2,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`
+	OzonApiKey      string                 `protobuf:"bytes,3,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	WbPrices        []*WBPrice             `protobuf:"bytes,4,rep,name=wb_prices,json=wbPrices,proto3" json:"wb_prices,omitempty"`
+	OzonPrices      []*OzonPrice           `protobuf:"bytes,5,rep,name=ozon_prices,json=ozonPrices,proto3" json:"ozon_prices,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdatePricesRequest) Reset() {
+	*x = UpdatePricesRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePricesRequest) ProtoMessage() {}
+
+func (x *UpdatePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePricesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePricesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdatePricesRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *UpdatePricesRequest) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *UpdatePricesRequest) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+func (x *UpdatePricesRequest) GetWbPrices() []*WBPrice {
+	if x != nil {
+		return x.WbPrices
+	}
+	return nil
+}
+
+func (x *UpdatePricesRequest) GetOzonPrices() []*OzonPrice {
+	if x != nil {
+		return x.OzonPrices
+	}
+	return nil
+}
+
+// WBPrice is the price of a WB card, WB sets one price for all sizes of a card
+type WBPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NmId          int64                  `protobuf:"varint,1,opt,name=nm_id,json=nmId,proto3" json:"nm_id,omitempty"`
+	Price         int32                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`       // Price before discount, rubles
+	Discount      int32                  `protobuf:"varint,3,opt,name=discount,proto3" json:"discount,omitempty"` // Discount in percent, 0-99
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBPrice) Reset() {
+	*x = WBPrice{}
+	mi := &file_api_v1_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBPrice) ProtoMessage() {}
+
+func (x *WBPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBPrice.ProtoReflect.Descriptor instead.
+func (*WBPrice) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WBPrice) GetNmId() int64 {
+	if x != nil {
+		return x.NmId
+	}
+	return 0
+}
+
+func (x *WBPrice) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *WBPrice) GetDiscount() int32 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type OzonPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Price         int32                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`                       // Price with discount, rubles
+	OldPrice      int32                  `protobuf:"varint,3,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"` // Price before discount, 0 resets it
+	MinPrice      int32                  `protobuf:"varint,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"` // Lowest price for Ozon promotions, 0 leaves it unchanged
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonPrice) Reset() {
+	*x = OzonPrice{}
+	mi := &file_api_v1_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonPrice) ProtoMessage() {}
+
+func (x *OzonPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonPrice.ProtoReflect.Descriptor instead.
+func (*OzonPrice) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OzonPrice) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OzonPrice) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OzonPrice) GetOldPrice() int32 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *OzonPrice) GetMinPrice() int32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+type OzonPriceResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Updated       bool                   `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonPriceResult) Reset() {
+	*x = OzonPriceResult{}
+	mi := &file_api_v1_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonPriceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonPriceResult) ProtoMessage() {}
+
+func (x *OzonPriceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonPriceResult.ProtoReflect.Descriptor instead.
+func (*OzonPriceResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OzonPriceResult) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OzonPriceResult) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OzonPriceResult) GetUpdated() bool {
+	if x != nil {
+		return x.Updated
+	}
+	return false
+}
+
+func (x *OzonPriceResult) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type UpdatePricesResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WbUploadId       int64                  `protobuf:"varint,1,opt,name=wb_upload_id,json=wbUploadId,proto3" json:"wb_upload_id,omitempty"`
+	WbTaskStatus     int32                  `protobuf:"varint,2,opt,name=wb_task_status,json=wbTaskStatus,proto3" json:"wb_task_status,omitempty"` // 3 processed, 4 canceled, 5 partially failed, 6 failed; 0 while WB is still processing the upload
+	WbGoodsTotal     int32                  `protobuf:"varint,3,opt,name=wb_goods_total,json=wbGoodsTotal,proto3" json:"wb_goods_total,omitempty"`
+	WbGoodsSucceeded int32                  `protobuf:"varint,4,opt,name=wb_goods_succeeded,json=wbGoodsSucceeded,proto3" json:"wb_goods_succeeded,omitempty"`
+	WbError          string                 `protobuf:"bytes,5,opt,name=wb_error,json=wbError,proto3" json:"wb_error,omitempty"` // Error of the WB upload or of its processing
+	OzonResults      []*OzonPriceResult     `protobuf:"bytes,6,rep,name=ozon_results,json=ozonResults,proto3" json:"ozon_results,omitempty"`
+	OzonError        string                 `protobuf:"bytes,7,opt,name=ozon_error,json=ozonError,proto3" json:"ozon_error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdatePricesResponse) Reset() {
+	*x = UpdatePricesResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePricesResponse) ProtoMessage() {}
+
+func (x *UpdatePricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePricesResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePricesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdatePricesResponse) GetWbUploadId() int64 {
+	if x != nil {
+		return x.WbUploadId
+	}
+	return 0
+}
+
+func (x *UpdatePricesResponse) GetWbTaskStatus() int32 {
+	if x != nil {
+		return x.WbTaskStatus
+	}
+	return 0
+}
+
+func (x *UpdatePricesResponse) GetWbGoodsTotal() int32 {
+	if x != nil {
+		return x.WbGoodsTotal
+	}
+	return 0
+}
+
+func (x *UpdatePricesResponse) GetWbGoodsSucceeded() int32 {
+	if x != nil {
+		return x.WbGoodsSucceeded
+	}
+	return 0
+}
+
+func (x *UpdatePricesResponse) GetWbError() string {
+	if x != nil {
+		return x.WbError
+	}
+	return ""
+}
+
+func (x *UpdatePricesResponse) GetOzonResults() []*OzonPriceResult {
+	if x != nil {
+		return x.OzonResults
+	}
+	return nil
+}
+
+func (x *UpdatePricesResponse) GetOzonError() string {
+	if x != nil {
+		return x.OzonError
+	}
+	return ""
+}
+
+// CrossListRequest copies an existing card with its content, media, dimensions and sizes to the other marketplace:
+// a WB card (nm_id) to Ozon or an Ozon product (offer_id) to WB
+type CrossListRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	WbApiKey              string                 `protobuf:"bytes,1,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	OzonApiClientId       string                 `protobuf:"bytes,2,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`
+	OzonApiKey            string                 `protobuf:"bytes,3,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	NmId                  int64                  `protobuf:"varint,4,opt,name=nm_id,json=nmId,proto3" json:"nm_id,omitempty"`                                                      // WB card to copy to Ozon
+	DescriptionCategoryId int32                  `protobuf:"varint,5,opt,name=description_category_id,json=descriptionCategoryId,proto3" json:"description_category_id,omitempty"` // Ozon category; taken from the category mappings or resolved by CardCraftAI from the WB subject if unset
+	TypeId                int32                  `protobuf:"varint,6,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`                                                // Ozon type, required together with description_category_id
+	Price                 int32                  `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`                                                                // Price of every size on the target in rubles; defaults to the WB price with discount, or the Ozon price before discount together with the discount
+	DryRun                *bool                  `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3,oneof" json:"dry_run,omitempty"`                                          // Prepare the target request without sending it; defaults to true in development environments
+	OfferId               string                 `protobuf:"bytes,9,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`                                              // Ozon product to copy to WB, instead of nm_id
+	SubjectId             int32                  `protobuf:"varint,10,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`                                      // WB subject; taken from the category mappings or resolved by CardCraftAI from the Ozon category if unset
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CrossListRequest) Reset() {
+	*x = CrossListRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrossListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossListRequest) ProtoMessage() {}
+
+func (x *CrossListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossListRequest.ProtoReflect.Descriptor instead.
+func (*CrossListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CrossListRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *CrossListRequest) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *CrossListRequest) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+func (x *CrossListRequest) GetNmId() int64 {
+	if x != nil {
+		return x.NmId
+	}
+	return 0
+}
+
+func (x *CrossListRequest) GetDescriptionCategoryId() int32 {
+	if x != nil {
+		return x.DescriptionCategoryId
+	}
+	return 0
+}
+
+func (x *CrossListRequest) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *CrossListRequest) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CrossListRequest) GetDryRun() bool {
+	if x != nil && x.DryRun != nil {
+		return *x.DryRun
+	}
+	return false
+}
+
+func (x *CrossListRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *CrossListRequest) GetSubjectId() int32 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+type CrossListResponse struct {
+	state                       protoimpl.MessageState        `protogen:"open.v1"`
+	VendorCode                  string                        `protobuf:"bytes,1,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"` // Ozon offer_id, or its prefix for cards with several sizes; WB vendor code
+	DescriptionCategoryId       int32                         `protobuf:"varint,2,opt,name=description_category_id,json=descriptionCategoryId,proto3" json:"description_category_id,omitempty"`
+	TypeId                      int32                         `protobuf:"varint,3,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName                    string                        `protobuf:"bytes,4,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`                                              // Set when the category was mapped or resolved by CardCraftAI
+	UnmappedCharacteristics     []string                      `protobuf:"bytes,5,rep,name=unmapped_characteristics,json=unmappedCharacteristics,proto3" json:"unmapped_characteristics,omitempty"` // Characteristics without a matching attribute of the target category, left out
+	OzonApiResponseJson         *string                       `protobuf:"bytes,6,opt,name=ozon_api_response_json,json=ozonApiResponseJson,proto3,oneof" json:"ozon_api_response_json,omitempty"`
+	OzonPreparedRequestJson     *string                       `protobuf:"bytes,7,opt,name=ozon_prepared_request_json,json=ozonPreparedRequestJson,proto3,oneof" json:"ozon_prepared_request_json,omitempty"`
+	OzonRequestAttempted        *bool                         `protobuf:"varint,8,opt,name=ozon_request_attempted,json=ozonRequestAttempted,proto3,oneof" json:"ozon_request_attempted,omitempty"`
+	OzonError                   *OzonError                    `protobuf:"bytes,9,opt,name=ozon_error,json=ozonError,proto3,oneof" json:"ozon_error,omitempty"`
+	DryRun                      bool                          `protobuf:"varint,10,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	GeneratedBarcodes           []*GeneratedBarcodes          `protobuf:"bytes,11,rep,name=generated_barcodes,json=generatedBarcodes,proto3" json:"generated_barcodes,omitempty"`
+	SubjectId                   int32                         `protobuf:"varint,12,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`      // WB subject of a card copied to WB
+	SubjectName                 string                        `protobuf:"bytes,13,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"` // Set when the subject was mapped or resolved by CardCraftAI
+	WbApiResponseJson           *string                       `protobuf:"bytes,14,opt,name=wb_api_response_json,json=wbApiResponseJson,proto3,oneof" json:"wb_api_response_json,omitempty"`
+	WbPreparedRequestJson       *string                       `protobuf:"bytes,15,opt,name=wb_prepared_request_json,json=wbPreparedRequestJson,proto3,oneof" json:"wb_prepared_request_json,omitempty"`
+	WbRequestAttempted          *bool                         `protobuf:"varint,16,opt,name=wb_request_attempted,json=wbRequestAttempted,proto3,oneof" json:"wb_request_attempted,omitempty"`
+	WbMediaPending              bool                          `protobuf:"varint,17,opt,name=wb_media_pending,json=wbMediaPending,proto3" json:"wb_media_pending,omitempty"` // Images are saved in the background once WB has created the card
+	WbMediaSaveByLinksResponses []*WBMediaSaveByLinksResponse `protobuf:"bytes,18,rep,name=wb_media_save_by_links_responses,json=wbMediaSaveByLinksResponses,proto3" json:"wb_media_save_by_links_responses,omitempty"`
+	ContentViolations           []*ContentViolation           `protobuf:"bytes,19,rep,name=content_violations,json=contentViolations,proto3" json:"content_violations,omitempty"` // Title and description fixed to fit the target
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *CrossListResponse) Reset() {
+	*x = CrossListResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrossListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossListResponse) ProtoMessage() {}
+
+func (x *CrossListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossListResponse.ProtoReflect.Descriptor instead.
+func (*CrossListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CrossListResponse) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetDescriptionCategoryId() int32 {
+	if x != nil {
+		return x.DescriptionCategoryId
+	}
+	return 0
+}
+
+func (x *CrossListResponse) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+func (x *CrossListResponse) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetUnmappedCharacteristics() []string {
+	if x != nil {
+		return x.UnmappedCharacteristics
+	}
+	return nil
+}
+
+func (x *CrossListResponse) GetOzonApiResponseJson() string {
+	if x != nil && x.OzonApiResponseJson != nil {
+		return *x.OzonApiResponseJson
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetOzonPreparedRequestJson() string {
+	if x != nil && x.OzonPreparedRequestJson != nil {
+		return *x.OzonPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetOzonRequestAttempted() bool {
+	if x != nil && x.OzonRequestAttempted != nil {
+		return *x.OzonRequestAttempted
+	}
+	return false
+}
+
+func (x *CrossListResponse) GetOzonError() *OzonError {
+	if x != nil {
+		return x.OzonError
+	}
+	return nil
+}
+
+func (x *CrossListResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *CrossListResponse) GetGeneratedBarcodes() []*GeneratedBarcodes {
+	if x != nil {
+		return x.GeneratedBarcodes
+	}
+	return nil
+}
+
+func (x *CrossListResponse) GetSubjectId() int32 {
+	if x != nil {
+		return x.SubjectId
+	}
+	return 0
+}
+
+func (x *CrossListResponse) GetSubjectName() string {
+	if x != nil {
+		return x.SubjectName
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetWbApiResponseJson() string {
+	if x != nil && x.WbApiResponseJson != nil {
+		return *x.WbApiResponseJson
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetWbPreparedRequestJson() string {
+	if x != nil && x.WbPreparedRequestJson != nil {
+		return *x.WbPreparedRequestJson
+	}
+	return ""
+}
+
+func (x *CrossListResponse) GetWbRequestAttempted() bool {
+	if x != nil && x.WbRequestAttempted != nil {
+		return *x.WbRequestAttempted
+	}
+	return false
+}
+
+func (x *CrossListResponse) GetWbMediaPending() bool {
+	if x != nil {
+		return x.WbMediaPending
+	}
+	return false
+}
+
+func (x *CrossListResponse) GetWbMediaSaveByLinksResponses() []*WBMediaSaveByLinksResponse {
+	if x != nil {
+		return x.WbMediaSaveByLinksResponses
+	}
+	return nil
+}
+
+func (x *CrossListResponse) GetContentViolations() []*ContentViolation {
+	if x != nil {
+		return x.ContentViolations
+	}
+	return nil
+}
+
+// CategoryMapping is a WB subject and an Ozon category and type resolved together by CardCraftAI or set by a content manager
+type CategoryMapping struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	WbSubjectId                 int32                  `protobuf:"varint,1,opt,name=wb_subject_id,json=wbSubjectId,proto3" json:"wb_subject_id,omitempty"`
+	WbSubjectName               string                 `protobuf:"bytes,2,opt,name=wb_subject_name,json=wbSubjectName,proto3" json:"wb_subject_name,omitempty"`
+	OzonDescriptionCategoryId   int32                  `protobuf:"varint,3,opt,name=ozon_description_category_id,json=ozonDescriptionCategoryId,proto3" json:"ozon_description_category_id,omitempty"`
+	OzonDescriptionCategoryName string                 `protobuf:"bytes,4,opt,name=ozon_description_category_name,json=ozonDescriptionCategoryName,proto3" json:"ozon_description_category_name,omitempty"`
+	OzonTypeId                  int32                  `protobuf:"varint,5,opt,name=ozon_type_id,json=ozonTypeId,proto3" json:"ozon_type_id,omitempty"`
+	OzonTypeName                string                 `protobuf:"bytes,6,opt,name=ozon_type_name,json=ozonTypeName,proto3" json:"ozon_type_name,omitempty"`
+	Resolutions                 int32                  `protobuf:"varint,7,opt,name=resolutions,proto3" json:"resolutions,omitempty"`              // Times CardCraftAI resolved this pair
+	Confidence                  float64                `protobuf:"fixed64,8,opt,name=confidence,proto3" json:"confidence,omitempty"`               // Share of the resolutions of the WB subject that gave this pair
+	Overridden                  bool                   `protobuf:"varint,9,opt,name=overridden,proto3" json:"overridden,omitempty"`                // Set by a content manager, used for the WB subject instead of the resolved pairs
+	UpdatedAt                   string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC 3339
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *CategoryMapping) Reset() {
+	*x = CategoryMapping{}
+	mi := &file_api_v1_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryMapping) ProtoMessage() {}
+
+func (x *CategoryMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryMapping.ProtoReflect.Descriptor instead.
+func (*CategoryMapping) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CategoryMapping) GetWbSubjectId() int32 {
+	if x != nil {
+		return x.WbSubjectId
+	}
+	return 0
+}
+
+func (x *CategoryMapping) GetWbSubjectName() string {
+	if x != nil {
+		return x.WbSubjectName
+	}
+	return ""
+}
+
+func (x *CategoryMapping) GetOzonDescriptionCategoryId() int32 {
+	if x != nil {
+		return x.OzonDescriptionCategoryId
+	}
+	return 0
+}
+
+func (x *CategoryMapping) GetOzonDescriptionCategoryName() string {
+	if x != nil {
+		return x.OzonDescriptionCategoryName
+	}
+	return ""
+}
+
+func (x *CategoryMapping) GetOzonTypeId() int32 {
+	if x != nil {
+		return x.OzonTypeId
+	}
+	return 0
+}
+
+func (x *CategoryMapping) GetOzonTypeName() string {
+	if x != nil {
+		return x.OzonTypeName
+	}
+	return ""
+}
+
+func (x *CategoryMapping) GetResolutions() int32 {
+	if x != nil {
+		return x.Resolutions
+	}
+	return 0
+}
+
+func (x *CategoryMapping) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *CategoryMapping) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+func (x *CategoryMapping) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// ListCategoryMappingsRequest filters the mappings; unset fields match everything
+type ListCategoryMappingsRequest struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	WbSubjectId               int32                  `protobuf:"varint,1,opt,name=wb_subject_id,json=wbSubjectId,proto3" json:"wb_subject_id,omitempty"`
+	OzonDescriptionCategoryId int32                  `protobuf:"varint,2,opt,name=ozon_description_category_id,json=ozonDescriptionCategoryId,proto3" json:"ozon_description_category_id,omitempty"`
+	OzonTypeId                int32                  `protobuf:"varint,3,opt,name=ozon_type_id,json=ozonTypeId,proto3" json:"ozon_type_id,omitempty"`
+	OverriddenOnly            bool                   `protobuf:"varint,4,opt,name=overridden_only,json=overriddenOnly,proto3" json:"overridden_only,omitempty"`
+	Limit                     int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"` // At most 500, the default
+	Offset                    int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListCategoryMappingsRequest) Reset() {
+	*x = ListCategoryMappingsRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryMappingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryMappingsRequest) ProtoMessage() {}
+
+func (x *ListCategoryMappingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryMappingsRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoryMappingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListCategoryMappingsRequest) GetWbSubjectId() int32 {
+	if x != nil {
+		return x.WbSubjectId
+	}
+	return 0
+}
+
+func (x *ListCategoryMappingsRequest) GetOzonDescriptionCategoryId() int32 {
+	if x != nil {
+		return x.OzonDescriptionCategoryId
+	}
+	return 0
+}
+
+func (x *ListCategoryMappingsRequest) GetOzonTypeId() int32 {
+	if x != nil {
+		return x.OzonTypeId
+	}
+	return 0
+}
+
+func (x *ListCategoryMappingsRequest) GetOverriddenOnly() bool {
+	if x != nil {
+		return x.OverriddenOnly
+	}
+	return false
+}
+
+func (x *ListCategoryMappingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCategoryMappingsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListCategoryMappingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mappings      []*CategoryMapping     `protobuf:"bytes,1,rep,name=mappings,proto3" json:"mappings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryMappingsResponse) Reset() {
+	*x = ListCategoryMappingsResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryMappingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryMappingsResponse) ProtoMessage() {}
+
+func (x *ListCategoryMappingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryMappingsResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoryMappingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListCategoryMappingsResponse) GetMappings() []*CategoryMapping {
+	if x != nil {
+		return x.Mappings
+	}
+	return nil
+}
+
+// OverrideCategoryMappingRequest sets the Ozon category used for a WB subject, and the WB subject used for the Ozon
+// category, in all future cards. Only API keys of content managers may override mappings.
+type OverrideCategoryMappingRequest struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	WbSubjectId                 int32                  `protobuf:"varint,1,opt,name=wb_subject_id,json=wbSubjectId,proto3" json:"wb_subject_id,omitempty"`
+	OzonDescriptionCategoryId   int32                  `protobuf:"varint,2,opt,name=ozon_description_category_id,json=ozonDescriptionCategoryId,proto3" json:"ozon_description_category_id,omitempty"` // Required unless reset_override is set
+	OzonTypeId                  int32                  `protobuf:"varint,3,opt,name=ozon_type_id,json=ozonTypeId,proto3" json:"ozon_type_id,omitempty"`                                                // Required unless reset_override is set
+	WbSubjectName               string                 `protobuf:"bytes,4,opt,name=wb_subject_name,json=wbSubjectName,proto3" json:"wb_subject_name,omitempty"`                                        // Names are kept from the resolved pair if unset
+	OzonDescriptionCategoryName string                 `protobuf:"bytes,5,opt,name=ozon_description_category_name,json=ozonDescriptionCategoryName,proto3" json:"ozon_description_category_name,omitempty"`
+	OzonTypeName                string                 `protobuf:"bytes,6,opt,name=ozon_type_name,json=ozonTypeName,proto3" json:"ozon_type_name,omitempty"`
+	ResetOverride               bool                   `protobuf:"varint,7,opt,name=reset_override,json=resetOverride,proto3" json:"reset_override,omitempty"` // Remove the override of the WB subject, so the resolved pairs are used again
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *OverrideCategoryMappingRequest) Reset() {
+	*x = OverrideCategoryMappingRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverrideCategoryMappingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideCategoryMappingRequest) ProtoMessage() {}
+
+func (x *OverrideCategoryMappingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideCategoryMappingRequest.ProtoReflect.Descriptor instead.
+func (*OverrideCategoryMappingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *OverrideCategoryMappingRequest) GetWbSubjectId() int32 {
+	if x != nil {
+		return x.WbSubjectId
+	}
+	return 0
+}
+
+func (x *OverrideCategoryMappingRequest) GetOzonDescriptionCategoryId() int32 {
+	if x != nil {
+		return x.OzonDescriptionCategoryId
+	}
+	return 0
+}
+
+func (x *OverrideCategoryMappingRequest) GetOzonTypeId() int32 {
+	if x != nil {
+		return x.OzonTypeId
+	}
+	return 0
+}
+
+func (x *OverrideCategoryMappingRequest) GetWbSubjectName() string {
+	if x != nil {
+		return x.WbSubjectName
+	}
+	return ""
+}
+
+func (x *OverrideCategoryMappingRequest) GetOzonDescriptionCategoryName() string {
+	if x != nil {
+		return x.OzonDescriptionCategoryName
+	}
+	return ""
+}
+
+func (x *OverrideCategoryMappingRequest) GetOzonTypeName() string {
+	if x != nil {
+		return x.OzonTypeName
+	}
+	return ""
+}
+
+func (x *OverrideCategoryMappingRequest) GetResetOverride() bool {
+	if x != nil {
+		return x.ResetOverride
+	}
+	return false
+}
+
+type OverrideCategoryMappingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverrideCategoryMappingResponse) Reset() {
+	*x = OverrideCategoryMappingResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverrideCategoryMappingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideCategoryMappingResponse) ProtoMessage() {}
+
+func (x *OverrideCategoryMappingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideCategoryMappingResponse.ProtoReflect.Descriptor instead.
+func (*OverrideCategoryMappingResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{35}
+}
+
+// Balance request and response messages
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{36}
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"` // Current balance amount
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetBalanceResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+// Payment system messages
+type PaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`                              // Amount in kopecks (1 ruble = 100 kopecks)
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`                                 // Customer email
+	OrderNumber   int64                  `protobuf:"varint,3,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"` // Unique order number
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                     // Payment description
+	EndDate       string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`              // Subscription end date (YYYY-MM-DD format)
+	Receipt       *Receipt               `protobuf:"bytes,6,opt,name=receipt,proto3" json:"receipt,omitempty"`                             // Receipt data for fiscal compliance
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentRequest) Reset() {
+	*x = PaymentRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequest) ProtoMessage() {}
+
+func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
+func (*PaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetOrderNumber() int64 {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return 0
+}
+
+func (x *PaymentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type Receipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`       // Customer email for receipt
+	Taxation      string                 `protobuf:"bytes,2,opt,name=taxation,proto3" json:"taxation,omitempty"` // Taxation system (e.g., "usn_income")
+	Items         []*ReceiptItem         `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`       // Items in the receipt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_api_v1_product_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Receipt) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Receipt) GetTaxation() string {
+	if x != nil {
+		return x.Taxation
+	}
+	return ""
+}
+
+func (x *Receipt) GetItems() []*ReceiptItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReceiptItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                        // Item name
+	Price         int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`                                     // Item price in kopecks
+	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`                              // Item quantity
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                   // Total amount for this item in kopecks
+	Tax           string                 `protobuf:"bytes,5,opt,name=tax,proto3" json:"tax,omitempty"`                                          // Tax type (e.g., "none", "vat10", "vat20")
+	PaymentMethod string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Payment method (e.g., "full_payment")
+	PaymentObject string                 `protobuf:"bytes,7,opt,name=payment_object,json=paymentObject,proto3" json:"payment_object,omitempty"` // Payment object type (e.g., "service", "commodity")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptItem) Reset() {
+	*x = ReceiptItem{}
+	mi := &file_api_v1_product_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptItem) ProtoMessage() {}
+
+func (x *ReceiptItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptItem.ProtoReflect.Descriptor instead.
+func (*ReceiptItem) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReceiptItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReceiptItem) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ReceiptItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReceiptItem) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReceiptItem) GetTax() string {
+	if x != nil {
+		return x.Tax
+	}
+	return ""
+}
+
+func (x *ReceiptItem) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *ReceiptItem) GetPaymentObject() string {
+	if x != nil {
+		return x.PaymentObject
+	}
+	return ""
+}
+
+type PaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                              // Whether the payment request was successful
+	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`       // URL for customer to complete payment
+	PaymentId     string                 `protobuf:"bytes,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`          // Tinkoff payment ID
+	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Error message if success is false
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentResponse) Reset() {
+	*x = PaymentResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentResponse) ProtoMessage() {}
+
+func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentResponse.ProtoReflect.Descriptor instead.
+func (*PaymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PaymentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PaymentResponse) GetPaymentUrl() string {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type TinkoffNotificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TerminalKey   string                 `protobuf:"bytes,1,opt,name=terminal_key,json=terminalKey,proto3" json:"terminal_key,omitempty"` // Terminal key
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`                             // Payment amount
+	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`            // Order ID
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`                           // Payment success status
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                              // Payment status
+	PaymentId     int64                  `protobuf:"varint,6,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`      // Payment ID
+	ErrorCode     string                 `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`       // Error code (if any)
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`                            // Status message
+	Details       string                 `protobuf:"bytes,9,opt,name=details,proto3" json:"details,omitempty"`                            // Additional details
+	Token         string                 `protobuf:"bytes,10,opt,name=token,proto3" json:"token,omitempty"`                               // Security token/signature
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TinkoffNotificationRequest) Reset() {
+	*x = TinkoffNotificationRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TinkoffNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinkoffNotificationRequest) ProtoMessage() {}
+
+func (x *TinkoffNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinkoffNotificationRequest.ProtoReflect.Descriptor instead.
+func (*TinkoffNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TinkoffNotificationRequest) GetTerminalKey() string {
+	if x != nil {
+		return x.TerminalKey
+	}
+	return ""
+}
+
+func (x *TinkoffNotificationRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TinkoffNotificationRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *TinkoffNotificationRequest) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TinkoffNotificationRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TinkoffNotificationRequest) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *TinkoffNotificationRequest) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *TinkoffNotificationRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TinkoffNotificationRequest) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *TinkoffNotificationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type TinkoffNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // Response status ("OK" for successful processing)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TinkoffNotificationResponse) Reset() {
+	*x = TinkoffNotificationResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TinkoffNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TinkoffNotificationResponse) ProtoMessage() {}
+
+func (x *TinkoffNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TinkoffNotificationResponse.ProtoReflect.Descriptor instead.
+func (*TinkoffNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TinkoffNotificationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Media upload messages
+type UploadMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadMediaRequest_Metadata
+	//	*UploadMediaRequest_Chunk
+	Payload       isUploadMediaRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMediaRequest) Reset() {
+	*x = UploadMediaRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMediaRequest) ProtoMessage() {}
+
+func (x *UploadMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMediaRequest.ProtoReflect.Descriptor instead.
+func (*UploadMediaRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UploadMediaRequest) GetPayload() isUploadMediaRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadMediaRequest) GetMetadata() *MediaMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadMediaRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadMediaRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadMediaRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadMediaRequest_Payload interface {
+	isUploadMediaRequest_Payload()
+}
+
+type UploadMediaRequest_Metadata struct {
+	Metadata *MediaMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"` // Must be the first message of the stream
+}
+
+type UploadMediaRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"` // Subsequent messages carry file content
+}
+
+func (*UploadMediaRequest_Metadata) isUploadMediaRequest_Payload() {}
+
+func (*UploadMediaRequest_Chunk) isUploadMediaRequest_Payload() {}
+
+type MediaMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                          // Original filename, e.g. "photo.jpg"
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Optional, detected from filename if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaMetadata) Reset() {
+	*x = MediaMetadata{}
+	mi := &file_api_v1_product_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaMetadata) ProtoMessage() {}
+
+func (x *MediaMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaMetadata.ProtoReflect.Descriptor instead.
+func (*MediaMetadata) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MediaMetadata) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type UploadMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaId       string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"` // ID to reference the media in CreateRequest.media
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                        // Public URL of the stored media
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                     // Stored size in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadMediaResponse) Reset() {
+	*x = UploadMediaResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMediaResponse) ProtoMessage() {}
+
+func (x *UploadMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadMediaResponse.ProtoReflect.Descriptor instead.
+func (*UploadMediaResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UploadMediaResponse) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *UploadMediaResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UploadMediaResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Stock messages
+type ListWarehousesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WbApiKey        string                 `protobuf:"bytes,1,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`                        // WB warehouses are listed if set
+	OzonApiClientId string                 `protobuf:"bytes,2,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"` // Ozon warehouses are listed if both Ozon credentials are set
+	OzonApiKey      string                 `protobuf:"bytes,3,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListWarehousesRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *ListWarehousesRequest) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *ListWarehousesRequest) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marketplace   Marketplace            `protobuf:"varint,1,opt,name=marketplace,proto3,enum=api.v1.Marketplace" json:"marketplace,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_api_v1_product_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Warehouse) GetMarketplace() Marketplace {
+	if x != nil {
+		return x.Marketplace
+	}
+	return Marketplace_MARKETPLACE_UNSPECIFIED
+}
+
+func (x *Warehouse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	WbError       string                 `protobuf:"bytes,2,opt,name=wb_error,json=wbError,proto3" json:"wb_error,omitempty"`
+	OzonError     string                 `protobuf:"bytes,3,opt,name=ozon_error,json=ozonError,proto3" json:"ozon_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+func (x *ListWarehousesResponse) GetWbError() string {
+	if x != nil {
+		return x.WbError
+	}
+	return ""
+}
+
+func (x *ListWarehousesResponse) GetOzonError() string {
+	if x != nil {
+		return x.OzonError
+	}
+	return ""
+}
+
+// WBStock is the stock of one size, identified by its barcode
+type WBStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"` // Barcode of the size
+	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WBStock) Reset() {
+	*x = WBStock{}
+	mi := &file_api_v1_product_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WBStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WBStock) ProtoMessage() {}
+
+func (x *WBStock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WBStock.ProtoReflect.Descriptor instead.
+func (*WBStock) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WBStock) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *WBStock) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *WBStock) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type OzonStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonStock) Reset() {
+	*x = OzonStock{}
+	mi := &file_api_v1_product_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonStock) ProtoMessage() {}
+
+func (x *OzonStock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonStock.ProtoReflect.Descriptor instead.
+func (*OzonStock) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *OzonStock) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *OzonStock) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OzonStock) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type SetStocksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WbApiKey        string                 `protobuf:"bytes,1,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	OzonApiClientId string                 `protobuf:"bytes,2,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`
+	OzonApiKey      string                 `protobuf:"bytes,3,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	WbStocks        []*WBStock             `protobuf:"bytes,4,rep,name=wb_stocks,json=wbStocks,proto3" json:"wb_stocks,omitempty"`
+	OzonStocks      []*OzonStock           `protobuf:"bytes,5,rep,name=ozon_stocks,json=ozonStocks,proto3" json:"ozon_stocks,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetStocksRequest) Reset() {
+	*x = SetStocksRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStocksRequest) ProtoMessage() {}
+
+func (x *SetStocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStocksRequest.ProtoReflect.Descriptor instead.
+func (*SetStocksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SetStocksRequest) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *SetStocksRequest) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *SetStocksRequest) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+func (x *SetStocksRequest) GetWbStocks() []*WBStock {
+	if x != nil {
+		return x.WbStocks
+	}
+	return nil
+}
+
+func (x *SetStocksRequest) GetOzonStocks() []*OzonStock {
+	if x != nil {
+		return x.OzonStocks
+	}
+	return nil
+}
+
+type OzonStockResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Updated       bool                   `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	Errors        []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OzonStockResult) Reset() {
+	*x = OzonStockResult{}
+	mi := &file_api_v1_product_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OzonStockResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OzonStockResult) ProtoMessage() {}
+
+func (x *OzonStockResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OzonStockResult.ProtoReflect.Descriptor instead.
+func (*OzonStockResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *OzonStockResult) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *OzonStockResult) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OzonStockResult) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OzonStockResult) GetUpdated() bool {
+	if x != nil {
+		return x.Updated
+	}
+	return false
+}
+
+func (x *OzonStockResult) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SetStocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WbError       string                 `protobuf:"bytes,1,opt,name=wb_error,json=wbError,proto3" json:"wb_error,omitempty"` // WB accepts or rejects the stocks of a warehouse as a whole
+	OzonResults   []*OzonStockResult     `protobuf:"bytes,2,rep,name=ozon_results,json=ozonResults,proto3" json:"ozon_results,omitempty"`
+	OzonError     string                 `protobuf:"bytes,3,opt,name=ozon_error,json=ozonError,proto3" json:"ozon_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStocksResponse) Reset() {
+	*x = SetStocksResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStocksResponse) ProtoMessage() {}
+
+func (x *SetStocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStocksResponse.ProtoReflect.Descriptor instead.
+func (*SetStocksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SetStocksResponse) GetWbError() string {
+	if x != nil {
+		return x.WbError
+	}
+	return ""
+}
+
+func (x *SetStocksResponse) GetOzonResults() []*OzonStockResult {
+	if x != nil {
+		return x.OzonResults
+	}
+	return nil
+}
+
+func (x *SetStocksResponse) GetOzonError() string {
+	if x != nil {
+		return x.OzonError
+	}
+	return ""
+}
+
+// StockSyncSettings configure copying stocks of one FBS warehouse from the source marketplace to the other one
+type StockSyncSettings struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Source          Marketplace            `protobuf:"varint,1,opt,name=source,proto3,enum=api.v1.Marketplace" json:"source,omitempty"` // Source of truth of the stocks
+	WbApiKey        string                 `protobuf:"bytes,2,opt,name=wb_api_key,json=wbApiKey,proto3" json:"wb_api_key,omitempty"`
+	WbWarehouseId   int64                  `protobuf:"varint,3,opt,name=wb_warehouse_id,json=wbWarehouseId,proto3" json:"wb_warehouse_id,omitempty"`
+	OzonApiClientId string                 `protobuf:"bytes,4,opt,name=ozon_api_client_id,json=ozonApiClientId,proto3" json:"ozon_api_client_id,omitempty"`
+	OzonApiKey      string                 `protobuf:"bytes,5,opt,name=ozon_api_key,json=ozonApiKey,proto3" json:"ozon_api_key,omitempty"`
+	OzonWarehouseId int64                  `protobuf:"varint,6,opt,name=ozon_warehouse_id,json=ozonWarehouseId,proto3" json:"ozon_warehouse_id,omitempty"`
+	Enabled         bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`             // Sync periodically; disabled accounts can still be synced with RunStockSync
+	DryRun          bool                   `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Report the changes without writing them
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StockSyncSettings) Reset() {
+	*x = StockSyncSettings{}
+	mi := &file_api_v1_product_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSyncSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSyncSettings) ProtoMessage() {}
+
+func (x *StockSyncSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockSyncSettings.ProtoReflect.Descriptor instead.
+func (*StockSyncSettings) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *StockSyncSettings) GetSource() Marketplace {
+	if x != nil {
+		return x.Source
+	}
+	return Marketplace_MARKETPLACE_UNSPECIFIED
+}
+
+func (x *StockSyncSettings) GetWbApiKey() string {
+	if x != nil {
+		return x.WbApiKey
+	}
+	return ""
+}
+
+func (x *StockSyncSettings) GetWbWarehouseId() int64 {
+	if x != nil {
+		return x.WbWarehouseId
+	}
+	return 0
+}
+
+func (x *StockSyncSettings) GetOzonApiClientId() string {
+	if x != nil {
+		return x.OzonApiClientId
+	}
+	return ""
+}
+
+func (x *StockSyncSettings) GetOzonApiKey() string {
+	if x != nil {
+		return x.OzonApiKey
+	}
+	return ""
+}
+
+func (x *StockSyncSettings) GetOzonWarehouseId() int64 {
+	if x != nil {
+		return x.OzonWarehouseId
+	}
+	return 0
+}
+
+func (x *StockSyncSettings) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *StockSyncSettings) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type SetStockSyncSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *StockSyncSettings     `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockSyncSettingsRequest) Reset() {
+	*x = SetStockSyncSettingsRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockSyncSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockSyncSettingsRequest) ProtoMessage() {}
+
+func (x *SetStockSyncSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockSyncSettingsRequest.ProtoReflect.Descriptor instead.
+func (*SetStockSyncSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetStockSyncSettingsRequest) GetSettings() *StockSyncSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SetStockSyncSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockSyncSettingsResponse) Reset() {
+	*x = SetStockSyncSettingsResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockSyncSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockSyncSettingsResponse) ProtoMessage() {}
+
+func (x *SetStockSyncSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockSyncSettingsResponse.ProtoReflect.Descriptor instead.
+func (*SetStockSyncSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{57}
+}
+
+// StockSyncMapping links a WB size to the Ozon offer of the same item
+type StockSyncMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WbSku         string                 `protobuf:"bytes,1,opt,name=wb_sku,json=wbSku,proto3" json:"wb_sku,omitempty"`                // Barcode of the WB size
+	VendorCode    string                 `protobuf:"bytes,2,opt,name=vendor_code,json=vendorCode,proto3" json:"vendor_code,omitempty"` // WB vendor code, informational
+	OzonOfferId   string                 `protobuf:"bytes,3,opt,name=ozon_offer_id,json=ozonOfferId,proto3" json:"ozon_offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockSyncMapping) Reset() {
+	*x = StockSyncMapping{}
+	mi := &file_api_v1_product_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSyncMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSyncMapping) ProtoMessage() {}
+
+func (x *StockSyncMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockSyncMapping.ProtoReflect.Descriptor instead.
+func (*StockSyncMapping) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *StockSyncMapping) GetWbSku() string {
+	if x != nil {
+		return x.WbSku
+	}
+	return ""
+}
+
+func (x *StockSyncMapping) GetVendorCode() string {
+	if x != nil {
+		return x.VendorCode
+	}
+	return ""
+}
+
+func (x *StockSyncMapping) GetOzonOfferId() string {
+	if x != nil {
+		return x.OzonOfferId
+	}
+	return ""
+}
+
+type SetStockSyncMappingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mappings      []*StockSyncMapping    `protobuf:"bytes,1,rep,name=mappings,proto3" json:"mappings,omitempty"` // Replaces all mappings of the account
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockSyncMappingsRequest) Reset() {
+	*x = SetStockSyncMappingsRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockSyncMappingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockSyncMappingsRequest) ProtoMessage() {}
+
+func (x *SetStockSyncMappingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockSyncMappingsRequest.ProtoReflect.Descriptor instead.
+func (*SetStockSyncMappingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SetStockSyncMappingsRequest) GetMappings() []*StockSyncMapping {
+	if x != nil {
+		return x.Mappings
+	}
+	return nil
+}
+
+type SetStockSyncMappingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStockSyncMappingsResponse) Reset() {
+	*x = SetStockSyncMappingsResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStockSyncMappingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStockSyncMappingsResponse) ProtoMessage() {}
+
+func (x *SetStockSyncMappingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStockSyncMappingsResponse.ProtoReflect.Descriptor instead.
+func (*SetStockSyncMappingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{60}
+}
+
+type RunStockSyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        *bool                  `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3,oneof" json:"dry_run,omitempty"` // Defaults to the dry_run setting of the account
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunStockSyncRequest) Reset() {
+	*x = RunStockSyncRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunStockSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunStockSyncRequest) ProtoMessage() {}
+
+func (x *RunStockSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunStockSyncRequest.ProtoReflect.Descriptor instead.
+func (*RunStockSyncRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RunStockSyncRequest) GetDryRun() bool {
+	if x != nil && x.DryRun != nil {
+		return *x.DryRun
+	}
+	return false
+}
+
+type GetStockSyncReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockSyncReportRequest) Reset() {
+	*x = GetStockSyncReportRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockSyncReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockSyncReportRequest) ProtoMessage() {}
+
+func (x *GetStockSyncReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockSyncReportRequest.ProtoReflect.Descriptor instead.
+func (*GetStockSyncReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{62}
+}
+
+type StockSyncChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WbSku         string                 `protobuf:"bytes,1,opt,name=wb_sku,json=wbSku,proto3" json:"wb_sku,omitempty"`
+	OzonOfferId   string                 `protobuf:"bytes,2,opt,name=ozon_offer_id,json=ozonOfferId,proto3" json:"ozon_offer_id,omitempty"`
+	From          int32                  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"` // Stock on the target marketplace before the sync
+	To            int32                  `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`     // Stock on the source marketplace
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockSyncChange) Reset() {
+	*x = StockSyncChange{}
+	mi := &file_api_v1_product_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSyncChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSyncChange) ProtoMessage() {}
+
+func (x *StockSyncChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockSyncChange.ProtoReflect.Descriptor instead.
+func (*StockSyncChange) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *StockSyncChange) GetWbSku() string {
 	if x != nil {
-		return x.RootId
+		return x.WbSku
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateResponse) GetRootName() string {
+func (x *StockSyncChange) GetOzonOfferId() string {
 	if x != nil {
-		return x.RootName
+		return x.OzonOfferId
 	}
 	return ""
 }
 
-func (x *CreateResponse) GetTypeId() int32 {
+func (x *StockSyncChange) GetFrom() int32 {
 	if x != nil {
-		return x.TypeId
+		return x.From
 	}
 	return 0
 }
 
-func (x *CreateResponse) GetTypeName() string {
+func (x *StockSyncChange) GetTo() int32 {
 	if x != nil {
-		return x.TypeName
+		return x.To
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateResponse) GetSubId() int32 {
-	if x != nil {
-		return x.SubId
-	}
-	return 0
+type StockSyncConflict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WbSku         string                 `protobuf:"bytes,1,opt,name=wb_sku,json=wbSku,proto3" json:"wb_sku,omitempty"`
+	OzonOfferId   string                 `protobuf:"bytes,2,opt,name=ozon_offer_id,json=ozonOfferId,proto3" json:"ozon_offer_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // missing_in_source, missing_in_target, target_changed or update_failed
+	SourceAmount  int32                  `protobuf:"varint,4,opt,name=source_amount,json=sourceAmount,proto3" json:"source_amount,omitempty"`
+	TargetAmount  int32                  `protobuf:"varint,5,opt,name=target_amount,json=targetAmount,proto3" json:"target_amount,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateResponse) GetSubName() string {
+func (x *StockSyncConflict) Reset() {
+	*x = StockSyncConflict{}
+	mi := &file_api_v1_product_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSyncConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSyncConflict) ProtoMessage() {}
+
+func (x *StockSyncConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[64]
 	if x != nil {
-		return x.SubName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateResponse) GetWbApiResponseJson() string {
-	if x != nil && x.WbApiResponseJson != nil {
-		return *x.WbApiResponseJson
-	}
-	return ""
+// Deprecated: Use StockSyncConflict.ProtoReflect.Descriptor instead.
+func (*StockSyncConflict) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *CreateResponse) GetWbPreparedRequestJson() string {
-	if x != nil && x.WbPreparedRequestJson != nil {
-		return *x.WbPreparedRequestJson
+func (x *StockSyncConflict) GetWbSku() string {
+	if x != nil {
+		return x.WbSku
 	}
 	return ""
 }
 
-func (x *CreateResponse) GetWbRequestAttempted() bool {
-	if x != nil && x.WbRequestAttempted != nil {
-		return *x.WbRequestAttempted
+func (x *StockSyncConflict) GetOzonOfferId() string {
+	if x != nil {
+		return x.OzonOfferId
 	}
-	return false
+	return ""
 }
 
-func (x *CreateResponse) GetWbMediaUploadIndividualResponses() []*WBMediaUploadIndividualResponse {
+func (x *StockSyncConflict) GetKind() string {
 	if x != nil {
-		return x.WbMediaUploadIndividualResponses
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateResponse) GetWbMediaSaveByLinksResponse() *WBMediaSaveByLinksResponse {
+func (x *StockSyncConflict) GetSourceAmount() int32 {
 	if x != nil {
-		return x.WbMediaSaveByLinksResponse
+		return x.SourceAmount
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateResponse) GetOzonApiResponseJson() string {
-	if x != nil && x.OzonApiResponseJson != nil {
-		return *x.OzonApiResponseJson
+func (x *StockSyncConflict) GetTargetAmount() int32 {
+	if x != nil {
+		return x.TargetAmount
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateResponse) GetOzonRequestAttempted() bool {
-	if x != nil && x.OzonRequestAttempted != nil {
-		return *x.OzonRequestAttempted
+func (x *StockSyncConflict) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
-	return false
+	return ""
 }
 
-type WBMediaUploadIndividualResponse struct {
+type StockSyncReport struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PhotoNumber   int32                  `protobuf:"varint,1,opt,name=photo_number,json=photoNumber,proto3" json:"photo_number,omitempty"`         // Corresponds to the photo_number from WBMediaFileToUpload
-	ResponseJson  *string                `protobuf:"bytes,2,opt,name=response_json,json=responseJson,proto3,oneof" json:"response_json,omitempty"` // JSON string of WBMediaGenericResponse
-	ErrorMessage  *string                `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"` // Error message if this specific upload failed
+	Source        Marketplace            `protobuf:"varint,1,opt,name=source,proto3,enum=api.v1.Marketplace" json:"source,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // RFC 3339
+	FinishedAt    string                 `protobuf:"bytes,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Changes       []*StockSyncChange     `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"` // Written, or to be written in dry-run mode
+	Conflicts     []*StockSyncConflict   `protobuf:"bytes,6,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Set if the sync failed as a whole
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WBMediaUploadIndividualResponse) Reset() {
-	*x = WBMediaUploadIndividualResponse{}
-	mi := &file_api_v1_product_proto_msgTypes[5]
+func (x *StockSyncReport) Reset() {
+	*x = StockSyncReport{}
+	mi := &file_api_v1_product_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WBMediaUploadIndividualResponse) String() string {
+func (x *StockSyncReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WBMediaUploadIndividualResponse) ProtoMessage() {}
+func (*StockSyncReport) ProtoMessage() {}
 
-func (x *WBMediaUploadIndividualResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[5]
+func (x *StockSyncReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,106 +5519,82 @@ func (x *WBMediaUploadIndividualResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WBMediaUploadIndividualResponse.ProtoReflect.Descriptor instead.
-func (*WBMediaUploadIndividualResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use StockSyncReport.ProtoReflect.Descriptor instead.
+func (*StockSyncReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *WBMediaUploadIndividualResponse) GetPhotoNumber() int32 {
+func (x *StockSyncReport) GetSource() Marketplace {
 	if x != nil {
-		return x.PhotoNumber
+		return x.Source
 	}
-	return 0
+	return Marketplace_MARKETPLACE_UNSPECIFIED
 }
 
-func (x *WBMediaUploadIndividualResponse) GetResponseJson() string {
-	if x != nil && x.ResponseJson != nil {
-		return *x.ResponseJson
+func (x *StockSyncReport) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-func (x *WBMediaUploadIndividualResponse) GetErrorMessage() string {
-	if x != nil && x.ErrorMessage != nil {
-		return *x.ErrorMessage
+func (x *StockSyncReport) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
 	}
 	return ""
 }
 
-type WBMediaSaveByLinksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResponseJson  *string                `protobuf:"bytes,1,opt,name=response_json,json=responseJson,proto3,oneof" json:"response_json,omitempty"` // JSON string of WBMediaGenericResponse
-	ErrorMessage  *string                `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"` // Error message if save by links operation failed
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *WBMediaSaveByLinksResponse) Reset() {
-	*x = WBMediaSaveByLinksResponse{}
-	mi := &file_api_v1_product_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *WBMediaSaveByLinksResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*WBMediaSaveByLinksResponse) ProtoMessage() {}
-
-func (x *WBMediaSaveByLinksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[6]
+func (x *StockSyncReport) GetFinishedAt() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FinishedAt
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use WBMediaSaveByLinksResponse.ProtoReflect.Descriptor instead.
-func (*WBMediaSaveByLinksResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{6}
+func (x *StockSyncReport) GetChanges() []*StockSyncChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
 }
 
-func (x *WBMediaSaveByLinksResponse) GetResponseJson() string {
-	if x != nil && x.ResponseJson != nil {
-		return *x.ResponseJson
+func (x *StockSyncReport) GetConflicts() []*StockSyncConflict {
+	if x != nil {
+		return x.Conflicts
 	}
-	return ""
+	return nil
 }
 
-func (x *WBMediaSaveByLinksResponse) GetErrorMessage() string {
-	if x != nil && x.ErrorMessage != nil {
-		return *x.ErrorMessage
+func (x *StockSyncReport) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-// Balance request and response messages
-type GetBalanceRequest struct {
+type StockSyncReportResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *StockSyncReport       `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"` // Unset if the account has not been synced yet
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBalanceRequest) Reset() {
-	*x = GetBalanceRequest{}
-	mi := &file_api_v1_product_proto_msgTypes[7]
+func (x *StockSyncReportResponse) Reset() {
+	*x = StockSyncReportResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBalanceRequest) String() string {
+func (x *StockSyncReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceRequest) ProtoMessage() {}
+func (*StockSyncReportResponse) ProtoMessage() {}
 
-func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[7]
+func (x *StockSyncReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -805,33 +5605,42 @@ func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
-func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use StockSyncReportResponse.ProtoReflect.Descriptor instead.
+func (*StockSyncReportResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{66}
 }
 
-type GetBalanceResponse struct {
+func (x *StockSyncReportResponse) GetReport() *StockSyncReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type WBParentCategory struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"` // Current balance amount
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsVisible     bool                   `protobuf:"varint,3,opt,name=is_visible,json=isVisible,proto3" json:"is_visible,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBalanceResponse) Reset() {
-	*x = GetBalanceResponse{}
-	mi := &file_api_v1_product_proto_msgTypes[8]
+func (x *WBParentCategory) Reset() {
+	*x = WBParentCategory{}
+	mi := &file_api_v1_product_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBalanceResponse) String() string {
+func (x *WBParentCategory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBalanceResponse) ProtoMessage() {}
+func (*WBParentCategory) ProtoMessage() {}
 
-func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[8]
+func (x *WBParentCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,46 +5651,53 @@ func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
-func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use WBParentCategory.ProtoReflect.Descriptor instead.
+func (*WBParentCategory) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *GetBalanceResponse) GetBalance() int32 {
+func (x *WBParentCategory) GetId() int32 {
 	if x != nil {
-		return x.Balance
+		return x.Id
 	}
 	return 0
 }
 
-// Payment system messages
-type PaymentRequest struct {
+func (x *WBParentCategory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WBParentCategory) GetIsVisible() bool {
+	if x != nil {
+		return x.IsVisible
+	}
+	return false
+}
+
+type ListWBParentCategoriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`                              // Amount in kopecks (1 ruble = 100 kopecks)
-	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`                                 // Customer email
-	OrderNumber   int64                  `protobuf:"varint,3,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"` // Unique order number
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                     // Payment description
-	EndDate       string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`              // Subscription end date (YYYY-MM-DD format)
-	Receipt       *Receipt               `protobuf:"bytes,6,opt,name=receipt,proto3" json:"receipt,omitempty"`                             // Receipt data for fiscal compliance
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentRequest) Reset() {
-	*x = PaymentRequest{}
-	mi := &file_api_v1_product_proto_msgTypes[9]
+func (x *ListWBParentCategoriesRequest) Reset() {
+	*x = ListWBParentCategoriesRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentRequest) String() string {
+func (x *ListWBParentCategoriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentRequest) ProtoMessage() {}
+func (*ListWBParentCategoriesRequest) ProtoMessage() {}
 
-func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[9]
+func (x *ListWBParentCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,77 +5708,80 @@ func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
-func (*PaymentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListWBParentCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWBParentCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *PaymentRequest) GetAmount() int64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
+type ListWBParentCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*WBParentCategory    `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+func (x *ListWBParentCategoriesResponse) Reset() {
+	*x = ListWBParentCategoriesResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentRequest) GetOrderNumber() int64 {
-	if x != nil {
-		return x.OrderNumber
-	}
-	return 0
+func (x *ListWBParentCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PaymentRequest) GetDescription() string {
+func (*ListWBParentCategoriesResponse) ProtoMessage() {}
+
+func (x *ListWBParentCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[69]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PaymentRequest) GetEndDate() string {
-	if x != nil {
-		return x.EndDate
-	}
-	return ""
+// Deprecated: Use ListWBParentCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWBParentCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *PaymentRequest) GetReceipt() *Receipt {
+func (x *ListWBParentCategoriesResponse) GetCategories() []*WBParentCategory {
 	if x != nil {
-		return x.Receipt
+		return x.Categories
 	}
 	return nil
 }
 
-type Receipt struct {
+type WBSubject struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`       // Customer email for receipt
-	Taxation      string                 `protobuf:"bytes,2,opt,name=taxation,proto3" json:"taxation,omitempty"` // Taxation system (e.g., "usn_income")
-	Items         []*ReceiptItem         `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`       // Items in the receipt
+	SubjectId     int32                  `protobuf:"varint,1,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"`
+	SubjectName   string                 `protobuf:"bytes,2,opt,name=subject_name,json=subjectName,proto3" json:"subject_name,omitempty"`
+	ParentId      int32                  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ParentName    string                 `protobuf:"bytes,4,opt,name=parent_name,json=parentName,proto3" json:"parent_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Receipt) Reset() {
-	*x = Receipt{}
-	mi := &file_api_v1_product_proto_msgTypes[10]
+func (x *WBSubject) Reset() {
+	*x = WBSubject{}
+	mi := &file_api_v1_product_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Receipt) String() string {
+func (x *WBSubject) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Receipt) ProtoMessage() {}
-
-func (x *Receipt) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[10]
+func (*WBSubject) ProtoMessage() {}
+
+func (x *WBSubject) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,60 +5792,64 @@ func (x *Receipt) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
-func (*Receipt) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use WBSubject.ProtoReflect.Descriptor instead.
+func (*WBSubject) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *Receipt) GetEmail() string {
+func (x *WBSubject) GetSubjectId() int32 {
 	if x != nil {
-		return x.Email
+		return x.SubjectId
 	}
-	return ""
+	return 0
 }
 
-func (x *Receipt) GetTaxation() string {
+func (x *WBSubject) GetSubjectName() string {
 	if x != nil {
-		return x.Taxation
+		return x.SubjectName
 	}
 	return ""
 }
 
-func (x *Receipt) GetItems() []*ReceiptItem {
+func (x *WBSubject) GetParentId() int32 {
 	if x != nil {
-		return x.Items
+		return x.ParentId
 	}
-	return nil
+	return 0
 }
 
-type ReceiptItem struct {
+func (x *WBSubject) GetParentName() string {
+	if x != nil {
+		return x.ParentName
+	}
+	return ""
+}
+
+type SearchWBSubjectsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                        // Item name
-	Price         int64                  `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`                                     // Item price in kopecks
-	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`                              // Item quantity
-	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                   // Total amount for this item in kopecks
-	Tax           string                 `protobuf:"bytes,5,opt,name=tax,proto3" json:"tax,omitempty"`                                          // Tax type (e.g., "none", "vat10", "vat20")
-	PaymentMethod string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Payment method (e.g., "full_payment")
-	PaymentObject string                 `protobuf:"bytes,7,opt,name=payment_object,json=paymentObject,proto3" json:"payment_object,omitempty"` // Payment object type (e.g., "service", "commodity")
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // Part of the subject name, case-insensitive; names starting with it come first
+	ParentId      int32                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // 50 if unset, at most 1000
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReceiptItem) Reset() {
-	*x = ReceiptItem{}
-	mi := &file_api_v1_product_proto_msgTypes[11]
+func (x *SearchWBSubjectsRequest) Reset() {
+	*x = SearchWBSubjectsRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReceiptItem) String() string {
+func (x *SearchWBSubjectsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReceiptItem) ProtoMessage() {}
+func (*SearchWBSubjectsRequest) ProtoMessage() {}
 
-func (x *ReceiptItem) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[11]
+func (x *SearchWBSubjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1037,85 +5860,107 @@ func (x *ReceiptItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReceiptItem.ProtoReflect.Descriptor instead.
-func (*ReceiptItem) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use SearchWBSubjectsRequest.ProtoReflect.Descriptor instead.
+func (*SearchWBSubjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ReceiptItem) GetName() string {
+func (x *SearchWBSubjectsRequest) GetQuery() string {
 	if x != nil {
-		return x.Name
+		return x.Query
 	}
 	return ""
 }
 
-func (x *ReceiptItem) GetPrice() int64 {
+func (x *SearchWBSubjectsRequest) GetParentId() int32 {
 	if x != nil {
-		return x.Price
+		return x.ParentId
 	}
 	return 0
 }
 
-func (x *ReceiptItem) GetQuantity() float64 {
+func (x *SearchWBSubjectsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *ReceiptItem) GetAmount() int64 {
+func (x *SearchWBSubjectsRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Amount
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *ReceiptItem) GetTax() string {
-	if x != nil {
-		return x.Tax
-	}
-	return ""
+type SearchWBSubjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subjects      []*WBSubject           `protobuf:"bytes,1,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReceiptItem) GetPaymentMethod() string {
+func (x *SearchWBSubjectsResponse) Reset() {
+	*x = SearchWBSubjectsResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchWBSubjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWBSubjectsResponse) ProtoMessage() {}
+
+func (x *SearchWBSubjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[72]
 	if x != nil {
-		return x.PaymentMethod
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ReceiptItem) GetPaymentObject() string {
+// Deprecated: Use SearchWBSubjectsResponse.ProtoReflect.Descriptor instead.
+func (*SearchWBSubjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SearchWBSubjectsResponse) GetSubjects() []*WBSubject {
 	if x != nil {
-		return x.PaymentObject
+		return x.Subjects
 	}
-	return ""
+	return nil
 }
 
-type PaymentResponse struct {
+type WBDirectoryValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                              // Whether the payment request was successful
-	PaymentUrl    string                 `protobuf:"bytes,2,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`       // URL for customer to complete payment
-	PaymentId     string                 `protobuf:"bytes,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`          // Tinkoff payment ID
-	ErrorMessage  string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"` // Error message if success is false
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`   // Basic color of a color, full name of a country
+	IsKiz         bool                   `protobuf:"varint,3,opt,name=is_kiz,json=isKiz,proto3" json:"is_kiz,omitempty"` // The goods of a TNVED code need a marking code
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentResponse) Reset() {
-	*x = PaymentResponse{}
-	mi := &file_api_v1_product_proto_msgTypes[12]
+func (x *WBDirectoryValue) Reset() {
+	*x = WBDirectoryValue{}
+	mi := &file_api_v1_product_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentResponse) String() string {
+func (x *WBDirectoryValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentResponse) ProtoMessage() {}
+func (*WBDirectoryValue) ProtoMessage() {}
 
-func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[12]
+func (x *WBDirectoryValue) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1126,70 +5971,58 @@ func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentResponse.ProtoReflect.Descriptor instead.
-func (*PaymentResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *PaymentResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use WBDirectoryValue.ProtoReflect.Descriptor instead.
+func (*WBDirectoryValue) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *PaymentResponse) GetPaymentUrl() string {
+func (x *WBDirectoryValue) GetValue() string {
 	if x != nil {
-		return x.PaymentUrl
+		return x.Value
 	}
 	return ""
 }
 
-func (x *PaymentResponse) GetPaymentId() string {
+func (x *WBDirectoryValue) GetDescription() string {
 	if x != nil {
-		return x.PaymentId
+		return x.Description
 	}
 	return ""
 }
 
-func (x *PaymentResponse) GetErrorMessage() string {
+func (x *WBDirectoryValue) GetIsKiz() bool {
 	if x != nil {
-		return x.ErrorMessage
+		return x.IsKiz
 	}
-	return ""
+	return false
 }
 
-type TinkoffNotificationRequest struct {
+type SearchWBDirectoryValuesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TerminalKey   string                 `protobuf:"bytes,1,opt,name=terminal_key,json=terminalKey,proto3" json:"terminal_key,omitempty"` // Terminal key
-	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`                             // Payment amount
-	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`            // Order ID
-	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`                           // Payment success status
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                              // Payment status
-	PaymentId     int64                  `protobuf:"varint,6,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`      // Payment ID
-	ErrorCode     string                 `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`       // Error code (if any)
-	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`                            // Status message
-	Details       string                 `protobuf:"bytes,9,opt,name=details,proto3" json:"details,omitempty"`                            // Additional details
-	Token         string                 `protobuf:"bytes,10,opt,name=token,proto3" json:"token,omitempty"`                               // Security token/signature
+	Directory     WBDirectory            `protobuf:"varint,1,opt,name=directory,proto3,enum=api.v1.WBDirectory" json:"directory,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                           // Part of the value, case-insensitive
+	SubjectId     int32                  `protobuf:"varint,3,opt,name=subject_id,json=subjectId,proto3" json:"subject_id,omitempty"` // Subject of the TNVED codes
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                          // 50 if unset, at most 1000
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TinkoffNotificationRequest) Reset() {
-	*x = TinkoffNotificationRequest{}
-	mi := &file_api_v1_product_proto_msgTypes[13]
+func (x *SearchWBDirectoryValuesRequest) Reset() {
+	*x = SearchWBDirectoryValuesRequest{}
+	mi := &file_api_v1_product_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TinkoffNotificationRequest) String() string {
+func (x *SearchWBDirectoryValuesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TinkoffNotificationRequest) ProtoMessage() {}
+func (*SearchWBDirectoryValuesRequest) ProtoMessage() {}
 
-func (x *TinkoffNotificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[13]
+func (x *SearchWBDirectoryValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,103 +6033,68 @@ func (x *TinkoffNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TinkoffNotificationRequest.ProtoReflect.Descriptor instead.
-func (*TinkoffNotificationRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *TinkoffNotificationRequest) GetTerminalKey() string {
-	if x != nil {
-		return x.TerminalKey
-	}
-	return ""
-}
-
-func (x *TinkoffNotificationRequest) GetAmount() int64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
-func (x *TinkoffNotificationRequest) GetOrderId() int64 {
-	if x != nil {
-		return x.OrderId
-	}
-	return 0
+// Deprecated: Use SearchWBDirectoryValuesRequest.ProtoReflect.Descriptor instead.
+func (*SearchWBDirectoryValuesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *TinkoffNotificationRequest) GetSuccess() bool {
+func (x *SearchWBDirectoryValuesRequest) GetDirectory() WBDirectory {
 	if x != nil {
-		return x.Success
+		return x.Directory
 	}
-	return false
+	return WBDirectory_WB_DIRECTORY_UNSPECIFIED
 }
 
-func (x *TinkoffNotificationRequest) GetStatus() string {
+func (x *SearchWBDirectoryValuesRequest) GetQuery() string {
 	if x != nil {
-		return x.Status
+		return x.Query
 	}
 	return ""
 }
 
-func (x *TinkoffNotificationRequest) GetPaymentId() int64 {
+func (x *SearchWBDirectoryValuesRequest) GetSubjectId() int32 {
 	if x != nil {
-		return x.PaymentId
+		return x.SubjectId
 	}
 	return 0
 }
 
-func (x *TinkoffNotificationRequest) GetErrorCode() string {
-	if x != nil {
-		return x.ErrorCode
-	}
-	return ""
-}
-
-func (x *TinkoffNotificationRequest) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
-func (x *TinkoffNotificationRequest) GetDetails() string {
+func (x *SearchWBDirectoryValuesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Details
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *TinkoffNotificationRequest) GetToken() string {
+func (x *SearchWBDirectoryValuesRequest) GetOffset() int32 {
 	if x != nil {
-		return x.Token
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-type TinkoffNotificationResponse struct {
+type SearchWBDirectoryValuesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // Response status ("OK" for successful processing)
+	Values        []*WBDirectoryValue    `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TinkoffNotificationResponse) Reset() {
-	*x = TinkoffNotificationResponse{}
-	mi := &file_api_v1_product_proto_msgTypes[14]
+func (x *SearchWBDirectoryValuesResponse) Reset() {
+	*x = SearchWBDirectoryValuesResponse{}
+	mi := &file_api_v1_product_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TinkoffNotificationResponse) String() string {
+func (x *SearchWBDirectoryValuesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TinkoffNotificationResponse) ProtoMessage() {}
+func (*SearchWBDirectoryValuesResponse) ProtoMessage() {}
 
-func (x *TinkoffNotificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_product_proto_msgTypes[14]
+func (x *SearchWBDirectoryValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_product_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,23 +6105,24 @@ func (x *TinkoffNotificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TinkoffNotificationResponse.ProtoReflect.Descriptor instead.
-func (*TinkoffNotificationResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_product_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SearchWBDirectoryValuesResponse.ProtoReflect.Descriptor instead.
+func (*SearchWBDirectoryValuesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_product_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *TinkoffNotificationResponse) GetStatus() string {
+func (x *SearchWBDirectoryValuesResponse) GetValues() []*WBDirectoryValue {
 	if x != nil {
-		return x.Status
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
 var File_api_v1_product_proto protoreflect.FileDescriptor
 
 const file_api_v1_product_proto_rawDesc = "" +
 	"\n" +
-	"\x14api/v1/product.proto\x12\x06api.v1\"\xdc\x05\n" +
+	"\x14api/v1/product.proto\x12\x06api.v1\"\xaf\n" +
+	"\n" +
 	"\rCreateRequest\x12#\n" +
 	"\rproduct_title\x18\x01 \x01(\tR\fproductTitle\x12/\n" +
 	"\x13product_description\x18\x02 \x01(\tR\x12productDescription\x12\x1b\n" +
@@ -1351,7 +6150,45 @@ const file_api_v1_product_proto_rawDesc = "" +
 	"\x16wb_media_to_save_links\x18\x12 \x03(\tR\x12wbMediaToSaveLinks\x12+\n" +
 	"\x12ozon_api_client_id\x18\x13 \x01(\tR\x0fozonApiClientId\x12 \n" +
 	"\fozon_api_key\x18\x14 \x01(\tR\n" +
-	"ozonApiKey\"\xed\x01\n" +
+	"ozonApiKey\x12,\n" +
+	"\x05media\x18\x15 \x03(\v2\x16.api.v1.MediaReferenceR\x05media\x12B\n" +
+	"\x12resolve_categories\x18\x16 \x03(\x0e2\x13.api.v1.MarketplaceR\x11resolveCategories\x12)\n" +
+	"\x10force_regenerate\x18\x17 \x01(\bR\x0fforceRegenerate\x12B\n" +
+	"\x10content_provider\x18\x18 \x01(\x0e2\x17.api.v1.ContentProviderR\x0fcontentProvider\x12)\n" +
+	"\x10content_variants\x18\x19 \x01(\x05R\x0fcontentVariants\x12U\n" +
+	"\x17content_validation_mode\x18\x1a \x01(\x0e2\x1d.api.v1.ContentValidationModeR\x15contentValidationMode\x12\x1c\n" +
+	"\adry_run\x18\x1b \x01(\bH\x00R\x06dryRun\x88\x01\x01\x12\x15\n" +
+	"\x06imt_id\x18\x1c \x01(\x03R\x05imtId\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x1d \x01(\tR\tmodelName\x122\n" +
+	"\bvariants\x18\x1e \x03(\v2\x16.api.v1.ProductVariantR\bvariants\x12\x1f\n" +
+	"\vwb_discount\x18\x1f \x01(\x05R\n" +
+	"wbDiscount\x129\n" +
+	"\rinitial_stock\x18  \x01(\v2\x14.api.v1.InitialStockR\finitialStockB\n" +
+	"\n" +
+	"\b_dry_run\"z\n" +
+	"\fInitialStock\x12&\n" +
+	"\x0fwb_warehouse_id\x18\x01 \x01(\x03R\rwbWarehouseId\x12*\n" +
+	"\x11ozon_warehouse_id\x18\x02 \x01(\x03R\x0fozonWarehouseId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\"\xa2\x02\n" +
+	"\x0eProductVariant\x12\x1f\n" +
+	"\vvendor_code\x18\x01 \x01(\tR\n" +
+	"vendorCode\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12\"\n" +
+	"\x05sizes\x18\x03 \x03(\v2\f.api.v1.SizeR\x05sizes\x12S\n" +
+	"\x18wb_media_to_upload_files\x18\x04 \x03(\v2\x1b.api.v1.WBMediaFileToUploadR\x14wbMediaToUploadFiles\x122\n" +
+	"\x16wb_media_to_save_links\x18\x05 \x03(\tR\x12wbMediaToSaveLinks\x12,\n" +
+	"\x05media\x18\x06 \x03(\v2\x16.api.v1.MediaReferenceR\x05media\"\xa3\x01\n" +
+	"\x10ContentViolation\x125\n" +
+	"\vmarketplace\x18\x01 \x01(\x0e2\x13.api.v1.MarketplaceR\vmarketplace\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x12\n" +
+	"\x04rule\x18\x03 \x01(\tR\x04rule\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x14\n" +
+	"\x05fixed\x18\x05 \x01(\bR\x05fixed\"R\n" +
+	"\x16ContentValidationError\x128\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2\x18.api.v1.ContentViolationR\n" +
+	"violations\"\xed\x01\n" +
 	"\n" +
 	"Dimensions\x12\x16\n" +
 	"\x06length\x18\x01 \x01(\x05R\x06length\x12\x14\n" +
@@ -1372,11 +6209,17 @@ const file_api_v1_product_proto_rawDesc = "" +
 	"\n" +
 	"ozon_price\x18\x06 \x01(\x05H\x01R\tozonPrice\x88\x01\x01B\v\n" +
 	"\t_wb_priceB\r\n" +
-	"\v_ozon_price\"n\n" +
+	"\v_ozon_price\"\x95\x01\n" +
 	"\x13WBMediaFileToUpload\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\fR\acontent\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
-	"\fphoto_number\x18\x03 \x01(\x05R\vphotoNumber\"\x9d\t\n" +
+	"\fphoto_number\x18\x03 \x01(\x05R\vphotoNumber\x12%\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x11.api.v1.MediaKindR\x04kind\"\x8f\x01\n" +
+	"\x0eMediaReference\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12!\n" +
+	"\fphoto_number\x18\x02 \x01(\x05R\vphotoNumber\x12%\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x11.api.v1.MediaKindR\x04kind\x12\x18\n" +
+	"\aprimary\x18\x04 \x01(\bR\aprimary\"\xf3\x0e\n" +
 	"\x0eCreateResponse\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12F\n" +
 	"\n" +
@@ -1402,7 +6245,19 @@ const file_api_v1_product_proto_rawDesc = "" +
 	"$wb_media_upload_individual_responses\x18\x11 \x03(\v2'.api.v1.WBMediaUploadIndividualResponseR wbMediaUploadIndividualResponses\x12l\n" +
 	"\x1fwb_media_save_by_links_response\x18\x12 \x01(\v2\".api.v1.WBMediaSaveByLinksResponseH\x03R\x1awbMediaSaveByLinksResponse\x88\x01\x01\x128\n" +
 	"\x16ozon_api_response_json\x18\x13 \x01(\tH\x04R\x13ozonApiResponseJson\x88\x01\x01\x129\n" +
-	"\x16ozon_request_attempted\x18\x14 \x01(\bH\x05R\x14ozonRequestAttempted\x88\x01\x01\x1a=\n" +
+	"\x16ozon_request_attempted\x18\x14 \x01(\bH\x05R\x14ozonRequestAttempted\x88\x01\x01\x12,\n" +
+	"\x12content_from_cache\x18\x15 \x01(\bR\x10contentFromCache\x12B\n" +
+	"\x10content_provider\x18\x16 \x01(\x0e2\x17.api.v1.ContentProviderR\x0fcontentProvider\x12A\n" +
+	"\x10content_variants\x18\x17 \x03(\v2\x16.api.v1.ContentVariantR\x0fcontentVariants\x12G\n" +
+	"\x12content_violations\x18\x18 \x03(\v2\x18.api.v1.ContentViolationR\x11contentViolations\x12@\n" +
+	"\x1aozon_prepared_request_json\x18\x19 \x01(\tH\x06R\x17ozonPreparedRequestJson\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\x1a \x01(\bR\x06dryRun\x125\n" +
+	"\n" +
+	"ozon_error\x18\x1b \x01(\v2\x11.api.v1.OzonErrorH\aR\tozonError\x88\x01\x01\x12(\n" +
+	"\x10wb_media_pending\x18\x1c \x01(\bR\x0ewbMediaPending\x12i\n" +
+	" wb_media_save_by_links_responses\x18\x1d \x03(\v2\".api.v1.WBMediaSaveByLinksResponseR\x1bwbMediaSaveByLinksResponses\x12H\n" +
+	"\x12generated_barcodes\x18\x1e \x03(\v2\x19.api.v1.GeneratedBarcodesR\x11generatedBarcodes\x127\n" +
+	"\x18ozon_initial_stock_error\x18\x1f \x01(\tR\x15ozonInitialStockError\x1a=\n" +
 	"\x0fAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x17\n" +
@@ -1411,18 +6266,271 @@ const file_api_v1_product_proto_rawDesc = "" +
 	"\x15_wb_request_attemptedB\"\n" +
 	" _wb_media_save_by_links_responseB\x19\n" +
 	"\x17_ozon_api_response_jsonB\x19\n" +
-	"\x17_ozon_request_attempted\"\xbc\x01\n" +
+	"\x17_ozon_request_attemptedB\x1d\n" +
+	"\x1b_ozon_prepared_request_jsonB\r\n" +
+	"\v_ozon_error\"\xa4\x01\n" +
+	"\x11GeneratedBarcodes\x125\n" +
+	"\vmarketplace\x18\x01 \x01(\x0e2\x13.api.v1.MarketplaceR\vmarketplace\x12\x1f\n" +
+	"\vvendor_code\x18\x02 \x01(\tR\n" +
+	"vendorCode\x12\x1b\n" +
+	"\ttech_size\x18\x03 \x01(\tR\btechSize\x12\x1a\n" +
+	"\bbarcodes\x18\x04 \x03(\tR\bbarcodes\"\x89\x03\n" +
+	"\x0eContentVariant\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12F\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2&.api.v1.ContentVariant.AttributesEntryR\n" +
+	"attributes\x12#\n" +
+	"\rprompt_tokens\x18\x06 \x01(\x05R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\a \x01(\x05R\x10completionTokens\x12\x12\n" +
+	"\x04cost\x18\b \x01(\x05R\x04cost\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\t \x01(\bR\tfromCache\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
+	"\x15PublishVariantRequest\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x0e\n" +
+	"\x02wb\x18\x02 \x01(\bR\x02wb\x12\x12\n" +
+	"\x04ozon\x18\x03 \x01(\bR\x04ozon\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x04 \x01(\tR\bwbApiKey\x12+\n" +
+	"\x12ozon_api_client_id\x18\x05 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x06 \x01(\tR\n" +
+	"ozonApiKey\x12U\n" +
+	"\x17content_validation_mode\x18\a \x01(\x0e2\x1d.api.v1.ContentValidationModeR\x15contentValidationMode\x12\x1c\n" +
+	"\adry_run\x18\b \x01(\bH\x00R\x06dryRun\x88\x01\x01B\n" +
+	"\n" +
+	"\b_dry_run\"\xe9\t\n" +
+	"\x16PublishVariantResponse\x124\n" +
+	"\x14wb_api_response_json\x18\x01 \x01(\tH\x00R\x11wbApiResponseJson\x88\x01\x01\x12<\n" +
+	"\x18wb_prepared_request_json\x18\x02 \x01(\tH\x01R\x15wbPreparedRequestJson\x88\x01\x01\x125\n" +
+	"\x14wb_request_attempted\x18\x03 \x01(\bH\x02R\x12wbRequestAttempted\x88\x01\x01\x12w\n" +
+	"$wb_media_upload_individual_responses\x18\x04 \x03(\v2'.api.v1.WBMediaUploadIndividualResponseR wbMediaUploadIndividualResponses\x12l\n" +
+	"\x1fwb_media_save_by_links_response\x18\x05 \x01(\v2\".api.v1.WBMediaSaveByLinksResponseH\x03R\x1awbMediaSaveByLinksResponse\x88\x01\x01\x128\n" +
+	"\x16ozon_api_response_json\x18\x06 \x01(\tH\x04R\x13ozonApiResponseJson\x88\x01\x01\x129\n" +
+	"\x16ozon_request_attempted\x18\a \x01(\bH\x05R\x14ozonRequestAttempted\x88\x01\x01\x12G\n" +
+	"\x12content_violations\x18\b \x03(\v2\x18.api.v1.ContentViolationR\x11contentViolations\x12@\n" +
+	"\x1aozon_prepared_request_json\x18\t \x01(\tH\x06R\x17ozonPreparedRequestJson\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\n" +
+	" \x01(\bR\x06dryRun\x125\n" +
+	"\n" +
+	"ozon_error\x18\v \x01(\v2\x11.api.v1.OzonErrorH\aR\tozonError\x88\x01\x01\x12(\n" +
+	"\x10wb_media_pending\x18\f \x01(\bR\x0ewbMediaPending\x12i\n" +
+	" wb_media_save_by_links_responses\x18\r \x03(\v2\".api.v1.WBMediaSaveByLinksResponseR\x1bwbMediaSaveByLinksResponses\x12H\n" +
+	"\x12generated_barcodes\x18\x0e \x03(\v2\x19.api.v1.GeneratedBarcodesR\x11generatedBarcodes\x127\n" +
+	"\x18ozon_initial_stock_error\x18\x0f \x01(\tR\x15ozonInitialStockErrorB\x17\n" +
+	"\x15_wb_api_response_jsonB\x1b\n" +
+	"\x19_wb_prepared_request_jsonB\x17\n" +
+	"\x15_wb_request_attemptedB\"\n" +
+	" _wb_media_save_by_links_responseB\x19\n" +
+	"\x17_ozon_api_response_jsonB\x19\n" +
+	"\x17_ozon_request_attemptedB\x1d\n" +
+	"\x1b_ozon_prepared_request_jsonB\r\n" +
+	"\v_ozon_error\"\x8d\x01\n" +
+	"\tOzonError\x12\x1f\n" +
+	"\vhttp_status\x18\x01 \x01(\x05R\n" +
+	"httpStatus\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x121\n" +
+	"\adetails\x18\x04 \x03(\v2\x17.api.v1.OzonErrorDetailR\adetails\"B\n" +
+	"\x0fOzonErrorDetail\x12\x19\n" +
+	"\btype_url\x18\x01 \x01(\tR\atypeUrl\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xdd\x01\n" +
 	"\x1fWBMediaUploadIndividualResponse\x12!\n" +
 	"\fphoto_number\x18\x01 \x01(\x05R\vphotoNumber\x12(\n" +
 	"\rresponse_json\x18\x02 \x01(\tH\x00R\fresponseJson\x88\x01\x01\x12(\n" +
-	"\rerror_message\x18\x03 \x01(\tH\x01R\ferrorMessage\x88\x01\x01B\x10\n" +
+	"\rerror_message\x18\x03 \x01(\tH\x01R\ferrorMessage\x88\x01\x01\x12\x1f\n" +
+	"\vvendor_code\x18\x04 \x01(\tR\n" +
+	"vendorCodeB\x10\n" +
 	"\x0e_response_jsonB\x10\n" +
-	"\x0e_error_message\"\x94\x01\n" +
+	"\x0e_error_message\"\xb5\x01\n" +
 	"\x1aWBMediaSaveByLinksResponse\x12(\n" +
 	"\rresponse_json\x18\x01 \x01(\tH\x00R\fresponseJson\x88\x01\x01\x12(\n" +
-	"\rerror_message\x18\x02 \x01(\tH\x01R\ferrorMessage\x88\x01\x01B\x10\n" +
+	"\rerror_message\x18\x02 \x01(\tH\x01R\ferrorMessage\x88\x01\x01\x12\x1f\n" +
+	"\vvendor_code\x18\x03 \x01(\tR\n" +
+	"vendorCodeB\x10\n" +
 	"\x0e_response_jsonB\x10\n" +
-	"\x0e_error_message\"\x13\n" +
+	"\x0e_error_message\"\xf5\x01\n" +
+	"\x12ListWBCardsRequest\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x01 \x01(\tR\bwbApiKey\x12\x1f\n" +
+	"\vtext_search\x18\x02 \x01(\tR\n" +
+	"textSearch\x12\x16\n" +
+	"\x06brands\x18\x03 \x03(\tR\x06brands\x12\x1f\n" +
+	"\vsubject_ids\x18\x04 \x03(\x03R\n" +
+	"subjectIds\x12\x17\n" +
+	"\atag_ids\x18\x05 \x03(\x03R\x06tagIds\x12\"\n" +
+	"\n" +
+	"with_photo\x18\x06 \x01(\bH\x00R\twithPhoto\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSizeB\r\n" +
+	"\v_with_photo\";\n" +
+	"\x13ListWBCardsResponse\x12$\n" +
+	"\x05cards\x18\x01 \x03(\v2\x0e.api.v1.WBCardR\x05cards\"\xac\x04\n" +
+	"\x06WBCard\x12\x13\n" +
+	"\x05nm_id\x18\x01 \x01(\x03R\x04nmId\x12\x15\n" +
+	"\x06imt_id\x18\x02 \x01(\x03R\x05imtId\x12\x1f\n" +
+	"\vvendor_code\x18\x03 \x01(\tR\n" +
+	"vendorCode\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x04 \x01(\x03R\tsubjectId\x12!\n" +
+	"\fsubject_name\x18\x05 \x01(\tR\vsubjectName\x12\x14\n" +
+	"\x05brand\x18\x06 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"photo_urls\x18\t \x03(\tR\tphotoUrls\x12\x1b\n" +
+	"\tvideo_url\x18\n" +
+	" \x01(\tR\bvideoUrl\x122\n" +
+	"\n" +
+	"dimensions\x18\v \x01(\v2\x12.api.v1.DimensionsR\n" +
+	"dimensions\x12F\n" +
+	"\x0fcharacteristics\x18\f \x03(\v2\x1c.api.v1.WBCardCharacteristicR\x0fcharacteristics\x12(\n" +
+	"\x05sizes\x18\r \x03(\v2\x12.api.v1.WBCardSizeR\x05sizes\x12%\n" +
+	"\x04tags\x18\x0e \x03(\v2\x11.api.v1.WBCardTagR\x04tags\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\tR\tupdatedAt\"Y\n" +
+	"\x14WBCardCharacteristic\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"value_json\x18\x03 \x01(\tR\tvalueJson\"o\n" +
+	"\n" +
+	"WBCardSize\x12\x17\n" +
+	"\achrt_id\x18\x01 \x01(\x03R\x06chrtId\x12\x1b\n" +
+	"\ttech_size\x18\x02 \x01(\tR\btechSize\x12\x17\n" +
+	"\awb_size\x18\x03 \x01(\tR\x06wbSize\x12\x12\n" +
+	"\x04skus\x18\x04 \x03(\tR\x04skus\"E\n" +
+	"\tWBCardTag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"\xe4\x01\n" +
+	"\x13UpdatePricesRequest\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x01 \x01(\tR\bwbApiKey\x12+\n" +
+	"\x12ozon_api_client_id\x18\x02 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x03 \x01(\tR\n" +
+	"ozonApiKey\x12,\n" +
+	"\twb_prices\x18\x04 \x03(\v2\x0f.api.v1.WBPriceR\bwbPrices\x122\n" +
+	"\vozon_prices\x18\x05 \x03(\v2\x11.api.v1.OzonPriceR\n" +
+	"ozonPrices\"P\n" +
+	"\aWBPrice\x12\x13\n" +
+	"\x05nm_id\x18\x01 \x01(\x03R\x04nmId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x05R\x05price\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x05R\bdiscount\"v\n" +
+	"\tOzonPrice\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x05R\x05price\x12\x1b\n" +
+	"\told_price\x18\x03 \x01(\x05R\boldPrice\x12\x1b\n" +
+	"\tmin_price\x18\x04 \x01(\x05R\bminPrice\"}\n" +
+	"\x0fOzonPriceResult\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03R\tproductId\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\bR\aupdated\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\xa8\x02\n" +
+	"\x14UpdatePricesResponse\x12 \n" +
+	"\fwb_upload_id\x18\x01 \x01(\x03R\n" +
+	"wbUploadId\x12$\n" +
+	"\x0ewb_task_status\x18\x02 \x01(\x05R\fwbTaskStatus\x12$\n" +
+	"\x0ewb_goods_total\x18\x03 \x01(\x05R\fwbGoodsTotal\x12,\n" +
+	"\x12wb_goods_succeeded\x18\x04 \x01(\x05R\x10wbGoodsSucceeded\x12\x19\n" +
+	"\bwb_error\x18\x05 \x01(\tR\awbError\x12:\n" +
+	"\fozon_results\x18\x06 \x03(\v2\x17.api.v1.OzonPriceResultR\vozonResults\x12\x1d\n" +
+	"\n" +
+	"ozon_error\x18\a \x01(\tR\tozonError\"\xdf\x02\n" +
+	"\x10CrossListRequest\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x01 \x01(\tR\bwbApiKey\x12+\n" +
+	"\x12ozon_api_client_id\x18\x02 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x03 \x01(\tR\n" +
+	"ozonApiKey\x12\x13\n" +
+	"\x05nm_id\x18\x04 \x01(\x03R\x04nmId\x126\n" +
+	"\x17description_category_id\x18\x05 \x01(\x05R\x15descriptionCategoryId\x12\x17\n" +
+	"\atype_id\x18\x06 \x01(\x05R\x06typeId\x12\x14\n" +
+	"\x05price\x18\a \x01(\x05R\x05price\x12\x1c\n" +
+	"\adry_run\x18\b \x01(\bH\x00R\x06dryRun\x88\x01\x01\x12\x19\n" +
+	"\boffer_id\x18\t \x01(\tR\aofferId\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\n" +
+	" \x01(\x05R\tsubjectIdB\n" +
+	"\n" +
+	"\b_dry_run\"\xac\t\n" +
+	"\x11CrossListResponse\x12\x1f\n" +
+	"\vvendor_code\x18\x01 \x01(\tR\n" +
+	"vendorCode\x126\n" +
+	"\x17description_category_id\x18\x02 \x01(\x05R\x15descriptionCategoryId\x12\x17\n" +
+	"\atype_id\x18\x03 \x01(\x05R\x06typeId\x12\x1b\n" +
+	"\ttype_name\x18\x04 \x01(\tR\btypeName\x129\n" +
+	"\x18unmapped_characteristics\x18\x05 \x03(\tR\x17unmappedCharacteristics\x128\n" +
+	"\x16ozon_api_response_json\x18\x06 \x01(\tH\x00R\x13ozonApiResponseJson\x88\x01\x01\x12@\n" +
+	"\x1aozon_prepared_request_json\x18\a \x01(\tH\x01R\x17ozonPreparedRequestJson\x88\x01\x01\x129\n" +
+	"\x16ozon_request_attempted\x18\b \x01(\bH\x02R\x14ozonRequestAttempted\x88\x01\x01\x125\n" +
+	"\n" +
+	"ozon_error\x18\t \x01(\v2\x11.api.v1.OzonErrorH\x03R\tozonError\x88\x01\x01\x12\x17\n" +
+	"\adry_run\x18\n" +
+	" \x01(\bR\x06dryRun\x12H\n" +
+	"\x12generated_barcodes\x18\v \x03(\v2\x19.api.v1.GeneratedBarcodesR\x11generatedBarcodes\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\f \x01(\x05R\tsubjectId\x12!\n" +
+	"\fsubject_name\x18\r \x01(\tR\vsubjectName\x124\n" +
+	"\x14wb_api_response_json\x18\x0e \x01(\tH\x04R\x11wbApiResponseJson\x88\x01\x01\x12<\n" +
+	"\x18wb_prepared_request_json\x18\x0f \x01(\tH\x05R\x15wbPreparedRequestJson\x88\x01\x01\x125\n" +
+	"\x14wb_request_attempted\x18\x10 \x01(\bH\x06R\x12wbRequestAttempted\x88\x01\x01\x12(\n" +
+	"\x10wb_media_pending\x18\x11 \x01(\bR\x0ewbMediaPending\x12i\n" +
+	" wb_media_save_by_links_responses\x18\x12 \x03(\v2\".api.v1.WBMediaSaveByLinksResponseR\x1bwbMediaSaveByLinksResponses\x12G\n" +
+	"\x12content_violations\x18\x13 \x03(\v2\x18.api.v1.ContentViolationR\x11contentViolationsB\x19\n" +
+	"\x17_ozon_api_response_jsonB\x1d\n" +
+	"\x1b_ozon_prepared_request_jsonB\x19\n" +
+	"\x17_ozon_request_attemptedB\r\n" +
+	"\v_ozon_errorB\x17\n" +
+	"\x15_wb_api_response_jsonB\x1b\n" +
+	"\x19_wb_prepared_request_jsonB\x17\n" +
+	"\x15_wb_request_attempted\"\xac\x03\n" +
+	"\x0fCategoryMapping\x12\"\n" +
+	"\rwb_subject_id\x18\x01 \x01(\x05R\vwbSubjectId\x12&\n" +
+	"\x0fwb_subject_name\x18\x02 \x01(\tR\rwbSubjectName\x12?\n" +
+	"\x1cozon_description_category_id\x18\x03 \x01(\x05R\x19ozonDescriptionCategoryId\x12C\n" +
+	"\x1eozon_description_category_name\x18\x04 \x01(\tR\x1bozonDescriptionCategoryName\x12 \n" +
+	"\fozon_type_id\x18\x05 \x01(\x05R\n" +
+	"ozonTypeId\x12$\n" +
+	"\x0eozon_type_name\x18\x06 \x01(\tR\fozonTypeName\x12 \n" +
+	"\vresolutions\x18\a \x01(\x05R\vresolutions\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\b \x01(\x01R\n" +
+	"confidence\x12\x1e\n" +
+	"\n" +
+	"overridden\x18\t \x01(\bR\n" +
+	"overridden\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\"\xfb\x01\n" +
+	"\x1bListCategoryMappingsRequest\x12\"\n" +
+	"\rwb_subject_id\x18\x01 \x01(\x05R\vwbSubjectId\x12?\n" +
+	"\x1cozon_description_category_id\x18\x02 \x01(\x05R\x19ozonDescriptionCategoryId\x12 \n" +
+	"\fozon_type_id\x18\x03 \x01(\x05R\n" +
+	"ozonTypeId\x12'\n" +
+	"\x0foverridden_only\x18\x04 \x01(\bR\x0eoverriddenOnly\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\"S\n" +
+	"\x1cListCategoryMappingsResponse\x123\n" +
+	"\bmappings\x18\x01 \x03(\v2\x17.api.v1.CategoryMappingR\bmappings\"\xe1\x02\n" +
+	"\x1eOverrideCategoryMappingRequest\x12\"\n" +
+	"\rwb_subject_id\x18\x01 \x01(\x05R\vwbSubjectId\x12?\n" +
+	"\x1cozon_description_category_id\x18\x02 \x01(\x05R\x19ozonDescriptionCategoryId\x12 \n" +
+	"\fozon_type_id\x18\x03 \x01(\x05R\n" +
+	"ozonTypeId\x12&\n" +
+	"\x0fwb_subject_name\x18\x04 \x01(\tR\rwbSubjectName\x12C\n" +
+	"\x1eozon_description_category_name\x18\x05 \x01(\tR\x1bozonDescriptionCategoryName\x12$\n" +
+	"\x0eozon_type_name\x18\x06 \x01(\tR\fozonTypeName\x12%\n" +
+	"\x0ereset_override\x18\a \x01(\bR\rresetOverride\"!\n" +
+	"\x1fOverrideCategoryMappingResponse\"\x13\n" +
 	"\x11GetBalanceRequest\".\n" +
 	"\x12GetBalanceResponse\x12\x18\n" +
 	"\abalance\x18\x01 \x01(\x05R\abalance\"\xc9\x01\n" +
@@ -1467,15 +6575,206 @@ const file_api_v1_product_proto_rawDesc = "" +
 	"\x05token\x18\n" +
 	" \x01(\tR\x05token\"5\n" +
 	"\x1bTinkoffNotificationResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status2K\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"l\n" +
+	"\x12UploadMediaRequest\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x15.api.v1.MediaMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"N\n" +
+	"\rMediaMetadata\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"V\n" +
+	"\x13UploadMediaResponse\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"\x84\x01\n" +
+	"\x15ListWarehousesRequest\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x01 \x01(\tR\bwbApiKey\x12+\n" +
+	"\x12ozon_api_client_id\x18\x02 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x03 \x01(\tR\n" +
+	"ozonApiKey\"f\n" +
+	"\tWarehouse\x125\n" +
+	"\vmarketplace\x18\x01 \x01(\x0e2\x13.api.v1.MarketplaceR\vmarketplace\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"\x85\x01\n" +
+	"\x16ListWarehousesResponse\x121\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x11.api.v1.WarehouseR\n" +
+	"warehouses\x12\x19\n" +
+	"\bwb_error\x18\x02 \x01(\tR\awbError\x12\x1d\n" +
+	"\n" +
+	"ozon_error\x18\x03 \x01(\tR\tozonError\"V\n" +
+	"\aWBStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03R\vwarehouseId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\"a\n" +
+	"\tOzonStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03R\vwarehouseId\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\"\xe1\x01\n" +
+	"\x10SetStocksRequest\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x01 \x01(\tR\bwbApiKey\x12+\n" +
+	"\x12ozon_api_client_id\x18\x02 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x03 \x01(\tR\n" +
+	"ozonApiKey\x12,\n" +
+	"\twb_stocks\x18\x04 \x03(\v2\x0f.api.v1.WBStockR\bwbStocks\x122\n" +
+	"\vozon_stocks\x18\x05 \x03(\v2\x11.api.v1.OzonStockR\n" +
+	"ozonStocks\"\xa0\x01\n" +
+	"\x0fOzonStockResult\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03R\vwarehouseId\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\bR\aupdated\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"\x89\x01\n" +
+	"\x11SetStocksResponse\x12\x19\n" +
+	"\bwb_error\x18\x01 \x01(\tR\awbError\x12:\n" +
+	"\fozon_results\x18\x02 \x03(\v2\x17.api.v1.OzonStockResultR\vozonResults\x12\x1d\n" +
+	"\n" +
+	"ozon_error\x18\x03 \x01(\tR\tozonError\"\xb4\x02\n" +
+	"\x11StockSyncSettings\x12+\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x13.api.v1.MarketplaceR\x06source\x12\x1c\n" +
+	"\n" +
+	"wb_api_key\x18\x02 \x01(\tR\bwbApiKey\x12&\n" +
+	"\x0fwb_warehouse_id\x18\x03 \x01(\x03R\rwbWarehouseId\x12+\n" +
+	"\x12ozon_api_client_id\x18\x04 \x01(\tR\x0fozonApiClientId\x12 \n" +
+	"\fozon_api_key\x18\x05 \x01(\tR\n" +
+	"ozonApiKey\x12*\n" +
+	"\x11ozon_warehouse_id\x18\x06 \x01(\x03R\x0fozonWarehouseId\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12\x17\n" +
+	"\adry_run\x18\b \x01(\bR\x06dryRun\"T\n" +
+	"\x1bSetStockSyncSettingsRequest\x125\n" +
+	"\bsettings\x18\x01 \x01(\v2\x19.api.v1.StockSyncSettingsR\bsettings\"\x1e\n" +
+	"\x1cSetStockSyncSettingsResponse\"n\n" +
+	"\x10StockSyncMapping\x12\x15\n" +
+	"\x06wb_sku\x18\x01 \x01(\tR\x05wbSku\x12\x1f\n" +
+	"\vvendor_code\x18\x02 \x01(\tR\n" +
+	"vendorCode\x12\"\n" +
+	"\rozon_offer_id\x18\x03 \x01(\tR\vozonOfferId\"S\n" +
+	"\x1bSetStockSyncMappingsRequest\x124\n" +
+	"\bmappings\x18\x01 \x03(\v2\x18.api.v1.StockSyncMappingR\bmappings\"\x1e\n" +
+	"\x1cSetStockSyncMappingsResponse\"?\n" +
+	"\x13RunStockSyncRequest\x12\x1c\n" +
+	"\adry_run\x18\x01 \x01(\bH\x00R\x06dryRun\x88\x01\x01B\n" +
+	"\n" +
+	"\b_dry_run\"\x1b\n" +
+	"\x19GetStockSyncReportRequest\"p\n" +
+	"\x0fStockSyncChange\x12\x15\n" +
+	"\x06wb_sku\x18\x01 \x01(\tR\x05wbSku\x12\"\n" +
+	"\rozon_offer_id\x18\x02 \x01(\tR\vozonOfferId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x05R\x02to\"\xc6\x01\n" +
+	"\x11StockSyncConflict\x12\x15\n" +
+	"\x06wb_sku\x18\x01 \x01(\tR\x05wbSku\x12\"\n" +
+	"\rozon_offer_id\x18\x02 \x01(\tR\vozonOfferId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12#\n" +
+	"\rsource_amount\x18\x04 \x01(\x05R\fsourceAmount\x12#\n" +
+	"\rtarget_amount\x18\x05 \x01(\x05R\ftargetAmount\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"\x99\x02\n" +
+	"\x0fStockSyncReport\x12+\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x13.api.v1.MarketplaceR\x06source\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x04 \x01(\tR\n" +
+	"finishedAt\x121\n" +
+	"\achanges\x18\x05 \x03(\v2\x17.api.v1.StockSyncChangeR\achanges\x127\n" +
+	"\tconflicts\x18\x06 \x03(\v2\x19.api.v1.StockSyncConflictR\tconflicts\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"J\n" +
+	"\x17StockSyncReportResponse\x12/\n" +
+	"\x06report\x18\x01 \x01(\v2\x17.api.v1.StockSyncReportR\x06report\"U\n" +
+	"\x10WBParentCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"is_visible\x18\x03 \x01(\bR\tisVisible\"\x1f\n" +
+	"\x1dListWBParentCategoriesRequest\"Z\n" +
+	"\x1eListWBParentCategoriesResponse\x128\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x18.api.v1.WBParentCategoryR\n" +
+	"categories\"\x8b\x01\n" +
+	"\tWBSubject\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x01 \x01(\x05R\tsubjectId\x12!\n" +
+	"\fsubject_name\x18\x02 \x01(\tR\vsubjectName\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\x05R\bparentId\x12\x1f\n" +
+	"\vparent_name\x18\x04 \x01(\tR\n" +
+	"parentName\"z\n" +
+	"\x17SearchWBSubjectsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x05R\bparentId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"I\n" +
+	"\x18SearchWBSubjectsResponse\x12-\n" +
+	"\bsubjects\x18\x01 \x03(\v2\x11.api.v1.WBSubjectR\bsubjects\"a\n" +
+	"\x10WBDirectoryValue\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x15\n" +
+	"\x06is_kiz\x18\x03 \x01(\bR\x05isKiz\"\xb6\x01\n" +
+	"\x1eSearchWBDirectoryValuesRequest\x121\n" +
+	"\tdirectory\x18\x01 \x01(\x0e2\x13.api.v1.WBDirectoryR\tdirectory\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1d\n" +
+	"\n" +
+	"subject_id\x18\x03 \x01(\x05R\tsubjectId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\"S\n" +
+	"\x1fSearchWBDirectoryValuesResponse\x120\n" +
+	"\x06values\x18\x01 \x03(\v2\x18.api.v1.WBDirectoryValueR\x06values*\xb2\x01\n" +
+	"\x15ContentValidationMode\x12'\n" +
+	"#CONTENT_VALIDATION_MODE_UNSPECIFIED\x10\x00\x12$\n" +
+	" CONTENT_VALIDATION_MODE_TRUNCATE\x10\x01\x12&\n" +
+	"\"CONTENT_VALIDATION_MODE_REGENERATE\x10\x02\x12\"\n" +
+	"\x1eCONTENT_VALIDATION_MODE_REJECT\x10\x03*t\n" +
+	"\x0fContentProvider\x12 \n" +
+	"\x1cCONTENT_PROVIDER_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eCONTENT_PROVIDER_CARD_CRAFT_AI\x10\x01\x12\x1b\n" +
+	"\x17CONTENT_PROVIDER_OPENAI\x10\x02*T\n" +
+	"\vMarketplace\x12\x1b\n" +
+	"\x17MARKETPLACE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eMARKETPLACE_WB\x10\x01\x12\x14\n" +
+	"\x10MARKETPLACE_OZON\x10\x02*\x8a\x01\n" +
+	"\tMediaKind\x12\x1a\n" +
+	"\x16MEDIA_KIND_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10MEDIA_KIND_PHOTO\x10\x01\x12\x14\n" +
+	"\x10MEDIA_KIND_VIDEO\x10\x02\x12\x18\n" +
+	"\x14MEDIA_KIND_IMAGE_360\x10\x03\x12\x1b\n" +
+	"\x17MEDIA_KIND_COLOR_SWATCH\x10\x04*\xc0\x01\n" +
+	"\vWBDirectory\x12\x1c\n" +
+	"\x18WB_DIRECTORY_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13WB_DIRECTORY_COLORS\x10\x01\x12\x16\n" +
+	"\x12WB_DIRECTORY_KINDS\x10\x02\x12\x1a\n" +
+	"\x16WB_DIRECTORY_COUNTRIES\x10\x03\x12\x18\n" +
+	"\x14WB_DIRECTORY_SEASONS\x10\x04\x12\x14\n" +
+	"\x10WB_DIRECTORY_VAT\x10\x05\x12\x16\n" +
+	"\x12WB_DIRECTORY_TNVED\x10\x062\xce\x04\n" +
 	"\x0eProductService\x129\n" +
-	"\x06Create\x12\x15.api.v1.CreateRequest\x1a\x16.api.v1.CreateResponse\"\x002W\n" +
+	"\x06Create\x12\x15.api.v1.CreateRequest\x1a\x16.api.v1.CreateResponse\"\x00\x12Q\n" +
+	"\x0ePublishVariant\x12\x1d.api.v1.PublishVariantRequest\x1a\x1e.api.v1.PublishVariantResponse\"\x00\x12J\n" +
+	"\vListWBCards\x12\x1a.api.v1.ListWBCardsRequest\x1a\x1b.api.v1.ListWBCardsResponse\"\x000\x01\x12K\n" +
+	"\fUpdatePrices\x12\x1b.api.v1.UpdatePricesRequest\x1a\x1c.api.v1.UpdatePricesResponse\"\x00\x12B\n" +
+	"\tCrossList\x12\x18.api.v1.CrossListRequest\x1a\x19.api.v1.CrossListResponse\"\x00\x12c\n" +
+	"\x14ListCategoryMappings\x12#.api.v1.ListCategoryMappingsRequest\x1a$.api.v1.ListCategoryMappingsResponse\"\x00\x12l\n" +
+	"\x17OverrideCategoryMapping\x12&.api.v1.OverrideCategoryMappingRequest\x1a'.api.v1.OverrideCategoryMappingResponse\"\x002W\n" +
 	"\x0eBalanceService\x12E\n" +
 	"\n" +
 	"GetBalance\x12\x19.api.v1.GetBalanceRequest\x1a\x1a.api.v1.GetBalanceResponse\"\x002\xb0\x01\n" +
 	"\x0ePaymentService\x12<\n" +
 	"\aPayment\x12\x16.api.v1.PaymentRequest\x1a\x17.api.v1.PaymentResponse\"\x00\x12`\n" +
-	"\x13TinkoffNotification\x12\".api.v1.TinkoffNotificationRequest\x1a#.api.v1.TinkoffNotificationResponse\"\x00B\x16Z\x14api/gen/api/v1;apiv1b\x06proto3"
+	"\x13TinkoffNotification\x12\".api.v1.TinkoffNotificationRequest\x1a#.api.v1.TinkoffNotificationResponse\"\x002U\n" +
+	"\fMediaService\x12E\n" +
+	"\x06Upload\x12\x1a.api.v1.UploadMediaRequest\x1a\x1b.api.v1.UploadMediaResponse\"\x00(\x012\x9b\x04\n" +
+	"\fStockService\x12Q\n" +
+	"\x0eListWarehouses\x12\x1d.api.v1.ListWarehousesRequest\x1a\x1e.api.v1.ListWarehousesResponse\"\x00\x12B\n" +
+	"\tSetStocks\x12\x18.api.v1.SetStocksRequest\x1a\x19.api.v1.SetStocksResponse\"\x00\x12c\n" +
+	"\x14SetStockSyncSettings\x12#.api.v1.SetStockSyncSettingsRequest\x1a$.api.v1.SetStockSyncSettingsResponse\"\x00\x12c\n" +
+	"\x14SetStockSyncMappings\x12#.api.v1.SetStockSyncMappingsRequest\x1a$.api.v1.SetStockSyncMappingsResponse\"\x00\x12N\n" +
+	"\fRunStockSync\x12\x1b.api.v1.RunStockSyncRequest\x1a\x1f.api.v1.StockSyncReportResponse\"\x00\x12Z\n" +
+	"\x12GetStockSyncReport\x12!.api.v1.GetStockSyncReportRequest\x1a\x1f.api.v1.StockSyncReportResponse\"\x002\xc2\x02\n" +
+	"\x0eCatalogService\x12i\n" +
+	"\x16ListWBParentCategories\x12%.api.v1.ListWBParentCategoriesRequest\x1a&.api.v1.ListWBParentCategoriesResponse\"\x00\x12W\n" +
+	"\x10SearchWBSubjects\x12\x1f.api.v1.SearchWBSubjectsRequest\x1a .api.v1.SearchWBSubjectsResponse\"\x00\x12l\n" +
+	"\x17SearchWBDirectoryValues\x12&.api.v1.SearchWBDirectoryValuesRequest\x1a'.api.v1.SearchWBDirectoryValuesResponse\"\x00B\x16Z\x14api/gen/api/v1;apiv1b\x06proto3"
 
 var (
 	file_api_v1_product_proto_rawDescOnce sync.Once
@@ -1489,47 +6788,206 @@ func file_api_v1_product_proto_rawDescGZIP() []byte {
 	return file_api_v1_product_proto_rawDescData
 }
 
-var file_api_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_v1_product_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_api_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
 var file_api_v1_product_proto_goTypes = []any{
-	(*CreateRequest)(nil),                   // 0: api.v1.CreateRequest
-	(*Dimensions)(nil),                      // 1: api.v1.Dimensions
-	(*Size)(nil),                            // 2: api.v1.Size
-	(*WBMediaFileToUpload)(nil),             // 3: api.v1.WBMediaFileToUpload
-	(*CreateResponse)(nil),                  // 4: api.v1.CreateResponse
-	(*WBMediaUploadIndividualResponse)(nil), // 5: api.v1.WBMediaUploadIndividualResponse
-	(*WBMediaSaveByLinksResponse)(nil),      // 6: api.v1.WBMediaSaveByLinksResponse
-	(*GetBalanceRequest)(nil),               // 7: api.v1.GetBalanceRequest
-	(*GetBalanceResponse)(nil),              // 8: api.v1.GetBalanceResponse
-	(*PaymentRequest)(nil),                  // 9: api.v1.PaymentRequest
-	(*Receipt)(nil),                         // 10: api.v1.Receipt
-	(*ReceiptItem)(nil),                     // 11: api.v1.ReceiptItem
-	(*PaymentResponse)(nil),                 // 12: api.v1.PaymentResponse
-	(*TinkoffNotificationRequest)(nil),      // 13: api.v1.TinkoffNotificationRequest
-	(*TinkoffNotificationResponse)(nil),     // 14: api.v1.TinkoffNotificationResponse
-	nil,                                     // 15: api.v1.CreateResponse.AttributesEntry
+	(ContentValidationMode)(0),              // 0: api.v1.ContentValidationMode
+	(ContentProvider)(0),                    // 1: api.v1.ContentProvider
+	(Marketplace)(0),                        // 2: api.v1.Marketplace
+	(MediaKind)(0),                          // 3: api.v1.MediaKind
+	(WBDirectory)(0),                        // 4: api.v1.WBDirectory
+	(*CreateRequest)(nil),                   // 5: api.v1.CreateRequest
+	(*InitialStock)(nil),                    // 6: api.v1.InitialStock
+	(*ProductVariant)(nil),                  // 7: api.v1.ProductVariant
+	(*ContentViolation)(nil),                // 8: api.v1.ContentViolation
+	(*ContentValidationError)(nil),          // 9: api.v1.ContentValidationError
+	(*Dimensions)(nil),                      // 10: api.v1.Dimensions
+	(*Size)(nil),                            // 11: api.v1.Size
+	(*WBMediaFileToUpload)(nil),             // 12: api.v1.WBMediaFileToUpload
+	(*MediaReference)(nil),                  // 13: api.v1.MediaReference
+	(*CreateResponse)(nil),                  // 14: api.v1.CreateResponse
+	(*GeneratedBarcodes)(nil),               // 15: api.v1.GeneratedBarcodes
+	(*ContentVariant)(nil),                  // 16: api.v1.ContentVariant
+	(*PublishVariantRequest)(nil),           // 17: api.v1.PublishVariantRequest
+	(*PublishVariantResponse)(nil),          // 18: api.v1.PublishVariantResponse
+	(*OzonError)(nil),                       // 19: api.v1.OzonError
+	(*OzonErrorDetail)(nil),                 // 20: api.v1.OzonErrorDetail
+	(*WBMediaUploadIndividualResponse)(nil), // 21: api.v1.WBMediaUploadIndividualResponse
+	(*WBMediaSaveByLinksResponse)(nil),      // 22: api.v1.WBMediaSaveByLinksResponse
+	(*ListWBCardsRequest)(nil),              // 23: api.v1.ListWBCardsRequest
+	(*ListWBCardsResponse)(nil),             // 24: api.v1.ListWBCardsResponse
+	(*WBCard)(nil),                          // 25: api.v1.WBCard
+	(*WBCardCharacteristic)(nil),            // 26: api.v1.WBCardCharacteristic
+	(*WBCardSize)(nil),                      // 27: api.v1.WBCardSize
+	(*WBCardTag)(nil),                       // 28: api.v1.WBCardTag
+	(*UpdatePricesRequest)(nil),             // 29: api.v1.UpdatePricesRequest
+	(*WBPrice)(nil),                         // 30: api.v1.WBPrice
+	(*OzonPrice)(nil),                       // 31: api.v1.OzonPrice
+	(*OzonPriceResult)(nil),                 // 32: api.v1.OzonPriceResult
+	(*UpdatePricesResponse)(nil),            // 33: api.v1.UpdatePricesResponse
+	(*CrossListRequest)(nil),                // 34: api.v1.CrossListRequest
+	(*CrossListResponse)(nil),               // 35: api.v1.CrossListResponse
+	(*CategoryMapping)(nil),                 // 36: api.v1.CategoryMapping
+	(*ListCategoryMappingsRequest)(nil),     // 37: api.v1.ListCategoryMappingsRequest
+	(*ListCategoryMappingsResponse)(nil),    // 38: api.v1.ListCategoryMappingsResponse
+	(*OverrideCategoryMappingRequest)(nil),  // 39: api.v1.OverrideCategoryMappingRequest
+	(*OverrideCategoryMappingResponse)(nil), // 40: api.v1.OverrideCategoryMappingResponse
+	(*GetBalanceRequest)(nil),               // 41: api.v1.GetBalanceRequest
+	(*GetBalanceResponse)(nil),              // 42: api.v1.GetBalanceResponse
+	(*PaymentRequest)(nil),                  // 43: api.v1.PaymentRequest
+	(*Receipt)(nil),                         // 44: api.v1.Receipt
+	(*ReceiptItem)(nil),                     // 45: api.v1.ReceiptItem
+	(*PaymentResponse)(nil),                 // 46: api.v1.PaymentResponse
+	(*TinkoffNotificationRequest)(nil),      // 47: api.v1.TinkoffNotificationRequest
+	(*TinkoffNotificationResponse)(nil),     // 48: api.v1.TinkoffNotificationResponse
+	(*UploadMediaRequest)(nil),              // 49: api.v1.UploadMediaRequest
+	(*MediaMetadata)(nil),                   // 50: api.v1.MediaMetadata
+	(*UploadMediaResponse)(nil),             // 51: api.v1.UploadMediaResponse
+	(*ListWarehousesRequest)(nil),           // 52: api.v1.ListWarehousesRequest
+	(*Warehouse)(nil),                       // 53: api.v1.Warehouse
+	(*ListWarehousesResponse)(nil),          // 54: api.v1.ListWarehousesResponse
+	(*WBStock)(nil),                         // 55: api.v1.WBStock
+	(*OzonStock)(nil),                       // 56: api.v1.OzonStock
+	(*SetStocksRequest)(nil),                // 57: api.v1.SetStocksRequest
+	(*OzonStockResult)(nil),                 // 58: api.v1.OzonStockResult
+	(*SetStocksResponse)(nil),               // 59: api.v1.SetStocksResponse
+	(*StockSyncSettings)(nil),               // 60: api.v1.StockSyncSettings
+	(*SetStockSyncSettingsRequest)(nil),     // 61: api.v1.SetStockSyncSettingsRequest
+	(*SetStockSyncSettingsResponse)(nil),    // 62: api.v1.SetStockSyncSettingsResponse
+	(*StockSyncMapping)(nil),                // 63: api.v1.StockSyncMapping
+	(*SetStockSyncMappingsRequest)(nil),     // 64: api.v1.SetStockSyncMappingsRequest
+	(*SetStockSyncMappingsResponse)(nil),    // 65: api.v1.SetStockSyncMappingsResponse
+	(*RunStockSyncRequest)(nil),             // 66: api.v1.RunStockSyncRequest
+	(*GetStockSyncReportRequest)(nil),       // 67: api.v1.GetStockSyncReportRequest
+	(*StockSyncChange)(nil),                 // 68: api.v1.StockSyncChange
+	(*StockSyncConflict)(nil),               // 69: api.v1.StockSyncConflict
+	(*StockSyncReport)(nil),                 // 70: api.v1.StockSyncReport
+	(*StockSyncReportResponse)(nil),         // 71: api.v1.StockSyncReportResponse
+	(*WBParentCategory)(nil),                // 72: api.v1.WBParentCategory
+	(*ListWBParentCategoriesRequest)(nil),   // 73: api.v1.ListWBParentCategoriesRequest
+	(*ListWBParentCategoriesResponse)(nil),  // 74: api.v1.ListWBParentCategoriesResponse
+	(*WBSubject)(nil),                       // 75: api.v1.WBSubject
+	(*SearchWBSubjectsRequest)(nil),         // 76: api.v1.SearchWBSubjectsRequest
+	(*SearchWBSubjectsResponse)(nil),        // 77: api.v1.SearchWBSubjectsResponse
+	(*WBDirectoryValue)(nil),                // 78: api.v1.WBDirectoryValue
+	(*SearchWBDirectoryValuesRequest)(nil),  // 79: api.v1.SearchWBDirectoryValuesRequest
+	(*SearchWBDirectoryValuesResponse)(nil), // 80: api.v1.SearchWBDirectoryValuesResponse
+	nil,                                     // 81: api.v1.CreateResponse.AttributesEntry
+	nil,                                     // 82: api.v1.ContentVariant.AttributesEntry
 }
 var file_api_v1_product_proto_depIdxs = []int32{
-	1,  // 0: api.v1.CreateRequest.dimensions:type_name -> api.v1.Dimensions
-	2,  // 1: api.v1.CreateRequest.sizes:type_name -> api.v1.Size
-	3,  // 2: api.v1.CreateRequest.wb_media_to_upload_files:type_name -> api.v1.WBMediaFileToUpload
-	15, // 3: api.v1.CreateResponse.attributes:type_name -> api.v1.CreateResponse.AttributesEntry
-	5,  // 4: api.v1.CreateResponse.wb_media_upload_individual_responses:type_name -> api.v1.WBMediaUploadIndividualResponse
-	6,  // 5: api.v1.CreateResponse.wb_media_save_by_links_response:type_name -> api.v1.WBMediaSaveByLinksResponse
-	10, // 6: api.v1.PaymentRequest.receipt:type_name -> api.v1.Receipt
-	11, // 7: api.v1.Receipt.items:type_name -> api.v1.ReceiptItem
-	0,  // 8: api.v1.ProductService.Create:input_type -> api.v1.CreateRequest
-	7,  // 9: api.v1.BalanceService.GetBalance:input_type -> api.v1.GetBalanceRequest
-	9,  // 10: api.v1.PaymentService.Payment:input_type -> api.v1.PaymentRequest
-	13, // 11: api.v1.PaymentService.TinkoffNotification:input_type -> api.v1.TinkoffNotificationRequest
-	4,  // 12: api.v1.ProductService.Create:output_type -> api.v1.CreateResponse
-	8,  // 13: api.v1.BalanceService.GetBalance:output_type -> api.v1.GetBalanceResponse
-	12, // 14: api.v1.PaymentService.Payment:output_type -> api.v1.PaymentResponse
-	14, // 15: api.v1.PaymentService.TinkoffNotification:output_type -> api.v1.TinkoffNotificationResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	10, // 0: api.v1.CreateRequest.dimensions:type_name -> api.v1.Dimensions
+	11, // 1: api.v1.CreateRequest.sizes:type_name -> api.v1.Size
+	12, // 2: api.v1.CreateRequest.wb_media_to_upload_files:type_name -> api.v1.WBMediaFileToUpload
+	13, // 3: api.v1.CreateRequest.media:type_name -> api.v1.MediaReference
+	2,  // 4: api.v1.CreateRequest.resolve_categories:type_name -> api.v1.Marketplace
+	1,  // 5: api.v1.CreateRequest.content_provider:type_name -> api.v1.ContentProvider
+	0,  // 6: api.v1.CreateRequest.content_validation_mode:type_name -> api.v1.ContentValidationMode
+	7,  // 7: api.v1.CreateRequest.variants:type_name -> api.v1.ProductVariant
+	6,  // 8: api.v1.CreateRequest.initial_stock:type_name -> api.v1.InitialStock
+	11, // 9: api.v1.ProductVariant.sizes:type_name -> api.v1.Size
+	12, // 10: api.v1.ProductVariant.wb_media_to_upload_files:type_name -> api.v1.WBMediaFileToUpload
+	13, // 11: api.v1.ProductVariant.media:type_name -> api.v1.MediaReference
+	2,  // 12: api.v1.ContentViolation.marketplace:type_name -> api.v1.Marketplace
+	8,  // 13: api.v1.ContentValidationError.violations:type_name -> api.v1.ContentViolation
+	3,  // 14: api.v1.WBMediaFileToUpload.kind:type_name -> api.v1.MediaKind
+	3,  // 15: api.v1.MediaReference.kind:type_name -> api.v1.MediaKind
+	81, // 16: api.v1.CreateResponse.attributes:type_name -> api.v1.CreateResponse.AttributesEntry
+	21, // 17: api.v1.CreateResponse.wb_media_upload_individual_responses:type_name -> api.v1.WBMediaUploadIndividualResponse
+	22, // 18: api.v1.CreateResponse.wb_media_save_by_links_response:type_name -> api.v1.WBMediaSaveByLinksResponse
+	1,  // 19: api.v1.CreateResponse.content_provider:type_name -> api.v1.ContentProvider
+	16, // 20: api.v1.CreateResponse.content_variants:type_name -> api.v1.ContentVariant
+	8,  // 21: api.v1.CreateResponse.content_violations:type_name -> api.v1.ContentViolation
+	19, // 22: api.v1.CreateResponse.ozon_error:type_name -> api.v1.OzonError
+	22, // 23: api.v1.CreateResponse.wb_media_save_by_links_responses:type_name -> api.v1.WBMediaSaveByLinksResponse
+	15, // 24: api.v1.CreateResponse.generated_barcodes:type_name -> api.v1.GeneratedBarcodes
+	2,  // 25: api.v1.GeneratedBarcodes.marketplace:type_name -> api.v1.Marketplace
+	82, // 26: api.v1.ContentVariant.attributes:type_name -> api.v1.ContentVariant.AttributesEntry
+	0,  // 27: api.v1.PublishVariantRequest.content_validation_mode:type_name -> api.v1.ContentValidationMode
+	21, // 28: api.v1.PublishVariantResponse.wb_media_upload_individual_responses:type_name -> api.v1.WBMediaUploadIndividualResponse
+	22, // 29: api.v1.PublishVariantResponse.wb_media_save_by_links_response:type_name -> api.v1.WBMediaSaveByLinksResponse
+	8,  // 30: api.v1.PublishVariantResponse.content_violations:type_name -> api.v1.ContentViolation
+	19, // 31: api.v1.PublishVariantResponse.ozon_error:type_name -> api.v1.OzonError
+	22, // 32: api.v1.PublishVariantResponse.wb_media_save_by_links_responses:type_name -> api.v1.WBMediaSaveByLinksResponse
+	15, // 33: api.v1.PublishVariantResponse.generated_barcodes:type_name -> api.v1.GeneratedBarcodes
+	20, // 34: api.v1.OzonError.details:type_name -> api.v1.OzonErrorDetail
+	25, // 35: api.v1.ListWBCardsResponse.cards:type_name -> api.v1.WBCard
+	10, // 36: api.v1.WBCard.dimensions:type_name -> api.v1.Dimensions
+	26, // 37: api.v1.WBCard.characteristics:type_name -> api.v1.WBCardCharacteristic
+	27, // 38: api.v1.WBCard.sizes:type_name -> api.v1.WBCardSize
+	28, // 39: api.v1.WBCard.tags:type_name -> api.v1.WBCardTag
+	30, // 40: api.v1.UpdatePricesRequest.wb_prices:type_name -> api.v1.WBPrice
+	31, // 41: api.v1.UpdatePricesRequest.ozon_prices:type_name -> api.v1.OzonPrice
+	32, // 42: api.v1.UpdatePricesResponse.ozon_results:type_name -> api.v1.OzonPriceResult
+	19, // 43: api.v1.CrossListResponse.ozon_error:type_name -> api.v1.OzonError
+	15, // 44: api.v1.CrossListResponse.generated_barcodes:type_name -> api.v1.GeneratedBarcodes
+	22, // 45: api.v1.CrossListResponse.wb_media_save_by_links_responses:type_name -> api.v1.WBMediaSaveByLinksResponse
+	8,  // 46: api.v1.CrossListResponse.content_violations:type_name -> api.v1.ContentViolation
+	36, // 47: api.v1.ListCategoryMappingsResponse.mappings:type_name -> api.v1.CategoryMapping
+	44, // 48: api.v1.PaymentRequest.receipt:type_name -> api.v1.Receipt
+	45, // 49: api.v1.Receipt.items:type_name -> api.v1.ReceiptItem
+	50, // 50: api.v1.UploadMediaRequest.metadata:type_name -> api.v1.MediaMetadata
+	2,  // 51: api.v1.Warehouse.marketplace:type_name -> api.v1.Marketplace
+	53, // 52: api.v1.ListWarehousesResponse.warehouses:type_name -> api.v1.Warehouse
+	55, // 53: api.v1.SetStocksRequest.wb_stocks:type_name -> api.v1.WBStock
+	56, // 54: api.v1.SetStocksRequest.ozon_stocks:type_name -> api.v1.OzonStock
+	58, // 55: api.v1.SetStocksResponse.ozon_results:type_name -> api.v1.OzonStockResult
+	2,  // 56: api.v1.StockSyncSettings.source:type_name -> api.v1.Marketplace
+	60, // 57: api.v1.SetStockSyncSettingsRequest.settings:type_name -> api.v1.StockSyncSettings
+	63, // 58: api.v1.SetStockSyncMappingsRequest.mappings:type_name -> api.v1.StockSyncMapping
+	2,  // 59: api.v1.StockSyncReport.source:type_name -> api.v1.Marketplace
+	68, // 60: api.v1.StockSyncReport.changes:type_name -> api.v1.StockSyncChange
+	69, // 61: api.v1.StockSyncReport.conflicts:type_name -> api.v1.StockSyncConflict
+	70, // 62: api.v1.StockSyncReportResponse.report:type_name -> api.v1.StockSyncReport
+	72, // 63: api.v1.ListWBParentCategoriesResponse.categories:type_name -> api.v1.WBParentCategory
+	75, // 64: api.v1.SearchWBSubjectsResponse.subjects:type_name -> api.v1.WBSubject
+	4,  // 65: api.v1.SearchWBDirectoryValuesRequest.directory:type_name -> api.v1.WBDirectory
+	78, // 66: api.v1.SearchWBDirectoryValuesResponse.values:type_name -> api.v1.WBDirectoryValue
+	5,  // 67: api.v1.ProductService.Create:input_type -> api.v1.CreateRequest
+	17, // 68: api.v1.ProductService.PublishVariant:input_type -> api.v1.PublishVariantRequest
+	23, // 69: api.v1.ProductService.ListWBCards:input_type -> api.v1.ListWBCardsRequest
+	29, // 70: api.v1.ProductService.UpdatePrices:input_type -> api.v1.UpdatePricesRequest
+	34, // 71: api.v1.ProductService.CrossList:input_type -> api.v1.CrossListRequest
+	37, // 72: api.v1.ProductService.ListCategoryMappings:input_type -> api.v1.ListCategoryMappingsRequest
+	39, // 73: api.v1.ProductService.OverrideCategoryMapping:input_type -> api.v1.OverrideCategoryMappingRequest
+	41, // 74: api.v1.BalanceService.GetBalance:input_type -> api.v1.GetBalanceRequest
+	43, // 75: api.v1.PaymentService.Payment:input_type -> api.v1.PaymentRequest
+	47, // 76: api.v1.PaymentService.TinkoffNotification:input_type -> api.v1.TinkoffNotificationRequest
+	49, // 77: api.v1.MediaService.Upload:input_type -> api.v1.UploadMediaRequest
+	52, // 78: api.v1.StockService.ListWarehouses:input_type -> api.v1.ListWarehousesRequest
+	57, // 79: api.v1.StockService.SetStocks:input_type -> api.v1.SetStocksRequest
+	61, // 80: api.v1.StockService.SetStockSyncSettings:input_type -> api.v1.SetStockSyncSettingsRequest
+	64, // 81: api.v1.StockService.SetStockSyncMappings:input_type -> api.v1.SetStockSyncMappingsRequest
+	66, // 82: api.v1.StockService.RunStockSync:input_type -> api.v1.RunStockSyncRequest
+	67, // 83: api.v1.StockService.GetStockSyncReport:input_type -> api.v1.GetStockSyncReportRequest
+	73, // 84: api.v1.CatalogService.ListWBParentCategories:input_type -> api.v1.ListWBParentCategoriesRequest
+	76, // 85: api.v1.CatalogService.SearchWBSubjects:input_type -> api.v1.SearchWBSubjectsRequest
+	79, // 86: api.v1.CatalogService.SearchWBDirectoryValues:input_type -> api.v1.SearchWBDirectoryValuesRequest
+	14, // 87: api.v1.ProductService.Create:output_type -> api.v1.CreateResponse
+	18, // 88: api.v1.ProductService.PublishVariant:output_type -> api.v1.PublishVariantResponse
+	24, // 89: api.v1.ProductService.ListWBCards:output_type -> api.v1.ListWBCardsResponse
+	33, // 90: api.v1.ProductService.UpdatePrices:output_type -> api.v1.UpdatePricesResponse
+	35, // 91: api.v1.ProductService.CrossList:output_type -> api.v1.CrossListResponse
+	38, // 92: api.v1.ProductService.ListCategoryMappings:output_type -> api.v1.ListCategoryMappingsResponse
+	40, // 93: api.v1.ProductService.OverrideCategoryMapping:output_type -> api.v1.OverrideCategoryMappingResponse
+	42, // 94: api.v1.BalanceService.GetBalance:output_type -> api.v1.GetBalanceResponse
+	46, // 95: api.v1.PaymentService.Payment:output_type -> api.v1.PaymentResponse
+	48, // 96: api.v1.PaymentService.TinkoffNotification:output_type -> api.v1.TinkoffNotificationResponse
+	51, // 97: api.v1.MediaService.Upload:output_type -> api.v1.UploadMediaResponse
+	54, // 98: api.v1.StockService.ListWarehouses:output_type -> api.v1.ListWarehousesResponse
+	59, // 99: api.v1.StockService.SetStocks:output_type -> api.v1.SetStocksResponse
+	62, // 100: api.v1.StockService.SetStockSyncSettings:output_type -> api.v1.SetStockSyncSettingsResponse
+	65, // 101: api.v1.StockService.SetStockSyncMappings:output_type -> api.v1.SetStockSyncMappingsResponse
+	71, // 102: api.v1.StockService.RunStockSync:output_type -> api.v1.StockSyncReportResponse
+	71, // 103: api.v1.StockService.GetStockSyncReport:output_type -> api.v1.StockSyncReportResponse
+	74, // 104: api.v1.CatalogService.ListWBParentCategories:output_type -> api.v1.ListWBParentCategoriesResponse
+	77, // 105: api.v1.CatalogService.SearchWBSubjects:output_type -> api.v1.SearchWBSubjectsResponse
+	80, // 106: api.v1.CatalogService.SearchWBDirectoryValues:output_type -> api.v1.SearchWBDirectoryValuesResponse
+	87, // [87:107] is the sub-list for method output_type
+	67, // [67:87] is the sub-list for method input_type
+	67, // [67:67] is the sub-list for extension type_name
+	67, // [67:67] is the sub-list for extension extendee
+	0,  // [0:67] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_product_proto_init() }
@@ -1537,22 +6995,34 @@ func file_api_v1_product_proto_init() {
 	if File_api_v1_product_proto != nil {
 		return
 	}
-	file_api_v1_product_proto_msgTypes[2].OneofWrappers = []any{}
-	file_api_v1_product_proto_msgTypes[4].OneofWrappers = []any{}
-	file_api_v1_product_proto_msgTypes[5].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[0].OneofWrappers = []any{}
 	file_api_v1_product_proto_msgTypes[6].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[9].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[12].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[13].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[16].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[17].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[18].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[29].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[30].OneofWrappers = []any{}
+	file_api_v1_product_proto_msgTypes[44].OneofWrappers = []any{
+		(*UploadMediaRequest_Metadata)(nil),
+		(*UploadMediaRequest_Chunk)(nil),
+	}
+	file_api_v1_product_proto_msgTypes[61].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_product_proto_rawDesc), len(file_api_v1_product_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   16,
+			NumEnums:      5,
+			NumMessages:   78,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   6,
 		},
 		GoTypes:           file_api_v1_product_proto_goTypes,
 		DependencyIndexes: file_api_v1_product_proto_depIdxs,
+		EnumInfos:         file_api_v1_product_proto_enumTypes,
 		MessageInfos:      file_api_v1_product_proto_msgTypes,
 	}.Build()
 	File_api_v1_product_proto = out.File
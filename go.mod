@@ -5,6 +5,7 @@ go 1.24.2
 require (
 	connectrpc.com/connect v1.18.1
 	github.com/ilyakaznacheev/cleanenv v1.5.0
+	github.com/jackc/pgx/v4 v4.18.1
 	github.com/marketconnect/db_client v0.0.0-20241120113557-e67aaf70aaac
 	github.com/prometheus/client_golang v1.22.0
 	google.golang.org/protobuf v1.36.6
@@ -21,7 +22,6 @@ require (
 	github.com/jackc/pgproto3/v2 v2.3.2 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
 	github.com/jackc/pgtype v1.14.0 // indirect
-	github.com/jackc/pgx/v4 v4.18.1 // indirect
 	github.com/jackc/puddle v1.3.0 // indirect
 	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
@@ -69,4 +69,68 @@ var (
 		},
 		[]string{"operation_type"}, // "upload_file", "save_by_link"
 	)
+	// AppExternalAPIRetriesTotal is a counter for retried calls to external APIs.
+	AppExternalAPIRetriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "app_external_api_retries_total",
+			Help: "Total number of retried calls to external APIs.",
+		},
+		[]string{"api_name"}, // e.g., "card_craft_ai_session", "card_craft_ai_run"
+	)
+	// AppCircuitBreakerState is a gauge with the current state of circuit breakers (0 closed, 1 half-open, 2 open).
+	AppCircuitBreakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "app_circuit_breaker_state",
+			Help: "Current circuit breaker state: 0 closed, 1 half-open, 2 open.",
+		},
+		[]string{"breaker"}, // e.g., "card_craft_ai"
+	)
+	// AppExternalAPIRateLimitWaitSeconds is a histogram of time requests spent queued by client-side rate limiters.
+	AppExternalAPIRateLimitWaitSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "app_external_api_rate_limit_wait_seconds",
+			Help:    "Time requests to external APIs waited for the client-side rate limiter.",
+			Buckets: []float64{0, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"api_name"}, // e.g., "wb_card_upload", "wb_get_card_list"
+	)
+	// AppExternalAPIRateLimitedTotal is a counter for 429 Too Many Requests responses from external APIs.
+	AppExternalAPIRateLimitedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "app_external_api_rate_limited_total",
+			Help: "Total number of 429 Too Many Requests responses from external APIs.",
+		},
+		[]string{"api_name"},
+	)
+	// AppWBMediaPendingJobs is a gauge of WB media waiting in the background for the nmID of its card.
+	AppWBMediaPendingJobs = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "app_wb_media_pending_jobs",
+			Help: "Number of Wildberries media jobs waiting for the nmID of a card being created.",
+		},
+	)
+	// AppStockSyncChangesTotal is a counter for stocks written by the stock sync.
+	AppStockSyncChangesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "app_stock_sync_changes_total",
+			Help: "Total number of stocks written to the target marketplace by the stock sync.",
+		},
+		[]string{"target"}, // "wb" or "ozon"
+	)
+	// AppStockSyncConflictsTotal is a counter for items the stock sync could not sync cleanly.
+	AppStockSyncConflictsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "app_stock_sync_conflicts_total",
+			Help: "Total number of stock sync conflicts.",
+		},
+		[]string{"kind"}, // e.g., "target_changed", "missing_in_target"
+	)
+	// AppCategoryMappingHitsTotal is a counter for categories taken from the category mappings instead of CardCraftAI.
+	AppCategoryMappingHitsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "app_category_mapping_hits_total",
+			Help: "Total number of marketplace categories filled from the category mappings.",
+		},
+		[]string{"target"}, // "wb" or "ozon"
+	)
 )
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS card_content_cache (
+    cache_key TEXT PRIMARY KEY,
+    content JSONB NOT NULL,
+    prompt_tokens INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS card_content_cache_created_at_idx ON card_content_cache (created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS account_content_settings;
+DROP TABLE IF EXISTS prompt_templates;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    name TEXT PRIMARY KEY,
+    system_prompt TEXT NOT NULL,
+    user_prompt TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS account_content_settings (
+    api_key TEXT PRIMARY KEY,
+    content_provider TEXT NOT NULL
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS content_variants (
+    id TEXT PRIMARY KEY,
+    generation_id TEXT NOT NULL,
+    variant_index INTEGER NOT NULL,
+    api_key TEXT NOT NULL,
+    provider TEXT NOT NULL DEFAULT '',
+    content JSONB NOT NULL,
+    product_card JSONB NOT NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    cost INTEGER NOT NULL DEFAULT 0,
+    from_cache BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ,
+    published_to TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS content_variants_generation_id_idx ON content_variants (generation_id);
+CREATE INDEX IF NOT EXISTS content_variants_api_key_idx ON content_variants (api_key, created_at);
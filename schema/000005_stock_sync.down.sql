@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stock_sync_reports;
+DROP TABLE IF EXISTS stock_sync_mappings;
+DROP TABLE IF EXISTS stock_sync_settings;
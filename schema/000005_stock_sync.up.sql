@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS stock_sync_settings (
+    api_key TEXT PRIMARY KEY,
+    source_marketplace TEXT NOT NULL,
+    wb_api_key TEXT NOT NULL DEFAULT '',
+    wb_warehouse_id BIGINT NOT NULL DEFAULT 0,
+    ozon_client_id TEXT NOT NULL DEFAULT '',
+    ozon_api_key TEXT NOT NULL DEFAULT '',
+    ozon_warehouse_id BIGINT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS stock_sync_mappings (
+    api_key TEXT NOT NULL,
+    wb_sku TEXT NOT NULL,
+    vendor_code TEXT NOT NULL DEFAULT '',
+    ozon_offer_id TEXT NOT NULL,
+    last_amount INTEGER,
+    PRIMARY KEY (api_key, wb_sku)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS stock_sync_mappings_offer_id_idx ON stock_sync_mappings (api_key, ozon_offer_id);
+
+CREATE TABLE IF NOT EXISTS stock_sync_reports (
+    id BIGSERIAL PRIMARY KEY,
+    api_key TEXT NOT NULL,
+    source_marketplace TEXT NOT NULL,
+    dry_run BOOLEAN NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    changes JSONB NOT NULL,
+    conflicts JSONB NOT NULL,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS stock_sync_reports_api_key_idx ON stock_sync_reports (api_key, started_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS category_mappings (
+    wb_subject_id INTEGER NOT NULL,
+    ozon_description_category_id INTEGER NOT NULL,
+    ozon_type_id INTEGER NOT NULL,
+    wb_subject_name TEXT NOT NULL DEFAULT '',
+    ozon_description_category_name TEXT NOT NULL DEFAULT '',
+    ozon_type_name TEXT NOT NULL DEFAULT '',
+    resolutions INTEGER NOT NULL DEFAULT 0,
+    overridden BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (wb_subject_id, ozon_description_category_id, ozon_type_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS category_mappings_override_idx ON category_mappings (wb_subject_id) WHERE overridden;
+CREATE INDEX IF NOT EXISTS category_mappings_ozon_type_idx ON category_mappings (ozon_description_category_id, ozon_type_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS wb_tnved_codes;
+DROP TABLE IF EXISTS wb_directory_values;
+DROP TABLE IF EXISTS wb_subjects;
+DROP TABLE IF EXISTS wb_parent_categories;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS wb_parent_categories (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    is_visible BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS wb_subjects (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id INTEGER NOT NULL,
+    parent_name TEXT NOT NULL DEFAULT '',
+    tnved_synced_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS wb_subjects_parent_id_idx ON wb_subjects (parent_id);
+
+CREATE TABLE IF NOT EXISTS wb_directory_values (
+    directory TEXT NOT NULL,
+    value TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (directory, value)
+);
+
+CREATE TABLE IF NOT EXISTS wb_tnved_codes (
+    subject_id INTEGER NOT NULL,
+    tnved TEXT NOT NULL,
+    is_kiz BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (subject_id, tnved)
+);
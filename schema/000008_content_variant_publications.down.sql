@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS content_variant_publications;
+ALTER TABLE content_variants DROP COLUMN IF EXISTS inline_media_files;
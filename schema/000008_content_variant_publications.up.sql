@@ -0,0 +1,11 @@
+ALTER TABLE content_variants ADD COLUMN IF NOT EXISTS inline_media_files INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS content_variant_publications (
+    variant_id TEXT NOT NULL REFERENCES content_variants (id) ON DELETE CASCADE,
+    marketplace TEXT NOT NULL,
+    vendor_code TEXT NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS content_variant_publications_variant_id_idx ON content_variant_publications (variant_id);
+CREATE INDEX IF NOT EXISTS content_variant_publications_vendor_code_idx ON content_variant_publications (marketplace, vendor_code);
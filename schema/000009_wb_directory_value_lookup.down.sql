@@ -0,0 +1 @@
+DROP INDEX IF EXISTS wb_directory_values_lower_value_idx;
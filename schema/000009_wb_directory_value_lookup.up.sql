@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS wb_directory_values_lower_value_idx ON wb_directory_values (directory, LOWER(value));